@@ -2,6 +2,7 @@ package ast
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	sitter "github.com/smacker/go-tree-sitter"
@@ -52,6 +53,14 @@ type ClassDeclarationNode struct {
 	// Metadata
 	isAbstract     bool
 	accessModifier AccessModifier
+
+	// Partial declarations of the class (C# partial class) merged into this one,
+	// members of each declaration are resolved from the content of its own file
+	isPartial bool
+	partials  []*ClassDeclarationNode
+
+	// Enclosing namespace eg. crypto::aes in C++
+	namespace string
 }
 
 // NewClassDeclarationNode creates a new ClassDeclarationNode instance
@@ -81,12 +90,32 @@ func (c *ClassDeclarationNode) BaseClasses() []string {
 			baseClasses = append(baseClasses, baseClass)
 		}
 	}
+
+	// Partial declarations may repeat the base classes
+	for _, partial := range c.partials {
+		for _, baseClass := range partial.BaseClasses() {
+			if !slices.Contains(baseClasses, baseClass) {
+				baseClasses = append(baseClasses, baseClass)
+			}
+		}
+	}
+
 	return baseClasses
 }
 
 // HasInheritance returns true if this class inherits from one or more base classes
 func (c *ClassDeclarationNode) HasInheritance() bool {
-	return len(c.baseClassNodes) > 0
+	if len(c.baseClassNodes) > 0 {
+		return true
+	}
+
+	for _, partial := range c.partials {
+		if partial.HasInheritance() {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Methods returns the content of all method nodes
@@ -97,6 +126,9 @@ func (c *ClassDeclarationNode) Methods() []string {
 			methods = append(methods, method)
 		}
 	}
+	for _, partial := range c.partials {
+		methods = append(methods, partial.Methods()...)
+	}
 	return methods
 }
 
@@ -108,12 +140,25 @@ func (c *ClassDeclarationNode) Fields() []string {
 			fields = append(fields, field)
 		}
 	}
+	for _, partial := range c.partials {
+		fields = append(fields, partial.Fields()...)
+	}
 	return fields
 }
 
 // Constructor returns the content of the constructor method
 func (c *ClassDeclarationNode) Constructor() string {
-	return c.contentForNode(c.constructorNode)
+	if c.constructorNode != nil {
+		return c.contentForNode(c.constructorNode)
+	}
+
+	for _, partial := range c.partials {
+		if constructor := partial.Constructor(); constructor != "" {
+			return constructor
+		}
+	}
+
+	return ""
 }
 
 // Decorators returns the content of all decorator nodes
@@ -124,12 +169,36 @@ func (c *ClassDeclarationNode) Decorators() []string {
 			decorators = append(decorators, decorator)
 		}
 	}
+	for _, partial := range c.partials {
+		decorators = append(decorators, partial.Decorators()...)
+	}
 	return decorators
 }
 
-// IsAbstract returns true if this is an abstract class
+// IsAbstract returns true if this is an abstract class. A partial
+// class is abstract if any of its declarations is abstract
 func (c *ClassDeclarationNode) IsAbstract() bool {
-	return c.isAbstract
+	if c.isAbstract {
+		return true
+	}
+
+	for _, partial := range c.partials {
+		if partial.IsAbstract() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsPartial returns true if the class is declared in parts eg. partial class in C#
+func (c *ClassDeclarationNode) IsPartial() bool {
+	return c.isPartial
+}
+
+// Partials returns the partial declarations merged into this class
+func (c *ClassDeclarationNode) Partials() []*ClassDeclarationNode {
+	return c.partials
 }
 
 // AccessModifier returns the access modifier of the class
@@ -137,6 +206,11 @@ func (c *ClassDeclarationNode) AccessModifier() AccessModifier {
 	return c.accessModifier
 }
 
+// Namespace returns the enclosing namespace of the class if available
+func (c *ClassDeclarationNode) Namespace() string {
+	return c.namespace
+}
+
 // Getter methods for Tree-Sitter nodes
 func (c *ClassDeclarationNode) GetClassNameNode() *sitter.Node {
 	return c.classNameNode
@@ -211,6 +285,19 @@ func (c *ClassDeclarationNode) SetAccessModifier(accessModifier AccessModifier)
 	c.accessModifier = accessModifier
 }
 
+func (c *ClassDeclarationNode) SetIsPartial(isPartial bool) {
+	c.isPartial = isPartial
+}
+
+func (c *ClassDeclarationNode) SetNamespace(namespace string) {
+	c.namespace = namespace
+}
+
+// MergePartial merges another declaration of a partial class into this one
+func (c *ClassDeclarationNode) MergePartial(partial *ClassDeclarationNode) {
+	c.partials = append(c.partials, partial)
+}
+
 // String returns a string representation of the ClassDeclarationNode for debugging
 func (c *ClassDeclarationNode) String() string {
 	var parts []string
@@ -221,7 +308,7 @@ func (c *ClassDeclarationNode) String() string {
 	}
 
 	// Abstract modifier
-	if c.isAbstract {
+	if c.IsAbstract() {
 		parts = append(parts, "abstract")
 	}
 
@@ -249,6 +336,11 @@ func (c *ClassDeclarationNode) String() string {
 	methodCount := len(c.methodNodes)
 	fieldCount := len(c.fieldNodes)
 	hasConstructor := c.constructorNode != nil
+	for _, partial := range c.partials {
+		methodCount += len(partial.methodNodes)
+		fieldCount += len(partial.fieldNodes)
+		hasConstructor = hasConstructor || partial.constructorNode != nil
+	}
 
 	classModifiers := strings.Join(parts, " ")
 	if classModifiers != "" {
@@ -264,3 +356,28 @@ func (c *ClassDeclarationNode) String() string {
 		fieldCount,
 		hasConstructor)
 }
+
+// MergePartialClasses merges the declarations of partial classes having the
+// same name, eg. declared across files, into their first declaration. Other
+// classes are returned as is, in their original order
+func MergePartialClasses(classes []*ClassDeclarationNode) []*ClassDeclarationNode {
+	merged := []*ClassDeclarationNode{}
+	partialClasses := make(map[string]*ClassDeclarationNode)
+
+	for _, class := range classes {
+		if !class.IsPartial() {
+			merged = append(merged, class)
+			continue
+		}
+
+		if existing, exists := partialClasses[class.ClassName()]; exists {
+			existing.MergePartial(class)
+			continue
+		}
+
+		partialClasses[class.ClassName()] = class
+		merged = append(merged, class)
+	}
+
+	return merged
+}
@@ -148,3 +148,22 @@ func TestClassDeclarationNodeContentMethods(t *testing.T) {
 	assert.Empty(t, node.Constructor(), "Expected empty constructor for nil constructor node")
 	assert.Empty(t, node.Decorators(), "Expected empty decorators for no decorator nodes")
 }
+
+func TestClassDeclarationNodePartials(t *testing.T) {
+	content := ToContent([]byte("partial class TestClass {}"))
+
+	node := NewClassDeclarationNode(content)
+	node.SetIsPartial(true)
+	assert.True(t, node.IsPartial(), "Expected class to be partial after setting")
+	assert.False(t, node.HasInheritance(), "Expected partial class to have no inheritance by default")
+
+	partial := NewClassDeclarationNode(content)
+	partial.SetIsPartial(true)
+	partial.SetIsAbstract(true)
+	partial.AddBaseClassNode(&sitter.Node{})
+
+	node.MergePartial(partial)
+	assert.Len(t, node.Partials(), 1, "Should have one partial declaration after merge")
+	assert.True(t, node.IsAbstract(), "Expected class to be abstract when a partial declaration is abstract")
+	assert.True(t, node.HasInheritance(), "Expected class to inherit the base classes of its partial declarations")
+}
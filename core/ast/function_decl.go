@@ -56,6 +56,9 @@ type FunctionDeclarationNode struct {
 
 	// Parent class context (for methods)
 	parentClassName string
+
+	// Enclosing namespace eg. crypto::aes in C++
+	namespace string
 }
 
 // NewFunctionDeclarationNode creates a new FunctionDeclarationNode instance
@@ -141,6 +144,11 @@ func (f *FunctionDeclarationNode) GetParentClassName() string {
 	return f.parentClassName
 }
 
+// GetNamespace returns the enclosing namespace of the function if available
+func (f *FunctionDeclarationNode) GetNamespace() string {
+	return f.namespace
+}
+
 // GetFunctionNameNode returns the function name node
 func (f *FunctionDeclarationNode) GetFunctionNameNode() *sitter.Node {
 	return f.functionNameNode
@@ -222,3 +230,8 @@ func (f *FunctionDeclarationNode) SetIsAsync(async bool) {
 func (f *FunctionDeclarationNode) SetParentClassName(className string) {
 	f.parentClassName = className
 }
+
+// SetNamespace sets the enclosing namespace
+func (f *FunctionDeclarationNode) SetNamespace(namespace string) {
+	f.namespace = namespace
+}
@@ -15,6 +15,10 @@ type ImportNode struct {
 	// The package or module being imported
 	moduleNameNode *sitter.Node
 
+	// Name of the module when it is composed from multiple nodes
+	// eg. `use std::{io::Read}` in Rust imports Read from std::io
+	moduleName string
+
 	// The object being imported
 	moduleItemNode *sitter.Node
 
@@ -23,6 +27,14 @@ type ImportNode struct {
 
 	// Whether the import is a wildcard import
 	isWildcardImport bool
+
+	// Whether the import only brings types into scope
+	// eg. `import type { Foo } from 'foo'` in TypeScript
+	isTypeOnlyImport bool
+
+	// Whether the module is loaded at runtime by a function call
+	// eg. `importlib.import_module("yaml")` in Python
+	isDynamicImport bool
 }
 
 // NewImportNode creates a new ImportNode instance
@@ -35,6 +47,10 @@ func NewImportNode(content Content) *ImportNode {
 }
 
 func (i *ImportNode) ModuleName() string {
+	if i.moduleName != "" {
+		return i.moduleName
+	}
+
 	return i.contentForNode(i.moduleNameNode)
 }
 
@@ -50,6 +66,18 @@ func (i *ImportNode) IsWildcardImport() bool {
 	return i.isWildcardImport
 }
 
+// IsTypeOnlyImport returns true when the import is erased at runtime
+// because it only brings types into scope
+func (i *ImportNode) IsTypeOnlyImport() bool {
+	return i.isTypeOnlyImport
+}
+
+// IsDynamicImport returns true when the module is loaded at runtime by a
+// function call instead of an import statement
+func (i *ImportNode) IsDynamicImport() bool {
+	return i.isDynamicImport
+}
+
 func (i *ImportNode) GetModuleNameNode() *sitter.Node {
 	return i.moduleNameNode
 }
@@ -58,6 +86,12 @@ func (i *ImportNode) SetModuleNameNode(node *sitter.Node) {
 	i.moduleNameNode = node
 }
 
+// SetModuleName sets the module name when it is not the content of the module
+// name node. The module name node is still used for the position of the import
+func (i *ImportNode) SetModuleName(moduleName string) {
+	i.moduleName = moduleName
+}
+
 func (i *ImportNode) GetModuleItemNode() *sitter.Node {
 	return i.moduleItemNode
 }
@@ -78,6 +112,14 @@ func (i *ImportNode) SetIsWildcardImport(isWildcardImport bool) {
 	i.isWildcardImport = isWildcardImport
 }
 
+func (i *ImportNode) SetIsTypeOnlyImport(isTypeOnlyImport bool) {
+	i.isTypeOnlyImport = isTypeOnlyImport
+}
+
+func (i *ImportNode) SetIsDynamicImport(isDynamicImport bool) {
+	i.isDynamicImport = isDynamicImport
+}
+
 func (i *ImportNode) String() string {
 	return fmt.Sprintf("ImportNode{ModuleName: %s, ModuleItem: %s, ModuleAlias: %s, WildcardImport: %t}",
 		i.ModuleName(), i.ModuleItem(), i.ModuleAlias(), i.IsWildcardImport())
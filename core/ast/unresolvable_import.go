@@ -0,0 +1,73 @@
+package ast
+
+import (
+	"fmt"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// UnresolvableImportNode represents a dynamic import of a module whose name
+// is computed at runtime and can not be resolved statically
+// eg. `require(process.env.PLUGIN)` in JavaScript. Such imports are
+// commonly used by plugin loaders, but also by obfuscated code hiding
+// the modules it loads
+type UnresolvableImportNode struct {
+	Node
+
+	// The call importing the module eg. require(name)
+	callNode *sitter.Node
+
+	// The expression computing the name of the module
+	specifierNode *sitter.Node
+
+	// The name of the module where the parts computed at runtime
+	// are replaced by a wildcard eg. `./locale/${lang}.js` -> ./locale/*.js
+	specifierPattern string
+}
+
+// NewUnresolvableImportNode creates a new UnresolvableImportNode instance
+// using the call node from the tree-sitter parser
+func NewUnresolvableImportNode(content Content) *UnresolvableImportNode {
+	return &UnresolvableImportNode{
+		Node: Node{content},
+	}
+}
+
+// Specifier returns the source of the expression computing the module name
+func (i *UnresolvableImportNode) Specifier() string {
+	return i.contentForNode(i.specifierNode)
+}
+
+func (i *UnresolvableImportNode) SpecifierPattern() string {
+	return i.specifierPattern
+}
+
+// Position returns the position of the call importing the module
+func (i *UnresolvableImportNode) Position() NodePosition {
+	return GetNodePosition(i.callNode)
+}
+
+func (i *UnresolvableImportNode) GetCallNode() *sitter.Node {
+	return i.callNode
+}
+
+func (i *UnresolvableImportNode) SetCallNode(node *sitter.Node) {
+	i.callNode = node
+}
+
+func (i *UnresolvableImportNode) GetSpecifierNode() *sitter.Node {
+	return i.specifierNode
+}
+
+func (i *UnresolvableImportNode) SetSpecifierNode(node *sitter.Node) {
+	i.specifierNode = node
+}
+
+func (i *UnresolvableImportNode) SetSpecifierPattern(specifierPattern string) {
+	i.specifierPattern = specifierPattern
+}
+
+func (i *UnresolvableImportNode) String() string {
+	return fmt.Sprintf("UnresolvableImportNode{Specifier: %s, SpecifierPattern: %s, Line: %d}",
+		i.Specifier(), i.SpecifierPattern(), i.Position().StartLine)
+}
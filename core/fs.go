@@ -21,6 +21,16 @@ type File interface {
 	IsImport() bool
 }
 
+// LanguageAwareFile is a File whose language is already detected
+// eg. by the source walker, such that the language of a file is
+// resolved once and consistently by the parser and the plugins
+type LanguageAwareFile interface {
+	File
+
+	// The language of the file
+	Language() Language
+}
+
 type FileSystem interface {
 	// Enumerate the contents of the file system
 	Enumerate(context.Context, func(File) error) error
@@ -44,3 +54,43 @@ type ImportAwareFileSystem interface {
 	// Enumerate import source files in the file system
 	EnumerateImports(context.Context, func(File) error) error
 }
+
+// NotebookPosition is a position in a cell of a notebook
+type NotebookPosition struct {
+	// Index of the cell in the notebook, starting from 0. Cells other than
+	// code cells eg. markdown cells are counted
+	Cell int
+
+	// Line in the cell, starting from 1
+	Line uint32
+}
+
+// NotebookFile is a File whose source is generated from the code cells of a
+// notebook eg. a Jupyter notebook, such that the source can be parsed by the
+// language of the notebook. Positions in the source are mapped back to the
+// cells of the notebook
+type NotebookFile interface {
+	LanguageAwareFile
+
+	// NotebookPosition maps a row of the generated source, starting from 0,
+	// to the position in the notebook. It returns false for rows not
+	// generated from a cell
+	NotebookPosition(row uint32) (NotebookPosition, bool)
+}
+
+// GetNotebookPosition maps a row of the source of a file, starting from 0,
+// to the position in the notebook the source is generated from. It returns
+// nil for files other than notebooks
+func GetNotebookPosition(file File, row uint32) *NotebookPosition {
+	notebookFile, ok := file.(NotebookFile)
+	if !ok {
+		return nil
+	}
+
+	position, ok := notebookFile.NotebookPosition(row)
+	if !ok {
+		return nil
+	}
+
+	return &position
+}
@@ -38,6 +38,16 @@ type ObjectOrientedLanguageResolvers interface {
 	ResolveInheritance(tree ParseTree) (*ast.InheritanceGraph, error)
 }
 
+// DynamicImportLanguageResolvers define the additional contract
+// for a language implementation to report dynamic imports which
+// can not be resolved statically eg. `require(name)` in JavaScript,
+// such that analyzers can flag code loading modules it hides
+type DynamicImportLanguageResolvers interface {
+	// ResolveUnresolvableImports returns a list of dynamic imports
+	// of modules whose name is computed at runtime
+	ResolveUnresolvableImports(tree ParseTree) ([]*ast.UnresolvableImportNode, error)
+}
+
 type LanguageCode string
 
 const (
@@ -45,6 +55,15 @@ const (
 	LanguageCodeJavascript LanguageCode = "javascript"
 	LanguageCodeJava       LanguageCode = "java"
 	LanguageCodeGo         LanguageCode = "go"
+	LanguageCodeTypescript LanguageCode = "typescript"
+	LanguageCodeRust       LanguageCode = "rust"
+	LanguageCodeRuby       LanguageCode = "ruby"
+	LanguageCodePhp        LanguageCode = "php"
+	LanguageCodeCsharp     LanguageCode = "csharp"
+	LanguageCodeKotlin     LanguageCode = "kotlin"
+	LanguageCodeC          LanguageCode = "c"
+	LanguageCodeCpp        LanguageCode = "cpp"
+	LanguageCodeBash       LanguageCode = "bash"
 )
 
 // LanguageMeta is exposes metadata about a language
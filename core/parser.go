@@ -24,3 +24,15 @@ type ParseTree interface {
 	// The language used to parse the tree
 	Language() (Language, error)
 }
+
+// MultiLanguageParser is a Parser for files embedding source code of other
+// languages eg. <script> tags in HTML or code fences in Markdown
+type MultiLanguageParser interface {
+	Parser
+
+	// ParseAll parses a file into a tree per source, host documents are
+	// parsed into a tree per embedded source and other files into a single
+	// tree. Trees of embedded sources share the data and the file of the
+	// host document, such that node positions are positions in the host
+	ParseAll(context.Context, File) ([]ParseTree, error)
+}
@@ -27,3 +27,77 @@ type FilePlugin interface {
 
 	AnalyzeSource(context.Context, File) error
 }
+
+// ResultName identifies a result published by a plugin
+// for other plugins to consume
+type ResultName string
+
+// ResultKey is a typed handle of a result. Plugins producing and consuming
+// a result are expected to share the same key eg. an exported package variable
+type ResultKey[T any] struct {
+	name ResultName
+}
+
+func NewResultKey[T any](name ResultName) ResultKey[T] {
+	return ResultKey[T]{name: name}
+}
+
+func (k ResultKey[T]) Name() ResultName {
+	return k.name
+}
+
+// ResultSet holds the results published by plugins
+type ResultSet interface {
+	Get(ResultName) (any, bool)
+
+	// Put publishes a result. It fails if the plugin
+	// did not declare the result in Produces
+	Put(ResultName, any) error
+}
+
+// PluginResults gives a plugin access to the results shared
+// for the file being analyzed and across the project
+type PluginResults interface {
+	// Results scoped to the file being analyzed
+	File() ResultSet
+
+	// Results shared across all files analyzed by an executor.
+	// Plugins may be executed concurrently, values stored here
+	// must be safe for concurrent use
+	Project() ResultSet
+}
+
+// CascadingPlugin is the contract for a plugin that declares the results
+// it produces and consumes. Executors run the producers of a result
+// before its consumers so that higher level analysis can be built on
+// results of other plugins without recomputing them
+type CascadingPlugin interface {
+	Plugin
+
+	Produces() []ResultName
+	Consumes() []ResultName
+
+	AnalyzeResults(context.Context, ParseTree, PluginResults) error
+}
+
+// GetResult is a typed accessor for a result in the result set
+func GetResult[T any](results ResultSet, key ResultKey[T]) (T, bool) {
+	var zero T
+
+	value, exists := results.Get(key.Name())
+	if !exists {
+		return zero, false
+	}
+
+	typedValue, ok := value.(T)
+	if !ok {
+		return zero, false
+	}
+
+	return typedValue, true
+}
+
+// PutResult is a typed publisher of a result in the result set
+func PutResult[T any](results ResultSet, key ResultKey[T], value T) error {
+	return results.Put(key.Name(), value)
+}
@@ -12,6 +12,7 @@ import (
 var (
 	inputDir           string
 	outputDatabasePath string
+	skipInitSchema     bool
 )
 
 func NewScanCommand() *cobra.Command {
@@ -33,6 +34,7 @@ func NewScanCommand() *cobra.Command {
 
 	cmd.Flags().StringVarP(&inputDir, "dir", "D", "", "Input directory to scan")
 	cmd.Flags().StringVarP(&outputDatabasePath, "output", "o", "", "Output database path")
+	cmd.Flags().BoolVar(&skipInitSchema, "skip-init-schema", false, "Skip creating the schema in an existing database")
 
 	_ = cmd.MarkFlagRequired("dir")
 	_ = cmd.MarkFlagRequired("output")
@@ -44,6 +46,7 @@ func executeScan() error {
 	scanner, err := scan.New(scan.Config{
 		InputDirectory:     inputDir,
 		OutputDatabasePath: outputDatabasePath,
+		SkipInitSchema:     skipInitSchema,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create scanner: %w", err)
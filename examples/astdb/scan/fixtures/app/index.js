@@ -0,0 +1,9 @@
+import express from 'express';
+const { readFileSync } = require('fs');
+
+function loadConfig(path) {
+  return JSON.parse(readFileSync(path));
+}
+
+const app = express();
+app.listen(loadConfig('config.json').port);
@@ -0,0 +1,22 @@
+import requests
+from app.storage import save
+
+
+class Fetcher:
+    def fetch(self, url):
+        return requests.get(url)
+
+
+class CachingFetcher(Fetcher):
+    def fetch(self, url):
+        response = super().fetch(url)
+        save(url, response)
+        return response
+
+
+def main():
+    fetcher = CachingFetcher()
+    fetcher.fetch("https://example.com")
+
+
+main()
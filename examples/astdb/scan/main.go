@@ -1,9 +1,29 @@
 package scan
 
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/fs"
+	"github.com/safedep/code/lang"
+	"github.com/safedep/code/parser"
+	"github.com/safedep/code/plugin"
+	"github.com/safedep/code/plugin/callgraph"
+	"github.com/safedep/code/plugin/depsusage"
+	"github.com/safedep/dry/log"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
 type Config struct {
 	InputDirectory     string
 	OutputDatabasePath string
-	SkipInitSchema     bool
+
+	// SkipInitSchema skips creating the tables and indexes. The database
+	// must already have the schema in place
+	SkipInitSchema bool
 }
 
 type scanner struct {
@@ -11,11 +31,108 @@ type scanner struct {
 }
 
 func New(config Config) (*scanner, error) {
+	if config.InputDirectory == "" {
+		return nil, fmt.Errorf("input directory is required")
+	}
+
+	if config.OutputDatabasePath == "" {
+		return nil, fmt.Errorf("output database path is required")
+	}
+
 	return &scanner{
 		config: config,
 	}, nil
 }
 
+// Run scans the input directory and persists the parse results into the
+// database. Scanning into an existing database replaces the results of
+// files scanned earlier from the same input directory.
 func (s *scanner) Run() error {
+	db, err := sql.Open("sqlite3", s.config.OutputDatabasePath+"?_foreign_keys=on")
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+
+	defer db.Close()
+
+	if !s.config.SkipInitSchema {
+		if err := initSchema(db); err != nil {
+			return fmt.Errorf("failed to initialize schema: %w", err)
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Rollback is a no-op once the transaction is committed
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	store, err := newStore(tx, s.config.InputDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to create store: %w", err)
+	}
+
+	if err := s.scan(context.Background(), store); err != nil {
+		return err
+	}
+
+	if err := store.finish(); err != nil {
+		return fmt.Errorf("failed to finish scan: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	log.Infof("Scanned %d files from %s into %s", len(store.fileIDs),
+		s.config.InputDirectory, s.config.OutputDatabasePath)
+
+	return nil
+}
+
+func (s *scanner) scan(ctx context.Context, store *store) error {
+	fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+		AppDirectories: []string{s.config.InputDirectory},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create local filesystem: %w", err)
+	}
+
+	languages, err := lang.AllLanguages()
+	if err != nil {
+		return fmt.Errorf("failed to get all languages: %w", err)
+	}
+
+	walker, err := fs.NewSourceWalker(fs.SourceWalkerConfig{}, languages)
+	if err != nil {
+		return fmt.Errorf("failed to create source walker: %w", err)
+	}
+
+	treeWalker, err := parser.NewWalkingParser(walker, languages)
+	if err != nil {
+		return fmt.Errorf("failed to create tree walker: %w", err)
+	}
+
+	// The AST plugin must run first since it records the file
+	// which the results of the other plugins refer to
+	plugins := []core.Plugin{
+		newASTPlugin(store, languages),
+		callgraph.NewCallGraphPlugin(store.saveCallGraph),
+		depsusage.NewDependencyUsagePlugin(store.saveUsageEvidence),
+	}
+
+	pluginExecutor, err := plugin.NewTreeWalkPluginExecutor(treeWalker, plugins)
+	if err != nil {
+		return fmt.Errorf("failed to create plugin executor: %w", err)
+	}
+
+	if err := pluginExecutor.Execute(ctx, fileSystem); err != nil {
+		return fmt.Errorf("failed to execute plugins: %w", err)
+	}
+
 	return nil
 }
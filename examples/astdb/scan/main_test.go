@@ -0,0 +1,190 @@
+package scan
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func copyFixtures(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	entries, err := os.ReadDir("fixtures/app")
+	assert.NoError(t, err)
+
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join("fixtures/app", entry.Name()))
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, entry.Name()), data, 0o600))
+	}
+
+	return dir
+}
+
+func runScan(t *testing.T, config Config) {
+	t.Helper()
+
+	scanner, err := New(config)
+	assert.NoError(t, err)
+	assert.NoError(t, scanner.Run())
+}
+
+func queryStrings(t *testing.T, db *sql.DB, query string, args ...any) []string {
+	t.Helper()
+
+	rows, err := db.Query(query, args...)
+	assert.NoError(t, err)
+
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		assert.NoError(t, rows.Scan(&value))
+		values = append(values, value)
+	}
+
+	assert.NoError(t, rows.Err())
+	return values
+}
+
+func countRows(t *testing.T, db *sql.DB, table string) int {
+	t.Helper()
+
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM "+table).Scan(&count))
+	return count
+}
+
+func TestScanner(t *testing.T) {
+	t.Run("should reject missing configuration", func(t *testing.T) {
+		_, err := New(Config{InputDirectory: "fixtures/app"})
+		assert.Error(t, err)
+
+		_, err = New(Config{OutputDatabasePath: "ast.db"})
+		assert.Error(t, err)
+	})
+
+	t.Run("should persist parse results", func(t *testing.T) {
+		inputDir := copyFixtures(t)
+		dbPath := filepath.Join(t.TempDir(), "ast.db")
+		runScan(t, Config{InputDirectory: inputDir, OutputDatabasePath: dbPath})
+
+		db, err := sql.Open("sqlite3", dbPath)
+		assert.NoError(t, err)
+
+		defer db.Close()
+
+		mainPy := filepath.Join(inputDir, "main.py")
+		indexJs := filepath.Join(inputDir, "index.js")
+
+		assert.ElementsMatch(t, []string{mainPy + ":python", indexJs + ":javascript"},
+			queryStrings(t, db, `SELECT f.path || ':' || l.code FROM files f JOIN languages l ON l.id = f.language_id`))
+
+		// Which files import requests
+		assert.Equal(t, []string{mainPy}, queryStrings(t, db,
+			`SELECT DISTINCT f.path FROM imports i JOIN files f ON f.id = i.file_id WHERE i.module_name = ?`, "requests"))
+
+		assert.ElementsMatch(t, []string{"main.py:fetch:Fetcher:6", "main.py:fetch:CachingFetcher:11",
+			"main.py:main::17", "index.js:loadConfig::4"}, queryStrings(t, db,
+			`SELECT REPLACE(f.path, ?, '') || ':' || fn.name || ':' || fn.parent_class_name || ':' || fn.start_line
+				FROM functions fn JOIN files f ON f.id = fn.file_id`, inputDir+string(filepath.Separator)))
+
+		assert.ElementsMatch(t, []string{"Fetcher", "CachingFetcher"},
+			queryStrings(t, db, `SELECT name FROM classes`))
+
+		assert.Equal(t, []string{"CachingFetcher:Fetcher"},
+			queryStrings(t, db, `SELECT child_class_name || ':' || parent_class_name FROM inheritance_edges`))
+
+		// Who calls requests.get
+		assert.Equal(t, []string{mainPy + "//Fetcher//fetch:7"}, queryStrings(t, db,
+			`SELECT caller_namespace || ':' || start_line FROM call_edges WHERE callee_namespace = ?`, "requests//get"))
+
+		assert.Contains(t, queryStrings(t, db,
+			`SELECT module_name || ':' || identifier || ':' || line FROM usage_evidences`), "fs:readFileSync:5")
+	})
+
+	t.Run("should replace results when re-scanning", func(t *testing.T) {
+		inputDir := copyFixtures(t)
+		dbPath := filepath.Join(t.TempDir(), "ast.db")
+		runScan(t, Config{InputDirectory: inputDir, OutputDatabasePath: dbPath})
+
+		db, err := sql.Open("sqlite3", dbPath)
+		assert.NoError(t, err)
+
+		defer db.Close()
+
+		tables := []string{"files", "imports", "functions", "classes", "inheritance_edges",
+			"call_edges", "usage_evidences"}
+
+		counts := map[string]int{}
+		for _, table := range tables {
+			counts[table] = countRows(t, db, table)
+			assert.NotZero(t, counts[table], table)
+		}
+
+		runScan(t, Config{InputDirectory: inputDir, OutputDatabasePath: dbPath, SkipInitSchema: true})
+
+		for _, table := range tables {
+			assert.Equal(t, counts[table], countRows(t, db, table), table)
+		}
+
+		assert.Equal(t, 2, countRows(t, db, "scans"))
+
+		// Files deleted from the input directory are removed on re-scan
+		assert.NoError(t, os.Remove(filepath.Join(inputDir, "main.py")))
+		runScan(t, Config{InputDirectory: inputDir, OutputDatabasePath: dbPath})
+
+		assert.Equal(t, []string{filepath.Join(inputDir, "index.js")}, queryStrings(t, db, `SELECT path FROM files`))
+		assert.Zero(t, countRows(t, db, "classes"))
+	})
+
+	t.Run("should persist every language of host documents", func(t *testing.T) {
+		inputDir := t.TempDir()
+		indexHTML := filepath.Join(inputDir, "index.html")
+		assert.NoError(t, os.WriteFile(indexHTML, []byte(`<html>
+  <script>
+    import axios from "axios";
+    function load() {}
+  </script>
+  <script lang="ts">
+    import lodash from "lodash";
+    function render(): void {}
+  </script>
+</html>
+`), 0o600))
+
+		dbPath := filepath.Join(t.TempDir(), "ast.db")
+		runScan(t, Config{InputDirectory: inputDir, OutputDatabasePath: dbPath})
+		runScan(t, Config{InputDirectory: inputDir, OutputDatabasePath: dbPath, SkipInitSchema: true})
+
+		db, err := sql.Open("sqlite3", dbPath)
+		assert.NoError(t, err)
+
+		defer db.Close()
+
+		assert.ElementsMatch(t, []string{indexHTML + ":javascript", indexHTML + ":typescript"},
+			queryStrings(t, db, `SELECT f.path || ':' || l.code FROM files f JOIN languages l ON l.id = f.language_id`))
+
+		assert.ElementsMatch(t, []string{"javascript:axios", "typescript:lodash"}, queryStrings(t, db,
+			`SELECT l.code || ':' || i.module_name FROM imports i JOIN files f ON f.id = i.file_id
+				JOIN languages l ON l.id = f.language_id`))
+
+		assert.ElementsMatch(t, []string{"load:4", "render:8"},
+			queryStrings(t, db, `SELECT name || ':' || start_line FROM functions`))
+	})
+
+	t.Run("should fail without schema when skipping schema initialization", func(t *testing.T) {
+		scanner, err := New(Config{
+			InputDirectory:     "fixtures/app",
+			OutputDatabasePath: filepath.Join(t.TempDir(), "ast.db"),
+			SkipInitSchema:     true,
+		})
+		assert.NoError(t, err)
+		assert.Error(t, scanner.Run())
+	})
+}
@@ -0,0 +1,107 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/safedep/code/core"
+)
+
+// astPlugin records the file along with the imports, functions,
+// classes and inheritance edges resolved from its parse tree
+type astPlugin struct {
+	store     *store
+	languages []core.LanguageCode
+}
+
+// Verify contract
+var _ core.TreePlugin = (*astPlugin)(nil)
+
+func newASTPlugin(store *store, languages []core.Language) *astPlugin {
+	codes := make([]core.LanguageCode, 0, len(languages))
+	for _, language := range languages {
+		codes = append(codes, language.Meta().Code)
+	}
+
+	return &astPlugin{
+		store:     store,
+		languages: codes,
+	}
+}
+
+func (p *astPlugin) Name() string {
+	return "ASTPlugin"
+}
+
+func (p *astPlugin) SupportedLanguages() []core.LanguageCode {
+	return p.languages
+}
+
+func (p *astPlugin) AnalyzeTree(ctx context.Context, tree core.ParseTree) error {
+	language, err := tree.Language()
+	if err != nil {
+		return fmt.Errorf("failed to get language: %w", err)
+	}
+
+	file, err := tree.File()
+	if err != nil {
+		return fmt.Errorf("failed to get file: %w", err)
+	}
+
+	data, err := tree.Data()
+	if err != nil {
+		return fmt.Errorf("failed to get tree data: %w", err)
+	}
+
+	fileID, err := p.store.saveFile(file.Name(), language, len(*data))
+	if err != nil {
+		return err
+	}
+
+	resolvers := language.Resolvers()
+
+	imports, err := resolvers.ResolveImports(tree)
+	if err != nil {
+		return fmt.Errorf("failed to resolve imports: %w", err)
+	}
+
+	for _, imp := range imports {
+		if err := p.store.saveImport(fileID, imp); err != nil {
+			return err
+		}
+	}
+
+	functions, err := resolvers.ResolveFunctions(tree)
+	if err != nil {
+		return fmt.Errorf("failed to resolve functions: %w", err)
+	}
+
+	for _, function := range functions {
+		if err := p.store.saveFunction(fileID, function); err != nil {
+			return err
+		}
+	}
+
+	ooResolvers, ok := resolvers.(core.ObjectOrientedLanguageResolvers)
+	if !ok {
+		return nil
+	}
+
+	classes, err := ooResolvers.ResolveClasses(tree)
+	if err != nil {
+		return fmt.Errorf("failed to resolve classes: %w", err)
+	}
+
+	for _, class := range classes {
+		if err := p.store.saveClass(fileID, class); err != nil {
+			return err
+		}
+	}
+
+	inheritance, err := ooResolvers.ResolveInheritance(tree)
+	if err != nil {
+		return fmt.Errorf("failed to resolve inheritance: %w", err)
+	}
+
+	return p.store.saveInheritance(fileID, inheritance)
+}
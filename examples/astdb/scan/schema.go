@@ -0,0 +1,20 @@
+package scan
+
+import (
+	"database/sql"
+	_ "embed"
+	"fmt"
+)
+
+//go:embed schema.sql
+var schema string
+
+// initSchema creates the tables and indexes of the AST database.
+// It is idempotent so that existing databases can be scanned into
+func initSchema(db *sql.DB) error {
+	if _, err := db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to execute schema: %w", err)
+	}
+
+	return nil
+}
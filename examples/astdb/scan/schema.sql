@@ -0,0 +1,116 @@
+-- AST database schema
+--
+-- Lines are 1-based and columns are 0-based byte offsets within the line,
+-- matching the positions reported by tree-sitter.
+
+CREATE TABLE IF NOT EXISTS scans (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    input_directory TEXT NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS languages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    code TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS files (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    scan_id INTEGER NOT NULL REFERENCES scans(id),
+    language_id INTEGER NOT NULL REFERENCES languages(id),
+    input_directory TEXT NOT NULL,
+    path TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    -- Host documents eg. HTML files have a row per embedded language
+    UNIQUE (path, language_id)
+);
+
+CREATE TABLE IF NOT EXISTS imports (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    file_id INTEGER NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    module_name TEXT NOT NULL,
+    module_item TEXT NOT NULL,
+    module_alias TEXT NOT NULL,
+    is_wildcard BOOLEAN NOT NULL,
+    start_line INTEGER NOT NULL,
+    start_column INTEGER NOT NULL,
+    end_line INTEGER NOT NULL,
+    end_column INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS functions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    file_id INTEGER NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    function_type TEXT NOT NULL,
+    access_modifier TEXT NOT NULL,
+    parent_class_name TEXT NOT NULL,
+    is_abstract BOOLEAN NOT NULL,
+    is_static BOOLEAN NOT NULL,
+    is_async BOOLEAN NOT NULL,
+    start_line INTEGER NOT NULL,
+    start_column INTEGER NOT NULL,
+    end_line INTEGER NOT NULL,
+    end_column INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS classes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    file_id INTEGER NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    access_modifier TEXT NOT NULL,
+    is_abstract BOOLEAN NOT NULL,
+    start_line INTEGER NOT NULL,
+    start_column INTEGER NOT NULL,
+    end_line INTEGER NOT NULL,
+    end_column INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS inheritance_edges (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    file_id INTEGER NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    child_class_name TEXT NOT NULL,
+    parent_class_name TEXT NOT NULL,
+    relationship_type TEXT NOT NULL,
+    line INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS call_edges (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    file_id INTEGER NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    caller_namespace TEXT NOT NULL,
+    callee_namespace TEXT NOT NULL,
+    start_line INTEGER,
+    start_column INTEGER,
+    end_line INTEGER,
+    end_column INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS usage_evidences (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    file_id INTEGER NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    package_hint TEXT NOT NULL,
+    module_name TEXT NOT NULL,
+    module_item TEXT NOT NULL,
+    module_alias TEXT NOT NULL,
+    is_wildcard_usage BOOLEAN NOT NULL,
+    identifier TEXT NOT NULL,
+    line INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_files_input_directory ON files(input_directory);
+CREATE INDEX IF NOT EXISTS idx_imports_file_id ON imports(file_id);
+CREATE INDEX IF NOT EXISTS idx_imports_module_name ON imports(module_name);
+CREATE INDEX IF NOT EXISTS idx_functions_file_id ON functions(file_id);
+CREATE INDEX IF NOT EXISTS idx_functions_name ON functions(name);
+CREATE INDEX IF NOT EXISTS idx_classes_file_id ON classes(file_id);
+CREATE INDEX IF NOT EXISTS idx_classes_name ON classes(name);
+CREATE INDEX IF NOT EXISTS idx_inheritance_edges_file_id ON inheritance_edges(file_id);
+CREATE INDEX IF NOT EXISTS idx_inheritance_edges_parent ON inheritance_edges(parent_class_name);
+CREATE INDEX IF NOT EXISTS idx_call_edges_file_id ON call_edges(file_id);
+CREATE INDEX IF NOT EXISTS idx_call_edges_caller ON call_edges(caller_namespace);
+CREATE INDEX IF NOT EXISTS idx_call_edges_callee ON call_edges(callee_namespace);
+CREATE INDEX IF NOT EXISTS idx_usage_evidences_file_id ON usage_evidences(file_id);
+CREATE INDEX IF NOT EXISTS idx_usage_evidences_package_hint ON usage_evidences(package_hint);
@@ -0,0 +1,264 @@
+package scan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/core/ast"
+	"github.com/safedep/code/plugin/callgraph"
+	"github.com/safedep/code/plugin/depsusage"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// store persists the results of a single scan within a transaction
+type store struct {
+	tx             *sql.Tx
+	scanID         int64
+	inputDirectory string
+	languageIDs    map[core.LanguageCode]int64
+
+	// File recorded last for a path, the results of the plugins running
+	// after the AST plugin refer to it. Host documents eg. HTML files are
+	// recorded for every embedded language as their trees are analyzed
+	fileIDs map[string]int64
+}
+
+func newStore(tx *sql.Tx, inputDirectory string) (*store, error) {
+	res, err := tx.Exec("INSERT INTO scans (input_directory, started_at) VALUES (?, ?)",
+		inputDirectory, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert scan: %w", err)
+	}
+
+	scanID, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scan id: %w", err)
+	}
+
+	return &store{
+		tx:             tx,
+		scanID:         scanID,
+		inputDirectory: inputDirectory,
+		languageIDs:    make(map[core.LanguageCode]int64),
+		fileIDs:        make(map[string]int64),
+	}, nil
+}
+
+// finish removes files of earlier scans of the input directory which
+// were not found in this scan, eg. deleted files
+func (s *store) finish() error {
+	_, err := s.tx.Exec("DELETE FROM files WHERE input_directory = ? AND scan_id <> ?",
+		s.inputDirectory, s.scanID)
+	if err != nil {
+		return fmt.Errorf("failed to delete stale files: %w", err)
+	}
+
+	_, err = s.tx.Exec("UPDATE scans SET finished_at = ? WHERE id = ?", time.Now().UTC(), s.scanID)
+	if err != nil {
+		return fmt.Errorf("failed to update scan: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) languageID(language core.Language) (int64, error) {
+	code := language.Meta().Code
+	if id, ok := s.languageIDs[code]; ok {
+		return id, nil
+	}
+
+	_, err := s.tx.Exec("INSERT OR IGNORE INTO languages (code, name) VALUES (?, ?)",
+		string(code), language.Meta().Name)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert language: %w", err)
+	}
+
+	var id int64
+	err = s.tx.QueryRow("SELECT id FROM languages WHERE code = ?", string(code)).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get language id: %w", err)
+	}
+
+	s.languageIDs[code] = id
+	return id, nil
+}
+
+// saveFile records a file in a language, replacing the results of an earlier
+// scan of the same file in the language
+func (s *store) saveFile(path string, language core.Language, size int) (int64, error) {
+	languageID, err := s.languageID(language)
+	if err != nil {
+		return 0, err
+	}
+
+	// Results of the file are removed through cascading deletes
+	_, err = s.tx.Exec("DELETE FROM files WHERE path = ? AND language_id = ?", path, languageID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	res, err := s.tx.Exec(`INSERT INTO files (scan_id, language_id, input_directory, path, size)
+		VALUES (?, ?, ?, ?, ?)`, s.scanID, languageID, s.inputDirectory, path, size)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert file: %w", err)
+	}
+
+	fileID, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get file id: %w", err)
+	}
+
+	s.fileIDs[path] = fileID
+	return fileID, nil
+}
+
+func (s *store) fileID(path string) (int64, error) {
+	fileID, ok := s.fileIDs[path]
+	if !ok {
+		return 0, fmt.Errorf("file not recorded: %s", path)
+	}
+
+	return fileID, nil
+}
+
+func (s *store) saveImport(fileID int64, imp *ast.ImportNode) error {
+	pos := nodePosition(imp.GetModuleNameNode())
+	_, err := s.tx.Exec(`INSERT INTO imports (file_id, module_name, module_item, module_alias, is_wildcard,
+		start_line, start_column, end_line, end_column) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		fileID, imp.ModuleName(), imp.ModuleItem(), imp.ModuleAlias(), imp.IsWildcardImport(),
+		pos.startLine, pos.startColumn, pos.endLine, pos.endColumn)
+	if err != nil {
+		return fmt.Errorf("failed to insert import: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) saveFunction(fileID int64, function *ast.FunctionDeclarationNode) error {
+	pos := nodePosition(declarationNode(function.GetFunctionNameNode()))
+	_, err := s.tx.Exec(`INSERT INTO functions (file_id, name, function_type, access_modifier, parent_class_name,
+		is_abstract, is_static, is_async, start_line, start_column, end_line, end_column)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		fileID, function.FunctionName(), string(function.GetFunctionType()), string(function.GetAccessModifier()),
+		function.GetParentClassName(), function.IsAbstract(), function.IsStatic(), function.IsAsync(),
+		pos.startLine, pos.startColumn, pos.endLine, pos.endColumn)
+	if err != nil {
+		return fmt.Errorf("failed to insert function: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) saveClass(fileID int64, class *ast.ClassDeclarationNode) error {
+	pos := nodePosition(declarationNode(class.GetClassNameNode()))
+	_, err := s.tx.Exec(`INSERT INTO classes (file_id, name, access_modifier, is_abstract,
+		start_line, start_column, end_line, end_column) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		fileID, class.ClassName(), string(class.AccessModifier()), class.IsAbstract(),
+		pos.startLine, pos.startColumn, pos.endLine, pos.endColumn)
+	if err != nil {
+		return fmt.Errorf("failed to insert class: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) saveInheritance(fileID int64, graph *ast.InheritanceGraph) error {
+	for _, className := range graph.GetAllClasses() {
+		for _, rel := range graph.GetDirectParents(className) {
+			_, err := s.tx.Exec(`INSERT INTO inheritance_edges (file_id, child_class_name, parent_class_name,
+				relationship_type, line) VALUES (?, ?, ?, ?, ?)`,
+				fileID, rel.ChildClassName, rel.ParentClassName, string(rel.RelationshipType), rel.LineNumber)
+			if err != nil {
+				return fmt.Errorf("failed to insert inheritance edge: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *store) saveCallGraph(_ context.Context, cg *callgraph.CallGraph) error {
+	fileID, err := s.fileID(cg.FileName)
+	if err != nil {
+		return err
+	}
+
+	// Sorted for a stable insertion order across scans
+	callers := make([]string, 0, len(cg.Nodes))
+	for namespace := range cg.Nodes {
+		callers = append(callers, namespace)
+	}
+	slices.Sort(callers)
+
+	for _, caller := range callers {
+		for _, call := range cg.Nodes[caller].CallsTo {
+			var startLine, startColumn, endLine, endColumn sql.NullInt64
+			if call.CallerIdentifier != nil {
+				pos := nodePosition(call.CallerIdentifier)
+				startLine = sql.NullInt64{Int64: int64(pos.startLine), Valid: true}
+				startColumn = sql.NullInt64{Int64: int64(pos.startColumn), Valid: true}
+				endLine = sql.NullInt64{Int64: int64(pos.endLine), Valid: true}
+				endColumn = sql.NullInt64{Int64: int64(pos.endColumn), Valid: true}
+			}
+
+			_, err := s.tx.Exec(`INSERT INTO call_edges (file_id, caller_namespace, callee_namespace,
+				start_line, start_column, end_line, end_column) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+				fileID, caller, call.CalleeNamespace, startLine, startColumn, endLine, endColumn)
+			if err != nil {
+				return fmt.Errorf("failed to insert call edge: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *store) saveUsageEvidence(_ context.Context, evidence *depsusage.UsageEvidence) error {
+	fileID, err := s.fileID(evidence.FilePath)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.tx.Exec(`INSERT INTO usage_evidences (file_id, package_hint, module_name, module_item,
+		module_alias, is_wildcard_usage, identifier, line) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		fileID, evidence.PackageHint, evidence.ModuleName, evidence.ModuleItem, evidence.ModuleAlias,
+		evidence.IsWildCardUsage, evidence.Identifier, evidence.Line)
+	if err != nil {
+		return fmt.Errorf("failed to insert usage evidence: %w", err)
+	}
+
+	return nil
+}
+
+// position of a node with 1-based lines and 0-based columns
+type position struct {
+	startLine, startColumn, endLine, endColumn uint32
+}
+
+func nodePosition(node *sitter.Node) position {
+	if node == nil {
+		return position{}
+	}
+
+	start, end := node.StartPoint(), node.EndPoint()
+	return position{
+		startLine:   start.Row + 1,
+		startColumn: start.Column,
+		endLine:     end.Row + 1,
+		endColumn:   end.Column,
+	}
+}
+
+// declarationNode returns the declaration enclosing the name of a
+// function or class so that the position covers the whole declaration
+func declarationNode(nameNode *sitter.Node) *sitter.Node {
+	if nameNode == nil || nameNode.Parent() == nil {
+		return nameNode
+	}
+
+	return nameNode.Parent()
+}
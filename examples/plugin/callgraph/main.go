@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/safedep/code/core"
@@ -19,6 +20,7 @@ import (
 var (
 	dirToWalk string
 	language  string
+	sarifPath string
 )
 
 func init() {
@@ -26,6 +28,7 @@ func init() {
 
 	flag.StringVar(&dirToWalk, "dir", "", "Directory to walk")
 	flag.StringVar(&language, "lang", "python", "Language to use for parsing files")
+	flag.StringVar(&sarifPath, "sarif", "", "Write signature matches as SARIF to this file")
 
 	flag.Parse()
 }
@@ -65,6 +68,10 @@ func run() error {
 		return fmt.Errorf("failed to create tree walker: %w", err)
 	}
 
+	sarifReporter := callgraph.NewSarifReporter(callgraph.SarifReporterConfig{
+		SourceRoot: dirToWalk,
+	})
+
 	// consume callgraph
 	var callgraphCallback callgraph.CallgraphCallback = func(_ context.Context, cg *callgraph.CallGraph) error {
 		err := cg.PrintAssignmentGraph()
@@ -118,6 +125,11 @@ func run() error {
 			return fmt.Errorf("failed to match signatures: %w", err)
 		}
 
+		err = sarifReporter.AddMatches(cg, signatureMatches)
+		if err != nil {
+			return fmt.Errorf("failed to add signature matches to sarif report: %w", err)
+		}
+
 		fmt.Printf("\nSignature matches for %s:\n", cg.FileName)
 		for _, match := range signatureMatches {
 			fmt.Printf("Match found: %s (%s)\n", match.MatchedSignature.Id, match.MatchedLanguageCode)
@@ -167,5 +179,21 @@ func run() error {
 		return fmt.Errorf("failed to create plugin executor: %w", err)
 	}
 
-	return pluginExecutor.Execute(context.Background(), fileSystem)
+	err = pluginExecutor.Execute(context.Background(), fileSystem)
+	if err != nil {
+		return fmt.Errorf("failed to execute plugin: %w", err)
+	}
+
+	if sarifPath == "" {
+		return nil
+	}
+
+	sarifFile, err := os.Create(sarifPath)
+	if err != nil {
+		return fmt.Errorf("failed to create sarif file: %w", err)
+	}
+
+	defer sarifFile.Close()
+
+	return sarifReporter.Write(sarifFile)
 }
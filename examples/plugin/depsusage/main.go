@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/safedep/code/core"
@@ -18,6 +19,7 @@ import (
 var (
 	dirToWalk string
 	languages arrayFlags
+	report    bool
 )
 
 type arrayFlags []string
@@ -35,6 +37,7 @@ func init() {
 
 	flag.StringVar(&dirToWalk, "dir", "", "Directory to walk")
 	flag.Var(&languages, "lang", "Languages to use for parsing files")
+	flag.BoolVar(&report, "report", false, "Report unused, undeclared and wildcard only dependencies as JSON")
 
 	flag.Parse()
 }
@@ -86,19 +89,46 @@ func run() error {
 		return fmt.Errorf("failed to create tree walker: %w", err)
 	}
 
+	// resolve package hints using the manifests found in the directory
+	packageHintResolver, err := depsusage.NewManifestPackageHintResolver(context.Background(), fileSystem)
+	if err != nil {
+		return fmt.Errorf("failed to create package hint resolver: %w", err)
+	}
+
 	// consume usage evidences
 	var usageCallback depsusage.DependencyUsageCallback = func(ctx context.Context, evidence *depsusage.UsageEvidence) error {
 		fmt.Println(evidence)
 		return nil
 	}
 
+	reportBuilder := depsusage.NewDependencyReportBuilder(packageHintResolver)
+	if report {
+		usageCallback = reportBuilder.Callback()
+	}
+
 	pluginExecutor, err := plugin.NewTreeWalkPluginExecutor(treeWalker, []core.Plugin{
-		depsusage.NewDependencyUsagePlugin(usageCallback),
+		depsusage.NewDependencyUsagePluginWithConfig(depsusage.DependencyUsagePluginConfig{
+			PackageHintResolver: packageHintResolver,
+		}, usageCallback),
 	})
 
 	if err != nil {
 		return fmt.Errorf("failed to create plugin executor: %w", err)
 	}
 
-	return pluginExecutor.Execute(context.Background(), fileSystem)
+	err = pluginExecutor.Execute(context.Background(), fileSystem)
+	if err != nil {
+		return fmt.Errorf("failed to execute plugin executor: %w", err)
+	}
+
+	if !report {
+		return nil
+	}
+
+	dependencyReport, err := reportBuilder.Build()
+	if err != nil {
+		return fmt.Errorf("failed to build dependency report: %w", err)
+	}
+
+	return dependencyReport.Write(os.Stdout)
 }
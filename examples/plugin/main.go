@@ -17,6 +17,7 @@ import (
 var (
 	dirToWalk string
 	languages arrayFlags
+	workers   int
 )
 
 type arrayFlags []string
@@ -34,6 +35,7 @@ func init() {
 
 	flag.StringVar(&dirToWalk, "dir", "", "Directory to walk")
 	flag.Var(&languages, "lang", "Languages to use for parsing files")
+	flag.IntVar(&workers, "workers", 0, "Number of workers to analyze files in parallel")
 
 	flag.Parse()
 }
@@ -115,14 +117,24 @@ func run() error {
 		return fmt.Errorf("failed to create source walker: %w", err)
 	}
 
-	treeWalker, err := parser.NewWalkingParser(walker, filteredLanguages)
-	if err != nil {
-		return fmt.Errorf("failed to create tree walker: %w", err)
+	plugins := []core.Plugin{
+		&exampleTreePlugin{},
 	}
 
-	pluginExecutor, err := plugin.NewTreeWalkPluginExecutor(treeWalker, []core.Plugin{
-		&exampleTreePlugin{},
-	})
+	var pluginExecutor plugin.PluginExecutor
+	if workers > 0 {
+		pluginExecutor, err = plugin.NewParallelPluginExecutor(walker, filteredLanguages, plugins,
+			plugin.ParallelPluginExecutorConfig{
+				Workers: workers,
+			})
+	} else {
+		treeWalker, treeWalkerErr := parser.NewWalkingParser(walker, filteredLanguages)
+		if treeWalkerErr != nil {
+			return fmt.Errorf("failed to create tree walker: %w", treeWalkerErr)
+		}
+
+		pluginExecutor, err = plugin.NewTreeWalkPluginExecutor(treeWalker, plugins)
+	}
 
 	if err != nil {
 		return fmt.Errorf("failed to create plugin executor: %w", err)
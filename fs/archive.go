@@ -0,0 +1,662 @@
+package fs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/dry/log"
+)
+
+// Separates the path of an archive from the path of an entry within it
+// eg. app.jar!/lib/inner.jar!/com/acme/Main.java
+const archiveEntrySeparator = "!/"
+
+const (
+	defaultArchiveMaxEntrySize    = 32 * 1024 * 1024
+	defaultArchiveMaxSize         = 1024 * 1024 * 1024
+	defaultArchiveMaxNestingDepth = 3
+)
+
+var (
+	errArchiveEntryTooLarge = errors.New("archive entry exceeds size limit")
+	errArchiveTooLarge      = errors.New("archive exceeds size limit")
+	errArchiveWalkStopped   = errors.New("archive walk stopped")
+	errArchiveEntryNotFound = errors.New("archive entry not found")
+)
+
+type archiveFormat int
+
+const (
+	archiveFormatUnknown archiveFormat = iota
+	archiveFormatZip
+	archiveFormatTar
+	archiveFormatTarGzip
+)
+
+// Archive formats are identified by extension. Wheels, eggs, jars and
+// Go module zips are zip files while sdists, npm and cargo packages are
+// gzip compressed tar files.
+var archiveExtensions = []struct {
+	extension string
+	format    archiveFormat
+}{
+	{".tar.gz", archiveFormatTarGzip},
+	{".tgz", archiveFormatTarGzip},
+	{".crate", archiveFormatTarGzip},
+	{".tar", archiveFormatTar},
+	{".zip", archiveFormatZip},
+	{".jar", archiveFormatZip},
+	{".war", archiveFormatZip},
+	{".ear", archiveFormatZip},
+	{".whl", archiveFormatZip},
+	{".egg", archiveFormatZip},
+	{".nupkg", archiveFormatZip},
+}
+
+func resolveArchiveFormat(name string) archiveFormat {
+	name = strings.ToLower(name)
+	for _, archiveExtension := range archiveExtensions {
+		if strings.HasSuffix(name, archiveExtension.extension) {
+			return archiveExtension.format
+		}
+	}
+
+	return archiveFormatUnknown
+}
+
+type archiveFile struct {
+	name     string
+	entry    string
+	isImport bool
+	open     func() (io.ReadCloser, error)
+}
+
+var _ core.File = (*archiveFile)(nil)
+
+func (f *archiveFile) Name() string {
+	return f.name
+}
+
+func (f *archiveFile) Reader() (io.ReadCloser, error) {
+	return f.open()
+}
+
+func (f *archiveFile) IsApp() bool {
+	return !f.isImport
+}
+
+func (f *archiveFile) IsImport() bool {
+	return f.isImport
+}
+
+type ArchiveFileSystemConfig struct {
+	// The archives containing 1st party source files
+	AppArchives []string
+
+	// The archives containing 3rd party source files
+	// imported by the application
+	ImportArchives []string
+
+	// Regular expressions to exclude entries from traversal. Patterns
+	// are matched against the file name eg. app.jar!/com/acme/Main.java
+	ExcludePatterns []*regexp.Regexp
+
+	// Maximum uncompressed size of an entry, including nested archives.
+	// Larger entries are skipped. Defaults to 32 MiB.
+	MaxEntrySize int64
+
+	// Maximum uncompressed size of all the entries of an archive, including
+	// nested archives. Enumeration fails when exceeded. Defaults to 1 GiB.
+	MaxArchiveSize int64
+
+	// Maximum depth of archives nested within archives. Deeper archives
+	// are skipped. Defaults to 3.
+	MaxNestingDepth int
+}
+
+type archiveFileSystem struct {
+	config ArchiveFileSystemConfig
+}
+
+// NewArchiveFileSystem creates a file system which enumerates the entries of
+// archives without extracting them to disk. Archives nested within archives eg.
+// jars within a war are enumerated as well. Entries with paths escaping the
+// root of the archive (zip slip) are skipped.
+//
+// Files are named with the path of the archive and the path of the entry
+// separated by "!/" eg. app.whl!/app/main.py while Find accepts the path
+// of the entry eg. app/main.py
+func NewArchiveFileSystem(config ArchiveFileSystemConfig) (core.ImportAwareFileSystem, error) {
+	for _, archive := range append(append([]string{}, config.AppArchives...), config.ImportArchives...) {
+		if resolveArchiveFormat(archive) == archiveFormatUnknown {
+			return nil, fmt.Errorf("unsupported archive format: %s", archive)
+		}
+	}
+
+	if config.MaxEntrySize <= 0 {
+		config.MaxEntrySize = defaultArchiveMaxEntrySize
+	}
+
+	if config.MaxArchiveSize <= 0 {
+		config.MaxArchiveSize = defaultArchiveMaxSize
+	}
+
+	if config.MaxNestingDepth <= 0 {
+		config.MaxNestingDepth = defaultArchiveMaxNestingDepth
+	}
+
+	return &archiveFileSystem{config: config}, nil
+}
+
+func (fs *archiveFileSystem) Find(ctx context.Context, name string) (core.File, error) {
+	entry := path.Clean(filepath.ToSlash(name))
+
+	for _, archive := range fs.config.AppArchives {
+		file, err := fs.findFileInArchive(ctx, archive, entry, false)
+		if err == nil {
+			return file, nil
+		}
+
+		if !errors.Is(err, errArchiveEntryNotFound) {
+			return nil, fmt.Errorf("error finding in app archive: %s: %w", archive, err)
+		}
+	}
+
+	for _, archive := range fs.config.ImportArchives {
+		file, err := fs.findFileInArchive(ctx, archive, entry, true)
+		if err == nil {
+			return file, nil
+		}
+
+		if !errors.Is(err, errArchiveEntryNotFound) {
+			return nil, fmt.Errorf("error finding in import archive: %s: %w", archive, err)
+		}
+	}
+
+	return nil, fmt.Errorf("file not found: %s", name)
+}
+
+func (fs *archiveFileSystem) EnumerateApp(ctx context.Context, callback func(core.File) error) error {
+	for _, archive := range fs.config.AppArchives {
+		if err := fs.enumerateArchive(ctx, archive, false, callback); err != nil {
+			return fmt.Errorf("error enumerating app archive: %s: %w", archive, err)
+		}
+	}
+
+	return nil
+}
+
+func (fs *archiveFileSystem) EnumerateImports(ctx context.Context, callback func(core.File) error) error {
+	for _, archive := range fs.config.ImportArchives {
+		if err := fs.enumerateArchive(ctx, archive, true, callback); err != nil {
+			return fmt.Errorf("error enumerating import archive: %s: %w", archive, err)
+		}
+	}
+
+	return nil
+}
+
+func (fs *archiveFileSystem) Enumerate(ctx context.Context, callback func(core.File) error) error {
+	err := fs.EnumerateApp(ctx, callback)
+	if err != nil {
+		return err
+	}
+
+	return fs.EnumerateImports(ctx, callback)
+}
+
+// findFileInArchive walks an archive for an entry, entries other than the
+// entry and the archives nesting it are skipped without being read
+func (fs *archiveFileSystem) findFileInArchive(ctx context.Context, archive, entry string, isImport bool) (core.File, error) {
+	var found core.File
+	walker := &archiveWalker{
+		ctx:      ctx,
+		config:   fs.config,
+		isImport: isImport,
+		find:     entry,
+		callback: func(f core.File) error {
+			found = f
+			return errArchiveWalkStopped
+		},
+	}
+
+	err := walker.walk(archive+archiveEntrySeparator, "", resolveArchiveFormat(archive), localArchiveSource(archive), 0)
+	if found != nil {
+		return found, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("%w: %s", errArchiveEntryNotFound, entry)
+}
+
+func (fs *archiveFileSystem) enumerateArchive(ctx context.Context, archive string, isImport bool,
+	callback func(core.File) error) error {
+	walker := &archiveWalker{
+		ctx:      ctx,
+		config:   fs.config,
+		isImport: isImport,
+		callback: callback,
+	}
+
+	return walker.walk(archive+archiveEntrySeparator, "", resolveArchiveFormat(archive), localArchiveSource(archive), 0)
+}
+
+// archiveSource provides random access to the contents of an archive. Archives on disk
+// are opened every time so that entries can be read after enumeration, possibly
+// concurrently, without holding file handles. Nested archives are held in memory.
+type archiveSource func() (io.ReaderAt, int64, io.Closer, error)
+
+func localArchiveSource(archive string) archiveSource {
+	return func() (io.ReaderAt, int64, io.Closer, error) {
+		file, err := os.Open(archive)
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to open archive: %w", err)
+		}
+
+		st, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, 0, nil, fmt.Errorf("failed to stat archive: %w", err)
+		}
+
+		return file, st.Size(), file, nil
+	}
+}
+
+func memoryArchiveSource(data []byte) archiveSource {
+	return func() (io.ReaderAt, int64, io.Closer, error) {
+		return bytes.NewReader(data), int64(len(data)), nopCloser{}, nil
+	}
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+type archiveWalker struct {
+	ctx      context.Context
+	config   ArchiveFileSystemConfig
+	isImport bool
+	callback func(core.File) error
+
+	// Path of the entry looked up by Find relative to the root archive,
+	// only the entry is visited when set
+	find string
+
+	// Uncompressed bytes of all the entries seen so far
+	size int64
+}
+
+// walk enumerates the entries of an archive. namePrefix is prepended to the entry paths
+// to build file names while entryPrefix is the path of the archive relative to the root
+// archive which is empty for the root archive itself
+func (w *archiveWalker) walk(namePrefix, entryPrefix string, format archiveFormat, source archiveSource, depth int) error {
+	switch format {
+	case archiveFormatZip:
+		return w.walkZip(namePrefix, entryPrefix, source, depth)
+	case archiveFormatTar:
+		return w.walkTar(namePrefix, entryPrefix, source, false, depth)
+	case archiveFormatTarGzip:
+		return w.walkTar(namePrefix, entryPrefix, source, true, depth)
+	default:
+		return fmt.Errorf("unsupported archive format: %s", namePrefix)
+	}
+}
+
+func (w *archiveWalker) walkZip(namePrefix, entryPrefix string, source archiveSource, depth int) error {
+	readerAt, size, closer, err := source()
+	if err != nil {
+		return err
+	}
+
+	defer closer.Close()
+
+	zipReader, err := zip.NewReader(readerAt, size)
+	if err != nil {
+		return fmt.Errorf("failed to read zip archive: %w", err)
+	}
+
+	for _, zipEntry := range zipReader.File {
+		if err := w.cancelled(); err != nil {
+			return err
+		}
+
+		if !zipEntry.Mode().IsRegular() {
+			continue
+		}
+
+		entryPath, ok := w.entryPath(namePrefix, zipEntry.Name, int64(zipEntry.UncompressedSize64))
+		if !ok {
+			continue
+		}
+
+		nestedFormat := resolveArchiveFormat(entryPath)
+		if !w.wanted(entryPrefix+entryPath, nestedFormat) {
+			continue
+		}
+
+		if nestedFormat != archiveFormatUnknown {
+			reader, err := zipEntry.Open()
+			if err != nil {
+				return fmt.Errorf("failed to open nested archive: %s: %w", entryPath, err)
+			}
+
+			err = w.walkNested(namePrefix, entryPrefix, entryPath, nestedFormat, reader, depth)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// Zip entries are read after enumeration, hence they are accounted with
+		// their declared size while reading them is bounded by MaxEntrySize
+		if err := w.account(int64(zipEntry.UncompressedSize64)); err != nil {
+			return err
+		}
+
+		offset, err := zipEntry.DataOffset()
+		if err != nil {
+			return fmt.Errorf("failed to locate zip entry: %s: %w", entryPath, err)
+		}
+
+		method, compressedSize, maxEntrySize := zipEntry.Method, int64(zipEntry.CompressedSize64), w.config.MaxEntrySize
+		err = w.visit(namePrefix, entryPrefix, entryPath, func() (io.ReadCloser, error) {
+			return openZipEntry(source, method, offset, compressedSize, maxEntrySize)
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *archiveWalker) walkTar(namePrefix, entryPrefix string, source archiveSource, gzipped bool, depth int) error {
+	readerAt, size, closer, err := source()
+	if err != nil {
+		return err
+	}
+
+	defer closer.Close()
+
+	var reader io.Reader = io.NewSectionReader(readerAt, 0, size)
+	if gzipped {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return fmt.Errorf("failed to read gzip stream: %w", err)
+		}
+
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read tar archive: %w", err)
+		}
+
+		if err := w.cancelled(); err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		entryPath, ok := w.entryPath(namePrefix, header.Name, header.Size)
+		if !ok {
+			continue
+		}
+
+		// Entries not wanted are skipped by the next call to Next without being read
+		nestedFormat := resolveArchiveFormat(entryPath)
+		if !w.wanted(entryPrefix+entryPath, nestedFormat) {
+			continue
+		}
+
+		if nestedFormat != archiveFormatUnknown {
+			err = w.walkNested(namePrefix, entryPrefix, entryPath, nestedFormat, io.NopCloser(tarReader), depth)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// Tar archives are streamed, hence entries are held
+		// in memory to be read after enumeration
+		data, err := readArchiveEntry(io.NopCloser(tarReader), w.config.MaxEntrySize)
+		if err != nil {
+			return fmt.Errorf("failed to read tar entry: %s: %w", entryPath, err)
+		}
+
+		if err := w.account(int64(len(data))); err != nil {
+			return err
+		}
+
+		err = w.visit(namePrefix, entryPrefix, entryPath, func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *archiveWalker) walkNested(namePrefix, entryPrefix, entryPath string, format archiveFormat,
+	reader io.ReadCloser, depth int) error {
+	defer reader.Close()
+
+	if depth+1 > w.config.MaxNestingDepth {
+		log.Warnf("Skipping nested archive %s%s: nesting depth exceeds %d",
+			namePrefix, entryPath, w.config.MaxNestingDepth)
+		return nil
+	}
+
+	data, err := readArchiveEntry(reader, w.config.MaxEntrySize)
+	if err != nil {
+		return fmt.Errorf("failed to read nested archive: %s: %w", entryPath, err)
+	}
+
+	if err := w.account(int64(len(data))); err != nil {
+		return err
+	}
+
+	return w.walk(namePrefix+entryPath+archiveEntrySeparator, entryPrefix+entryPath+archiveEntrySeparator,
+		format, memoryArchiveSource(data), depth+1)
+}
+
+func (w *archiveWalker) visit(namePrefix, entryPrefix, entryPath string, open func() (io.ReadCloser, error)) error {
+	return w.callback(&archiveFile{
+		name:     namePrefix + entryPath,
+		entry:    entryPrefix + entryPath,
+		isImport: w.isImport,
+		open:     open,
+	})
+}
+
+// entryPath validates an entry of the archive and returns its normalised path
+func (w *archiveWalker) entryPath(namePrefix, name string, size int64) (string, bool) {
+	entryPath, safe := safeArchiveEntryPath(name)
+	if !safe {
+		log.Warnf("Skipping archive entry %s%s: path escapes the archive", namePrefix, name)
+		return "", false
+	}
+
+	if w.skipPattern(namePrefix + entryPath) {
+		return "", false
+	}
+
+	if size > w.config.MaxEntrySize {
+		log.Warnf("Skipping archive entry %s%s: size %d exceeds %d",
+			namePrefix, entryPath, size, w.config.MaxEntrySize)
+		return "", false
+	}
+
+	return entryPath, true
+}
+
+// wanted checks whether an entry is visited, which is every entry unless an
+// entry is looked up. Nested archives are wanted when they nest the entry
+func (w *archiveWalker) wanted(entry string, format archiveFormat) bool {
+	if w.find == "" {
+		return true
+	}
+
+	if format != archiveFormatUnknown {
+		return strings.HasPrefix(w.find, entry+archiveEntrySeparator)
+	}
+
+	return w.find == entry
+}
+
+func (w *archiveWalker) cancelled() error {
+	select {
+	case <-w.ctx.Done():
+		return fmt.Errorf("enumeration cancelled by context: %w", w.ctx.Err())
+	default:
+		return nil
+	}
+}
+
+func (w *archiveWalker) account(size int64) error {
+	w.size += size
+	if w.size > w.config.MaxArchiveSize {
+		return fmt.Errorf("%w: %d bytes", errArchiveTooLarge, w.config.MaxArchiveSize)
+	}
+
+	return nil
+}
+
+func (w *archiveWalker) skipPattern(name string) bool {
+	for _, pattern := range w.config.ExcludePatterns {
+		if pattern.MatchString(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// safeArchiveEntryPath normalises the path of an archive entry and rejects
+// absolute paths and paths traversing outside of the archive root (zip slip)
+func safeArchiveEntryPath(name string) (string, bool) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if name == "" || strings.HasPrefix(name, "/") || filepath.VolumeName(name) != "" ||
+		(len(name) > 1 && name[1] == ':') {
+		return "", false
+	}
+
+	cleaned := path.Clean(name)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", false
+	}
+
+	// Nested archive separator in an entry path would make names ambiguous
+	if strings.Contains(cleaned, archiveEntrySeparator) {
+		return "", false
+	}
+
+	return cleaned, true
+}
+
+func openZipEntry(source archiveSource, method uint16, offset, compressedSize, maxEntrySize int64) (io.ReadCloser, error) {
+	readerAt, _, closer, err := source()
+	if err != nil {
+		return nil, err
+	}
+
+	section := io.NewSectionReader(readerAt, offset, compressedSize)
+
+	var reader io.ReadCloser
+	switch method {
+	case zip.Store:
+		reader = io.NopCloser(section)
+	case zip.Deflate:
+		reader = flate.NewReader(section)
+	default:
+		closer.Close()
+		return nil, fmt.Errorf("unsupported zip compression method: %d", method)
+	}
+
+	return &archiveEntryReader{
+		Reader:  &sizeLimitedReader{reader: reader, remaining: maxEntrySize},
+		closers: []io.Closer{reader, closer},
+	}, nil
+}
+
+func readArchiveEntry(reader io.ReadCloser, maxEntrySize int64) ([]byte, error) {
+	defer reader.Close()
+	return io.ReadAll(&sizeLimitedReader{reader: reader, remaining: maxEntrySize})
+}
+
+type archiveEntryReader struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *archiveEntryReader) Close() error {
+	var errs []error
+	for _, closer := range r.closers {
+		errs = append(errs, closer.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// sizeLimitedReader fails reading beyond the limit instead of truncating
+// the content, since sizes declared in archive headers can't be trusted
+type sizeLimitedReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (r *sizeLimitedReader) Read(p []byte) (int, error) {
+	if r.remaining <= 0 {
+		var probe [1]byte
+		n, err := r.reader.Read(probe[:])
+		if n > 0 {
+			return 0, errArchiveEntryTooLarge
+		}
+
+		return 0, err
+	}
+
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+
+	return n, err
+}
@@ -0,0 +1,372 @@
+package fs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/lang"
+	"github.com/stretchr/testify/assert"
+)
+
+type archiveTestEntry struct {
+	name    string
+	content []byte
+}
+
+func buildZipArchive(t *testing.T, entries []archiveTestEntry) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, entry := range entries {
+		w, err := writer.Create(entry.name)
+		assert.NoError(t, err)
+
+		_, err = w.Write(entry.content)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func buildTarGzipArchive(t *testing.T, entries []archiveTestEntry) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	writer := tar.NewWriter(gzipWriter)
+	for _, entry := range entries {
+		err := writer.WriteHeader(&tar.Header{
+			Name:     entry.name,
+			Mode:     0o644,
+			Size:     int64(len(entry.content)),
+			Typeflag: tar.TypeReg,
+		})
+		assert.NoError(t, err)
+
+		_, err = writer.Write(entry.content)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, writer.Close())
+	assert.NoError(t, gzipWriter.Close())
+	return buf.Bytes()
+}
+
+func writeArchive(t *testing.T, name string, data []byte) string {
+	t.Helper()
+
+	archivePath := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(archivePath, data, 0o600))
+
+	return archivePath
+}
+
+func readArchiveFile(t *testing.T, file core.File) string {
+	t.Helper()
+
+	reader, err := file.Reader()
+	assert.NoError(t, err)
+
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+
+	return string(content)
+}
+
+func enumerateArchiveFiles(t *testing.T, fs core.ImportAwareFileSystem) map[string]string {
+	t.Helper()
+
+	files := map[string]string{}
+	err := fs.Enumerate(context.Background(), func(f core.File) error {
+		files[f.Name()] = readArchiveFile(t, f)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	return files
+}
+
+type collectingVisitor struct {
+	files []string
+}
+
+func (v *collectingVisitor) VisitFile(f core.File) error {
+	v.files = append(v.files, f.Name())
+	return nil
+}
+
+func TestArchiveFileSystem(t *testing.T) {
+	wheel := writeArchive(t, "app-1.0-py3-none-any.whl", buildZipArchive(t, []archiveTestEntry{
+		{"app/__init__.py", []byte("")},
+		{"app/main.py", []byte("import requests\n")},
+		{"app-1.0.dist-info/top_level.txt", []byte("app\n")},
+	}))
+
+	npmPackage := writeArchive(t, "left-pad-1.3.0.tgz", buildTarGzipArchive(t, []archiveTestEntry{
+		{"package/package.json", []byte(`{"name": "left-pad"}`)},
+		{"package/index.js", []byte("module.exports = leftPad;\n")},
+	}))
+
+	innerJar := buildZipArchive(t, []archiveTestEntry{
+		{"com/acme/util/Helper.java", []byte("class Helper {}\n")},
+	})
+
+	war := writeArchive(t, "app.war", buildZipArchive(t, []archiveTestEntry{
+		{"WEB-INF/classes/com/acme/Main.java", []byte("class Main {}\n")},
+		{"WEB-INF/lib/util.jar", innerJar},
+	}))
+
+	t.Run("NewArchiveFileSystem", func(t *testing.T) {
+		t.Run("should reject unsupported archive formats", func(t *testing.T) {
+			_, err := NewArchiveFileSystem(ArchiveFileSystemConfig{
+				AppArchives: []string{"app.rar"},
+			})
+
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("Enumerate", func(t *testing.T) {
+		t.Run("should enumerate entries of zip and tar archives", func(t *testing.T) {
+			fs, err := NewArchiveFileSystem(ArchiveFileSystemConfig{
+				AppArchives:    []string{wheel},
+				ImportArchives: []string{npmPackage},
+			})
+			assert.NoError(t, err)
+
+			assert.Equal(t, map[string]string{
+				wheel + "!/app/__init__.py":                 "",
+				wheel + "!/app/main.py":                     "import requests\n",
+				wheel + "!/app-1.0.dist-info/top_level.txt": "app\n",
+				npmPackage + "!/package/package.json":       `{"name": "left-pad"}`,
+				npmPackage + "!/package/index.js":           "module.exports = leftPad;\n",
+			}, enumerateArchiveFiles(t, fs))
+		})
+
+		t.Run("should distinguish app and import archives", func(t *testing.T) {
+			fs, err := NewArchiveFileSystem(ArchiveFileSystemConfig{
+				AppArchives:    []string{wheel},
+				ImportArchives: []string{npmPackage},
+			})
+			assert.NoError(t, err)
+
+			err = fs.EnumerateApp(context.Background(), func(f core.File) error {
+				assert.True(t, strings.HasPrefix(f.Name(), wheel))
+				assert.True(t, f.IsApp())
+				assert.False(t, f.IsImport())
+				return nil
+			})
+			assert.NoError(t, err)
+
+			err = fs.EnumerateImports(context.Background(), func(f core.File) error {
+				assert.True(t, strings.HasPrefix(f.Name(), npmPackage))
+				assert.True(t, f.IsImport())
+				assert.False(t, f.IsApp())
+				return nil
+			})
+			assert.NoError(t, err)
+		})
+
+		t.Run("should enumerate entries of nested archives", func(t *testing.T) {
+			fs, err := NewArchiveFileSystem(ArchiveFileSystemConfig{
+				AppArchives: []string{war},
+			})
+			assert.NoError(t, err)
+
+			assert.Equal(t, map[string]string{
+				war + "!/WEB-INF/classes/com/acme/Main.java":              "class Main {}\n",
+				war + "!/WEB-INF/lib/util.jar!/com/acme/util/Helper.java": "class Helper {}\n",
+			}, enumerateArchiveFiles(t, fs))
+		})
+
+		t.Run("should skip nested archives beyond the nesting depth", func(t *testing.T) {
+			nested := writeArchive(t, "nested.zip", buildZipArchive(t, []archiveTestEntry{
+				{"app.war", buildZipArchive(t, []archiveTestEntry{{"lib/util.jar", innerJar}})},
+			}))
+
+			fs, err := NewArchiveFileSystem(ArchiveFileSystemConfig{
+				AppArchives:     []string{nested},
+				MaxNestingDepth: 1,
+			})
+			assert.NoError(t, err)
+
+			assert.Empty(t, enumerateArchiveFiles(t, fs))
+		})
+
+		t.Run("should skip entries escaping the archive root", func(t *testing.T) {
+			malicious := writeArchive(t, "malicious.zip", buildZipArchive(t, []archiveTestEntry{
+				{"../../etc/evil.py", []byte("evil")},
+				{"/etc/absolute.py", []byte("evil")},
+				{"pkg/../../evil.py", []byte("evil")},
+				{"C:\\windows\\evil.py", []byte("evil")},
+				{"pkg/./safe.py", []byte("safe")},
+			}))
+
+			maliciousTar := writeArchive(t, "malicious.tar.gz", buildTarGzipArchive(t, []archiveTestEntry{
+				{"../evil.js", []byte("evil")},
+				{"package/safe.js", []byte("safe")},
+			}))
+
+			fs, err := NewArchiveFileSystem(ArchiveFileSystemConfig{
+				AppArchives: []string{malicious, maliciousTar},
+			})
+			assert.NoError(t, err)
+
+			assert.Equal(t, map[string]string{
+				malicious + "!/pkg/safe.py":        "safe",
+				maliciousTar + "!/package/safe.js": "safe",
+			}, enumerateArchiveFiles(t, fs))
+		})
+
+		t.Run("should skip entries larger than the entry size limit", func(t *testing.T) {
+			large := writeArchive(t, "large.tgz", buildTarGzipArchive(t, []archiveTestEntry{
+				{"package/large.js", bytes.Repeat([]byte("a"), 1024)},
+				{"package/small.js", []byte("small")},
+			}))
+
+			fs, err := NewArchiveFileSystem(ArchiveFileSystemConfig{
+				AppArchives:  []string{large},
+				MaxEntrySize: 512,
+			})
+			assert.NoError(t, err)
+
+			assert.Equal(t, map[string]string{
+				large + "!/package/small.js": "small",
+			}, enumerateArchiveFiles(t, fs))
+		})
+
+		t.Run("should fail when the archive size limit is exceeded", func(t *testing.T) {
+			fs, err := NewArchiveFileSystem(ArchiveFileSystemConfig{
+				AppArchives:    []string{npmPackage},
+				MaxArchiveSize: 16,
+			})
+			assert.NoError(t, err)
+
+			err = fs.Enumerate(context.Background(), func(f core.File) error {
+				return nil
+			})
+			assert.ErrorIs(t, err, errArchiveTooLarge)
+		})
+
+		t.Run("should respect exclude patterns", func(t *testing.T) {
+			fs, err := NewArchiveFileSystem(ArchiveFileSystemConfig{
+				AppArchives:     []string{wheel},
+				ExcludePatterns: []*regexp.Regexp{regexp.MustCompile(`\.dist-info/`)},
+			})
+			assert.NoError(t, err)
+
+			assert.Equal(t, map[string]string{
+				wheel + "!/app/__init__.py": "",
+				wheel + "!/app/main.py":     "import requests\n",
+			}, enumerateArchiveFiles(t, fs))
+		})
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		fs, err := NewArchiveFileSystem(ArchiveFileSystemConfig{
+			AppArchives:    []string{war},
+			ImportArchives: []string{npmPackage},
+		})
+		assert.NoError(t, err)
+
+		t.Run("should find an entry by path", func(t *testing.T) {
+			file, err := fs.Find(context.Background(), "package/index.js")
+
+			assert.NoError(t, err)
+			assert.Equal(t, npmPackage+"!/package/index.js", file.Name())
+			assert.True(t, file.IsImport())
+			assert.Equal(t, "module.exports = leftPad;\n", readArchiveFile(t, file))
+		})
+
+		t.Run("should find an entry of a nested archive", func(t *testing.T) {
+			file, err := fs.Find(context.Background(), "WEB-INF/lib/util.jar!/com/acme/util/Helper.java")
+
+			assert.NoError(t, err)
+			assert.True(t, file.IsApp())
+			assert.Equal(t, "class Helper {}\n", readArchiveFile(t, file))
+		})
+
+		t.Run("should return an error if the entry is not found", func(t *testing.T) {
+			file, err := fs.Find(context.Background(), "package/missing.js")
+
+			assert.Error(t, err)
+			assert.Nil(t, file)
+		})
+
+		t.Run("should read the entry looked up only", func(t *testing.T) {
+			large := writeArchive(t, "large.tgz", buildTarGzipArchive(t, []archiveTestEntry{
+				{"package/large.js", bytes.Repeat([]byte("a"), 1024)},
+				{"package/small.js", []byte("small")},
+			}))
+
+			fs, err := NewArchiveFileSystem(ArchiveFileSystemConfig{
+				AppArchives:    []string{large},
+				MaxArchiveSize: 512,
+			})
+			assert.NoError(t, err)
+
+			err = fs.Enumerate(context.Background(), func(f core.File) error {
+				return nil
+			})
+			assert.ErrorIs(t, err, errArchiveTooLarge)
+
+			file, err := fs.Find(context.Background(), "package/small.js")
+			assert.NoError(t, err)
+			assert.Equal(t, "small", readArchiveFile(t, file))
+		})
+
+		t.Run("should return an error if the archive can not be read", func(t *testing.T) {
+			broken := writeArchive(t, "broken.tgz", []byte("not a gzip stream"))
+
+			fs, err := NewArchiveFileSystem(ArchiveFileSystemConfig{
+				AppArchives: []string{broken},
+			})
+			assert.NoError(t, err)
+
+			file, err := fs.Find(context.Background(), "package/index.js")
+			assert.ErrorContains(t, err, "failed to read gzip stream")
+			assert.Nil(t, file)
+		})
+	})
+
+	t.Run("should walk source files within archives", func(t *testing.T) {
+		fs, err := NewArchiveFileSystem(ArchiveFileSystemConfig{
+			AppArchives:    []string{wheel},
+			ImportArchives: []string{npmPackage},
+		})
+		assert.NoError(t, err)
+
+		python, err := lang.GetLanguage(string(core.LanguageCodePython))
+		assert.NoError(t, err)
+
+		javascript, err := lang.GetLanguage(string(core.LanguageCodeJavascript))
+		assert.NoError(t, err)
+
+		walker, err := NewSourceWalker(SourceWalkerConfig{IncludeImports: true}, []core.Language{python, javascript})
+		assert.NoError(t, err)
+
+		visitor := &collectingVisitor{}
+		err = walker.Walk(context.Background(), fs, visitor)
+		assert.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			wheel + "!/app/__init__.py",
+			wheel + "!/app/main.py",
+			npmPackage + "!/package/index.js",
+		}, visitor.files)
+	})
+}
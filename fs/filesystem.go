@@ -81,9 +81,24 @@ type LocalFileSystemConfig struct {
 	// imported by the application
 	ImportDirectories []string
 
-	// Regular expressions to exclude files or directories
-	// from traversal
+	// Regular expressions to exclude files from traversal, matched against
+	// the path of every file. Directories are traversed even if their path
+	// matches, use ExcludePresets or ignore files to prune directories
 	ExcludePatterns []*regexp.Regexp
+
+	// Presets of directories to exclude from traversal eg.
+	// ExcludePresetNodeModules, use DefaultExcludePresets to exclude
+	// version control, dependency, build and cache directories
+	ExcludePresets []ExcludePreset
+
+	// Exclude files ignored by git, read from the .gitignore files of the
+	// directories and their parents in the repository and .git/info/exclude
+	RespectGitIgnore bool
+
+	// Optional name of a tool specific ignore file using the gitignore
+	// syntax eg. .codeignore, read from the same directories as .gitignore
+	// files. Its patterns take precedence over .gitignore files
+	IgnoreFileName string
 }
 
 type localFileSystem struct {
@@ -163,8 +178,17 @@ func (fs *localFileSystem) findFileInDir(ctx context.Context, dir, name string,
 	return nil, fmt.Errorf("file not found: %s", name)
 }
 
+// enumerateDir enumerates the files of a directory, directories excluded by
+// the ignore rules are pruned such that their children are never visited.
+// The directory itself is enumerated even if excluded eg. an import directory
+// which is a node_modules directory
 func (fs *localFileSystem) enumerateDir(ctx context.Context,
 	root string, isImport bool, callback func(core.File) error) error {
+	matcher, err := fs.ignoreMatcher(root)
+	if err != nil {
+		return fmt.Errorf("error reading ignore files: %w", err)
+	}
+
 	return filepath.WalkDir(root, func(path string, info os.DirEntry, err error) error {
 		if err != nil {
 			return fmt.Errorf("error walking %s: %w", path, err)
@@ -176,11 +200,32 @@ func (fs *localFileSystem) enumerateDir(ctx context.Context,
 		default:
 		}
 
-		if fs.skipPattern(path) {
+		if !info.IsDir() && fs.skipPattern(path) {
 			return nil
 		}
 
+		if path != root {
+			ignored, err := fs.ignored(matcher, path, info.IsDir())
+			if err != nil {
+				return err
+			}
+
+			if ignored && info.IsDir() {
+				return filepath.SkipDir
+			}
+
+			if ignored {
+				return nil
+			}
+		}
+
 		if info.IsDir() {
+			if matcher != nil {
+				if err := matcher.loadDir(path); err != nil {
+					return fmt.Errorf("error reading ignore files: %w", err)
+				}
+			}
+
 			return nil
 		}
 
@@ -198,6 +243,30 @@ func (fs *localFileSystem) enumerateDir(ctx context.Context,
 	})
 }
 
+// ignoreMatcher creates the matcher of the ignore rules for a directory,
+// it returns nil if no ignore rules are configured
+func (fs *localFileSystem) ignoreMatcher(root string) (*ignoreMatcher, error) {
+	if len(fs.config.ExcludePresets) == 0 && !fs.config.RespectGitIgnore && fs.config.IgnoreFileName == "" {
+		return nil, nil
+	}
+
+	return newIgnoreMatcher(root, fs.config.ExcludePresets, fs.config.RespectGitIgnore, fs.config.IgnoreFileName)
+}
+
+// ignored checks whether a path is excluded by the ignore rules
+func (fs *localFileSystem) ignored(matcher *ignoreMatcher, path string, isDir bool) (bool, error) {
+	if matcher == nil {
+		return false, nil
+	}
+
+	ignored, err := matcher.ignored(path, isDir)
+	if err != nil {
+		return false, fmt.Errorf("error matching ignore rules: %w", err)
+	}
+
+	return ignored, nil
+}
+
 func (fs *localFileSystem) skipPattern(dir string) bool {
 	for _, pattern := range fs.config.ExcludePatterns {
 		if pattern.MatchString(dir) {
@@ -0,0 +1,8 @@
+#pragma once
+
+namespace util {
+class Buffer {
+public:
+  std::string data;
+};
+}
@@ -0,0 +1,381 @@
+package fs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ExcludePreset is a set of directories commonly excluded from analysis
+// eg. dependencies installed by a package manager or build outputs
+type ExcludePreset string
+
+const (
+	// Version control metadata eg. .git
+	ExcludePresetVCS ExcludePreset = "vcs"
+
+	// Installed JavaScript dependencies eg. node_modules
+	ExcludePresetNodeModules ExcludePreset = "node_modules"
+
+	// Build outputs eg. dist, target
+	ExcludePresetBuildOutput ExcludePreset = "build_output"
+
+	// Python bytecode and tool caches eg. __pycache__
+	ExcludePresetPythonCache ExcludePreset = "python_cache"
+
+	// Python virtual environments eg. .venv
+	ExcludePresetVirtualEnv ExcludePreset = "virtualenv"
+
+	// Vendored dependencies eg. vendor in Go and PHP projects
+	ExcludePresetVendor ExcludePreset = "vendor"
+)
+
+// Patterns of the presets in the gitignore syntax
+var excludePresetPatterns = map[ExcludePreset][]string{
+	ExcludePresetVCS:         {".git/", ".hg/", ".svn/"},
+	ExcludePresetNodeModules: {"node_modules/", "bower_components/"},
+	ExcludePresetBuildOutput: {"dist/", "build/", "target/"},
+	ExcludePresetPythonCache: {"__pycache__/", ".pytest_cache/", ".mypy_cache/", ".tox/", "*.pyc"},
+	ExcludePresetVirtualEnv:  {".venv/", "venv/"},
+	ExcludePresetVendor:      {"vendor/"},
+}
+
+// DefaultExcludePresets are the presets excluding the directories which are
+// rarely of interest for analysis of an application. Vendored dependencies
+// are not excluded since they are part of the source of the application
+var DefaultExcludePresets = []ExcludePreset{
+	ExcludePresetVCS,
+	ExcludePresetNodeModules,
+	ExcludePresetBuildOutput,
+	ExcludePresetPythonCache,
+	ExcludePresetVirtualEnv,
+}
+
+const (
+	gitIgnoreFileName   = ".gitignore"
+	gitDirectoryName    = ".git"
+	gitInfoExcludePath  = "info/exclude"
+	ignorePathSeparator = "/"
+)
+
+// ignorePattern is a pattern in the gitignore syntax, matched against
+// slash separated paths relative to the directory of its ignore file
+type ignorePattern struct {
+	pattern *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// ignoreMatcher matches paths against the patterns of ignore files, where
+// the last matching pattern decides whether a path is ignored such that
+// patterns of nested ignore files take precedence over their parents
+type ignoreMatcher struct {
+	// Root of the repository, patterns are matched against paths relative
+	// to it. It is the enumerated directory if not in a git repository
+	root string
+
+	// Ignore files read from every directory
+	fileNames []string
+
+	// Patterns by the directory of their ignore file relative to the root,
+	// such that only the patterns of the directories enclosing a path are
+	// matched against it
+	patterns map[string][]ignorePattern
+}
+
+// newIgnoreMatcher creates a matcher for a directory being enumerated. Ignore
+// files of the parent directories up to the root of the git repository are
+// read along with .git/info/exclude, ignore files of the directory and its
+// children are read by loadDir while they are enumerated
+func newIgnoreMatcher(dir string, presets []ExcludePreset, respectGitIgnore bool,
+	ignoreFileName string) (*ignoreMatcher, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	m := &ignoreMatcher{root: absDir}
+	for _, preset := range presets {
+		patterns, ok := excludePresetPatterns[preset]
+		if !ok {
+			return nil, fmt.Errorf("unknown exclude preset: %s", preset)
+		}
+
+		for _, pattern := range patterns {
+			m.addPattern("", pattern)
+		}
+	}
+
+	if respectGitIgnore {
+		m.fileNames = append(m.fileNames, gitIgnoreFileName)
+
+		// Git never enumerates its own metadata
+		m.addPattern("", gitDirectoryName+ignorePathSeparator)
+
+		if repositoryRoot, ok := findRepositoryRoot(absDir); ok {
+			m.root = repositoryRoot
+
+			err := m.loadFile("", filepath.Join(repositoryRoot, gitDirectoryName, gitInfoExcludePath))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if ignoreFileName != "" {
+		m.fileNames = append(m.fileNames, ignoreFileName)
+	}
+
+	// Ignore files of the parent directories within the repository
+	relDir, err := filepath.Rel(m.root, absDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relative path: %w", err)
+	}
+
+	if relDir != "." {
+		parent := m.root
+		for _, name := range strings.Split(relDir, string(filepath.Separator)) {
+			if err := m.loadDir(parent); err != nil {
+				return nil, err
+			}
+
+			parent = filepath.Join(parent, name)
+		}
+	}
+
+	return m, nil
+}
+
+// findRepositoryRoot finds the closest directory having a .git directory
+func findRepositoryRoot(dir string) (string, bool) {
+	for {
+		if st, err := os.Stat(filepath.Join(dir, gitDirectoryName)); err == nil && st.IsDir() {
+			return dir, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+
+		dir = parent
+	}
+}
+
+// loadDir reads the ignore files of a directory
+func (m *ignoreMatcher) loadDir(dir string) error {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	base, err := m.relativePath(absDir)
+	if err != nil {
+		return err
+	}
+
+	for _, fileName := range m.fileNames {
+		if err := m.loadFile(base, filepath.Join(absDir, fileName)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadFile reads the patterns of an ignore file, missing files are skipped
+func (m *ignoreMatcher) loadFile(base string, path string) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to open ignore file: %w", err)
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		m.addPattern(base, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read ignore file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// addPattern adds a line of an ignore file of the base directory, blank
+// lines and comments are skipped
+func (m *ignoreMatcher) addPattern(base string, line string) {
+	line = strings.TrimSuffix(line, "\r")
+
+	// Trailing spaces are ignored unless escaped
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+		line = line[:len(line)-1]
+	}
+
+	if line == "" || strings.HasPrefix(line, "#") {
+		return
+	}
+
+	pattern := ignorePattern{}
+	if strings.HasPrefix(line, "!") {
+		pattern.negate = true
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, ignorePathSeparator) {
+		pattern.dirOnly = true
+		line = strings.TrimSuffix(line, ignorePathSeparator)
+	}
+
+	// Patterns having a separator are relative to the directory
+	// of the ignore file, others match at any depth
+	anchored := strings.Contains(line, ignorePathSeparator)
+	line = strings.TrimPrefix(line, ignorePathSeparator)
+
+	if line == "" {
+		return
+	}
+
+	expression := ignorePatternExpression(line)
+	if !anchored {
+		expression = "(?:.*/)?" + expression
+	}
+
+	compiled, err := regexp.Compile("^" + expression + "$")
+	if err != nil {
+		return
+	}
+
+	pattern.pattern = compiled
+
+	if m.patterns == nil {
+		m.patterns = make(map[string][]ignorePattern)
+	}
+
+	m.patterns[base] = append(m.patterns[base], pattern)
+}
+
+// ignorePatternExpression translates a gitignore glob to a regular expression
+func ignorePatternExpression(glob string) string {
+	var expression strings.Builder
+
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if strings.HasPrefix(glob[i:], "**") {
+				rest := glob[i+2:]
+				atStart := i == 0 || glob[i-1] == '/'
+
+				switch {
+				case atStart && strings.HasPrefix(rest, "/"):
+					// Zero or more directories eg. **/foo, a/**/b
+					expression.WriteString("(?:.*/)?")
+					i += 2
+				case atStart && rest == "":
+					// Everything inside eg. a/**
+					expression.WriteString(".*")
+					i++
+				default:
+					expression.WriteString("[^/]*")
+					i++
+				}
+
+				continue
+			}
+
+			expression.WriteString("[^/]*")
+		case '?':
+			expression.WriteString("[^/]")
+		case '[':
+			end := strings.Index(glob[i+1:], "]")
+			if end < 0 {
+				expression.WriteString(regexp.QuoteMeta("["))
+				continue
+			}
+
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+
+			expression.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+			}
+
+			expression.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return expression.String()
+}
+
+// ignored checks whether a path is ignored by the patterns of the directories
+// enclosing it, from the root to its parent such that patterns of nested
+// ignore files take precedence over their parents
+func (m *ignoreMatcher) ignored(path string, isDir bool) (bool, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	relPath, err := m.relativePath(absPath)
+	if err != nil {
+		return false, err
+	}
+
+	ignored := false
+	base, patternPath := "", relPath
+	for {
+		for _, pattern := range m.patterns[base] {
+			if pattern.dirOnly && !isDir {
+				continue
+			}
+
+			if pattern.pattern.MatchString(patternPath) {
+				ignored = !pattern.negate
+			}
+		}
+
+		dir, rest, found := strings.Cut(patternPath, ignorePathSeparator)
+		if !found {
+			break
+		}
+
+		if base != "" {
+			dir = base + ignorePathSeparator + dir
+		}
+
+		base, patternPath = dir, rest
+	}
+
+	return ignored, nil
+}
+
+// relativePath returns the slash separated path relative to the root
+func (m *ignoreMatcher) relativePath(absPath string) (string, error) {
+	relPath, err := filepath.Rel(m.root, absPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get relative path: %w", err)
+	}
+
+	if relPath == "." {
+		return "", nil
+	}
+
+	return filepath.ToSlash(relPath), nil
+}
@@ -0,0 +1,294 @@
+package fs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeFiles creates the files of a directory tree
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+// enumerateRelative enumerates the app files relative to the root
+func enumerateRelative(t *testing.T, root string, config LocalFileSystemConfig) []string {
+	t.Helper()
+
+	fs, err := NewLocalFileSystem(config)
+	assert.NoError(t, err)
+
+	var files []string
+	err = fs.EnumerateApp(context.Background(), func(f core.File) error {
+		relPath, err := filepath.Rel(root, f.Name())
+		if err != nil {
+			return err
+		}
+
+		files = append(files, filepath.ToSlash(relPath))
+		return nil
+	})
+	assert.NoError(t, err)
+
+	return files
+}
+
+func TestIgnoreMatcher(t *testing.T) {
+	testcases := []struct {
+		name     string
+		patterns []string
+		path     string
+		isDir    bool
+		ignored  bool
+	}{
+		{name: "name at any depth", patterns: []string{"*.log"}, path: "a/b/debug.log", ignored: true},
+		{name: "name not matching", patterns: []string{"*.log"}, path: "a/b/debug.txt", ignored: false},
+		{name: "anchored pattern", patterns: []string{"/build"}, path: "build", isDir: true, ignored: true},
+		{name: "anchored pattern in subdirectory", patterns: []string{"/build"}, path: "src/build", isDir: true, ignored: false},
+		{name: "pattern with separator is anchored", patterns: []string{"doc/frotz"}, path: "a/doc/frotz", ignored: false},
+		{name: "directory pattern matches directory", patterns: []string{"cache/"}, path: "a/cache", isDir: true, ignored: true},
+		{name: "directory pattern skips file", patterns: []string{"cache/"}, path: "a/cache", ignored: false},
+		{name: "negation", patterns: []string{"*.log", "!keep.log"}, path: "keep.log", ignored: false},
+		{name: "last pattern wins", patterns: []string{"!keep.log", "*.log"}, path: "keep.log", ignored: true},
+		{name: "leading double asterisk", patterns: []string{"**/foo/bar"}, path: "a/b/foo/bar", ignored: true},
+		{name: "leading double asterisk at root", patterns: []string{"**/foo/bar"}, path: "foo/bar", ignored: true},
+		{name: "trailing double asterisk", patterns: []string{"abc/**"}, path: "abc/x/y", ignored: true},
+		{name: "trailing double asterisk skips directory", patterns: []string{"abc/**"}, path: "abc", isDir: true, ignored: false},
+		{name: "middle double asterisk", patterns: []string{"a/**/b"}, path: "a/x/y/b", ignored: true},
+		{name: "middle double asterisk without directories", patterns: []string{"a/**/b"}, path: "a/b", ignored: true},
+		{name: "asterisk does not match separator", patterns: []string{"a/*.go"}, path: "a/b/c.go", ignored: false},
+		{name: "character class", patterns: []string{"file-[0-9].txt"}, path: "file-1.txt", ignored: true},
+		{name: "negated character class", patterns: []string{"file-[!0-9].txt"}, path: "file-1.txt", ignored: false},
+		{name: "question mark", patterns: []string{"?.txt"}, path: "a.txt", ignored: true},
+		{name: "comment", patterns: []string{"#a.txt"}, path: "#a.txt", ignored: false},
+		{name: "escaped comment", patterns: []string{`\#a.txt`}, path: "#a.txt", ignored: true},
+		{name: "escaped negation", patterns: []string{`\!a.txt`}, path: "!a.txt", ignored: true},
+		{name: "trailing spaces", patterns: []string{"a.txt  "}, path: "a.txt", ignored: true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			root := t.TempDir()
+			matcher := &ignoreMatcher{root: root}
+
+			for _, pattern := range testcase.patterns {
+				matcher.addPattern("", pattern)
+			}
+
+			ignored, err := matcher.ignored(filepath.Join(root, filepath.FromSlash(testcase.path)), testcase.isDir)
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.ignored, ignored)
+		})
+	}
+
+	t.Run("should match patterns relative to the directory of the ignore file", func(t *testing.T) {
+		root := t.TempDir()
+		matcher := &ignoreMatcher{root: root}
+		matcher.addPattern("src", "/generated")
+
+		ignored, err := matcher.ignored(filepath.Join(root, "src", "generated"), true)
+		assert.NoError(t, err)
+		assert.True(t, ignored)
+
+		ignored, err = matcher.ignored(filepath.Join(root, "generated"), true)
+		assert.NoError(t, err)
+		assert.False(t, ignored)
+	})
+
+	t.Run("should match patterns of the enclosing directories only", func(t *testing.T) {
+		root := t.TempDir()
+		matcher := &ignoreMatcher{root: root}
+		matcher.addPattern("src", "*.txt")
+		matcher.addPattern("src/app", "!keep.txt")
+
+		ignored, err := matcher.ignored(filepath.Join(root, "docs", "notes.txt"), false)
+		assert.NoError(t, err)
+		assert.False(t, ignored)
+
+		ignored, err = matcher.ignored(filepath.Join(root, "src", "app", "notes.txt"), false)
+		assert.NoError(t, err)
+		assert.True(t, ignored)
+
+		ignored, err = matcher.ignored(filepath.Join(root, "src", "app", "keep.txt"), false)
+		assert.NoError(t, err)
+		assert.False(t, ignored)
+
+		ignored, err = matcher.ignored(filepath.Join(root, "src", "keep.txt"), false)
+		assert.NoError(t, err)
+		assert.True(t, ignored)
+	})
+}
+
+func TestLocalFileSystemIgnoreRules(t *testing.T) {
+	t.Run("should exclude the directories of the presets", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{
+			"src/app.js":                   "",
+			"src/__pycache__/app.pyc":      "",
+			"node_modules/lodash/index.js": "",
+			"dist/bundle.js":               "",
+			".git/HEAD":                    "",
+			".venv/lib/site.py":            "",
+			"vendor/lib.go":                "",
+		})
+
+		files := enumerateRelative(t, root, LocalFileSystemConfig{
+			AppDirectories: []string{root},
+			ExcludePresets: DefaultExcludePresets,
+		})
+
+		assert.ElementsMatch(t, []string{"src/app.js", "vendor/lib.go"}, files)
+	})
+
+	t.Run("should exclude the toggled presets only", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{
+			"src/app.js":                   "",
+			"node_modules/lodash/index.js": "",
+			"vendor/lib.go":                "",
+		})
+
+		files := enumerateRelative(t, root, LocalFileSystemConfig{
+			AppDirectories: []string{root},
+			ExcludePresets: []ExcludePreset{ExcludePresetVendor},
+		})
+
+		assert.ElementsMatch(t, []string{"src/app.js", "node_modules/lodash/index.js"}, files)
+	})
+
+	t.Run("should enumerate an excluded directory when it is the root", func(t *testing.T) {
+		root := filepath.Join(t.TempDir(), "node_modules")
+		writeFiles(t, root, map[string]string{
+			"lodash/index.js": "",
+		})
+
+		files := enumerateRelative(t, root, LocalFileSystemConfig{
+			AppDirectories: []string{root},
+			ExcludePresets: DefaultExcludePresets,
+		})
+
+		assert.ElementsMatch(t, []string{"lodash/index.js"}, files)
+	})
+
+	t.Run("should match exclude patterns against files only", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{
+			"src/app.js":          "",
+			"generated/schema.js": "",
+			"generated/types.js":  "",
+		})
+
+		// Directories matching a pattern are traversed while their files are
+		// excluded when the pattern matches the path of the file as well
+		files := enumerateRelative(t, root, LocalFileSystemConfig{
+			AppDirectories: []string{root},
+			ExcludePatterns: []*regexp.Regexp{
+				regexp.MustCompile(`/src$`),
+				regexp.MustCompile(`/generated/types\.js$`),
+			},
+		})
+
+		assert.ElementsMatch(t, []string{"src/app.js", "generated/schema.js"}, files)
+	})
+
+	t.Run("should fail for unknown presets", func(t *testing.T) {
+		fs, err := NewLocalFileSystem(LocalFileSystemConfig{
+			AppDirectories: []string{t.TempDir()},
+			ExcludePresets: []ExcludePreset{"unknown"},
+		})
+		assert.NoError(t, err)
+
+		err = fs.EnumerateApp(context.Background(), func(f core.File) error {
+			return nil
+		})
+		assert.ErrorContains(t, err, "unknown exclude preset: unknown")
+	})
+
+	t.Run("should respect gitignore files", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{
+			".git/HEAD":         "",
+			".git/info/exclude": "secrets.txt\n",
+			".gitignore":        "# logs\n*.log\n!keep.log\n/build/\ncache/\n",
+			".codeignore":       "testdata/\n",
+			"app.py":            "",
+			"debug.log":         "",
+			"keep.log":          "",
+			"secrets.txt":       "",
+			"build/out.js":      "",
+			"src/build/gen.py":  "",
+			"src/cache/x.py":    "",
+			"src/.gitignore":    "!important.log\ngenerated.py\n",
+			"src/important.log": "",
+			"src/generated.py":  "",
+			"src/main.py":       "",
+			"src/testdata/a.py": "",
+			"tmp/.gitignore":    "*\n!.gitignore\n",
+			"tmp/file.py":       "",
+		})
+
+		files := enumerateRelative(t, root, LocalFileSystemConfig{
+			AppDirectories:   []string{root},
+			RespectGitIgnore: true,
+			IgnoreFileName:   ".codeignore",
+		})
+
+		assert.ElementsMatch(t, []string{
+			".gitignore",
+			".codeignore",
+			"app.py",
+			"keep.log",
+			"src/.gitignore",
+			"src/build/gen.py",
+			"src/important.log",
+			"src/main.py",
+			"tmp/.gitignore",
+		}, files)
+	})
+
+	t.Run("should not re-include files of an excluded directory", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{
+			".gitignore":           "generated/\n!generated/keep.py\n",
+			"generated/keep.py":    "",
+			"generated/.gitignore": "!keep.py\n",
+			"main.py":              "",
+		})
+
+		files := enumerateRelative(t, root, LocalFileSystemConfig{
+			AppDirectories:   []string{root},
+			RespectGitIgnore: true,
+		})
+
+		assert.ElementsMatch(t, []string{".gitignore", "main.py"}, files)
+	})
+
+	t.Run("should read gitignore files of the parent directories in the repository", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{
+			".git/HEAD":             "",
+			".gitignore":            "*.pyc\n/app/fixtures/\n",
+			"app/main.py":           "",
+			"app/main.pyc":          "",
+			"app/fixtures/input.py": "",
+		})
+
+		files := enumerateRelative(t, filepath.Join(root, "app"), LocalFileSystemConfig{
+			AppDirectories:   []string{filepath.Join(root, "app")},
+			RespectGitIgnore: true,
+		})
+
+		assert.ElementsMatch(t, []string{"main.py"}, files)
+	})
+}
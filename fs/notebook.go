@@ -0,0 +1,267 @@
+package fs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/lang"
+)
+
+// Extension of Jupyter notebooks
+const notebookFileExtension = ".ipynb"
+
+// jupyterNotebook is the subset of the Jupyter notebook format required to
+// extract the code cells. Cells are listed in worksheets up to nbformat 3
+type jupyterNotebook struct {
+	Cells      []jupyterCell `json:"cells"`
+	Worksheets []struct {
+		Cells []jupyterCell `json:"cells"`
+	} `json:"worksheets"`
+	Metadata struct {
+		Kernelspec struct {
+			Language string `json:"language"`
+		} `json:"kernelspec"`
+		LanguageInfo struct {
+			Name string `json:"name"`
+		} `json:"language_info"`
+	} `json:"metadata"`
+}
+
+type jupyterCell struct {
+	CellType string `json:"cell_type"`
+
+	// Source of the cell, named input in code cells up to nbformat 3
+	Source jupyterSource `json:"source"`
+	Input  jupyterSource `json:"input"`
+}
+
+// jupyterSource is the source of a cell, stored either as a string
+// or as a list of lines
+type jupyterSource string
+
+func (s *jupyterSource) UnmarshalJSON(data []byte) error {
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err == nil {
+		*s = jupyterSource(strings.Join(lines, ""))
+		return nil
+	}
+
+	var source string
+	if err := json.Unmarshal(data, &source); err != nil {
+		return fmt.Errorf("failed to unmarshal cell source: %w", err)
+	}
+
+	*s = jupyterSource(source)
+	return nil
+}
+
+// notebookFile is a Jupyter notebook read as the Python source of its code
+// cells. Cells are separated by a blank line, such that each line of a cell
+// is a line of the source and positions are mapped back to the cells
+type notebookFile struct {
+	core.File
+	language core.Language
+	content  []byte
+
+	// Position in the notebook of each row of the source, separators
+	// between cells are mapped to a cell of -1
+	positions []core.NotebookPosition
+}
+
+var _ core.NotebookFile = (*notebookFile)(nil)
+
+// NewNotebookFile creates a core.File reading the code cells of a Jupyter
+// notebook as Python source. IPython magics are rewritten as the calls they
+// are translated to eg. `!pip install requests` is read as
+// `get_ipython().system("pip install requests")`, while `%run helpers.py` is
+// read as `from helpers import *` since it runs the script in the namespace
+// of the notebook. Cells of other languages eg. `%%bash` are skipped.
+//
+// It returns an error if the file is not a notebook of a Python kernel
+func NewNotebookFile(file core.File) (*notebookFile, error) {
+	reader, err := file.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reader for file: %w", err)
+	}
+
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var notebook jupyterNotebook
+	if err := json.Unmarshal(data, &notebook); err != nil {
+		return nil, fmt.Errorf("failed to parse notebook: %w", err)
+	}
+
+	kernelLanguage := notebook.Metadata.LanguageInfo.Name
+	if kernelLanguage == "" {
+		kernelLanguage = notebook.Metadata.Kernelspec.Language
+	}
+
+	if kernelLanguage != "" && !strings.EqualFold(kernelLanguage, "python") {
+		return nil, fmt.Errorf("notebook language not supported: %s", kernelLanguage)
+	}
+
+	language, err := lang.NewPythonLanguage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create language: %w", err)
+	}
+
+	cells := notebook.Cells
+	for _, worksheet := range notebook.Worksheets {
+		cells = append(cells, worksheet.Cells...)
+	}
+
+	var content bytes.Buffer
+	var positions []core.NotebookPosition
+
+	for cellIndex, cell := range cells {
+		if cell.CellType != "code" {
+			continue
+		}
+
+		source := cell.Source
+		if source == "" {
+			source = cell.Input
+		}
+
+		lines := strings.Split(strings.TrimSuffix(string(source), "\n"), "\n")
+		for i, line := range rewriteNotebookCell(lines) {
+			content.WriteString(line)
+			content.WriteString("\n")
+			positions = append(positions, core.NotebookPosition{Cell: cellIndex, Line: uint32(i) + 1})
+		}
+
+		content.WriteString("\n")
+		positions = append(positions, core.NotebookPosition{Cell: -1})
+	}
+
+	return &notebookFile{
+		File:      file,
+		language:  language,
+		content:   content.Bytes(),
+		positions: positions,
+	}, nil
+}
+
+// isNotebookFile checks whether the file is a Jupyter notebook
+func isNotebookFile(name string) bool {
+	return filepath.Ext(name) == notebookFileExtension
+}
+
+func (f *notebookFile) Language() core.Language {
+	return f.language
+}
+
+func (f *notebookFile) Reader() (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(f.content)), nil
+}
+
+func (f *notebookFile) NotebookPosition(row uint32) (core.NotebookPosition, bool) {
+	if int(row) >= len(f.positions) || f.positions[row].Cell < 0 {
+		return core.NotebookPosition{}, false
+	}
+
+	return f.positions[row], true
+}
+
+// Cell magics running the body of the cell as Python eg. %%time
+var notebookPythonCellMagics = map[string]bool{
+	"time":    true,
+	"timeit":  true,
+	"capture": true,
+	"prun":    true,
+	"debug":   true,
+}
+
+var (
+	// Magic or shell command assigned to names eg. `files = !ls`
+	notebookAssignedMagicRegexp = regexp.MustCompile(`^(\s*[A-Za-z_][\w.]*(?:\s*,\s*[A-Za-z_][\w.]*)*\s*=\s*)([!%])(.*)$`)
+
+	// Module path of a script run by %run eg. lib/helpers.py
+	notebookRunScriptRegexp = regexp.MustCompile(`^([A-Za-z_]\w*(?:/[A-Za-z_]\w*)*)\.py$`)
+)
+
+// rewriteNotebookCell rewrites the IPython syntax of the lines of a cell as
+// Python, keeping one line per line of the cell
+func rewriteNotebookCell(lines []string) []string {
+	rewritten := make([]string, len(lines))
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if i == 0 && strings.HasPrefix(trimmed, "%%") {
+			magic := strings.Fields(strings.TrimPrefix(trimmed, "%%"))
+			if len(magic) > 0 && notebookPythonCellMagics[magic[0]] {
+				continue
+			}
+
+			// The body of the cell is not Python eg. %%bash, %%writefile
+			return rewritten
+		}
+
+		rewritten[i] = rewriteNotebookLine(line)
+	}
+
+	return rewritten
+}
+
+// rewriteNotebookLine rewrites a line having IPython syntax as the Python
+// code IPython translates it to, help requests eg. `df.merge?` are skipped
+func rewriteNotebookLine(line string) string {
+	trimmed := strings.TrimSpace(line)
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+
+	switch {
+	case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+		return line
+	case strings.HasPrefix(trimmed, "!"):
+		return indent + "get_ipython().system(" + strconv.Quote(strings.TrimSpace(trimmed[1:])) + ")"
+	case strings.HasPrefix(trimmed, "%"):
+		return indent + rewriteNotebookLineMagic(strings.TrimPrefix(trimmed, "%"))
+	case strings.HasPrefix(trimmed, "?") || strings.HasSuffix(trimmed, "?"):
+		return ""
+	}
+
+	if match := notebookAssignedMagicRegexp.FindStringSubmatch(line); match != nil {
+		if match[2] == "!" {
+			return match[1] + "get_ipython().getoutput(" + strconv.Quote(strings.TrimSpace(match[3])) + ")"
+		}
+
+		return match[1] + rewriteNotebookLineMagic(match[3])
+	}
+
+	return line
+}
+
+// rewriteNotebookLineMagic rewrites a line magic without the % prefix
+// eg. `pip install requests`
+func rewriteNotebookLineMagic(magic string) string {
+	name, arguments, _ := strings.Cut(strings.TrimSpace(magic), " ")
+	arguments = strings.TrimSpace(arguments)
+
+	if name == "run" {
+		for _, argument := range strings.Fields(arguments) {
+			if strings.HasPrefix(argument, "-") {
+				continue
+			}
+
+			if match := notebookRunScriptRegexp.FindStringSubmatch(argument); match != nil {
+				return "from " + strings.ReplaceAll(match[1], "/", ".") + " import *"
+			}
+
+			break
+		}
+	}
+
+	return "get_ipython().run_line_magic(" + strconv.Quote(name) + ", " + strconv.Quote(arguments) + ")"
+}
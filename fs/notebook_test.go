@@ -0,0 +1,153 @@
+package fs
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/lang"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotebookFile(t *testing.T) {
+	t.Run("NewNotebookFile", func(t *testing.T) {
+		t.Run("should read the code cells as Python source", func(t *testing.T) {
+			reader, err := os.Open("./fixtures/notebook/analysis.ipynb")
+			assert.NoError(t, err)
+
+			file, err := NewNotebookFile(NewFileFromReader(reader, "analysis.ipynb", false))
+			assert.NoError(t, err)
+
+			assert.Equal(t, core.LanguageCodePython, file.Language().Meta().Code)
+			assert.Equal(t, "analysis.ipynb", file.Name())
+
+			source, err := file.Reader()
+			assert.NoError(t, err)
+
+			content, err := io.ReadAll(source)
+			assert.NoError(t, err)
+
+			assert.Equal(t, strings.Join([]string{
+				`get_ipython().system("pip install requests pandas")`,
+				`get_ipython().run_line_magic("matplotlib", "inline")`,
+				`from helpers.plotting import *`,
+				``,
+				`import requests`,
+				`import pandas as pd`,
+				``,
+				``,
+				``,
+				``,
+				``,
+				`files = get_ipython().getoutput("ls data")`,
+				`response = requests.get("https://example.com/data.csv")`,
+				`df = pd.read_csv("data.csv")`,
+				``,
+				``,
+				`for name in files:`,
+				`    get_ipython().run_line_magic("time", "requests.head(name)")`,
+				``,
+				``,
+			}, "\n"), string(content))
+		})
+
+		t.Run("should map the rows of the source to the cells", func(t *testing.T) {
+			reader, err := os.Open("./fixtures/notebook/analysis.ipynb")
+			assert.NoError(t, err)
+
+			file, err := NewNotebookFile(NewFileFromReader(reader, "analysis.ipynb", false))
+			assert.NoError(t, err)
+
+			position, ok := file.NotebookPosition(0)
+			assert.True(t, ok)
+			assert.Equal(t, core.NotebookPosition{Cell: 1, Line: 1}, position)
+
+			position, ok = file.NotebookPosition(5)
+			assert.True(t, ok)
+			assert.Equal(t, core.NotebookPosition{Cell: 2, Line: 2}, position)
+
+			position, ok = file.NotebookPosition(12)
+			assert.True(t, ok)
+			assert.Equal(t, core.NotebookPosition{Cell: 4, Line: 3}, position)
+
+			_, ok = file.NotebookPosition(3)
+			assert.False(t, ok)
+
+			_, ok = file.NotebookPosition(100)
+			assert.False(t, ok)
+
+			assert.Equal(t, &core.NotebookPosition{Cell: 5, Line: 2}, core.GetNotebookPosition(file, 17))
+		})
+
+		t.Run("should fail for notebooks of other languages", func(t *testing.T) {
+			reader, err := os.Open("./fixtures/notebook/analysis.R.ipynb")
+			assert.NoError(t, err)
+
+			_, err = NewNotebookFile(NewFileFromReader(reader, "analysis.R.ipynb", false))
+			assert.ErrorContains(t, err, "notebook language not supported: R")
+		})
+
+		t.Run("should read the cells of nbformat 3 worksheets", func(t *testing.T) {
+			notebook := `{"worksheets": [{"cells": [{"cell_type": "code", "input": ["import os\n", "!ls"]}]}], "nbformat": 3}`
+
+			file, err := NewNotebookFile(NewFileFromReader(strings.NewReader(notebook), "legacy.ipynb", false))
+			assert.NoError(t, err)
+
+			source, err := file.Reader()
+			assert.NoError(t, err)
+
+			content, err := io.ReadAll(source)
+			assert.NoError(t, err)
+
+			assert.Equal(t, "import os\nget_ipython().system(\"ls\")\n\n", string(content))
+		})
+	})
+
+	t.Run("Walk", func(t *testing.T) {
+		fs, err := NewLocalFileSystem(LocalFileSystemConfig{
+			AppDirectories: []string{"./fixtures/notebook"},
+		})
+		assert.NoError(t, err)
+
+		python, err := lang.GetLanguage(string(core.LanguageCodePython))
+		assert.NoError(t, err)
+
+		t.Run("should visit Python notebooks as Python source", func(t *testing.T) {
+			walker, err := NewSourceWalker(SourceWalkerConfig{}, []core.Language{python})
+			assert.NoError(t, err)
+
+			visitor := &languageCollectingVisitor{
+				languages: map[string]core.LanguageCode{},
+				contents:  map[string]string{},
+			}
+
+			err = walker.Walk(context.Background(), fs, visitor)
+			assert.NoError(t, err)
+
+			assert.Equal(t, map[string]core.LanguageCode{
+				"fixtures/notebook/analysis.ipynb": core.LanguageCodePython,
+			}, visitor.languages)
+			assert.Contains(t, visitor.contents["fixtures/notebook/analysis.ipynb"], "import requests\n")
+		})
+
+		t.Run("should skip notebooks when Python is not walked", func(t *testing.T) {
+			c, err := lang.GetLanguage(string(core.LanguageCodeC))
+			assert.NoError(t, err)
+
+			walker, err := NewSourceWalker(SourceWalkerConfig{}, []core.Language{c})
+			assert.NoError(t, err)
+
+			visitor := &languageCollectingVisitor{
+				languages: map[string]core.LanguageCode{},
+				contents:  map[string]string{},
+			}
+
+			err = walker.Walk(context.Background(), fs, visitor)
+			assert.NoError(t, err)
+			assert.Empty(t, visitor.languages)
+		})
+	})
+}
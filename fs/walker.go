@@ -1,11 +1,15 @@
 package fs
 
 import (
+	"bytes"
 	"context"
 	"fmt"
-	"strings"
+	"io"
+	"path/filepath"
 
 	"github.com/safedep/code/core"
+	"github.com/safedep/code/lang"
+	"github.com/safedep/dry/log"
 )
 
 type SourceWalkerConfig struct {
@@ -19,6 +23,32 @@ type sourceWalker struct {
 
 var _ core.SourceWalker = (*sourceWalker)(nil)
 
+// Size of the head of a file read to detect its language, which covers the
+// shebang line, modelines and content heuristics
+const detectionContentSize = 8 * 1024
+
+// sourceFile is a source file along with its detected language. Files read
+// for detection keep their content since a reader may be read only once
+type sourceFile struct {
+	core.File
+	language core.Language
+	content  []byte
+}
+
+var _ core.LanguageAwareFile = (*sourceFile)(nil)
+
+func (f *sourceFile) Language() core.Language {
+	return f.language
+}
+
+func (f *sourceFile) Reader() (io.ReadCloser, error) {
+	if f.content == nil {
+		return f.File.Reader()
+	}
+
+	return io.NopCloser(bytes.NewReader(f.content)), nil
+}
+
 // NewSourceWalker creates a new source walker that
 // can walk the source files in a file system based on
 // language specific rules.
@@ -31,11 +61,24 @@ func NewSourceWalker(config SourceWalkerConfig, langs []core.Language) (*sourceW
 
 func (s *sourceWalker) Walk(ctx context.Context, fs core.ImportAwareFileSystem, visitor core.SourceVisitor) error {
 	enumFunc := func(f core.File) error {
-		if !s.validSourceFile(f) {
+		if isNotebookFile(f.Name()) {
+			return s.visitNotebookFile(f, visitor)
+		}
+
+		if lang.IsHostDocument(f.Name()) {
+			return s.visitHostDocument(ctx, f, visitor)
+		}
+
+		file, err := s.sourceFile(f)
+		if err != nil {
+			return err
+		}
+
+		if file == nil {
 			return nil
 		}
 
-		return visitor.VisitFile(f)
+		return visitor.VisitFile(file)
 	}
 
 	err := fs.EnumerateApp(ctx, enumFunc)
@@ -53,13 +96,127 @@ func (s *sourceWalker) Walk(ctx context.Context, fs core.ImportAwareFileSystem,
 	return nil
 }
 
-func (s *sourceWalker) validSourceFile(f core.File) bool {
-	for _, lang := range s.langs {
-		for _, ext := range lang.Meta().SourceFileExtensions {
-			if strings.HasSuffix(f.Name(), ext) {
-				return true
-			}
+// sourceFile detects the language of a file, the file is read only when its
+// path is not sufficient eg. scripts without extension or .h headers shared
+// by C and C++. It returns nil for files not in the languages of the walker
+func (s *sourceWalker) sourceFile(f core.File) (*sourceFile, error) {
+	detection, detected := lang.DetectLanguage(f.Name(), nil)
+
+	var content []byte
+	if (!detected && filepath.Ext(f.Name()) == "") ||
+		(detected && detection.Confidence < lang.DetectionConfidenceExtension) {
+		reader, err := f.Reader()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reader for file: %w", err)
+		}
+
+		defer reader.Close()
+
+		content, err = io.ReadAll(io.LimitReader(reader, detectionContentSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+
+		detection, detected = lang.DetectLanguage(f.Name(), content)
+		if !detected {
+			return nil, nil
+		}
+
+		rest, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
 		}
+
+		content = append(content, rest...)
 	}
+
+	if !detected || !s.validLanguage(detection.Language) {
+		return nil, nil
+	}
+
+	return &sourceFile{
+		File:     f,
+		language: detection.Language,
+		content:  content,
+	}, nil
+}
+
+// visitNotebookFile visits a notebook as the Python source of its code cells
+// when Python is in the languages of the walker. Notebooks which can not be
+// read as Python eg. notebooks of other kernels are skipped
+func (s *sourceWalker) visitNotebookFile(f core.File, visitor core.SourceVisitor) error {
+	python, err := lang.NewPythonLanguage()
+	if err != nil {
+		return fmt.Errorf("failed to create language: %w", err)
+	}
+
+	if !s.validLanguage(python) {
+		return nil
+	}
+
+	file, err := NewNotebookFile(f)
+	if err != nil {
+		log.Warnf("Skipping notebook %s: %v", f.Name(), err)
+		return nil
+	}
+
+	return visitor.VisitFile(file)
+}
+
+// hostDocumentFile is a host document read by the walker along with its
+// content and the sources embedded in it
+type hostDocumentFile struct {
+	core.File
+	content []byte
+	sources []lang.EmbeddedSource
+}
+
+var _ lang.EmbeddedSourceFile = (*hostDocumentFile)(nil)
+
+func (f *hostDocumentFile) Reader() (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(f.content)), nil
+}
+
+func (f *hostDocumentFile) EmbeddedSources() []lang.EmbeddedSource {
+	return f.sources
+}
+
+// visitHostDocument visits a host document as is when a source embedded in
+// it is in the languages of the walker, the sources are then parsed by a
+// core.MultiLanguageParser without resolving them again
+func (s *sourceWalker) visitHostDocument(ctx context.Context, f core.File, visitor core.SourceVisitor) error {
+	reader, err := f.Reader()
+	if err != nil {
+		return fmt.Errorf("failed to get reader for file: %w", err)
+	}
+
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	sources, err := lang.ResolveEmbeddedSources(ctx, f.Name(), content)
+	if err != nil {
+		return fmt.Errorf("failed to resolve embedded sources: %w", err)
+	}
+
+	for _, source := range sources {
+		if s.validLanguage(source.Language) {
+			return visitor.VisitFile(&hostDocumentFile{File: f, content: content, sources: sources})
+		}
+	}
+
+	return nil
+}
+
+func (s *sourceWalker) validLanguage(language core.Language) bool {
+	for _, l := range s.langs {
+		if l.Meta().Code == language.Meta().Code {
+			return true
+		}
+	}
+
 	return false
 }
@@ -1,11 +1,50 @@
 package fs
 
 import (
+	"context"
+	"io"
+	"os"
 	"testing"
 
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/lang"
 	"github.com/stretchr/testify/assert"
 )
 
+type languageCollectingVisitor struct {
+	languages map[string]core.LanguageCode
+	contents  map[string]string
+}
+
+func (v *languageCollectingVisitor) VisitFile(f core.File) error {
+	languageAwareFile, ok := f.(core.LanguageAwareFile)
+	if !ok {
+		return nil
+	}
+
+	reader, err := f.Reader()
+	if err != nil {
+		return err
+	}
+
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	v.languages[f.Name()] = languageAwareFile.Language().Meta().Code
+	v.contents[f.Name()] = string(content)
+	return nil
+}
+
+type visitorFunc func(core.File) error
+
+func (f visitorFunc) VisitFile(file core.File) error {
+	return f(file)
+}
+
 func TestSourceWalker(t *testing.T) {
 	t.Run("NewSourceWalker", func(t *testing.T) {
 		t.Run("should return a new SourceWalker", func(t *testing.T) {
@@ -18,4 +57,141 @@ func TestSourceWalker(t *testing.T) {
 			assert.NotNil(t, result)
 		})
 	})
+
+	t.Run("Walk", func(t *testing.T) {
+		fs, err := NewLocalFileSystem(LocalFileSystemConfig{
+			AppDirectories: []string{"./fixtures/walker"},
+		})
+		assert.NoError(t, err)
+
+		python, err := lang.GetLanguage(string(core.LanguageCodePython))
+		assert.NoError(t, err)
+
+		c, err := lang.GetLanguage(string(core.LanguageCodeC))
+		assert.NoError(t, err)
+
+		cpp, err := lang.GetLanguage(string(core.LanguageCodeCpp))
+		assert.NoError(t, err)
+
+		t.Run("should detect the language of source files", func(t *testing.T) {
+			walker, err := NewSourceWalker(SourceWalkerConfig{}, []core.Language{python, c, cpp})
+			assert.NoError(t, err)
+
+			visitor := &languageCollectingVisitor{
+				languages: map[string]core.LanguageCode{},
+				contents:  map[string]string{},
+			}
+
+			err = walker.Walk(context.Background(), fs, visitor)
+			assert.NoError(t, err)
+
+			assert.Equal(t, map[string]core.LanguageCode{
+				"fixtures/walker/main.py":          core.LanguageCodePython,
+				"fixtures/walker/bin/manage":       core.LanguageCodePython,
+				"fixtures/walker/include/add.h":    core.LanguageCodeC,
+				"fixtures/walker/include/buffer.h": core.LanguageCodeCpp,
+			}, visitor.languages)
+		})
+
+		t.Run("should preserve the content of files read for detection", func(t *testing.T) {
+			walker, err := NewSourceWalker(SourceWalkerConfig{}, []core.Language{python})
+			assert.NoError(t, err)
+
+			visitor := &languageCollectingVisitor{
+				languages: map[string]core.LanguageCode{},
+				contents:  map[string]string{},
+			}
+
+			err = walker.Walk(context.Background(), fs, visitor)
+			assert.NoError(t, err)
+
+			expected, err := os.ReadFile("./fixtures/walker/bin/manage")
+			assert.NoError(t, err)
+
+			assert.Equal(t, string(expected), visitor.contents["fixtures/walker/bin/manage"])
+			assert.Len(t, visitor.languages, 2)
+		})
+
+		t.Run("should visit host documents embedding sources of the languages", func(t *testing.T) {
+			fs, err := NewLocalFileSystem(LocalFileSystemConfig{
+				AppDirectories: []string{"./fixtures/embedded"},
+			})
+			assert.NoError(t, err)
+
+			javascript, err := lang.GetLanguage(string(core.LanguageCodeJavascript))
+			assert.NoError(t, err)
+
+			golang, err := lang.GetLanguage(string(core.LanguageCodeGo))
+			assert.NoError(t, err)
+
+			testcases := []struct {
+				name      string
+				languages []core.Language
+				expected  []string
+			}{
+				{
+					name:      "go",
+					languages: []core.Language{golang},
+					expected:  nil,
+				},
+				{
+					name:      "python",
+					languages: []core.Language{python},
+					expected:  []string{"fixtures/embedded/README.md", "fixtures/embedded/main.py"},
+				},
+				{
+					name:      "javascript",
+					languages: []core.Language{javascript},
+					expected:  []string{"fixtures/embedded/index.html"},
+				},
+			}
+
+			for _, testcase := range testcases {
+				t.Run(testcase.name, func(t *testing.T) {
+					walker, err := NewSourceWalker(SourceWalkerConfig{}, testcase.languages)
+					assert.NoError(t, err)
+
+					visitor := &collectingVisitor{}
+					err = walker.Walk(context.Background(), fs, visitor)
+					assert.NoError(t, err)
+					assert.ElementsMatch(t, testcase.expected, visitor.files)
+				})
+			}
+
+			t.Run("should preserve the content and sources of host documents", func(t *testing.T) {
+				walker, err := NewSourceWalker(SourceWalkerConfig{}, []core.Language{javascript})
+				assert.NoError(t, err)
+
+				var content []byte
+				var sources []lang.EmbeddedSource
+				err = walker.Walk(context.Background(), fs, visitorFunc(func(f core.File) error {
+					embeddedSourceFile, ok := f.(lang.EmbeddedSourceFile)
+					assert.True(t, ok)
+
+					if ok {
+						sources = embeddedSourceFile.EmbeddedSources()
+					}
+
+					reader, err := f.Reader()
+					if err != nil {
+						return err
+					}
+
+					defer reader.Close()
+
+					content, err = io.ReadAll(reader)
+					return err
+				}))
+				assert.NoError(t, err)
+
+				expected, err := os.ReadFile("./fixtures/embedded/index.html")
+				assert.NoError(t, err)
+				assert.Equal(t, string(expected), string(content))
+
+				if assert.Len(t, sources, 1) {
+					assert.Equal(t, core.LanguageCodeJavascript, sources[0].Language.Meta().Code)
+				}
+			})
+		})
+	})
 }
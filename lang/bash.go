@@ -0,0 +1,40 @@
+package lang
+
+import (
+	"github.com/safedep/code/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/bash"
+)
+
+const bashLanguageName = "bash"
+
+type bashLanguage struct{}
+
+var _ core.Language = (*bashLanguage)(nil)
+
+func NewBashLanguage() (*bashLanguage, error) {
+	return &bashLanguage{}, nil
+}
+
+func (l *bashLanguage) Name() string {
+	return bashLanguageName
+}
+
+func (l *bashLanguage) Meta() core.LanguageMeta {
+	return core.LanguageMeta{
+		Name:                 bashLanguageName,
+		Code:                 core.LanguageCodeBash,
+		ObjectOriented:       false,
+		SourceFileExtensions: []string{".sh", ".bash"},
+	}
+}
+
+func (l *bashLanguage) Language() *sitter.Language {
+	return bash.GetLanguage()
+}
+
+func (l *bashLanguage) Resolvers() core.LanguageResolvers {
+	return &bashResolvers{
+		language: l,
+	}
+}
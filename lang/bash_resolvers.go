@@ -0,0 +1,154 @@
+package lang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/core/ast"
+	"github.com/safedep/code/pkg/ts"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type bashResolvers struct {
+	language *bashLanguage
+}
+
+var _ core.LanguageResolvers = (*bashResolvers)(nil)
+
+const bashCommandQuery = `
+	(command) @command
+`
+
+// Builtins reading and executing a file in the current shell
+var bashSourceCommands = map[string]bool{
+	"source": true,
+	".":      true,
+}
+
+// ResolveImports resolves `source` and `.` commands with a literal path as
+// wildcard imports, since sourcing a file defines its functions and variables
+// in the current shell. Paths are resolved as written eg. ./lib/common.sh,
+// paths having expansions eg. "$DIR/common.sh" can not be resolved statically
+// and are skipped
+func (r *bashResolvers) ResolveImports(tree core.ParseTree) ([]*ast.ImportNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var imports []*ast.ImportNode
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(bashCommandQuery, func(m *sitter.QueryMatch) error {
+			commandNode := m.Captures[0].Node
+			nameNode := commandNode.ChildByFieldName("name")
+			if nameNode == nil || !bashSourceCommands[nameNode.Content(*data)] {
+				return nil
+			}
+
+			pathNode := commandNode.ChildByFieldName("argument")
+			if pathNode == nil {
+				return nil
+			}
+
+			modulePath, ok := bashLiteralValue(pathNode, *data)
+			if !ok || modulePath == "" {
+				return nil
+			}
+
+			node := ast.NewImportNode(data)
+			node.SetModuleNameNode(pathNode)
+			node.SetModuleName(modulePath)
+			node.SetIsWildcardImport(true)
+
+			imports = append(imports, node)
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+const bashFunctionQuery = `
+	(function_definition) @function
+`
+
+// ResolveFunctions extracts function definitions declared as `name() { ... }`
+// or `function name { ... }` from Bash parse tree. Shell functions do not
+// declare their parameters, which are passed as positional parameters
+func (r *bashResolvers) ResolveFunctions(tree core.ParseTree) ([]*ast.FunctionDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var functions []*ast.FunctionDeclarationNode
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(bashFunctionQuery, func(m *sitter.QueryMatch) error {
+			node := m.Captures[0].Node
+
+			nameNode := node.ChildByFieldName("name")
+			if nameNode == nil {
+				return nil
+			}
+
+			functionNode := ast.NewFunctionDeclarationNode(data)
+			functionNode.SetFunctionNameNode(nameNode)
+
+			if bodyNode := node.ChildByFieldName("body"); bodyNode != nil {
+				functionNode.SetFunctionBodyNode(bodyNode)
+			}
+
+			functions = append(functions, functionNode)
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract Bash functions: %w", err)
+	}
+
+	return functions, nil
+}
+
+// bashLiteralValue returns the value of a shell word without quotes
+// eg. 'util.sh' -> util.sh. Words having expansions or command substitutions
+// eg. "$HOME/.profile" are not literal
+func bashLiteralValue(node *sitter.Node, data []byte) (string, bool) {
+	switch node.Type() {
+	case "word", "number":
+		return node.Content(data), true
+	case "raw_string":
+		return strings.TrimSuffix(strings.TrimPrefix(node.Content(data), "'"), "'"), true
+	case "string":
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			if node.NamedChild(i).Type() != "string_content" {
+				return "", false
+			}
+		}
+
+		return strings.TrimSuffix(strings.TrimPrefix(node.Content(data), `"`), `"`), true
+	case "concatenation":
+		var value strings.Builder
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			part, ok := bashLiteralValue(node.NamedChild(i), data)
+			if !ok {
+				return "", false
+			}
+
+			value.WriteString(part)
+		}
+
+		return value.String(), true
+	}
+
+	return "", false
+}
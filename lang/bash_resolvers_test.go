@@ -0,0 +1,93 @@
+package lang_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/lang"
+	"github.com/stretchr/testify/assert"
+)
+
+var bashImportExpectations = []ImportExpectations{
+	{
+		filePath: "fixtures/imports.sh",
+		imports: []string{
+			"ImportNode{ModuleName: ./lib/common.sh, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: /etc/os-release, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: scripts/util.sh, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: ../config/defaults.sh, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+		},
+	},
+}
+
+var bashFunctionExpectations = map[string][]string{
+	"fixtures/functions.sh": {
+		"FunctionDeclarationNode{Name: log, Type: function, Access: public, ParentClass: }",
+		"FunctionDeclarationNode{Name: download, Type: function, Access: public, ParentClass: }",
+		"FunctionDeclarationNode{Name: verify, Type: function, Access: public, ParentClass: }",
+		"FunctionDeclarationNode{Name: main, Type: function, Access: public, ParentClass: }",
+		"FunctionDeclarationNode{Name: cleanup, Type: function, Access: public, ParentClass: }",
+	},
+}
+
+func TestBashLanguageResolvers(t *testing.T) {
+	bashLanguage, err := lang.NewBashLanguage()
+	assert.NoError(t, err)
+
+	t.Run("ResolversExists", func(t *testing.T) {
+		resolvers := bashLanguage.Resolvers()
+		assert.NotNil(t, resolvers)
+
+		_, ok := resolvers.(core.ObjectOrientedLanguageResolvers)
+		assert.False(t, ok)
+	})
+
+	t.Run("ResolveImports", func(t *testing.T) {
+		importExpectationsMapper := make(map[string][]string)
+		importFilePaths := []string{}
+		for _, ie := range bashImportExpectations {
+			importFilePaths = append(importFilePaths, ie.filePath)
+			importExpectationsMapper[ie.filePath] = ie.imports
+		}
+
+		parseCFixtures(t, bashLanguage, importFilePaths, func(parseTree core.ParseTree, f core.File) {
+			imports, err := bashLanguage.Resolvers().ResolveImports(parseTree)
+			assert.NoError(t, err)
+
+			expectedImports, ok := importExpectationsMapper[f.Name()]
+			assert.True(t, ok)
+
+			assert.Equal(t, len(expectedImports), len(imports))
+			for i, expectedImport := range expectedImports {
+				assert.Equal(t, expectedImport, imports[i].String())
+			}
+		})
+	})
+
+	t.Run("ResolveFunctions", func(t *testing.T) {
+		var filePaths []string
+		for path := range bashFunctionExpectations {
+			filePaths = append(filePaths, path)
+		}
+
+		parseCFixtures(t, bashLanguage, filePaths, func(parseTree core.ParseTree, f core.File) {
+			functions, err := bashLanguage.Resolvers().ResolveFunctions(parseTree)
+			assert.NoError(t, err)
+
+			expectedFunctions, ok := bashFunctionExpectations[f.Name()]
+			assert.True(t, ok)
+
+			var foundFunctions []string
+			for _, fun := range functions {
+				foundFunctions = append(foundFunctions,
+					fmt.Sprintf("FunctionDeclarationNode{Name: %s, Type: %s, Access: %s, ParentClass: %s}",
+						fun.FunctionName(), fun.GetFunctionType(), fun.GetAccessModifier(), fun.GetParentClassName()))
+
+				assert.Empty(t, fun.Parameters())
+			}
+
+			assert.Equal(t, expectedFunctions, foundFunctions)
+		})
+	})
+}
@@ -0,0 +1,25 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBashLanguageMeta(t *testing.T) {
+	t.Run("Name", func(t *testing.T) {
+		l := &bashLanguage{}
+		assert.Equal(t, bashLanguageName, l.Name())
+	})
+
+	t.Run("Code", func(t *testing.T) {
+		l := &bashLanguage{}
+		assert.Equal(t, core.LanguageCodeBash, l.Meta().Code)
+	})
+
+	t.Run("ObjectOriented", func(t *testing.T) {
+		l := &bashLanguage{}
+		assert.False(t, l.Meta().ObjectOriented)
+	})
+}
@@ -0,0 +1,40 @@
+package lang
+
+import (
+	"github.com/safedep/code/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/c"
+)
+
+const cLanguageName = "c"
+
+type cLanguage struct{}
+
+var _ core.Language = (*cLanguage)(nil)
+
+func NewCLanguage() (*cLanguage, error) {
+	return &cLanguage{}, nil
+}
+
+func (l *cLanguage) Name() string {
+	return cLanguageName
+}
+
+func (l *cLanguage) Meta() core.LanguageMeta {
+	return core.LanguageMeta{
+		Name:                 cLanguageName,
+		Code:                 core.LanguageCodeC,
+		ObjectOriented:       false,
+		SourceFileExtensions: []string{".c", ".h"},
+	}
+}
+
+func (l *cLanguage) Language() *sitter.Language {
+	return c.GetLanguage()
+}
+
+func (l *cLanguage) Resolvers() core.LanguageResolvers {
+	return &cResolvers{
+		language: l,
+	}
+}
@@ -0,0 +1,201 @@
+package lang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/core/ast"
+	"github.com/safedep/code/pkg/ts"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type cResolvers struct {
+	language *cLanguage
+}
+
+var _ core.LanguageResolvers = (*cResolvers)(nil)
+
+const cIncludeQuery = `
+	(preproc_include
+		path: (_) @path)
+`
+
+// ResolveImports resolves include directives as wildcard imports of the
+// included header. System headers are resolved as written eg. openssl/evp.h
+// for `#include <openssl/evp.h>` while local headers are resolved as relative
+// paths eg. ./util.h for `#include "util.h"`
+func (r *cResolvers) ResolveImports(tree core.ParseTree) ([]*ast.ImportNode, error) {
+	return cResolveIncludes(r.language, tree)
+}
+
+// cResolveIncludes resolves the include directives of C and C++ parse trees.
+// Includes of macros eg. `#include PLATFORM_HEADER` can not be resolved
+// statically and are skipped
+func cResolveIncludes(language core.Language, tree core.ParseTree) ([]*ast.ImportNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var imports []*ast.ImportNode
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(cIncludeQuery, func(m *sitter.QueryMatch) error {
+			if node := cNewIncludeImport(data, m.Captures[0].Node); node != nil {
+				imports = append(imports, node)
+			}
+
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+func cNewIncludeImport(data *[]byte, pathNode *sitter.Node) *ast.ImportNode {
+	var modulePath string
+	switch pathNode.Type() {
+	case "system_lib_string":
+		modulePath = strings.TrimSuffix(strings.TrimPrefix(pathNode.Content(*data), "<"), ">")
+	case "string_literal":
+		modulePath = strings.Trim(pathNode.Content(*data), `"`)
+		if !strings.HasPrefix(modulePath, "./") && !strings.HasPrefix(modulePath, "../") &&
+			!strings.HasPrefix(modulePath, "/") {
+			modulePath = "./" + modulePath
+		}
+	default:
+		return nil
+	}
+
+	if modulePath == "" {
+		return nil
+	}
+
+	node := ast.NewImportNode(data)
+	node.SetModuleNameNode(pathNode)
+	node.SetModuleName(modulePath)
+	node.SetIsWildcardImport(true)
+
+	return node
+}
+
+const cFunctionQuery = `
+	(function_definition) @function
+`
+
+// ResolveFunctions extracts function definitions from C parse tree. Functions
+// with internal linkage ie. declared static are private. Prototypes without
+// a body are not reported
+func (r *cResolvers) ResolveFunctions(tree core.ParseTree) ([]*ast.FunctionDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var functions []*ast.FunctionDeclarationNode
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(cFunctionQuery, func(m *sitter.QueryMatch) error {
+			node := m.Captures[0].Node
+
+			functionDeclaratorNode := cFunctionDeclaratorNode(node.ChildByFieldName("declarator"))
+			if functionDeclaratorNode == nil {
+				return nil
+			}
+
+			nameNode := functionDeclaratorNode.ChildByFieldName("declarator")
+			if nameNode == nil || nameNode.Type() != "identifier" {
+				return nil
+			}
+
+			functionNode := cNewFunctionNode(data, node, functionDeclaratorNode, nameNode)
+			if cHasStorageClass(data, node, "static") {
+				functionNode.SetAccessModifier(ast.AccessModifierPrivate)
+			}
+
+			functions = append(functions, functionNode)
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract C functions: %w", err)
+	}
+
+	return functions, nil
+}
+
+// cNewFunctionNode creates a function declaration with the name, parameters,
+// return type and body of a C or C++ function definition
+func cNewFunctionNode(data *[]byte, node, functionDeclaratorNode, nameNode *sitter.Node) *ast.FunctionDeclarationNode {
+	functionNode := ast.NewFunctionDeclarationNode(data)
+	functionNode.SetFunctionNameNode(nameNode)
+
+	if paramsNode := functionDeclaratorNode.ChildByFieldName("parameters"); paramsNode != nil {
+		for i := 0; i < int(paramsNode.NamedChildCount()); i++ {
+			paramNode := paramsNode.NamedChild(i)
+			switch paramNode.Type() {
+			case "parameter_declaration", "optional_parameter_declaration", "variadic_parameter_declaration":
+				// f(void) takes no parameters
+				if paramNode.ChildByFieldName("declarator") == nil && paramNode.Content(*data) == "void" {
+					continue
+				}
+
+				functionNode.AddFunctionParameterNode(paramNode)
+			}
+		}
+	}
+
+	if returnTypeNode := node.ChildByFieldName("type"); returnTypeNode != nil {
+		functionNode.SetFunctionReturnTypeNode(returnTypeNode)
+	}
+
+	if bodyNode := node.ChildByFieldName("body"); bodyNode != nil {
+		functionNode.SetFunctionBodyNode(bodyNode)
+	}
+
+	return functionNode
+}
+
+// cFunctionDeclaratorNode unwraps the declarator of a function definition
+// eg. `*duplicate(const char *s)` is a pointer declarator wrapping the
+// function declarator
+func cFunctionDeclaratorNode(node *sitter.Node) *sitter.Node {
+	for node != nil {
+		switch node.Type() {
+		case "function_declarator":
+			return node
+		case "pointer_declarator", "reference_declarator", "parenthesized_declarator":
+			next := node.ChildByFieldName("declarator")
+			if next == nil && node.NamedChildCount() > 0 {
+				next = node.NamedChild(int(node.NamedChildCount()) - 1)
+			}
+
+			node = next
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// cHasStorageClass checks whether a declaration has the storage class
+// specifier eg. static or extern
+func cHasStorageClass(data *[]byte, node *sitter.Node, storageClass string) bool {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if child.Type() == "storage_class_specifier" && child.Content(*data) == storageClass {
+			return true
+		}
+	}
+
+	return false
+}
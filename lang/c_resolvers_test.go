@@ -0,0 +1,125 @@
+package lang_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/fs"
+	"github.com/safedep/code/lang"
+	"github.com/safedep/code/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+var cImportExpectations = []ImportExpectations{
+	{
+		filePath: "fixtures/imports.c",
+		imports: []string{
+			"ImportNode{ModuleName: stdio.h, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: stdlib.h, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: openssl/evp.h, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: ./util.h, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: ../include/config.h, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: zlib.h, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+		},
+	},
+}
+
+var cFunctionExpectations = map[string][]string{
+	"fixtures/functions.c": {
+		"FunctionDeclarationNode{Name: helper, Type: function, Access: private, ParentClass: }",
+		"FunctionDeclarationNode{Name: add, Type: function, Access: public, ParentClass: }",
+		"FunctionDeclarationNode{Name: duplicate, Type: function, Access: public, ParentClass: }",
+		"FunctionDeclarationNode{Name: run_command, Type: function, Access: private, ParentClass: }",
+		"FunctionDeclarationNode{Name: main, Type: function, Access: public, ParentClass: }",
+	},
+}
+
+func parseCFixtures(t *testing.T, language core.Language, filePaths []string, visitor func(core.ParseTree, core.File)) {
+	t.Helper()
+
+	fileParser, err := parser.NewParser([]core.Language{language})
+	assert.NoError(t, err)
+
+	fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+		AppDirectories: filePaths,
+	})
+	assert.NoError(t, err)
+
+	err = fileSystem.EnumerateApp(context.Background(), func(f core.File) error {
+		parseTree, err := fileParser.Parse(context.Background(), f)
+		assert.NoError(t, err)
+
+		visitor(parseTree, f)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestCLanguageResolvers(t *testing.T) {
+	cLanguage, err := lang.NewCLanguage()
+	assert.NoError(t, err)
+
+	t.Run("ResolversExists", func(t *testing.T) {
+		resolvers := cLanguage.Resolvers()
+		assert.NotNil(t, resolvers)
+
+		_, ok := resolvers.(core.ObjectOrientedLanguageResolvers)
+		assert.False(t, ok)
+	})
+
+	t.Run("ResolveImports", func(t *testing.T) {
+		importExpectationsMapper := make(map[string][]string)
+		importFilePaths := []string{}
+		for _, ie := range cImportExpectations {
+			importFilePaths = append(importFilePaths, ie.filePath)
+			importExpectationsMapper[ie.filePath] = ie.imports
+		}
+
+		parseCFixtures(t, cLanguage, importFilePaths, func(parseTree core.ParseTree, f core.File) {
+			imports, err := cLanguage.Resolvers().ResolveImports(parseTree)
+			assert.NoError(t, err)
+
+			expectedImports, ok := importExpectationsMapper[f.Name()]
+			assert.True(t, ok)
+
+			assert.Equal(t, len(expectedImports), len(imports))
+			for i, expectedImport := range expectedImports {
+				assert.Equal(t, expectedImport, imports[i].String())
+			}
+		})
+	})
+
+	t.Run("ResolveFunctions", func(t *testing.T) {
+		var filePaths []string
+		for path := range cFunctionExpectations {
+			filePaths = append(filePaths, path)
+		}
+
+		parseCFixtures(t, cLanguage, filePaths, func(parseTree core.ParseTree, f core.File) {
+			functions, err := cLanguage.Resolvers().ResolveFunctions(parseTree)
+			assert.NoError(t, err)
+
+			expectedFunctions, ok := cFunctionExpectations[f.Name()]
+			assert.True(t, ok)
+
+			var foundFunctions []string
+			for _, fun := range functions {
+				foundFunctions = append(foundFunctions,
+					fmt.Sprintf("FunctionDeclarationNode{Name: %s, Type: %s, Access: %s, ParentClass: %s}",
+						fun.FunctionName(), fun.GetFunctionType(), fun.GetAccessModifier(), fun.GetParentClassName()))
+
+				switch fun.FunctionName() {
+				case "duplicate":
+					assert.Equal(t, []string{"const char *s", "size_t n"}, fun.Parameters())
+					assert.Equal(t, "char", fun.ReturnType())
+				case "main":
+					assert.Equal(t, []string{"int argc", "char **argv"}, fun.Parameters())
+				}
+			}
+
+			assert.Equal(t, expectedFunctions, foundFunctions)
+		})
+	})
+}
@@ -0,0 +1,25 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCLanguageMeta(t *testing.T) {
+	t.Run("Name", func(t *testing.T) {
+		l := &cLanguage{}
+		assert.Equal(t, cLanguageName, l.Name())
+	})
+
+	t.Run("Code", func(t *testing.T) {
+		l := &cLanguage{}
+		assert.Equal(t, core.LanguageCodeC, l.Meta().Code)
+	})
+
+	t.Run("ObjectOriented", func(t *testing.T) {
+		l := &cLanguage{}
+		assert.False(t, l.Meta().ObjectOriented)
+	})
+}
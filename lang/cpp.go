@@ -0,0 +1,40 @@
+package lang
+
+import (
+	"github.com/safedep/code/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/cpp"
+)
+
+const cppLanguageName = "cpp"
+
+type cppLanguage struct{}
+
+var _ core.Language = (*cppLanguage)(nil)
+
+func NewCppLanguage() (*cppLanguage, error) {
+	return &cppLanguage{}, nil
+}
+
+func (l *cppLanguage) Name() string {
+	return cppLanguageName
+}
+
+func (l *cppLanguage) Meta() core.LanguageMeta {
+	return core.LanguageMeta{
+		Name:                 cppLanguageName,
+		Code:                 core.LanguageCodeCpp,
+		ObjectOriented:       true,
+		SourceFileExtensions: []string{".cc", ".cpp", ".cxx", ".hpp", ".hh", ".hxx"},
+	}
+}
+
+func (l *cppLanguage) Language() *sitter.Language {
+	return cpp.GetLanguage()
+}
+
+func (l *cppLanguage) Resolvers() core.LanguageResolvers {
+	return &cppResolvers{
+		language: l,
+	}
+}
@@ -0,0 +1,461 @@
+package lang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/core/ast"
+	"github.com/safedep/code/pkg/ts"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type cppResolvers struct {
+	language *cppLanguage
+}
+
+var _ core.LanguageResolvers = (*cppResolvers)(nil)
+var _ core.ObjectOrientedLanguageResolvers = (*cppResolvers)(nil)
+
+const cppNamespaceSeparator = "::"
+
+// ResolveImports resolves include directives the same way as C, modules
+// and using directives are not imports of headers hence not reported
+func (r *cppResolvers) ResolveImports(tree core.ParseTree) ([]*ast.ImportNode, error) {
+	return cResolveIncludes(r.language, tree)
+}
+
+const cppFunctionQuery = `
+	(function_definition) @function
+`
+
+// ResolveFunctions extracts function definitions from C++ parse tree including
+// the methods defined in a class body and out of class eg. `Cipher::create`.
+// Pure virtual methods are reported as abstract methods. Members of classes are
+// private and members of structs are public unless declared under an access
+// specifier. The enclosing namespace is available as the function namespace
+func (r *cppResolvers) ResolveFunctions(tree core.ParseTree) ([]*ast.FunctionDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	// Out of class definitions are qualified by the class name which can not
+	// be told apart from a namespace without the classes of the file
+	classNames := map[string]bool{}
+	err = r.visitClasses(data, tree, func(classNode *sitter.Node) {
+		if nameNode := cppTypeNameNode(classNode.ChildByFieldName("name")); nameNode != nil {
+			classNames[nameNode.Content(*data)] = true
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract C++ classes: %w", err)
+	}
+
+	var functions []*ast.FunctionDeclarationNode
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(cppFunctionQuery, func(m *sitter.QueryMatch) error {
+			if functionNode := r.newFunctionNode(data, m.Captures[0].Node, classNames); functionNode != nil {
+				functions = append(functions, functionNode)
+			}
+
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract C++ functions: %w", err)
+	}
+
+	return functions, nil
+}
+
+func (r *cppResolvers) newFunctionNode(data *[]byte, node *sitter.Node, classNames map[string]bool) *ast.FunctionDeclarationNode {
+	functionDeclaratorNode := cFunctionDeclaratorNode(node.ChildByFieldName("declarator"))
+	if functionDeclaratorNode == nil {
+		return nil
+	}
+
+	scopeNodes, nameNode := cppSplitQualifiedName(functionDeclaratorNode.ChildByFieldName("declarator"))
+	if nameNode == nil {
+		return nil
+	}
+
+	if nameNode.Type() == "template_function" {
+		nameNode = nameNode.ChildByFieldName("name")
+	}
+
+	if nameNode == nil {
+		return nil
+	}
+
+	functionNode := cNewFunctionNode(data, node, functionDeclaratorNode, nameNode)
+
+	var scopes []string
+	for _, scopeNode := range scopeNodes {
+		if scopeNameNode := cppTypeNameNode(scopeNode); scopeNameNode != nil {
+			scopes = append(scopes, scopeNameNode.Content(*data))
+		}
+	}
+
+	parentClassName := ""
+	access := ast.AccessModifierPublic
+	if ownerNode := cppOwnerClass(node); ownerNode != nil {
+		if ownerNameNode := cppTypeNameNode(ownerNode.ChildByFieldName("name")); ownerNameNode != nil {
+			parentClassName = ownerNameNode.Content(*data)
+		}
+
+		access = cppMemberAccess(data, ownerNode, node)
+	} else if len(scopes) > 0 && classNames[scopes[len(scopes)-1]] {
+		parentClassName = scopes[len(scopes)-1]
+		scopes = scopes[:len(scopes)-1]
+	} else if cHasStorageClass(data, node, "static") {
+		access = ast.AccessModifierPrivate
+	}
+
+	functionNode.SetAccessModifier(access)
+	functionNode.SetNamespace(strings.Join(append(cppEnclosingNamespaces(data, node), scopes...),
+		cppNamespaceSeparator))
+
+	if parentClassName == "" {
+		return functionNode
+	}
+
+	functionNode.SetParentClassName(parentClassName)
+	functionNode.SetFunctionType(ast.FunctionTypeMethod)
+	functionNode.SetIsAbstract(cppIsPureVirtual(node))
+
+	if nameNode.Content(*data) == parentClassName {
+		functionNode.SetFunctionType(ast.FunctionTypeConstructor)
+	} else if cHasStorageClass(data, node, "static") {
+		functionNode.SetFunctionType(ast.FunctionTypeStaticMethod)
+		functionNode.SetIsStatic(true)
+	}
+
+	return functionNode
+}
+
+const cppClassQuery = `
+	(class_specifier
+		body: (_)) @class
+	(struct_specifier
+		body: (_)) @class
+`
+
+// ResolveClasses extracts classes and structs having a body from C++ parse
+// tree. Classes with a pure virtual method are abstract. Methods include the
+// methods declared in the class body and defined out of class. Forward
+// declarations and anonymous structs are not reported
+func (r *cppResolvers) ResolveClasses(tree core.ParseTree) ([]*ast.ClassDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var classes []*ast.ClassDeclarationNode
+
+	err = r.visitClasses(data, tree, func(classNode *sitter.Node) {
+		nameNode := cppTypeNameNode(classNode.ChildByFieldName("name"))
+		if nameNode == nil {
+			return
+		}
+
+		classDeclaration := ast.NewClassDeclarationNode(ast.ToContent(*data))
+		classDeclaration.SetClassNameNode(nameNode)
+		classDeclaration.SetNamespace(strings.Join(cppEnclosingNamespaces(data, classNode), cppNamespaceSeparator))
+
+		if ownerNode := cppOwnerClass(classNode); ownerNode != nil {
+			classDeclaration.SetAccessModifier(cppMemberAccess(data, ownerNode, classNode))
+		}
+
+		cppVisitBaseClasses(classNode, classDeclaration.AddBaseClassNode)
+
+		if bodyNode := classNode.ChildByFieldName("body"); bodyNode != nil {
+			r.addMembers(data, classDeclaration, nameNode.Content(*data), bodyNode)
+		}
+
+		classes = append(classes, classDeclaration)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract class definitions: %w", err)
+	}
+
+	return classes, nil
+}
+
+// addMembers adds the methods, the first constructor and the fields
+// declared in a class body
+func (r *cppResolvers) addMembers(data *[]byte, classDeclaration *ast.ClassDeclarationNode,
+	className string, bodyNode *sitter.Node) {
+	for i := 0; i < int(bodyNode.NamedChildCount()); i++ {
+		member := bodyNode.NamedChild(i)
+		if member.Type() == "template_declaration" && member.NamedChildCount() > 0 {
+			member = member.NamedChild(int(member.NamedChildCount()) - 1)
+		}
+
+		switch member.Type() {
+		case "function_definition", "field_declaration", "declaration":
+		default:
+			continue
+		}
+
+		declaratorNodes := cppDeclaratorNodes(member)
+		if len(declaratorNodes) == 0 {
+			continue
+		}
+
+		functionDeclaratorNode := cFunctionDeclaratorNode(declaratorNodes[0])
+		if functionDeclaratorNode == nil {
+			for _, declaratorNode := range declaratorNodes {
+				if fieldNode := cppDeclaredNameNode(declaratorNode); fieldNode != nil {
+					classDeclaration.AddFieldNode(fieldNode)
+				}
+			}
+
+			continue
+		}
+
+		if cppIsPureVirtual(member) {
+			classDeclaration.SetIsAbstract(true)
+		}
+
+		nameNode := functionDeclaratorNode.ChildByFieldName("declarator")
+		if nameNode != nil && nameNode.Content(*data) == className {
+			if classDeclaration.GetConstructorNode() == nil {
+				classDeclaration.SetConstructorNode(member)
+			}
+
+			continue
+		}
+
+		classDeclaration.AddMethodNode(member)
+	}
+}
+
+// ResolveInheritance builds inheritance graph from C++ classes and structs.
+// C++ supports multiple inheritance hence every base class is inherited,
+// eg. `class Circle : public Shape, protected Named`
+func (r *cppResolvers) ResolveInheritance(tree core.ParseTree) (*ast.InheritanceGraph, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	filename := ""
+	if file, err := tree.File(); err == nil && file != nil {
+		filename = file.Name()
+	}
+
+	inheritanceGraph := ast.NewInheritanceGraph()
+
+	err = r.visitClasses(data, tree, func(classNode *sitter.Node) {
+		nameNode := cppTypeNameNode(classNode.ChildByFieldName("name"))
+		if nameNode == nil {
+			return
+		}
+
+		className := nameNode.Content(*data)
+		cppVisitBaseClasses(classNode, func(baseNode *sitter.Node) {
+			inheritanceGraph.AddRelationship(className, baseNode.Content(*data),
+				ast.RelationshipTypeInherits, filename, baseNode.StartPoint().Row+1)
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute inheritance queries: %w", err)
+	}
+
+	return inheritanceGraph, nil
+}
+
+func (r *cppResolvers) visitClasses(data *[]byte, tree core.ParseTree, visitor func(classNode *sitter.Node)) error {
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(cppClassQuery, func(m *sitter.QueryMatch) error {
+			visitor(m.Captures[0].Node)
+			return nil
+		}),
+	}
+
+	return ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+}
+
+// cppVisitBaseClasses calls the visitor with the base classes of a class
+// eg. Shape and std::exception in `class A : public Shape, std::exception`
+func cppVisitBaseClasses(classNode *sitter.Node, visitor func(baseNode *sitter.Node)) {
+	for i := 0; i < int(classNode.NamedChildCount()); i++ {
+		baseClauseNode := classNode.NamedChild(i)
+		if baseClauseNode.Type() != "base_class_clause" {
+			continue
+		}
+
+		for j := 0; j < int(baseClauseNode.NamedChildCount()); j++ {
+			baseNode := baseClauseNode.NamedChild(j)
+			switch baseNode.Type() {
+			case "type_identifier", "qualified_identifier", "template_type":
+				visitor(baseNode)
+			}
+		}
+	}
+}
+
+// cppSplitQualifiedName splits a qualified name eg. `crypto::Cipher::create`
+// into the scope nodes and the name node
+func cppSplitQualifiedName(node *sitter.Node) ([]*sitter.Node, *sitter.Node) {
+	var scopeNodes []*sitter.Node
+	for node != nil && node.Type() == "qualified_identifier" {
+		if scopeNode := node.ChildByFieldName("scope"); scopeNode != nil {
+			scopeNodes = append(scopeNodes, scopeNode)
+		}
+
+		node = node.ChildByFieldName("name")
+	}
+
+	return scopeNodes, node
+}
+
+// cppTypeNameNode returns the simple name of a type
+// eg. Box for `Box<T>` and Cipher for `crypto::Cipher`
+func cppTypeNameNode(node *sitter.Node) *sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	switch node.Type() {
+	case "qualified_identifier":
+		_, nameNode := cppSplitQualifiedName(node)
+		return cppTypeNameNode(nameNode)
+	case "template_type":
+		return node.ChildByFieldName("name")
+	case "decltype":
+		return nil
+	}
+
+	return node
+}
+
+// cppOwnerClass returns the class or struct in whose body a member is declared
+func cppOwnerClass(node *sitter.Node) *sitter.Node {
+	parent := node.Parent()
+	if parent != nil && parent.Type() == "template_declaration" {
+		parent = parent.Parent()
+	}
+
+	if parent == nil || parent.Type() != "field_declaration_list" {
+		return nil
+	}
+
+	ownerNode := parent.Parent()
+	if ownerNode == nil {
+		return nil
+	}
+
+	switch ownerNode.Type() {
+	case "class_specifier", "struct_specifier", "union_specifier":
+		return ownerNode
+	}
+
+	return nil
+}
+
+// cppMemberAccess returns the access of a member from the last access specifier
+// preceding it in the class body. Members of classes are private by default
+// while members of structs and unions are public
+func cppMemberAccess(data *[]byte, ownerNode, memberNode *sitter.Node) ast.AccessModifier {
+	access := ast.AccessModifierPublic
+	if ownerNode.Type() == "class_specifier" {
+		access = ast.AccessModifierPrivate
+	}
+
+	bodyNode := ownerNode.ChildByFieldName("body")
+	if bodyNode == nil {
+		return access
+	}
+
+	for i := 0; i < int(bodyNode.NamedChildCount()); i++ {
+		child := bodyNode.NamedChild(i)
+		if child.StartByte() > memberNode.StartByte() {
+			break
+		}
+
+		if child.Type() != "access_specifier" {
+			continue
+		}
+
+		switch child.Content(*data) {
+		case "public":
+			access = ast.AccessModifierPublic
+		case "protected":
+			access = ast.AccessModifierProtected
+		case "private":
+			access = ast.AccessModifierPrivate
+		}
+	}
+
+	return access
+}
+
+// cppEnclosingNamespaces returns the names of the namespaces enclosing a node
+// from the outermost eg. [crypto aes] in `namespace crypto { namespace aes {`.
+// Anonymous namespaces are skipped
+func cppEnclosingNamespaces(data *[]byte, node *sitter.Node) []string {
+	var namespaces []string
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		if parent.Type() != "namespace_definition" {
+			continue
+		}
+
+		if nameNode := parent.ChildByFieldName("name"); nameNode != nil {
+			namespaces = append([]string{nameNode.Content(*data)}, namespaces...)
+		}
+	}
+
+	return namespaces
+}
+
+// cppIsPureVirtual checks whether a method is declared pure virtual
+// eg. `virtual void run() = 0;`
+func cppIsPureVirtual(node *sitter.Node) bool {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		if node.NamedChild(i).Type() == "pure_virtual_clause" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cppDeclaratorNodes returns the declarators of a declaration
+// eg. x and y in `int x, y;`
+func cppDeclaratorNodes(node *sitter.Node) []*sitter.Node {
+	var declaratorNodes []*sitter.Node
+	for i := 0; i < int(node.ChildCount()); i++ {
+		if node.FieldNameForChild(i) == "declarator" {
+			declaratorNodes = append(declaratorNodes, node.Child(i))
+		}
+	}
+
+	return declaratorNodes
+}
+
+// cppDeclaredNameNode unwraps the declarator of a field
+// eg. name_ in `const char *name_;`
+func cppDeclaredNameNode(node *sitter.Node) *sitter.Node {
+	for node != nil {
+		switch node.Type() {
+		case "field_identifier", "identifier":
+			return node
+		case "pointer_declarator", "reference_declarator", "array_declarator", "init_declarator":
+			next := node.ChildByFieldName("declarator")
+			if next == nil && node.NamedChildCount() > 0 {
+				next = node.NamedChild(int(node.NamedChildCount()) - 1)
+			}
+
+			node = next
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
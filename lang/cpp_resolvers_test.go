@@ -0,0 +1,157 @@
+package lang_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/core/ast"
+	"github.com/safedep/code/lang"
+	"github.com/stretchr/testify/assert"
+)
+
+var cppImportExpectations = []ImportExpectations{
+	{
+		filePath: "fixtures/functions.cpp",
+		imports: []string{
+			"ImportNode{ModuleName: string, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: memory, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+		},
+	},
+}
+
+var cppFunctionExpectations = map[string][]string{
+	"fixtures/functions.cpp": {
+		"FunctionDeclarationNode{Name: checksum, Type: function, Access: public, ParentClass: , Namespace: crypto}",
+		"FunctionDeclarationNode{Name: Cipher, Type: constructor, Access: public, ParentClass: Cipher, Namespace: crypto}",
+		"FunctionDeclarationNode{Name: ~Cipher, Type: method, Access: public, ParentClass: Cipher, Namespace: crypto}",
+		"FunctionDeclarationNode{Name: encrypt, Type: method, Access: public, ParentClass: Cipher, Namespace: crypto}",
+		"FunctionDeclarationNode{Name: name, Type: method, Access: public, ParentClass: Cipher, Namespace: crypto}",
+		"FunctionDeclarationNode{Name: reset, Type: method, Access: private, ParentClass: Cipher, Namespace: crypto}",
+		"FunctionDeclarationNode{Name: create, Type: method, Access: public, ParentClass: Cipher, Namespace: crypto}",
+		"FunctionDeclarationNode{Name: encrypt_block, Type: function, Access: public, ParentClass: , Namespace: crypto::aes}",
+		"FunctionDeclarationNode{Name: norm, Type: method, Access: public, ParentClass: Point, Namespace: }",
+		"FunctionDeclarationNode{Name: identity, Type: function, Access: public, ParentClass: , Namespace: }",
+		"FunctionDeclarationNode{Name: main, Type: function, Access: public, ParentClass: , Namespace: }",
+	},
+}
+
+func TestCppLanguageResolvers(t *testing.T) {
+	cppLanguage, err := lang.NewCppLanguage()
+	assert.NoError(t, err)
+
+	t.Run("ResolversExists", func(t *testing.T) {
+		resolvers := cppLanguage.Resolvers()
+		assert.NotNil(t, resolvers)
+
+		_, ok := resolvers.(core.ObjectOrientedLanguageResolvers)
+		assert.True(t, ok)
+	})
+
+	t.Run("ResolveImports", func(t *testing.T) {
+		importExpectationsMapper := make(map[string][]string)
+		importFilePaths := []string{}
+		for _, ie := range cppImportExpectations {
+			importFilePaths = append(importFilePaths, ie.filePath)
+			importExpectationsMapper[ie.filePath] = ie.imports
+		}
+
+		parseCFixtures(t, cppLanguage, importFilePaths, func(parseTree core.ParseTree, f core.File) {
+			imports, err := cppLanguage.Resolvers().ResolveImports(parseTree)
+			assert.NoError(t, err)
+
+			expectedImports, ok := importExpectationsMapper[f.Name()]
+			assert.True(t, ok)
+
+			assert.Equal(t, len(expectedImports), len(imports))
+			for i, expectedImport := range expectedImports {
+				assert.Equal(t, expectedImport, imports[i].String())
+			}
+		})
+	})
+
+	t.Run("ResolveFunctions", func(t *testing.T) {
+		var filePaths []string
+		for path := range cppFunctionExpectations {
+			filePaths = append(filePaths, path)
+		}
+
+		parseCFixtures(t, cppLanguage, filePaths, func(parseTree core.ParseTree, f core.File) {
+			functions, err := cppLanguage.Resolvers().ResolveFunctions(parseTree)
+			assert.NoError(t, err)
+
+			expectedFunctions, ok := cppFunctionExpectations[f.Name()]
+			assert.True(t, ok)
+
+			var foundFunctions []string
+			for _, fun := range functions {
+				foundFunctions = append(foundFunctions,
+					fmt.Sprintf("FunctionDeclarationNode{Name: %s, Type: %s, Access: %s, ParentClass: %s, Namespace: %s}",
+						fun.FunctionName(), fun.GetFunctionType(), fun.GetAccessModifier(),
+						fun.GetParentClassName(), fun.GetNamespace()))
+
+				switch fun.FunctionName() {
+				case "encrypt":
+					assert.True(t, fun.IsAbstract())
+					assert.Equal(t, []string{"const std::string &plain"}, fun.Parameters())
+				case "checksum":
+					assert.Equal(t, "int", fun.ReturnType())
+				case "identity":
+					assert.Equal(t, []string{"T value"}, fun.Parameters())
+				}
+			}
+
+			assert.Equal(t, expectedFunctions, foundFunctions)
+		})
+	})
+
+	t.Run("ResolveClasses", func(t *testing.T) {
+		parseCFixtures(t, cppLanguage, []string{"fixtures/cpp_class_hierarchy.cpp"}, func(parseTree core.ParseTree, _ core.File) {
+			resolvers := cppLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			classes, err := resolvers.ResolveClasses(parseTree)
+			assert.NoError(t, err)
+
+			found := map[string]string{}
+			for _, class := range classes {
+				found[class.ClassName()] = fmt.Sprintf("namespace=%s bases=%v methods=%d fields=%v constructor=%t abstract=%t",
+					class.Namespace(), class.BaseClasses(), len(class.Methods()), class.Fields(),
+					class.Constructor() != "", class.IsAbstract())
+
+				assert.Equal(t, ast.AccessModifierPublic, class.AccessModifier())
+			}
+
+			assert.Equal(t, map[string]string{
+				"Shape":      "namespace=shapes bases=[] methods=2 fields=[] constructor=false abstract=true",
+				"Named":      "namespace=shapes bases=[] methods=1 fields=[name_] constructor=false abstract=false",
+				"Circle":     "namespace=shapes bases=[Shape Named] methods=1 fields=[radius_] constructor=true abstract=false",
+				"ParseError": "namespace= bases=[std::runtime_error] methods=0 fields=[] constructor=false abstract=false",
+				"Box":        "namespace= bases=[shapes::Shape] methods=0 fields=[value] constructor=false abstract=false",
+			}, found)
+		})
+	})
+
+	t.Run("ResolveInheritance", func(t *testing.T) {
+		parseCFixtures(t, cppLanguage, []string{"fixtures/cpp_class_hierarchy.cpp"}, func(parseTree core.ParseTree, _ core.File) {
+			resolvers := cppLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			graph, err := resolvers.ResolveInheritance(parseTree)
+			assert.NoError(t, err)
+
+			var relationships []string
+			for _, class := range graph.GetAllClasses() {
+				for _, rel := range graph.GetDirectParents(class) {
+					relationships = append(relationships, fmt.Sprintf("%s %s %s", rel.ChildClassName,
+						rel.RelationshipType, rel.ParentClassName))
+				}
+			}
+
+			assert.ElementsMatch(t, []string{
+				"Circle inherits Shape",
+				"Circle inherits Named",
+				"ParseError inherits std::runtime_error",
+				"Box inherits shapes::Shape",
+			}, relationships)
+		})
+	})
+}
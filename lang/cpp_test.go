@@ -0,0 +1,25 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCppLanguageMeta(t *testing.T) {
+	t.Run("Name", func(t *testing.T) {
+		l := &cppLanguage{}
+		assert.Equal(t, cppLanguageName, l.Name())
+	})
+
+	t.Run("Code", func(t *testing.T) {
+		l := &cppLanguage{}
+		assert.Equal(t, core.LanguageCodeCpp, l.Meta().Code)
+	})
+
+	t.Run("ObjectOriented", func(t *testing.T) {
+		l := &cppLanguage{}
+		assert.True(t, l.Meta().ObjectOriented)
+	})
+}
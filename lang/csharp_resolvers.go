@@ -0,0 +1,491 @@
+package lang
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/core/ast"
+	"github.com/safedep/code/pkg/ts"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type csharpResolvers struct {
+	language *csharpLanguage
+}
+
+var _ core.LanguageResolvers = (*csharpResolvers)(nil)
+var _ core.ObjectOrientedLanguageResolvers = (*csharpResolvers)(nil)
+
+const csharpImportQuery = `
+	(using_directive) @import
+`
+
+// ResolveImports resolves using directives including `using static` and
+// global usings. Imported namespaces and types are wildcard imports of their
+// members while aliases eg. `using Json = System.Text.Json.JsonSerializer`
+// import the last segment as the module item
+func (r *csharpResolvers) ResolveImports(tree core.ParseTree) ([]*ast.ImportNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var imports []*ast.ImportNode
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(csharpImportQuery, func(m *sitter.QueryMatch) error {
+			if node := r.newUsingDirectiveImport(data, m.Captures[0].Node); node != nil {
+				imports = append(imports, node)
+			}
+
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+func (r *csharpResolvers) newUsingDirectiveImport(data *[]byte, directiveNode *sitter.Node) *ast.ImportNode {
+	aliasNode := directiveNode.ChildByFieldName("name")
+
+	var nameNode *sitter.Node
+	for i := 0; i < int(directiveNode.NamedChildCount()); i++ {
+		child := directiveNode.NamedChild(i)
+		if aliasNode != nil && child.Equal(aliasNode) {
+			continue
+		}
+
+		switch child.Type() {
+		case "identifier", "qualified_name", "generic_name", "alias_qualified_name":
+			nameNode = child
+		}
+
+		if nameNode != nil {
+			break
+		}
+	}
+
+	// Aliases of tuples or other types eg. `using Pair = (int, int);`
+	if nameNode == nil {
+		return nil
+	}
+
+	node := ast.NewImportNode(data)
+	if aliasNode == nil {
+		node.SetModuleNameNode(nameNode)
+		node.SetIsWildcardImport(true)
+		return node
+	}
+
+	node.SetModuleAliasNode(aliasNode)
+	if nameNode.Type() != "qualified_name" {
+		node.SetModuleNameNode(csharpTypeNameNode(nameNode))
+		return node
+	}
+
+	node.SetModuleNameNode(nameNode.ChildByFieldName("qualifier"))
+	node.SetModuleItemNode(csharpTypeNameNode(nameNode.ChildByFieldName("name")))
+
+	return node
+}
+
+const csharpFunctionQuery = `
+	(method_declaration) @function
+	(constructor_declaration) @function
+`
+
+// ResolveFunctions extracts the methods and constructors of classes, structs,
+// records and interfaces from C# parse tree. Members are private unless declared
+// with an access modifier, except for interface members which are public.
+// Attributes are reported as decorators. Local functions, lambdas and
+// destructors are not reported
+func (r *csharpResolvers) ResolveFunctions(tree core.ParseTree) ([]*ast.FunctionDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var functions []*ast.FunctionDeclarationNode
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(csharpFunctionQuery, func(m *sitter.QueryMatch) error {
+			if functionNode := r.newFunctionNode(data, m.Captures[0].Node); functionNode != nil {
+				functions = append(functions, functionNode)
+			}
+
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract C# functions: %w", err)
+	}
+
+	return functions, nil
+}
+
+func (r *csharpResolvers) newFunctionNode(data *[]byte, node *sitter.Node) *ast.FunctionDeclarationNode {
+	nameNode := node.ChildByFieldName("name")
+	if nameNode == nil {
+		return nil
+	}
+
+	functionNode := ast.NewFunctionDeclarationNode(data)
+	functionNode.SetFunctionNameNode(nameNode)
+
+	if paramsNode := node.ChildByFieldName("parameters"); paramsNode != nil {
+		functionNode.SetFunctionParameterNodes(csharpParameterNodes(paramsNode))
+	}
+
+	if returnTypeNode := node.ChildByFieldName("returns"); returnTypeNode != nil {
+		functionNode.SetFunctionReturnTypeNode(returnTypeNode)
+	}
+
+	bodyNode := node.ChildByFieldName("body")
+	if bodyNode != nil {
+		functionNode.SetFunctionBodyNode(bodyNode)
+	}
+
+	csharpVisitAttributes(node, functionNode.AddDecoratorNode)
+
+	defaultAccess := ast.AccessModifierPrivate
+	ownerNode := csharpOwnerNode(node)
+	if ownerNode != nil {
+		if ownerNameNode := ownerNode.ChildByFieldName("name"); ownerNameNode != nil {
+			functionNode.SetParentClassName(ownerNameNode.Content(*data))
+		}
+
+		if ownerNode.Type() == "interface_declaration" {
+			defaultAccess = ast.AccessModifierPublic
+		}
+	}
+
+	functionNode.SetAccessModifier(csharpAccessModifier(data, node, defaultAccess))
+	functionNode.SetIsAsync(csharpHasModifier(data, node, "async"))
+
+	if node.Type() == "constructor_declaration" {
+		functionNode.SetFunctionType(ast.FunctionTypeConstructor)
+		functionNode.SetIsStatic(csharpHasModifier(data, node, "static"))
+		return functionNode
+	}
+
+	functionNode.SetFunctionType(ast.FunctionTypeMethod)
+	functionNode.SetIsAbstract(bodyNode == nil || csharpHasModifier(data, node, "abstract"))
+
+	if csharpHasModifier(data, node, "static") {
+		functionNode.SetFunctionType(ast.FunctionTypeStaticMethod)
+		functionNode.SetIsStatic(true)
+	}
+
+	return functionNode
+}
+
+const csharpClassQuery = `
+	(class_declaration) @class
+	(interface_declaration) @class
+	(struct_declaration) @class
+	(record_declaration) @class
+`
+
+// ResolveClasses extracts classes, interfaces, structs and records from C#
+// parse tree. Interfaces are reported as abstract classes. Declarations of
+// partial classes are reported separately, use ast.MergePartialClasses to merge
+// them, eg. across the files of a project. Fields are the declared fields and
+// properties along with the parameters of record primary constructors
+func (r *csharpResolvers) ResolveClasses(tree core.ParseTree) ([]*ast.ClassDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var classes []*ast.ClassDeclarationNode
+
+	err = r.visitClasses(data, tree, func(classNode *sitter.Node) {
+		nameNode := classNode.ChildByFieldName("name")
+		if nameNode == nil {
+			return
+		}
+
+		// Types are internal unless declared with an access modifier,
+		// nested types are private
+		defaultAccess := ast.AccessModifierPackage
+		if csharpOwnerNode(classNode) != nil {
+			defaultAccess = ast.AccessModifierPrivate
+		}
+
+		classDeclaration := ast.NewClassDeclarationNode(ast.ToContent(*data))
+		classDeclaration.SetClassNameNode(nameNode)
+		classDeclaration.SetAccessModifier(csharpAccessModifier(data, classNode, defaultAccess))
+		classDeclaration.SetIsAbstract(classNode.Type() == "interface_declaration" ||
+			csharpHasModifier(data, classNode, "abstract"))
+		classDeclaration.SetIsPartial(csharpHasModifier(data, classNode, "partial"))
+
+		csharpVisitAttributes(classNode, classDeclaration.AddDecoratorNode)
+		csharpVisitBaseClasses(data, classNode, func(_ ast.RelationshipType, baseNode *sitter.Node) {
+			classDeclaration.AddBaseClassNode(baseNode)
+		})
+
+		for i := 0; i < int(classNode.NamedChildCount()); i++ {
+			if paramsNode := classNode.NamedChild(i); paramsNode.Type() == "parameter_list" {
+				for _, paramNode := range csharpParameterNodes(paramsNode) {
+					if paramNameNode := paramNode.ChildByFieldName("name"); paramNameNode != nil {
+						classDeclaration.AddFieldNode(paramNameNode)
+					}
+				}
+			}
+		}
+
+		if bodyNode := classNode.ChildByFieldName("body"); bodyNode != nil {
+			r.addMembers(classDeclaration, bodyNode)
+		}
+
+		classes = append(classes, classDeclaration)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract class definitions: %w", err)
+	}
+
+	return classes, nil
+}
+
+// addMembers adds the methods, the first constructor, the fields
+// and the properties declared in a type body
+func (r *csharpResolvers) addMembers(classDeclaration *ast.ClassDeclarationNode, bodyNode *sitter.Node) {
+	for i := 0; i < int(bodyNode.NamedChildCount()); i++ {
+		member := bodyNode.NamedChild(i)
+		switch member.Type() {
+		case "method_declaration":
+			classDeclaration.AddMethodNode(member)
+		case "constructor_declaration":
+			if classDeclaration.GetConstructorNode() == nil {
+				classDeclaration.SetConstructorNode(member)
+			}
+		case "property_declaration":
+			if nameNode := member.ChildByFieldName("name"); nameNode != nil {
+				classDeclaration.AddFieldNode(nameNode)
+			}
+		case "field_declaration":
+			for j := 0; j < int(member.NamedChildCount()); j++ {
+				declarationNode := member.NamedChild(j)
+				if declarationNode.Type() != "variable_declaration" {
+					continue
+				}
+
+				for k := 0; k < int(declarationNode.NamedChildCount()); k++ {
+					declaratorNode := declarationNode.NamedChild(k)
+					if declaratorNode.Type() != "variable_declarator" {
+						continue
+					}
+
+					if nameNode := declaratorNode.ChildByFieldName("name"); nameNode != nil {
+						classDeclaration.AddFieldNode(nameNode)
+					}
+				}
+			}
+		}
+	}
+}
+
+// ResolveInheritance builds inheritance graph from C# classes, interfaces,
+// structs and records. Interfaces extend their base interfaces, other types
+// extend their base class and implement interfaces
+func (r *csharpResolvers) ResolveInheritance(tree core.ParseTree) (*ast.InheritanceGraph, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	filename := ""
+	if file, err := tree.File(); err == nil && file != nil {
+		filename = file.Name()
+	}
+
+	inheritanceGraph := ast.NewInheritanceGraph()
+
+	err = r.visitClasses(data, tree, func(classNode *sitter.Node) {
+		nameNode := classNode.ChildByFieldName("name")
+		if nameNode == nil {
+			return
+		}
+
+		className := nameNode.Content(*data)
+		csharpVisitBaseClasses(data, classNode, func(relationshipType ast.RelationshipType, baseNode *sitter.Node) {
+			inheritanceGraph.AddRelationship(className, baseNode.Content(*data),
+				relationshipType, filename, baseNode.StartPoint().Row+1)
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute inheritance queries: %w", err)
+	}
+
+	return inheritanceGraph, nil
+}
+
+func (r *csharpResolvers) visitClasses(data *[]byte, tree core.ParseTree, visitor func(classNode *sitter.Node)) error {
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(csharpClassQuery, func(m *sitter.QueryMatch) error {
+			visitor(m.Captures[0].Node)
+			return nil
+		}),
+	}
+
+	return ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+}
+
+// Interface names follow the `IName` convention eg. IDisposable
+var csharpInterfaceNamePattern = regexp.MustCompile(`^I[A-Z]`)
+
+// csharpVisitBaseClasses calls the visitor with the types in the base list of
+// a type declaration. The base list does not distinguish the base class from
+// the implemented interfaces, hence names following the interface naming
+// convention are implemented interfaces and the first other type is the base
+// class. Structs and records can only implement interfaces
+func csharpVisitBaseClasses(data *[]byte, classNode *sitter.Node, visitor func(relationshipType ast.RelationshipType, baseNode *sitter.Node)) {
+	var baseListNode *sitter.Node
+	for i := 0; i < int(classNode.NamedChildCount()); i++ {
+		if child := classNode.NamedChild(i); child.Type() == "base_list" {
+			baseListNode = child
+			break
+		}
+	}
+
+	if baseListNode == nil {
+		return
+	}
+
+	hasBaseClass := classNode.Type() == "struct_declaration"
+	for i := 0; i < int(baseListNode.NamedChildCount()); i++ {
+		baseNode := baseListNode.NamedChild(i)
+
+		// Base record with primary constructor arguments eg. `record B(int X) : A(X)`
+		if baseNode.Type() == "primary_constructor_base_type" && baseNode.NamedChildCount() > 0 {
+			baseNode = baseNode.NamedChild(0)
+		}
+
+		switch baseNode.Type() {
+		case "identifier", "qualified_name", "generic_name", "alias_qualified_name":
+		default:
+			continue
+		}
+
+		if classNode.Type() == "interface_declaration" {
+			visitor(ast.RelationshipTypeExtends, baseNode)
+			continue
+		}
+
+		simpleName := csharpTypeNameNode(baseNode).Content(*data)
+		if !hasBaseClass && !csharpInterfaceNamePattern.MatchString(simpleName) {
+			hasBaseClass = true
+			visitor(ast.RelationshipTypeExtends, baseNode)
+			continue
+		}
+
+		visitor(ast.RelationshipTypeImplements, baseNode)
+	}
+}
+
+// csharpVisitAttributes calls the visitor with the attributes of a declaration
+// eg. `HttpGet("{id}")` and `Obsolete` in `[HttpGet("{id}"), Obsolete]`
+func csharpVisitAttributes(node *sitter.Node, visitor func(attributeNode *sitter.Node)) {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		attributeListNode := node.NamedChild(i)
+		if attributeListNode.Type() != "attribute_list" {
+			continue
+		}
+
+		for j := 0; j < int(attributeListNode.NamedChildCount()); j++ {
+			if attributeNode := attributeListNode.NamedChild(j); attributeNode.Type() == "attribute" {
+				visitor(attributeNode)
+			}
+		}
+	}
+}
+
+// csharpParameterNodes returns the parameters of a parameter list. The grammar
+// does not wrap `params` arrays in a parameter node, their name is used instead
+func csharpParameterNodes(parametersNode *sitter.Node) []*sitter.Node {
+	var paramNodes []*sitter.Node
+	for i := 0; i < int(parametersNode.ChildCount()); i++ {
+		child := parametersNode.Child(i)
+		if child.Type() == "parameter" || parametersNode.FieldNameForChild(i) == "name" {
+			paramNodes = append(paramNodes, child)
+		}
+	}
+
+	return paramNodes
+}
+
+// csharpOwnerNode returns the class, interface, struct or record declaring a member
+func csharpOwnerNode(node *sitter.Node) *sitter.Node {
+	for current := node.Parent(); current != nil; current = current.Parent() {
+		switch current.Type() {
+		case "class_declaration", "interface_declaration", "struct_declaration", "record_declaration":
+			return current
+		}
+	}
+
+	return nil
+}
+
+// csharpTypeNameNode returns the identifier of a type name
+// eg. `ILogger` in `ILogger<Program>` or `List<T>` in `System.Collections.Generic.List<T>`
+func csharpTypeNameNode(node *sitter.Node) *sitter.Node {
+	if node.Type() == "qualified_name" || node.Type() == "alias_qualified_name" {
+		if nameNode := node.ChildByFieldName("name"); nameNode != nil {
+			node = nameNode
+		}
+	}
+
+	if node.Type() == "generic_name" && node.NamedChildCount() > 0 {
+		return node.NamedChild(0)
+	}
+
+	return node
+}
+
+func csharpHasModifier(data *[]byte, node *sitter.Node, modifier string) bool {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if child.Type() == "modifier" && child.Content(*data) == modifier {
+			return true
+		}
+	}
+
+	return false
+}
+
+// csharpAccessModifier returns the access level of a declaration, `protected
+// internal` and `private protected` members are reported as protected
+func csharpAccessModifier(data *[]byte, node *sitter.Node, defaultAccess ast.AccessModifier) ast.AccessModifier {
+	var modifiers []string
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		if child := node.NamedChild(i); child.Type() == "modifier" {
+			modifiers = append(modifiers, strings.TrimSpace(child.Content(*data)))
+		}
+	}
+
+	for _, modifier := range []struct {
+		name   string
+		access ast.AccessModifier
+	}{
+		{"public", ast.AccessModifierPublic},
+		{"protected", ast.AccessModifierProtected},
+		{"internal", ast.AccessModifierPackage},
+		{"private", ast.AccessModifierPrivate},
+	} {
+		if slices.Contains(modifiers, modifier.name) {
+			return modifier.access
+		}
+	}
+
+	return defaultAccess
+}
@@ -0,0 +1,238 @@
+package lang_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/core/ast"
+	"github.com/safedep/code/fs"
+	"github.com/safedep/code/lang"
+	"github.com/safedep/code/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+var csharpImportExpectations = []ImportExpectations{
+	{
+		filePath: "fixtures/imports.cs",
+		imports: []string{
+			"ImportNode{ModuleName: System.Linq, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: System.Math, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: System, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: System.Collections.Generic, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: Newtonsoft.Json, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: System.Console, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: System.Text.Json, ModuleItem: JsonSerializer, ModuleAlias: Json, WildcardImport: false}",
+			"ImportNode{ModuleName: System.Net, ModuleItem: Http, ModuleAlias: Http, WildcardImport: false}",
+			"ImportNode{ModuleName: Microsoft.Extensions.Logging, ModuleItem: ILogger, ModuleAlias: Logger, WildcardImport: false}",
+			"ImportNode{ModuleName: Serilog, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: Dapper, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+		},
+	},
+}
+
+var csharpFunctionExpectations = map[string][]string{
+	"fixtures/functions.cs": {
+		"FunctionDeclarationNode{Name: Find, Type: method, Access: public, ParentClass: IRepository}",
+		"FunctionDeclarationNode{Name: Configure, Type: method, Access: protected, ParentClass: Service}",
+		"FunctionDeclarationNode{Name: OrderService, Type: constructor, Access: public, ParentClass: OrderService}",
+		"FunctionDeclarationNode{Name: GetAsync, Type: method, Access: public, ParentClass: OrderService}",
+		"FunctionDeclarationNode{Name: Create, Type: static_method, Access: public, ParentClass: OrderService}",
+		"FunctionDeclarationNode{Name: Configure, Type: method, Access: protected, ParentClass: OrderService}",
+		"FunctionDeclarationNode{Name: Process, Type: method, Access: package, ParentClass: OrderService}",
+		"FunctionDeclarationNode{Name: Hidden, Type: method, Access: private, ParentClass: OrderService}",
+		"FunctionDeclarationNode{Name: Total, Type: method, Access: public, ParentClass: Order}",
+		"FunctionDeclarationNode{Name: Money, Type: constructor, Access: public, ParentClass: Money}",
+	},
+}
+
+func parseCsharpFixtures(t *testing.T, filePaths []string, visitor func(core.ParseTree, core.File)) {
+	t.Helper()
+
+	csharpLanguage, err := lang.NewCsharpLanguage()
+	assert.NoError(t, err)
+
+	fileParser, err := parser.NewParser([]core.Language{csharpLanguage})
+	assert.NoError(t, err)
+
+	fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+		AppDirectories: filePaths,
+	})
+	assert.NoError(t, err)
+
+	err = fileSystem.EnumerateApp(context.Background(), func(f core.File) error {
+		parseTree, err := fileParser.Parse(context.Background(), f)
+		assert.NoError(t, err)
+
+		visitor(parseTree, f)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestCsharpLanguageResolvers(t *testing.T) {
+	csharpLanguage, err := lang.NewCsharpLanguage()
+	assert.NoError(t, err)
+
+	t.Run("ResolversExists", func(t *testing.T) {
+		resolvers := csharpLanguage.Resolvers()
+		assert.NotNil(t, resolvers)
+
+		_, ok := resolvers.(core.ObjectOrientedLanguageResolvers)
+		assert.True(t, ok)
+	})
+
+	t.Run("ResolveImports", func(t *testing.T) {
+		importExpectationsMapper := make(map[string][]string)
+		importFilePaths := []string{}
+		for _, ie := range csharpImportExpectations {
+			importFilePaths = append(importFilePaths, ie.filePath)
+			importExpectationsMapper[ie.filePath] = ie.imports
+		}
+
+		parseCsharpFixtures(t, importFilePaths, func(parseTree core.ParseTree, f core.File) {
+			imports, err := csharpLanguage.Resolvers().ResolveImports(parseTree)
+			assert.NoError(t, err)
+
+			expectedImports, ok := importExpectationsMapper[f.Name()]
+			assert.True(t, ok)
+
+			assert.Equal(t, len(expectedImports), len(imports))
+			for i, expectedImport := range expectedImports {
+				assert.Equal(t, expectedImport, imports[i].String())
+			}
+		})
+	})
+
+	t.Run("ResolveFunctions", func(t *testing.T) {
+		var filePaths []string
+		for path := range csharpFunctionExpectations {
+			filePaths = append(filePaths, path)
+		}
+
+		parseCsharpFixtures(t, filePaths, func(parseTree core.ParseTree, f core.File) {
+			functions, err := csharpLanguage.Resolvers().ResolveFunctions(parseTree)
+			assert.NoError(t, err)
+
+			expectedFunctions, ok := csharpFunctionExpectations[f.Name()]
+			assert.True(t, ok)
+
+			var foundFunctions []string
+			for _, fun := range functions {
+				foundFunctions = append(foundFunctions,
+					fmt.Sprintf("FunctionDeclarationNode{Name: %s, Type: %s, Access: %s, ParentClass: %s}",
+						fun.FunctionName(), fun.GetFunctionType(), fun.GetAccessModifier(), fun.GetParentClassName()))
+
+				switch fun.FunctionName() {
+				case "Find":
+					assert.True(t, fun.IsAbstract())
+				case "GetAsync":
+					assert.True(t, fun.IsAsync())
+					assert.Equal(t, []string{"int id", "CancellationToken token = default"}, fun.Parameters())
+					assert.Equal(t, []string{`HttpGet("{id}")`, `Authorize(Roles = "admin")`, "Obsolete"}, fun.Decorators())
+				case "Create":
+					assert.True(t, fun.IsStatic())
+					assert.False(t, fun.IsAbstract())
+				case "Process":
+					assert.Equal(t, []string{"orders"}, fun.Parameters())
+				}
+			}
+
+			assert.ElementsMatch(t, expectedFunctions, foundFunctions)
+		})
+	})
+
+	t.Run("ResolveClasses", func(t *testing.T) {
+		parseCsharpFixtures(t, []string{"fixtures/csharp_class_hierarchy.cs"}, func(parseTree core.ParseTree, _ core.File) {
+			resolvers := csharpLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			classes, err := resolvers.ResolveClasses(parseTree)
+			assert.NoError(t, err)
+
+			found := map[string]string{}
+			for _, class := range classes {
+				found[class.ClassName()] = fmt.Sprintf("bases=%v methods=%d fields=%v constructor=%t abstract=%t",
+					class.BaseClasses(), len(class.Methods()), class.Fields(),
+					class.Constructor() != "", class.IsAbstract())
+
+				switch class.ClassName() {
+				case "Entity":
+					assert.Equal(t, []string{"Serializable"}, class.Decorators())
+				case "Repository":
+					assert.Equal(t, ast.AccessModifierPackage, class.AccessModifier())
+				case "Cache":
+					assert.Equal(t, ast.AccessModifierPrivate, class.AccessModifier())
+				}
+			}
+
+			assert.Equal(t, map[string]string{
+				"Entity":         "bases=[] methods=1 fields=[Id] constructor=true abstract=true",
+				"IAuditable":     "bases=[] methods=1 fields=[] constructor=false abstract=true",
+				"ISoftDeletable": "bases=[IAuditable IDisposable] methods=0 fields=[IsDeleted] constructor=false abstract=true",
+				"Customer":       "bases=[Entity IAuditable] methods=2 fields=[name email] constructor=false abstract=false",
+				"Invoice":        "bases=[IAuditable] methods=1 fields=[Number Amount] constructor=false abstract=false",
+				"Point":          "bases=[IEquatable<Point>] methods=1 fields=[X] constructor=false abstract=false",
+				"Repository":     "bases=[System.Collections.Generic.List<T>] methods=0 fields=[] constructor=false abstract=false",
+				"Cache":          "bases=[] methods=0 fields=[] constructor=false abstract=false",
+			}, found)
+		})
+	})
+
+	t.Run("ResolvePartialClasses", func(t *testing.T) {
+		resolvers := csharpLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+		var classes []*ast.ClassDeclarationNode
+		partialFilePaths := []string{"fixtures/csharp_partial_a.cs", "fixtures/csharp_partial_b.cs"}
+		parseCsharpFixtures(t, partialFilePaths, func(parseTree core.ParseTree, _ core.File) {
+			fileClasses, err := resolvers.ResolveClasses(parseTree)
+			assert.NoError(t, err)
+
+			classes = append(classes, fileClasses...)
+		})
+
+		assert.Len(t, classes, 2)
+
+		merged := ast.MergePartialClasses(classes)
+		assert.Len(t, merged, 1)
+
+		order := merged[0]
+		assert.Equal(t, "Order", order.ClassName())
+		assert.True(t, order.IsPartial())
+		assert.Len(t, order.Partials(), 1)
+		assert.ElementsMatch(t, []string{"Entity", "IAuditable"}, order.BaseClasses())
+		assert.Len(t, order.Methods(), 3)
+		assert.ElementsMatch(t, []string{"id", "Status"}, order.Fields())
+		assert.NotEmpty(t, order.Constructor())
+		assert.Equal(t, []string{`Table("orders")`}, order.Decorators())
+	})
+
+	t.Run("ResolveInheritance", func(t *testing.T) {
+		parseCsharpFixtures(t, []string{"fixtures/csharp_class_hierarchy.cs"}, func(parseTree core.ParseTree, _ core.File) {
+			resolvers := csharpLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			graph, err := resolvers.ResolveInheritance(parseTree)
+			assert.NoError(t, err)
+
+			var relationships []string
+			for _, class := range graph.GetAllClasses() {
+				for _, rel := range graph.GetDirectParents(class) {
+					relationships = append(relationships, fmt.Sprintf("%s %s %s", rel.ChildClassName,
+						rel.RelationshipType, rel.ParentClassName))
+				}
+			}
+
+			assert.ElementsMatch(t, []string{
+				"ISoftDeletable extends IAuditable",
+				"ISoftDeletable extends IDisposable",
+				"Customer extends Entity",
+				"Customer implements IAuditable",
+				"Invoice implements IAuditable",
+				"Point implements IEquatable<Point>",
+				"Repository extends System.Collections.Generic.List<T>",
+			}, relationships)
+
+			assert.True(t, graph.IsAncestor("IAuditable", "Customer"))
+		})
+	})
+}
@@ -0,0 +1,25 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCsharpLanguageMeta(t *testing.T) {
+	t.Run("Name", func(t *testing.T) {
+		l := &csharpLanguage{}
+		assert.Equal(t, csharpLanguageName, l.Name())
+	})
+
+	t.Run("Code", func(t *testing.T) {
+		l := &csharpLanguage{}
+		assert.Equal(t, core.LanguageCodeCsharp, l.Meta().Code)
+	})
+
+	t.Run("ObjectOriented", func(t *testing.T) {
+		l := &csharpLanguage{}
+		assert.True(t, l.Meta().ObjectOriented)
+	})
+}
@@ -0,0 +1,234 @@
+package lang
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/safedep/code/core"
+)
+
+// DetectionSource is the signal from which the language of a file is detected
+type DetectionSource string
+
+const (
+	// Editor modeline eg. `# vim: set filetype=python :`
+	DetectionSourceModeline DetectionSource = "modeline"
+
+	// Well known file name eg. Rakefile
+	DetectionSourceFilename DetectionSource = "filename"
+
+	// Interpreter of a script eg. `#!/usr/bin/env bash`
+	DetectionSourceShebang DetectionSource = "shebang"
+
+	// File extension eg. .py
+	DetectionSourceExtension DetectionSource = "extension"
+
+	// Content of a file having an extension shared by languages eg. .h
+	DetectionSourceHeuristic DetectionSource = "heuristic"
+)
+
+// Confidence of a detected language being the language of the file, from 0 to 1
+const (
+	DetectionConfidenceModeline  = 1.0
+	DetectionConfidenceFilename  = 0.95
+	DetectionConfidenceShebang   = 0.9
+	DetectionConfidenceExtension = 0.8
+	DetectionConfidenceHeuristic = 0.7
+
+	// Extension shared by languages without content to disambiguate
+	DetectionConfidenceAmbiguous = 0.5
+)
+
+// LanguageDetection is the language detected for a file
+type LanguageDetection struct {
+	Language   core.Language
+	Source     DetectionSource
+	Confidence float64
+}
+
+// Files named by convention without a language specific extension
+var languageFilenames = map[string]core.LanguageCode{
+	"Rakefile":      core.LanguageCodeRuby,
+	"Gemfile":       core.LanguageCodeRuby,
+	"Guardfile":     core.LanguageCodeRuby,
+	"Podfile":       core.LanguageCodeRuby,
+	"Vagrantfile":   core.LanguageCodeRuby,
+	"Brewfile":      core.LanguageCodeRuby,
+	"Fastfile":      core.LanguageCodeRuby,
+	"Jakefile":      core.LanguageCodeJavascript,
+	"SConstruct":    core.LanguageCodePython,
+	"SConscript":    core.LanguageCodePython,
+	"PKGBUILD":      core.LanguageCodeBash,
+	"APKBUILD":      core.LanguageCodeBash,
+	".bashrc":       core.LanguageCodeBash,
+	".bash_profile": core.LanguageCodeBash,
+	".bash_logout":  core.LanguageCodeBash,
+	".profile":      core.LanguageCodeBash,
+}
+
+// Languages named in editor modelines eg. ft=sh in vim, mode: c++ in emacs
+var modelineLanguages = map[string]core.LanguageCode{
+	"python":       core.LanguageCodePython,
+	"python3":      core.LanguageCodePython,
+	"javascript":   core.LanguageCodeJavascript,
+	"js":           core.LanguageCodeJavascript,
+	"typescript":   core.LanguageCodeTypescript,
+	"ts":           core.LanguageCodeTypescript,
+	"java":         core.LanguageCodeJava,
+	"go":           core.LanguageCodeGo,
+	"rust":         core.LanguageCodeRust,
+	"ruby":         core.LanguageCodeRuby,
+	"php":          core.LanguageCodePhp,
+	"cs":           core.LanguageCodeCsharp,
+	"csharp":       core.LanguageCodeCsharp,
+	"kotlin":       core.LanguageCodeKotlin,
+	"c":            core.LanguageCodeC,
+	"cpp":          core.LanguageCodeCpp,
+	"c++":          core.LanguageCodeCpp,
+	"sh":           core.LanguageCodeBash,
+	"bash":         core.LanguageCodeBash,
+	"shell-script": core.LanguageCodeBash,
+}
+
+// Vim reads modelines from the first and the last lines of a file
+const modelineSearchLines = 5
+
+var (
+	vimModelineRegexp   = regexp.MustCompile(`(?:^|\s)(?:vi|vim|ex):.*?\b(?:ft|filetype|syntax)=([\w+#-]+)`)
+	emacsModelineRegexp = regexp.MustCompile(`-\*-(?:.*?\bmode:)?\s*([\w+#-]+)\s*;?.*?-\*-`)
+)
+
+type extensionHeuristic struct {
+	language core.LanguageCode
+	pattern  *regexp.Regexp
+}
+
+// Extensions shared by languages along with the patterns identifying the
+// content of the language other than the one the extension belongs to
+var ambiguousExtensions = map[string][]extensionHeuristic{
+	".h": {
+		{
+			language: core.LanguageCodeCpp,
+			pattern:  regexp.MustCompile(`(?m)^\s*(?:class|namespace|template)\b|\bstd::|^\s*(?:public|private|protected)\s*:|#include\s*<(?:iostream|string|vector|memory|map)>`),
+		},
+	},
+}
+
+// DetectLanguage detects the language of a file from its path and content.
+// Signals are evaluated in the order of modeline, file name, shebang and
+// extension, where files having an extension shared by languages eg. .h are
+// disambiguated by their content. The content is optional, such that the
+// language can be detected from the path alone, eg. the head of the file
+// is sufficient for detection.
+//
+// It returns nil, false if the language of the file is not detected.
+func DetectLanguage(filePath string, content []byte) (*LanguageDetection, bool) {
+	if len(content) > 0 {
+		if code, ok := detectModeline(content); ok {
+			return newLanguageDetection(code, DetectionSourceModeline, DetectionConfidenceModeline)
+		}
+	}
+
+	if code, ok := languageFilenames[filepath.Base(filePath)]; ok {
+		return newLanguageDetection(code, DetectionSourceFilename, DetectionConfidenceFilename)
+	}
+
+	if language, ok := ResolveLanguageFromShebang(content); ok {
+		return &LanguageDetection{
+			Language:   language,
+			Source:     DetectionSourceShebang,
+			Confidence: DetectionConfidenceShebang,
+		}, true
+	}
+
+	extension := filepath.Ext(filePath)
+	if extension == "" {
+		return nil, false
+	}
+
+	if heuristics, ok := ambiguousExtensions[extension]; ok {
+		if len(content) == 0 {
+			return newExtensionDetection(extension, DetectionSourceExtension, DetectionConfidenceAmbiguous)
+		}
+
+		for _, heuristic := range heuristics {
+			if heuristic.pattern.Match(content) {
+				return newLanguageDetection(heuristic.language, DetectionSourceHeuristic, DetectionConfidenceHeuristic)
+			}
+		}
+
+		return newExtensionDetection(extension, DetectionSourceHeuristic, DetectionConfidenceHeuristic)
+	}
+
+	return newExtensionDetection(extension, DetectionSourceExtension, DetectionConfidenceExtension)
+}
+
+func newLanguageDetection(code core.LanguageCode, source DetectionSource, confidence float64) (*LanguageDetection, bool) {
+	language, err := GetLanguage(string(code))
+	if err != nil {
+		return nil, false
+	}
+
+	return &LanguageDetection{
+		Language:   language,
+		Source:     source,
+		Confidence: confidence,
+	}, true
+}
+
+// newExtensionDetection detects the language the extension belongs to,
+// languages having dialects are detected with the dialect of the extension
+func newExtensionDetection(extension string, source DetectionSource, confidence float64) (*LanguageDetection, bool) {
+	code, ok := registry.languageForExtension(extension)
+	if !ok {
+		return nil, false
+	}
+
+	factory, ok := registry.factory(code)
+	if !ok {
+		return nil, false
+	}
+
+	l, err := factory()
+	if err != nil {
+		return nil, false
+	}
+
+	if d, ok := l.(dialectAwareLanguage); ok {
+		l = d.dialectForExtension(extension)
+	}
+
+	return &LanguageDetection{
+		Language:   l,
+		Source:     source,
+		Confidence: confidence,
+	}, true
+}
+
+// detectModeline detects the language declared by a vim or emacs modeline
+// in the first or the last lines of the content
+func detectModeline(content []byte) (core.LanguageCode, bool) {
+	lines := bytes.Split(content, []byte("\n"))
+
+	searchLines := lines
+	if len(lines) > 2*modelineSearchLines {
+		searchLines = append(lines[:modelineSearchLines:modelineSearchLines], lines[len(lines)-modelineSearchLines:]...)
+	}
+
+	for _, line := range searchLines {
+		for _, modelineRegexp := range []*regexp.Regexp{vimModelineRegexp, emacsModelineRegexp} {
+			match := modelineRegexp.FindSubmatch(line)
+			if match == nil {
+				continue
+			}
+
+			if code, ok := modelineLanguages[strings.ToLower(string(match[1]))]; ok {
+				return code, true
+			}
+		}
+	}
+
+	return "", false
+}
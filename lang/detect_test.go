@@ -0,0 +1,142 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	testcases := []struct {
+		name                 string
+		filePath             string
+		content              string
+		exists               bool
+		expectedLanguageCode core.LanguageCode
+		expectedSource       DetectionSource
+		expectedConfidence   float64
+	}{
+		{
+			name:                 "extension",
+			filePath:             "app/main.py",
+			exists:               true,
+			expectedLanguageCode: core.LanguageCodePython,
+			expectedSource:       DetectionSourceExtension,
+			expectedConfidence:   DetectionConfidenceExtension,
+		},
+		{
+			name:                 "extension of dialect",
+			filePath:             "src/component.tsx",
+			exists:               true,
+			expectedLanguageCode: core.LanguageCodeTypescript,
+			expectedSource:       DetectionSourceExtension,
+			expectedConfidence:   DetectionConfidenceExtension,
+		},
+		{
+			name:                 "well known file name",
+			filePath:             "project/Rakefile",
+			exists:               true,
+			expectedLanguageCode: core.LanguageCodeRuby,
+			expectedSource:       DetectionSourceFilename,
+			expectedConfidence:   DetectionConfidenceFilename,
+		},
+		{
+			name:                 "well known dotfile",
+			filePath:             "home/.bashrc",
+			exists:               true,
+			expectedLanguageCode: core.LanguageCodeBash,
+			expectedSource:       DetectionSourceFilename,
+			expectedConfidence:   DetectionConfidenceFilename,
+		},
+		{
+			name:                 "shebang without extension",
+			filePath:             "bin/manage",
+			content:              "#!/usr/bin/env python3\nimport sys\n",
+			exists:               true,
+			expectedLanguageCode: core.LanguageCodePython,
+			expectedSource:       DetectionSourceShebang,
+			expectedConfidence:   DetectionConfidenceShebang,
+		},
+		{
+			name:                 "shebang overrides extension",
+			filePath:             "scripts/run.txt",
+			content:              "#!/bin/bash\necho hello\n",
+			exists:               true,
+			expectedLanguageCode: core.LanguageCodeBash,
+			expectedSource:       DetectionSourceShebang,
+			expectedConfidence:   DetectionConfidenceShebang,
+		},
+		{
+			name:                 "vim modeline",
+			filePath:             "bin/setup",
+			content:              "echo hello\n# vim: set ft=sh ts=2 :\n",
+			exists:               true,
+			expectedLanguageCode: core.LanguageCodeBash,
+			expectedSource:       DetectionSourceModeline,
+			expectedConfidence:   DetectionConfidenceModeline,
+		},
+		{
+			name:                 "emacs modeline overrides extension",
+			filePath:             "include/module.h",
+			content:              "/* -*- mode: c++; indent-tabs-mode: nil -*- */\nint add(int a, int b);\n",
+			exists:               true,
+			expectedLanguageCode: core.LanguageCodeCpp,
+			expectedSource:       DetectionSourceModeline,
+			expectedConfidence:   DetectionConfidenceModeline,
+		},
+		{
+			name:                 "ambiguous extension without content",
+			filePath:             "include/module.h",
+			exists:               true,
+			expectedLanguageCode: core.LanguageCodeC,
+			expectedSource:       DetectionSourceExtension,
+			expectedConfidence:   DetectionConfidenceAmbiguous,
+		},
+		{
+			name:                 "ambiguous extension with C++ content",
+			filePath:             "include/module.h",
+			content:              "#pragma once\n\nnamespace util {\nclass Buffer {\npublic:\n  std::string data;\n};\n}\n",
+			exists:               true,
+			expectedLanguageCode: core.LanguageCodeCpp,
+			expectedSource:       DetectionSourceHeuristic,
+			expectedConfidence:   DetectionConfidenceHeuristic,
+		},
+		{
+			name:                 "ambiguous extension with C content",
+			filePath:             "include/module.h",
+			content:              "#include <stdio.h>\n\nstruct buffer {\n  char *data;\n};\n\nint add(int a, int b);\n",
+			exists:               true,
+			expectedLanguageCode: core.LanguageCodeC,
+			expectedSource:       DetectionSourceHeuristic,
+			expectedConfidence:   DetectionConfidenceHeuristic,
+		},
+		{
+			name:     "unknown extension",
+			filePath: "README.md",
+			content:  "# Title\n",
+			exists:   false,
+		},
+		{
+			name:     "without extension and signals",
+			filePath: "bin/data",
+			content:  "hello world\n",
+			exists:   false,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			detection, exists := DetectLanguage(testcase.filePath, []byte(testcase.content))
+			assert.Equal(t, testcase.exists, exists)
+			if !testcase.exists {
+				assert.Nil(t, detection)
+				return
+			}
+
+			assert.Equal(t, testcase.expectedLanguageCode, detection.Language.Meta().Code)
+			assert.Equal(t, testcase.expectedSource, detection.Source)
+			assert.Equal(t, testcase.expectedConfidence, detection.Confidence)
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package lang
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/safedep/code/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/html"
+	tree_sitter_markdown "github.com/smacker/go-tree-sitter/markdown/tree-sitter-markdown"
+	"github.com/smacker/go-tree-sitter/svelte"
+)
+
+// EmbeddedSource is source code of a language embedded in a host document
+// eg. a <script> tag in HTML. Ranges are ranges of the host document, such
+// that a tree parsed from the data of the host document with the ranges
+// included has node positions in the host document
+type EmbeddedSource struct {
+	Language core.Language
+	Ranges   []sitter.Range
+}
+
+// EmbeddedSourceFile is a host document along with its embedded sources,
+// resolved eg. by the source walker such that they are not resolved again
+type EmbeddedSourceFile interface {
+	core.File
+	EmbeddedSources() []EmbeddedSource
+}
+
+// hostDocument is a document format embedding source code of other
+// languages, parsed with the grammar of the format to find the sources
+type hostDocument struct {
+	grammar  func() *sitter.Language
+	resolver func(root *sitter.Node, data []byte) []EmbeddedSource
+}
+
+// Host documents by file extension. Vue single file components are
+// parsed as HTML since only their top level tags are of interest
+var hostDocuments = map[string]hostDocument{
+	".html":     {grammar: html.GetLanguage, resolver: resolveScriptElements},
+	".htm":      {grammar: html.GetLanguage, resolver: resolveScriptElements},
+	".vue":      {grammar: html.GetLanguage, resolver: resolveScriptElements},
+	".svelte":   {grammar: svelte.GetLanguage, resolver: resolveScriptElements},
+	".md":       {grammar: tree_sitter_markdown.GetLanguage, resolver: resolveCodeFences},
+	".markdown": {grammar: tree_sitter_markdown.GetLanguage, resolver: resolveCodeFences},
+}
+
+// Names of languages used by code fences and the lang attribute of
+// script tags, in addition to file extensions and modeline names
+var languageNames = map[string]core.LanguageCode{
+	"golang": core.LanguageCodeGo,
+	"jsx":    core.LanguageCodeJavascript,
+	"shell":  core.LanguageCodeBash,
+	"py3":    core.LanguageCodePython,
+}
+
+// Values of the type attribute of script tags containing JavaScript,
+// scripts of other types eg. application/json are data or templates
+var javascriptScriptTypes = map[string]bool{
+	"":                       true,
+	"module":                 true,
+	"text/javascript":        true,
+	"application/javascript": true,
+	"text/ecmascript":        true,
+	"application/ecmascript": true,
+	"text/jsx":               true,
+	"text/babel":             true,
+}
+
+// IsHostDocument checks whether the file is a document embedding source
+// code of other languages eg. HTML, Vue, Svelte and Markdown files
+func IsHostDocument(filePath string) bool {
+	_, ok := hostDocuments[strings.ToLower(filepath.Ext(filePath))]
+	return ok
+}
+
+// ResolveEmbeddedSources parses a host document and returns the sources
+// embedded in it. Scripts of HTML, Vue and Svelte documents are resolved
+// as a source per language since they share the scope of the document,
+// while every code fence of a Markdown document is a source of its own.
+// Sources of languages not registered are skipped.
+//
+// It returns an error if the file is not a host document.
+func ResolveEmbeddedSources(ctx context.Context, filePath string, data []byte) ([]EmbeddedSource, error) {
+	document, ok := hostDocuments[strings.ToLower(filepath.Ext(filePath))]
+	if !ok {
+		return nil, fmt.Errorf("file is not a host document: %s", filePath)
+	}
+
+	parser := sitter.NewParser()
+	defer parser.Close()
+
+	parser.SetLanguage(document.grammar())
+
+	tree, err := parser.ParseCtx(ctx, nil, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse host document: %w", err)
+	}
+
+	defer tree.Close()
+
+	return document.resolver(tree.RootNode(), data), nil
+}
+
+// ResolveLanguageFromName resolves the programming language from its name
+// as used by the info string of code fences eg. ```python or the lang
+// attribute of script tags eg. <script lang="ts">. File extensions without
+// the leading dot are resolved along with their dialect eg. tsx.
+//
+// It returns nil, false if the name is not of an implemented language.
+func ResolveLanguageFromName(name string) (core.Language, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return nil, false
+	}
+
+	if detection, ok := newExtensionDetection("."+name, DetectionSourceExtension, DetectionConfidenceExtension); ok {
+		return detection.Language, true
+	}
+
+	code, ok := languageNames[name]
+	if !ok {
+		code, ok = modelineLanguages[name]
+	}
+
+	if !ok {
+		return nil, false
+	}
+
+	language, err := GetLanguage(string(code))
+	if err != nil {
+		return nil, false
+	}
+
+	return language, true
+}
+
+// resolveScriptElements resolves the <script> tags of HTML like documents
+func resolveScriptElements(root *sitter.Node, data []byte) []EmbeddedSource {
+	var sources []EmbeddedSource
+
+	walkNamedNodes(root, func(node *sitter.Node) {
+		if node.Type() != "script_element" {
+			return
+		}
+
+		var attributes map[string]string
+		var content *sitter.Node
+
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			child := node.NamedChild(i)
+			switch child.Type() {
+			case "start_tag":
+				attributes = scriptAttributes(child, data)
+			case "raw_text":
+				content = child
+			}
+		}
+
+		// Scripts loaded from a source eg. <script src="app.js"> are empty
+		if content == nil || strings.TrimSpace(content.Content(data)) == "" {
+			return
+		}
+
+		if !javascriptScriptTypes[strings.ToLower(attributes["type"])] {
+			return
+		}
+
+		languageName := attributes["lang"]
+		if languageName == "" {
+			languageName = "js"
+		}
+
+		language, ok := ResolveLanguageFromName(languageName)
+		if !ok {
+			return
+		}
+
+		code := language.Meta().Code
+		if code != core.LanguageCodeJavascript && code != core.LanguageCodeTypescript {
+			return
+		}
+
+		// Scripts of a language are parsed with the dialect of the first one
+		for i := range sources {
+			if sources[i].Language.Meta().Code == code {
+				sources[i].Ranges = append(sources[i].Ranges, nodeRange(content))
+				return
+			}
+		}
+
+		sources = append(sources, EmbeddedSource{
+			Language: language,
+			Ranges:   []sitter.Range{nodeRange(content)},
+		})
+	})
+
+	return sources
+}
+
+// scriptAttributes returns the attributes of a start tag by name
+func scriptAttributes(startTag *sitter.Node, data []byte) map[string]string {
+	attributes := map[string]string{}
+
+	for i := 0; i < int(startTag.NamedChildCount()); i++ {
+		attribute := startTag.NamedChild(i)
+		if attribute.Type() != "attribute" {
+			continue
+		}
+
+		var name, value string
+		for j := 0; j < int(attribute.NamedChildCount()); j++ {
+			child := attribute.NamedChild(j)
+			switch child.Type() {
+			case "attribute_name":
+				name = strings.ToLower(child.Content(data))
+			case "attribute_value":
+				value = child.Content(data)
+			case "quoted_attribute_value":
+				value = strings.Trim(child.Content(data), `"'`)
+			}
+		}
+
+		attributes[name] = strings.TrimSpace(value)
+	}
+
+	return attributes
+}
+
+// resolveCodeFences resolves the fenced code blocks of Markdown documents
+// having a language in their info string eg. ```python
+func resolveCodeFences(root *sitter.Node, data []byte) []EmbeddedSource {
+	var sources []EmbeddedSource
+
+	walkNamedNodes(root, func(node *sitter.Node) {
+		if node.Type() != "fenced_code_block" {
+			return
+		}
+
+		var languageName string
+		var content *sitter.Node
+
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			child := node.NamedChild(i)
+			switch child.Type() {
+			case "info_string":
+				for j := 0; j < int(child.NamedChildCount()); j++ {
+					if child.NamedChild(j).Type() == "language" {
+						languageName = child.NamedChild(j).Content(data)
+					}
+				}
+			case "code_fence_content":
+				content = child
+			}
+		}
+
+		if content == nil {
+			return
+		}
+
+		language, ok := ResolveLanguageFromName(languageName)
+		if !ok {
+			return
+		}
+
+		ranges := codeFenceRanges(content)
+		if len(ranges) == 0 {
+			return
+		}
+
+		sources = append(sources, EmbeddedSource{
+			Language: language,
+			Ranges:   ranges,
+		})
+	})
+
+	return sources
+}
+
+// codeFenceRanges returns the ranges of the content of a code fence without
+// the indentation of its lines eg. code fences nested in list items
+func codeFenceRanges(content *sitter.Node) []sitter.Range {
+	var ranges []sitter.Range
+
+	current := nodeRange(content)
+	for i := 0; i < int(content.NamedChildCount()); i++ {
+		continuation := content.NamedChild(i)
+		if continuation.Type() != "block_continuation" || continuation.StartByte() == continuation.EndByte() {
+			continue
+		}
+
+		current.EndByte = continuation.StartByte()
+		current.EndPoint = continuation.StartPoint()
+		if current.StartByte < current.EndByte {
+			ranges = append(ranges, current)
+		}
+
+		current.StartByte = continuation.EndByte()
+		current.StartPoint = continuation.EndPoint()
+		current.EndByte = content.EndByte()
+		current.EndPoint = content.EndPoint()
+	}
+
+	if current.StartByte < current.EndByte {
+		ranges = append(ranges, current)
+	}
+
+	return ranges
+}
+
+func nodeRange(node *sitter.Node) sitter.Range {
+	return sitter.Range{
+		StartPoint: node.StartPoint(),
+		EndPoint:   node.EndPoint(),
+		StartByte:  node.StartByte(),
+		EndByte:    node.EndByte(),
+	}
+}
+
+// walkNamedNodes visits the named nodes of a tree in document order
+func walkNamedNodes(node *sitter.Node, visit func(*sitter.Node)) {
+	visit(node)
+
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		walkNamedNodes(node.NamedChild(i), visit)
+	}
+}
@@ -0,0 +1,163 @@
+package lang
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/stretchr/testify/assert"
+)
+
+type embeddedSourceExpectation struct {
+	languageCode core.LanguageCode
+	contents     []string
+	startLine    uint32
+}
+
+func TestResolveEmbeddedSources(t *testing.T) {
+	testcases := []struct {
+		filePath string
+		expected []embeddedSourceExpectation
+	}{
+		{
+			filePath: "fixtures/embedded/index.html",
+			expected: []embeddedSourceExpectation{
+				{
+					languageCode: core.LanguageCodeJavascript,
+					contents: []string{
+						"\n      import confetti from \"canvas-confetti\";\n    ",
+						"\n      document.getElementById(\"celebrate\").onclick = () => confetti();\n    ",
+					},
+					startLine: 4,
+				},
+			},
+		},
+		{
+			filePath: "fixtures/embedded/Component.vue",
+			expected: []embeddedSourceExpectation{
+				{
+					languageCode: core.LanguageCodeTypescript,
+					contents: []string{
+						"\nimport axios from \"axios\";\n\nconst message: string = await axios.get(\"/message\");\n",
+					},
+					startLine: 4,
+				},
+			},
+		},
+		{
+			filePath: "fixtures/embedded/App.svelte",
+			expected: []embeddedSourceExpectation{
+				{
+					languageCode: core.LanguageCodeJavascript,
+					contents: []string{
+						"import lodash from \"lodash\";\n",
+					},
+					startLine: 1,
+				},
+				{
+					languageCode: core.LanguageCodeTypescript,
+					contents: []string{
+						"export let name: string = lodash.capitalize(\"world\");\n",
+					},
+					startLine: 5,
+				},
+			},
+		},
+		{
+			filePath: "fixtures/embedded/README.md",
+			expected: []embeddedSourceExpectation{
+				{
+					languageCode: core.LanguageCodePython,
+					contents: []string{
+						"import requests\n\nrequests.get(\"https://example.com\")\n",
+					},
+					startLine: 5,
+				},
+				{
+					languageCode: core.LanguageCodeJavascript,
+					contents: []string{
+						"const fs = require(\"fs\");\n",
+						"fs.readFileSync(\"file.txt\");\n",
+					},
+					startLine: 13,
+				},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.filePath, func(t *testing.T) {
+			data, err := os.ReadFile(testcase.filePath)
+			assert.NoError(t, err)
+
+			assert.True(t, IsHostDocument(testcase.filePath))
+
+			sources, err := ResolveEmbeddedSources(context.Background(), testcase.filePath, data)
+			assert.NoError(t, err)
+			assert.Len(t, sources, len(testcase.expected))
+
+			for i, expected := range testcase.expected {
+				if i >= len(sources) {
+					break
+				}
+
+				assert.Equal(t, expected.languageCode, sources[i].Language.Meta().Code)
+				assert.Equal(t, expected.startLine, sources[i].Ranges[0].StartPoint.Row)
+
+				var contents []string
+				for _, r := range sources[i].Ranges {
+					contents = append(contents, string(data[r.StartByte:r.EndByte]))
+				}
+
+				assert.Equal(t, expected.contents, contents)
+			}
+		})
+	}
+
+	t.Run("should fail for files other than host documents", func(t *testing.T) {
+		assert.False(t, IsHostDocument("fixtures/imports.js"))
+
+		_, err := ResolveEmbeddedSources(context.Background(), "fixtures/imports.js", []byte(""))
+		assert.ErrorContains(t, err, "file is not a host document")
+	})
+}
+
+func TestResolveLanguageFromName(t *testing.T) {
+	testcases := []struct {
+		name                 string
+		exists               bool
+		expectedLanguageCode core.LanguageCode
+	}{
+		{name: "python", exists: true, expectedLanguageCode: core.LanguageCodePython},
+		{name: "py", exists: true, expectedLanguageCode: core.LanguageCodePython},
+		{name: "JavaScript", exists: true, expectedLanguageCode: core.LanguageCodeJavascript},
+		{name: "jsx", exists: true, expectedLanguageCode: core.LanguageCodeJavascript},
+		{name: "tsx", exists: true, expectedLanguageCode: core.LanguageCodeTypescript},
+		{name: "golang", exists: true, expectedLanguageCode: core.LanguageCodeGo},
+		{name: "c++", exists: true, expectedLanguageCode: core.LanguageCodeCpp},
+		{name: "shell", exists: true, expectedLanguageCode: core.LanguageCodeBash},
+		{name: "console", exists: false},
+		{name: "", exists: false},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			language, exists := ResolveLanguageFromName(testcase.name)
+			assert.Equal(t, testcase.exists, exists)
+
+			if testcase.exists {
+				assert.Equal(t, testcase.expectedLanguageCode, language.Meta().Code)
+			}
+		})
+	}
+
+	t.Run("should resolve the dialect of the name", func(t *testing.T) {
+		tsx, exists := ResolveLanguageFromName("tsx")
+		assert.True(t, exists)
+
+		detection, exists := DetectLanguage("component.tsx", nil)
+		assert.True(t, exists)
+		assert.Equal(t, detection.Language, tsx)
+	})
+}
@@ -1,15 +1,17 @@
 package lang
 
 import (
+	"bytes"
 	"fmt"
 	"path/filepath"
-	"slices"
+	"strings"
 
 	"github.com/safedep/code/core"
 	"github.com/safedep/dry/log"
 )
 
-var languages = map[core.LanguageCode]func() (core.Language, error){
+// Languages implemented in this module, registered by default
+var builtinLanguages = map[core.LanguageCode]LanguageFactory{
 	core.LanguageCodePython: func() (core.Language, error) {
 		return NewPythonLanguage()
 	},
@@ -22,12 +24,49 @@ var languages = map[core.LanguageCode]func() (core.Language, error){
 	core.LanguageCodeJava: func() (core.Language, error) {
 		return NewJavaLanguage()
 	},
+	core.LanguageCodeTypescript: func() (core.Language, error) {
+		return NewTypescriptLanguage()
+	},
+	core.LanguageCodeRust: func() (core.Language, error) {
+		return NewRustLanguage()
+	},
+	core.LanguageCodeRuby: func() (core.Language, error) {
+		return NewRubyLanguage()
+	},
+	core.LanguageCodePhp: func() (core.Language, error) {
+		return NewPhpLanguage()
+	},
+	core.LanguageCodeCsharp: func() (core.Language, error) {
+		return NewCsharpLanguage()
+	},
+	core.LanguageCodeKotlin: func() (core.Language, error) {
+		return NewKotlinLanguage()
+	},
+	core.LanguageCodeC: func() (core.Language, error) {
+		return NewCLanguage()
+	},
+	core.LanguageCodeCpp: func() (core.Language, error) {
+		return NewCppLanguage()
+	},
+	core.LanguageCodeBash: func() (core.Language, error) {
+		return NewBashLanguage()
+	},
+}
+
+// dialectAwareLanguage is implemented by languages having more than one
+// grammar where the grammar to use depends on the file extension
+// eg. TypeScript and TSX
+type dialectAwareLanguage interface {
+	dialectForExtension(extension string) core.Language
 }
 
+// AllLanguages returns the registered languages ordered by language code
 func AllLanguages() ([]core.Language, error) {
-	langs := make([]core.Language, 0, len(languages))
-	for _, getLang := range languages {
-		lang, err := getLang()
+	factories := registry.factories()
+
+	langs := make([]core.Language, 0, len(factories))
+	for _, factory := range factories {
+		lang, err := factory()
 		if err != nil {
 			log.Debugf("failed to get language: %v", err)
 			return nil, err
@@ -38,10 +77,10 @@ func AllLanguages() ([]core.Language, error) {
 	return langs, nil
 }
 
-// GetLanguage returns the core.Language implementation for the given language code
+// GetLanguage returns the registered core.Language implementation for the given language code
 func GetLanguage(languageCode string) (core.Language, error) {
-	if f, ok := languages[core.LanguageCode(languageCode)]; ok {
-		return f()
+	if factory, ok := registry.factory(core.LanguageCode(languageCode)); ok {
+		return factory()
 	}
 
 	return nil, fmt.Errorf("language not found: %s", languageCode)
@@ -49,22 +88,75 @@ func GetLanguage(languageCode string) (core.Language, error) {
 
 // ResolveLanguageFromPath resolves the programming language from the
 // filePath and returns the core.Language and a boolean indicating if the
-// language implementation exists for the specified file extension in filePath.
+// language implementation exists for the file name or extension in filePath.
+// Use DetectLanguage to detect the language from the content of the file as well.
 //
 // It returns nil, false if the file extension is not supported by any implemented language.
 func ResolveLanguageFromPath(filePath string) (core.Language, bool) {
-	extension := filepath.Ext(filePath)
+	detection, ok := DetectLanguage(filePath, nil)
+	if !ok {
+		return nil, false
+	}
 
-	for _, f := range languages {
-		l, err := f()
-		if err != nil {
-			return nil, false
+	return detection.Language, true
+}
+
+// Interpreters of scripts mapped to the language of the script. Versioned
+// interpreters eg. python3.12 are mapped by their name without the version
+var shebangInterpreters = map[string]core.LanguageCode{
+	"sh":     core.LanguageCodeBash,
+	"bash":   core.LanguageCodeBash,
+	"dash":   core.LanguageCodeBash,
+	"ash":    core.LanguageCodeBash,
+	"ksh":    core.LanguageCodeBash,
+	"python": core.LanguageCodePython,
+	"node":   core.LanguageCodeJavascript,
+	"nodejs": core.LanguageCodeJavascript,
+	"ruby":   core.LanguageCodeRuby,
+	"php":    core.LanguageCodePhp,
+}
+
+// ResolveLanguageFromShebang resolves the programming language of a script
+// from the interpreter in its shebang line eg. `#!/bin/sh` or
+// `#!/usr/bin/env -S python3 -u`, which is used for scripts without
+// a file extension eg. postinstall hooks.
+//
+// It returns nil, false if the content has no shebang line or the
+// interpreter is not supported by any implemented language.
+func ResolveLanguageFromShebang(content []byte) (core.Language, bool) {
+	line, _, _ := bytes.Cut(content, []byte("\n"))
+	if !bytes.HasPrefix(line, []byte("#!")) {
+		return nil, false
+	}
+
+	var interpreter string
+	for _, field := range strings.Fields(string(line[2:])) {
+		word := filepath.Base(field)
+
+		// The env command runs the interpreter found in PATH
+		// eg. #!/usr/bin/env -S FOO=bar node --inspect
+		if interpreter == "" && word == "env" {
+			interpreter = word
+			continue
 		}
 
-		if slices.Contains(l.Meta().SourceFileExtensions, extension) {
-			return l, true
+		if interpreter == "env" && (strings.HasPrefix(word, "-") || strings.Contains(word, "=")) {
+			continue
 		}
+
+		interpreter = word
+		break
+	}
+
+	code, ok := shebangInterpreters[strings.TrimRight(interpreter, "0123456789.")]
+	if !ok {
+		return nil, false
+	}
+
+	language, err := GetLanguage(string(code))
+	if err != nil {
+		return nil, false
 	}
 
-	return nil, false
+	return language, true
 }
@@ -18,7 +18,23 @@ var resolveLanguageTestcases = []struct {
 	{filePath: "test.mjs", exists: true, expectedLanguageCode: core.LanguageCodeJavascript},
 	{filePath: "test.go", exists: true, expectedLanguageCode: core.LanguageCodeGo},
 	{filePath: "test.java", exists: true, expectedLanguageCode: core.LanguageCodeJava},
-	{filePath: "test.rs", exists: false, expectedLanguageCode: ""},
+	{filePath: "test.ts", exists: true, expectedLanguageCode: core.LanguageCodeTypescript},
+	{filePath: "test.tsx", exists: true, expectedLanguageCode: core.LanguageCodeTypescript},
+	{filePath: "test.d.mts", exists: true, expectedLanguageCode: core.LanguageCodeTypescript},
+	{filePath: "test.rs", exists: true, expectedLanguageCode: core.LanguageCodeRust},
+	{filePath: "test.rb", exists: true, expectedLanguageCode: core.LanguageCodeRuby},
+	{filePath: "test.php", exists: true, expectedLanguageCode: core.LanguageCodePhp},
+	{filePath: "test.cs", exists: true, expectedLanguageCode: core.LanguageCodeCsharp},
+	{filePath: "test.kt", exists: true, expectedLanguageCode: core.LanguageCodeKotlin},
+	{filePath: "build.gradle.kts", exists: true, expectedLanguageCode: core.LanguageCodeKotlin},
+	{filePath: "native/module.c", exists: true, expectedLanguageCode: core.LanguageCodeC},
+	{filePath: "include/module.h", exists: true, expectedLanguageCode: core.LanguageCodeC},
+	{filePath: "src/binding.cc", exists: true, expectedLanguageCode: core.LanguageCodeCpp},
+	{filePath: "src/binding.cpp", exists: true, expectedLanguageCode: core.LanguageCodeCpp},
+	{filePath: "src/binding.hpp", exists: true, expectedLanguageCode: core.LanguageCodeCpp},
+	{filePath: "scripts/install.sh", exists: true, expectedLanguageCode: core.LanguageCodeBash},
+	{filePath: "build.bash", exists: true, expectedLanguageCode: core.LanguageCodeBash},
+	{filePath: "test.swift", exists: false, expectedLanguageCode: ""},
 	{filePath: "README.md", exists: false, expectedLanguageCode: ""},
 	{filePath: "withoutextension", exists: false, expectedLanguageCode: ""},
 }
@@ -50,3 +66,32 @@ func TestGetLanguage(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestResolveLanguageFromShebang(t *testing.T) {
+	testcases := []struct {
+		content              string
+		exists               bool
+		expectedLanguageCode core.LanguageCode
+	}{
+		{content: "#!/bin/sh\nset -e\n", exists: true, expectedLanguageCode: core.LanguageCodeBash},
+		{content: "#!/usr/bin/env bash\n", exists: true, expectedLanguageCode: core.LanguageCodeBash},
+		{content: "#! /bin/bash -eu\n", exists: true, expectedLanguageCode: core.LanguageCodeBash},
+		{content: "#!/usr/bin/python3.12\n", exists: true, expectedLanguageCode: core.LanguageCodePython},
+		{content: "#!/usr/bin/env -S NODE_ENV=production node --no-warnings\n", exists: true, expectedLanguageCode: core.LanguageCodeJavascript},
+		{content: "#!/usr/bin/env ruby", exists: true, expectedLanguageCode: core.LanguageCodeRuby},
+		{content: "#!/usr/bin/perl\n", exists: false},
+		{content: "#!/usr/bin/env\n", exists: false},
+		{content: "echo '#!/bin/sh'\n", exists: false},
+		{content: "", exists: false},
+	}
+
+	for _, testcase := range testcases {
+		l, exists := ResolveLanguageFromShebang([]byte(testcase.content))
+		assert.Equal(t, testcase.exists, exists, testcase.content)
+		if testcase.exists {
+			assert.Equal(t, testcase.expectedLanguageCode, l.Meta().Code)
+		} else {
+			assert.Nil(t, l)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+import React, { useState } from 'react';
+import type { ButtonProps } from './button';
+
+interface CounterProps extends ButtonProps {
+  initial: number;
+}
+
+export const Counter = ({ initial }: CounterProps) => {
+  const [count, setCount] = useState(initial);
+  return <button onClick={() => setCount(count + 1)}>{count}</button>;
+};
+
+export class Panel extends React.Component<CounterProps> {
+  render() {
+    return <div>{this.props.initial}</div>;
+  }
+}
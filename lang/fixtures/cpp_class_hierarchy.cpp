@@ -0,0 +1,37 @@
+#include <stdexcept>
+
+namespace shapes {
+
+class Shape {
+public:
+    virtual ~Shape() = default;
+    virtual double area() const = 0;
+};
+
+class Named {
+public:
+    const char *name() const { return name_; }
+
+private:
+    const char *name_;
+};
+
+class Circle : public Shape, protected Named {
+public:
+    Circle(double radius) : radius_(radius) {}
+    double area() const override { return 3.14 * radius_ * radius_; }
+
+private:
+    double radius_;
+};
+
+} // namespace shapes
+
+struct ParseError : std::runtime_error {
+    using std::runtime_error::runtime_error;
+};
+
+template <typename T>
+class Box : public shapes::Shape {
+    T value;
+};
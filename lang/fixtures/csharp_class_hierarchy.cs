@@ -0,0 +1,51 @@
+using System;
+using System.Collections.Generic;
+
+namespace Acme.Billing
+{
+    [Serializable]
+    public abstract class Entity
+    {
+        public int Id { get; set; }
+
+        protected Entity() { }
+
+        public abstract void Validate();
+    }
+
+    public interface IAuditable
+    {
+        void Audit();
+    }
+
+    public interface ISoftDeletable : IAuditable, IDisposable
+    {
+        bool IsDeleted { get; }
+    }
+
+    public class Customer : Entity, IAuditable
+    {
+        private string name, email;
+
+        public override void Validate() { }
+
+        public void Audit() { }
+    }
+
+    public record Invoice(int Number, decimal Amount) : IAuditable
+    {
+        public void Audit() { }
+    }
+
+    public struct Point : IEquatable<Point>
+    {
+        public int X;
+
+        public bool Equals(Point other) => X == other.X;
+    }
+
+    class Repository<T> : System.Collections.Generic.List<T> where T : Entity
+    {
+        private class Cache { }
+    }
+}
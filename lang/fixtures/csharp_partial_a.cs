@@ -0,0 +1,14 @@
+namespace Acme.Orders;
+
+[Table("orders")]
+public partial class Order : Entity
+{
+    private int id;
+
+    public Order(int id)
+    {
+        this.id = id;
+    }
+
+    public void Submit() { }
+}
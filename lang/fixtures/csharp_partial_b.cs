@@ -0,0 +1,10 @@
+namespace Acme.Orders;
+
+public partial class Order : IAuditable
+{
+    public string Status { get; set; }
+
+    public void Audit() { }
+
+    public void Cancel() { }
+}
@@ -0,0 +1,41 @@
+import importlib
+import importlib.util
+import sys
+import pkg_resources
+from importlib import import_module
+
+PLUGIN_PACKAGE = "acme_plugins"
+
+requests = importlib.import_module("requests")
+loader = import_module("yaml.loader")
+handlers = importlib.import_module(".handlers", package="acme.web")
+plugin = importlib.import_module(f"{PLUGIN_PACKAGE}.{sys.argv[2]}")
+codec = importlib.import_module("codecs_" + sys.argv[3])
+socket = __import__("socket")
+__import__("ctypes")
+
+spec = importlib.util.spec_from_file_location("payload", "/tmp/payload.py")
+main = pkg_resources.load_entry_point("acme-cli", "console_scripts", "acme")
+
+# Module names computed at runtime can not be resolved
+user_module = importlib.import_module(sys.argv[1])
+importlib.reload(requests)
+
+requests.get("https://example.com")
+socket.socket()
+
+# Functions called through aliased imports
+import importlib as il
+import importlib.metadata as metadata
+from importlib import import_module as load
+from pkg_resources import iter_entry_points
+
+toml = il.import_module("toml")
+load("jinja2")
+exec("import base64, marshal as m\nfrom zlib import decompress")
+exec(payload)
+
+for entry_point in iter_entry_points("acme.plugins"):
+    entry_point.load()
+
+metadata.entry_points(group="console_scripts")
@@ -0,0 +1,23 @@
+const PLUGINS = './plugins';
+const AUTH_PLUGIN = `${PLUGINS}/auth`;
+
+// Module names folded from constants of the file
+const auth = require(AUTH_PLUGIN);
+const { session, store: sessionStore } = require(`${PLUGINS}/session`);
+const metrics = await import(PLUGINS + '/metrics.js');
+require(`./polyfills`);
+
+// Module names computed at runtime
+const plugin = require(process.env.PLUGIN as string);
+import(`./locale/${navigator.language}.js`).then((messages) => messages.default);
+
+function load(name: string) {
+  return require(name);
+}
+
+// Constants shadowed by a parameter are not folded
+function loadPlugin(PLUGINS: string) {
+  return require(PLUGINS + '/index.js');
+}
+
+const loader = require(['child', 'process'].join('_'));
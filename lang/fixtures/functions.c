@@ -0,0 +1,33 @@
+#include <stdlib.h>
+#include <string.h>
+
+static int helper(int x);
+
+static int helper(int x)
+{
+    return x * 2;
+}
+
+int add(int a, int b)
+{
+    return a + helper(b);
+}
+
+char *duplicate(const char *s, size_t n)
+{
+    char *copy = malloc(n + 1);
+    memcpy(copy, s, n);
+    copy[n] = '\0';
+    return copy;
+}
+
+static inline void run_command(const char *cmd)
+{
+    system(cmd);
+}
+
+int main(int argc, char **argv)
+{
+    run_command(argv[1]);
+    return add(argc, 1);
+}
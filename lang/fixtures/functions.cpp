@@ -0,0 +1,56 @@
+#include <string>
+#include <memory>
+
+namespace crypto {
+
+int checksum(const std::string &data)
+{
+    return static_cast<int>(data.size());
+}
+
+class Cipher {
+public:
+    explicit Cipher(const std::string &key) : key_(key) {}
+    virtual ~Cipher() {}
+    virtual std::string encrypt(const std::string &plain) = 0;
+    static Cipher *create(const std::string &name);
+    std::string name() const { return "cipher"; }
+
+protected:
+    std::string key_;
+
+private:
+    void reset() { key_.clear(); }
+};
+
+Cipher *Cipher::create(const std::string &name)
+{
+    return nullptr;
+}
+
+namespace aes {
+
+std::string encrypt_block(const std::string &block)
+{
+    return block;
+}
+
+} // namespace aes
+} // namespace crypto
+
+struct Point {
+    int x;
+    int y;
+    int norm() const { return x * x + y * y; }
+};
+
+template <typename T>
+T identity(T value)
+{
+    return value;
+}
+
+int main()
+{
+    return crypto::checksum("main");
+}
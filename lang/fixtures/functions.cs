@@ -0,0 +1,57 @@
+using System;
+using System.Threading.Tasks;
+
+namespace Acme.Orders;
+
+public interface IRepository<T>
+{
+    T Find(int id);
+}
+
+public abstract class Service
+{
+    protected abstract void Configure();
+}
+
+public class OrderService : Service
+{
+    private readonly IRepository<Order> repository;
+
+    public OrderService(IRepository<Order> repository)
+    {
+        this.repository = repository;
+    }
+
+    [HttpGet("{id}")]
+    [Authorize(Roles = "admin"), Obsolete]
+    public async Task<Order> GetAsync(int id, CancellationToken token = default)
+    {
+        return await Task.FromResult(repository.Find(id));
+    }
+
+    public static OrderService Create() => new OrderService(null);
+
+    protected override void Configure()
+    {
+        int Local(int x) => x * 2;
+        Local(1);
+    }
+
+    internal void Process(params Order[] orders) { }
+
+    void Hidden() { }
+
+    ~OrderService() { }
+}
+
+public record Order(int Id, string Name)
+{
+    public decimal Total() => 0;
+}
+
+public struct Money
+{
+    public Money(decimal amount) { Amount = amount; }
+
+    public decimal Amount { get; }
+}
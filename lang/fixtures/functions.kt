@@ -0,0 +1,45 @@
+package com.acme.app
+
+import javax.crypto.Cipher
+
+fun topLevel(a: Int, b: String = "x", vararg rest: Int): Int = a
+
+private suspend fun fetch(url: String): String {
+    return url
+}
+
+fun String.shout(times: Int): String = this.uppercase().repeat(times)
+
+internal inline fun <reified T> parse(json: String): T? = null
+
+abstract class Service(val name: String) {
+    protected abstract fun configure()
+
+    constructor() : this("default")
+
+    @Deprecated("use start")
+    open fun run() {}
+
+    companion object {
+        @JvmStatic
+        fun create(): Service? = null
+    }
+}
+
+object Registry {
+    fun register(service: Service) {}
+}
+
+interface Repository {
+    fun find(id: Int): String
+}
+
+class Client {
+    private val retries = 3
+
+    constructor(retries: Int) {
+        fun local() {}
+    }
+
+    internal fun send(vararg payloads: String) {}
+}
@@ -0,0 +1,35 @@
+<?php
+
+function top_level_function(int $a, $b = 1, string ...$rest)
+{
+    return $a;
+}
+
+interface Repository
+{
+    public function find(int $id);
+}
+
+trait Timestamps
+{
+    public function touch() {}
+
+    protected static function now() {}
+}
+
+abstract class Model
+{
+    public function __construct(array $attributes = []) {}
+
+    abstract protected function table(): string;
+
+    public static function create(array $attributes) {}
+
+    function save() {}
+
+    private function dirty() {}
+}
+
+$handler = function ($event) {
+    return $event;
+};
@@ -0,0 +1,39 @@
+def top_level_function(a, b = 1, *rest, key:, **opts, &block)
+  yield a
+end
+
+module Formatting
+  def format_name(name)
+    name.strip
+  end
+
+  def self.default_format
+    :short
+  end
+end
+
+class Account
+  def initialize(owner)
+    @owner = owner
+  end
+
+  def self.open(owner)
+    new(owner)
+  end
+
+  class << self
+    def registry; end
+  end
+
+  def balance; end
+
+  protected
+
+  def ledger; end
+
+  private
+
+  def audit; end
+
+  public def summary; end
+end
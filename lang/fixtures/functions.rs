@@ -0,0 +1,53 @@
+use std::fmt;
+
+pub fn public_function(a: i32, b: i32) -> i32 {
+    a + b
+}
+
+fn private_function() {}
+
+pub(crate) async fn fetch(url: &str) -> Result<String, reqwest::Error> {
+    reqwest::get(url).await?.text().await
+}
+
+#[test]
+fn test_function() {
+    fn nested_helper() {}
+    nested_helper();
+}
+
+pub struct Counter {
+    count: u32,
+}
+
+impl Counter {
+    pub fn new() -> Self {
+        Counter { count: 0 }
+    }
+
+    pub fn increment(&mut self) {
+        self.count += 1;
+    }
+
+    fn reset(&mut self) {
+        self.count = 0;
+    }
+
+    pub fn with_count(count: u32) -> Self {
+        Counter { count }
+    }
+}
+
+impl fmt::Display for Counter {
+    fn fmt(&self, f: &mut fmt::Formatter) -> fmt::Result {
+        write!(f, "{}", self.count)
+    }
+}
+
+pub trait Resettable {
+    fn reset_all(&mut self);
+
+    fn describe(&self) -> String {
+        String::from("resettable")
+    }
+}
@@ -0,0 +1,23 @@
+#!/bin/sh
+
+log() {
+    echo "[install] $*"
+}
+
+function download {
+    curl -fsSL "$1" -o "$2"
+}
+
+function verify() {
+    sha256sum -c "$1.sha256"
+}
+
+main() {
+    cleanup() { rm -rf "$TMP_DIR"; }
+    trap cleanup EXIT
+
+    download "$URL" "$TMP_DIR/pkg.tar.gz"
+    log "done"
+}
+
+main "$@"
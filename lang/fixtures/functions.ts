@@ -0,0 +1,45 @@
+export function declaredFunction(name: string, count?: number): string {
+  return name.repeat(count ?? 1);
+}
+
+export async function asyncFunction(url: string): Promise<void> {
+  await fetch(url);
+}
+
+const arrowFunction = (a: number, b: number): number => a + b;
+
+const functionExpression = function named(value: string) {
+  return value;
+};
+
+function overloaded(x: string): string;
+function overloaded(x: number): number;
+function overloaded(x: any): any {
+  return x;
+}
+
+abstract class Shape {
+  constructor(protected readonly name: string) {}
+
+  abstract area(): number;
+
+  public describe(): string {
+    return `${this.name}: ${this.area()}`;
+  }
+}
+
+class Circle extends Shape {
+  constructor(private radius: number) {
+    super('circle');
+  }
+
+  area(): number {
+    return Math.PI * this.radius ** 2;
+  }
+
+  private static unit(): Circle {
+    return new Circle(1);
+  }
+
+  protected async refresh(): Promise<void> {}
+}
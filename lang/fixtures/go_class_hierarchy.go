@@ -0,0 +1,107 @@
+//go:build exclude
+
+package fixtures
+
+import (
+	"fmt"
+	"io"
+)
+
+// Shape is implemented by every type having Area and Perimeter
+type Shape interface {
+	Area() float64
+	Perimeter() float64
+}
+
+// Solid extends Shape by embedding
+type Solid interface {
+	Shape
+	Volume() float64
+}
+
+type namer interface {
+	fmt.Stringer
+	Name() string
+}
+
+// Number is a type constraint, not satisfied through methods
+type Number interface {
+	~int | ~float64
+}
+
+type Base struct {
+	id int
+}
+
+func (b *Base) ID() int {
+	return b.id
+}
+
+func (b Base) Name() string {
+	return fmt.Sprintf("base-%d", b.id)
+}
+
+// Rectangle embeds Base and satisfies Shape
+type Rectangle struct {
+	Base
+	Width, Height float64
+}
+
+func NewRectangle(width, height float64) *Rectangle {
+	return &Rectangle{Width: width, Height: height}
+}
+
+func (r *Rectangle) Area() float64 {
+	return r.Width * r.Height
+}
+
+func (r *Rectangle) Perimeter() float64 {
+	return 2 * (r.Width + r.Height)
+}
+
+// Cube satisfies Solid through the methods promoted from Rectangle
+type Cube struct {
+	*Rectangle
+	io.Writer
+	depth float64
+}
+
+func (c Cube) Volume() float64 {
+	return c.Area() * c.depth
+}
+
+// Circle has a method of Shape with another signature
+type Circle struct {
+	radius float64
+}
+
+func (c Circle) Area() float64 {
+	return 3.14 * c.radius * c.radius
+}
+
+func (c Circle) Perimeter(scale float64) float64 {
+	return 2 * 3.14 * c.radius * scale
+}
+
+// Stack is generic
+type Stack[T any] struct {
+	items []T
+}
+
+func (s *Stack[T]) Push(item T) {
+	s.items = append(s.items, item)
+}
+
+// Meters is a defined type having methods
+type Meters float64
+
+func (m Meters) String() string {
+	return fmt.Sprintf("%fm", float64(m))
+}
+
+func (m Meters) Name() string {
+	return "meters"
+}
+
+// Alias does not declare a new type
+type Alias = Rectangle
@@ -0,0 +1,15 @@
+#include <stdio.h>
+#include <stdlib.h>
+#include <openssl/evp.h>
+#include "util.h"
+#include "../include/config.h"
+#include PLATFORM_HEADER
+
+#ifdef USE_ZLIB
+#include <zlib.h>
+#endif
+
+int main(void)
+{
+    return 0;
+}
@@ -0,0 +1,19 @@
+global using System.Linq;
+global using static System.Math;
+using System;
+using System.Collections.Generic;
+using Newtonsoft.Json;
+using static System.Console;
+using Json = System.Text.Json.JsonSerializer;
+using Http = System.Net.Http;
+using Logger = Microsoft.Extensions.Logging.ILogger<Program>;
+
+namespace Acme.Orders
+{
+    using Serilog;
+    using Dapper;
+
+    public class OrderService
+    {
+    }
+}
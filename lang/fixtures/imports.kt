@@ -0,0 +1,11 @@
+package com.acme.app
+
+import okhttp3.OkHttpClient
+import okhttp3.Request as HttpRequest
+import javax.crypto.Cipher
+import kotlinx.coroutines.*
+import com.squareup.moshi.Moshi.Builder
+import java.util.concurrent.TimeUnit.SECONDS as Seconds
+
+fun main() {
+}
@@ -0,0 +1,30 @@
+<?php
+
+namespace App\Http\Controllers;
+
+use GuzzleHttp\Client;
+use Psr\Log\LoggerInterface as Logger;
+use Monolog;
+use Symfony\Component\Console\{Application, Command\Command as BaseCommand};
+use function Illuminate\Support\collect;
+use const App\Config\DEFAULT_TIMEOUT;
+use function Http\Adapter\{request, response as respond};
+use \Carbon\Carbon, Ramsey\Uuid\Uuid;
+
+require 'vendor/autoload.php';
+require_once __DIR__ . '/bootstrap.php';
+include("helpers/format.php");
+include_once 'config.php';
+
+// Not imports
+require $path;
+
+function load_plugin()
+{
+    require_once 'plugins/cache.php';
+}
+
+class Controller
+{
+    use Traits\Loggable;
+}
@@ -0,0 +1,15 @@
+require 'json'
+require "net/http"
+require 'active_support/core_ext/string' unless defined?(ActiveSupport)
+require_relative 'helpers/formatter'
+require_relative '../lib/version'
+load 'tasks/setup.rb'
+
+# Not imports
+Bundler.require(:default)
+require "#{__dir__}/generated"
+gem 'rails'
+
+def load_plugins
+  require 'sidekiq'
+end
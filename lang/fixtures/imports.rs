@@ -0,0 +1,15 @@
+extern crate serde_json as json;
+extern crate log;
+
+use std::collections::{HashMap, hash_map::Entry as MapEntry};
+use std::io::{self, Read};
+use std::fmt::*;
+use reqwest;
+use tokio::sync::Mutex as AsyncMutex;
+use crate::config::Settings;
+use super::utils;
+use self::models::{user::{User, Role}, *};
+use async_trait::async_trait as _;
+use ::anyhow::Result;
+
+fn main() {}
@@ -0,0 +1,12 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+source ./lib/common.sh
+. /etc/os-release
+source 'scripts/util.sh'
+. "../config/defaults.sh"
+source "$HOME/.profile"
+. "${SCRIPT_DIR}/helpers.sh"
+source
+
+echo "sourced"
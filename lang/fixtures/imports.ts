@@ -0,0 +1,16 @@
+import express from 'express';
+import * as path from 'path';
+import { Request, Response as ExpressResponse } from 'express';
+import type { Config } from './config';
+import { type Logger, createLogger } from './logger';
+import fs = require('fs');
+import './polyfills';
+import Default, { named } from '@acme/widgets';
+
+const lodash = require('lodash');
+const { v4 } = require('uuid');
+
+async function load() {
+  const dynamic = await import('./dynamic-module');
+  return dynamic;
+}
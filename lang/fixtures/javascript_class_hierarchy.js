@@ -0,0 +1,87 @@
+const util = require('util');
+const { inherits } = require('util');
+const EventEmitter = require('events');
+
+class Animal extends EventEmitter {
+  static count = 0;
+  #secret = 'animal';
+  name;
+
+  constructor(name) {
+    super();
+    this.name = name;
+    Animal.count++;
+  }
+
+  get secret() {
+    return this.#secret;
+  }
+
+  set secret(value) {
+    this.#secret = value;
+  }
+
+  static create(name) {
+    return new Animal(name);
+  }
+
+  #log(message) {
+    console.log(message);
+  }
+
+  speak() {
+    this.#log(`${this.name} makes a sound`);
+  }
+}
+
+class Dog extends Animal {
+  speak() {
+    return 'woof';
+  }
+}
+
+class Transport extends require('stream').Transform {
+  _transform(chunk, encoding, callback) {
+    callback(null, chunk);
+  }
+}
+
+const Cat = class extends Animal {
+  speak() {
+    return 'meow';
+  }
+};
+
+class Base {}
+
+// Legacy prototype inheritance
+function Stream() {
+  EventEmitter.call(this);
+}
+
+util.inherits(Stream, EventEmitter);
+
+function Readable() {
+  Stream.call(this);
+}
+
+inherits(Readable, Stream);
+
+function Shape() {}
+
+function Circle() {
+  Shape.call(this);
+}
+
+Circle.prototype = Object.create(Shape.prototype);
+Circle.prototype.constructor = Circle;
+
+function Square() {}
+
+Object.setPrototypeOf(Square.prototype, Shape.prototype);
+Object.setPrototypeOf(Square, Shape);
+
+// Not statically recognizable
+function Mixed() {}
+
+Mixed.prototype = Object.create(getParent().prototype);
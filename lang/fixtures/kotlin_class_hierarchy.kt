@@ -0,0 +1,34 @@
+package com.acme.model
+
+import java.io.Serializable
+
+interface Identifiable {
+    val id: Long
+}
+
+interface Auditable : Identifiable, Serializable
+
+abstract class Entity(override val id: Long) : Identifiable {
+    abstract fun validate()
+}
+
+@Entity
+data class Customer(override val id: Long, val name: String) : Entity(id), Auditable {
+    private val email: String = ""
+    var active = true
+
+    override fun validate() {}
+
+    companion object Factory {
+        fun create(name: String) = Customer(0, name)
+    }
+}
+
+sealed class Result {
+    class Success(val value: String) : Result()
+    object Failure : Result()
+}
+
+enum class Status : Serializable { ACTIVE, INACTIVE }
+
+object Registry : java.util.HashMap<String, Entity>()
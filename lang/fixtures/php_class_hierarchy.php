@@ -0,0 +1,48 @@
+<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model as EloquentModel;
+
+interface Arrayable {}
+
+interface JsonSerializable extends Arrayable, \Countable {}
+
+trait HasTimestamps
+{
+    protected $createdAt;
+
+    public function touch() {}
+}
+
+trait SoftDeletes {}
+
+abstract class Model extends EloquentModel implements JsonSerializable
+{
+    use HasTimestamps, SoftDeletes;
+
+    protected static $table;
+    private $attributes = [], $original;
+
+    public function __construct(private array $defaults = [], public ?string $connection = null)
+    {
+        $this->attributes = $defaults;
+    }
+
+    abstract public function keyName(): string;
+
+    public function toArray() {}
+}
+
+final class User extends Model
+{
+    public function keyName(): string
+    {
+        return 'id';
+    }
+}
+
+enum Status: string implements Arrayable
+{
+    case Active = 'active';
+}
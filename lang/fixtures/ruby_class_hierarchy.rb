@@ -0,0 +1,37 @@
+module Comparable2; end
+
+module Admin
+  module Auditable
+    def audit(event); end
+  end
+
+  class User < ApplicationRecord
+    include Auditable
+    include Comparable2
+    extend Forwardable
+    prepend Admin::Logging
+    attr_accessor :name, :email
+    attr_reader :role
+
+    @@count = 0
+
+    def initialize(name)
+      @name = name
+      @created_at = Time.now
+    end
+
+    def self.count
+      @@count
+    end
+
+    def promote
+      @role = :admin
+    end
+  end
+end
+
+class Point < Struct.new(:x, :y)
+  def to_s; end
+end
+
+class Admin::Guest < Admin::User; end
@@ -0,0 +1,49 @@
+use std::fmt::{self, Debug, Display};
+
+pub trait Shape: Debug {
+    fn area(&self) -> f64;
+}
+
+pub trait Solid: Shape + Display {
+    fn volume(&self) -> f64;
+}
+
+#[derive(Debug, Clone)]
+pub struct Cube {
+    side: f64,
+}
+
+impl Cube {
+    pub fn new(side: f64) -> Self {
+        Cube { side }
+    }
+}
+
+impl Shape for Cube {
+    fn area(&self) -> f64 {
+        6.0 * self.side * self.side
+    }
+}
+
+impl Solid for Cube {
+    fn volume(&self) -> f64 {
+        self.side * self.side * self.side
+    }
+}
+
+impl fmt::Display for Cube {
+    fn fmt(&self, f: &mut fmt::Formatter) -> fmt::Result {
+        write!(f, "Cube({})", self.side)
+    }
+}
+
+enum Unit {
+    Meter,
+    Inch,
+}
+
+impl<T: Shape> Shape for Vec<T> {
+    fn area(&self) -> f64 {
+        self.iter().map(|s| s.area()).sum()
+    }
+}
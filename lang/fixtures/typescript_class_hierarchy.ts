@@ -0,0 +1,41 @@
+interface Entity {
+  id: string;
+}
+
+interface Auditable extends Entity {
+  createdAt: Date;
+  touch(): void;
+}
+
+interface Repository<T> {
+  find(id: string): T | undefined;
+}
+
+abstract class BaseModel implements Entity {
+  id: string = '';
+
+  abstract validate(): boolean;
+}
+
+@Injectable()
+class User extends BaseModel implements Auditable, Repository<User> {
+  createdAt: Date = new Date();
+
+  constructor(public name: string) {
+    super();
+  }
+
+  validate(): boolean {
+    return this.name.length > 0;
+  }
+
+  touch(): void {
+    this.createdAt = new Date();
+  }
+
+  find(id: string): User | undefined {
+    return id === this.id ? this : undefined;
+  }
+}
+
+class Admin extends User {}
@@ -2,6 +2,8 @@ package lang
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/safedep/code/core"
 	"github.com/safedep/code/core/ast"
@@ -14,6 +16,7 @@ type goResolvers struct {
 }
 
 var _ core.LanguageResolvers = (*goResolvers)(nil)
+var _ core.ObjectOrientedLanguageResolvers = (*goResolvers)(nil)
 
 const goWholeModuleImportQuery = `
 	(import_declaration
@@ -380,3 +383,484 @@ func (r *goResolvers) isValidGoIdentifier(identifier string) bool {
 
 	return true
 }
+
+// Types and methods are resolved at the package level, types declared
+// within functions can not have methods
+const goTypeQuery = `
+	(source_file
+		(type_declaration
+			(type_spec) @type))
+
+	(source_file
+		(method_declaration) @method)
+
+	(source_file
+		(function_declaration) @function)
+`
+
+// goTypeDeclaration is a type declared in the file along with the methods
+// declared with the type as receiver
+type goTypeDeclaration struct {
+	class *ast.ClassDeclarationNode
+
+	nameNode    *sitter.Node
+	typeNode    *sitter.Node
+	isInterface bool
+
+	// Type names of embedded fields of structs or embedded interfaces
+	embeddedNodes []*sitter.Node
+
+	// Method declarations of the type or method elements of the interface
+	methodNodes []*sitter.Node
+
+	// Interfaces having type elements eg. `~int | ~float64` are
+	// type constraints which are not satisfied through methods
+	isConstraint bool
+}
+
+// ResolveClasses extracts structs, interfaces and defined types having methods
+// from Go parse tree. Methods are collected by the receiver type in the same
+// file, struct embedding is resolved as base classes and interfaces are
+// reported as abstract classes. Functions named New<Type> returning the type
+// are the constructor of the type by convention
+func (r *goResolvers) ResolveClasses(tree core.ParseTree) ([]*ast.ClassDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	declarations, err := r.resolveTypeDeclarations(data, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract class definitions: %w", err)
+	}
+
+	classes := make([]*ast.ClassDeclarationNode, 0, len(declarations))
+	for _, declaration := range declarations {
+		classes = append(classes, declaration.class)
+	}
+
+	return classes, nil
+}
+
+// ResolveInheritance builds inheritance graph from Go types. Embedding a type
+// in a struct or an interface extends the embedded type. Interfaces are
+// satisfied implicitly, hence types are inferred to implement the interfaces
+// declared in the file whose method set is a subset of the method set of the
+// type, including methods promoted from embedded types. Methods of types and
+// interfaces declared in other files of the package are not visible
+func (r *goResolvers) ResolveInheritance(tree core.ParseTree) (*ast.InheritanceGraph, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	filename := ""
+	if file, err := tree.File(); err == nil && file != nil {
+		filename = file.Name()
+	}
+
+	declarations, err := r.resolveTypeDeclarations(data, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute inheritance queries: %w", err)
+	}
+
+	declarationMap := make(map[string]*goTypeDeclaration, len(declarations))
+	for _, declaration := range declarations {
+		declarationMap[declaration.nameNode.Content(*data)] = declaration
+	}
+
+	inheritanceGraph := ast.NewInheritanceGraph()
+	for _, declaration := range declarations {
+		typeName := declaration.nameNode.Content(*data)
+		for _, embeddedNode := range declaration.embeddedNodes {
+			inheritanceGraph.AddRelationship(typeName, embeddedNode.Content(*data),
+				ast.RelationshipTypeExtends, filename, embeddedNode.StartPoint().Row+1)
+		}
+	}
+
+	for _, declaration := range declarations {
+		if declaration.isInterface {
+			continue
+		}
+
+		typeName := declaration.nameNode.Content(*data)
+		methodSet := r.methodSet(declaration, declarationMap, *data, map[string]bool{})
+
+		for _, iface := range declarations {
+			if !iface.isInterface || iface.isConstraint {
+				continue
+			}
+
+			interfaceName := iface.nameNode.Content(*data)
+			if slices.Contains(inheritanceGraph.GetDirectParentNames(typeName), interfaceName) {
+				continue
+			}
+
+			interfaceMethodSet, complete := r.interfaceMethodSet(iface, declarationMap, *data, map[string]bool{})
+			if !complete || len(interfaceMethodSet) == 0 {
+				continue
+			}
+
+			if r.satisfies(methodSet, interfaceMethodSet) {
+				inheritanceGraph.AddRelationship(typeName, interfaceName,
+					ast.RelationshipTypeImplements, filename, declaration.nameNode.StartPoint().Row+1)
+			}
+		}
+	}
+
+	return inheritanceGraph, nil
+}
+
+// resolveTypeDeclarations resolves the types declared in the file in the order
+// of declaration. Defined types other than structs and interfaces eg.
+// `type Celsius float64` are resolved only if they have methods
+func (r *goResolvers) resolveTypeDeclarations(data *[]byte, tree core.ParseTree) ([]*goTypeDeclaration, error) {
+	var declarations []*goTypeDeclaration
+	var methodNodes, functionNodes []*sitter.Node
+	declarationMap := make(map[string]*goTypeDeclaration)
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(goTypeQuery, func(m *sitter.QueryMatch) error {
+			node := m.Captures[0].Node
+			switch node.Type() {
+			case "method_declaration":
+				methodNodes = append(methodNodes, node)
+			case "function_declaration":
+				functionNodes = append(functionNodes, node)
+			case "type_spec":
+				if declaration := r.newTypeDeclaration(data, node); declaration != nil {
+					declarations = append(declarations, declaration)
+					declarationMap[declaration.nameNode.Content(*data)] = declaration
+				}
+			}
+
+			return nil
+		}),
+	}
+
+	err := ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, methodNode := range methodNodes {
+		declaration, exists := declarationMap[r.receiverTypeName(methodNode, *data)]
+		if !exists || declaration.isInterface {
+			continue
+		}
+
+		declaration.methodNodes = append(declaration.methodNodes, methodNode)
+		declaration.class.AddMethodNode(methodNode)
+	}
+
+	for _, functionNode := range functionNodes {
+		nameNode := functionNode.ChildByFieldName("name")
+		if nameNode == nil || !strings.HasPrefix(nameNode.Content(*data), goConstructorPrefix) {
+			continue
+		}
+
+		declaration, exists := declarationMap[strings.TrimPrefix(nameNode.Content(*data), goConstructorPrefix)]
+		if !exists || declaration.isInterface || declaration.class.GetConstructorNode() != nil {
+			continue
+		}
+
+		if r.returnsType(functionNode, declaration.nameNode.Content(*data), *data) {
+			declaration.class.SetConstructorNode(functionNode)
+		}
+	}
+
+	var resolved []*goTypeDeclaration
+	for _, declaration := range declarations {
+		if declaration.typeNode.Type() != "struct_type" && !declaration.isInterface && len(declaration.methodNodes) == 0 {
+			continue
+		}
+
+		resolved = append(resolved, declaration)
+	}
+
+	return resolved, nil
+}
+
+// By convention, the function New<Type> constructs the type eg. NewReader
+const goConstructorPrefix = "New"
+
+func (r *goResolvers) newTypeDeclaration(data *[]byte, specNode *sitter.Node) *goTypeDeclaration {
+	nameNode := specNode.ChildByFieldName("name")
+	typeNode := specNode.ChildByFieldName("type")
+	if nameNode == nil || typeNode == nil {
+		return nil
+	}
+
+	classNode := ast.NewClassDeclarationNode(ast.ToContent(*data))
+	classNode.SetClassNameNode(nameNode)
+	if r.isExportedGoIdentifier(nameNode.Content(*data)) {
+		classNode.SetAccessModifier(ast.AccessModifierPublic)
+	} else {
+		classNode.SetAccessModifier(ast.AccessModifierPackage)
+	}
+
+	declaration := &goTypeDeclaration{
+		class:    classNode,
+		nameNode: nameNode,
+		typeNode: typeNode,
+	}
+
+	switch typeNode.Type() {
+	case "struct_type":
+		for i := 0; i < int(typeNode.NamedChildCount()); i++ {
+			fieldList := typeNode.NamedChild(i)
+			if fieldList.Type() != "field_declaration_list" {
+				continue
+			}
+
+			for j := 0; j < int(fieldList.NamedChildCount()); j++ {
+				field := fieldList.NamedChild(j)
+				if field.Type() != "field_declaration" {
+					continue
+				}
+
+				// Embedded fields are declared with a type and without a name
+				if field.ChildByFieldName("name") != nil {
+					classNode.AddFieldNode(field)
+				} else if embeddedNode := goTypeNameNode(field.ChildByFieldName("type")); embeddedNode != nil {
+					declaration.embeddedNodes = append(declaration.embeddedNodes, embeddedNode)
+					classNode.AddBaseClassNode(embeddedNode)
+				}
+			}
+		}
+	case "interface_type":
+		declaration.isInterface = true
+		classNode.SetIsAbstract(true)
+
+		for i := 0; i < int(typeNode.NamedChildCount()); i++ {
+			element := typeNode.NamedChild(i)
+			switch element.Type() {
+			case "method_elem", "method_spec":
+				declaration.methodNodes = append(declaration.methodNodes, element)
+				classNode.AddMethodNode(element)
+			case "type_elem":
+				embeddedNode := goTypeNameNode(element.NamedChild(0))
+				if element.NamedChildCount() != 1 || embeddedNode == nil {
+					declaration.isConstraint = true
+					continue
+				}
+
+				declaration.embeddedNodes = append(declaration.embeddedNodes, embeddedNode)
+				classNode.AddBaseClassNode(embeddedNode)
+			}
+		}
+	}
+
+	return declaration
+}
+
+// methodSet returns the signatures of the methods of a type including the
+// methods promoted from embedded types declared in the file. Methods of both
+// value and pointer receivers are included since values are addressable
+// in most cases eg. variables and composite literals
+func (r *goResolvers) methodSet(declaration *goTypeDeclaration, declarationMap map[string]*goTypeDeclaration,
+	data []byte, visited map[string]bool) map[string]string {
+	methodSet := make(map[string]string)
+
+	typeName := declaration.nameNode.Content(data)
+	if visited[typeName] {
+		return methodSet
+	}
+
+	visited[typeName] = true
+
+	for _, embeddedNode := range declaration.embeddedNodes {
+		embedded, exists := declarationMap[embeddedNode.Content(data)]
+		if !exists {
+			continue
+		}
+
+		var promoted map[string]string
+		if embedded.isInterface {
+			promoted, _ = r.interfaceMethodSet(embedded, declarationMap, data, visited)
+		} else {
+			promoted = r.methodSet(embedded, declarationMap, data, visited)
+		}
+
+		for name, signature := range promoted {
+			methodSet[name] = signature
+		}
+	}
+
+	// Methods of the type shadow the promoted methods
+	for _, methodNode := range declaration.methodNodes {
+		name, signature := r.methodSignature(methodNode, data)
+		methodSet[name] = signature
+	}
+
+	return methodSet
+}
+
+// interfaceMethodSet returns the signatures of the methods of an interface
+// including the methods of embedded interfaces. The method set is not complete
+// if an embedded interface is not declared in the file eg. fmt.Stringer
+func (r *goResolvers) interfaceMethodSet(declaration *goTypeDeclaration, declarationMap map[string]*goTypeDeclaration,
+	data []byte, visited map[string]bool) (map[string]string, bool) {
+	methodSet := make(map[string]string)
+	complete := true
+
+	typeName := declaration.nameNode.Content(data)
+	if visited[typeName] {
+		return methodSet, complete
+	}
+
+	visited[typeName] = true
+	defer delete(visited, typeName)
+
+	for _, embeddedNode := range declaration.embeddedNodes {
+		embedded, exists := declarationMap[embeddedNode.Content(data)]
+		if !exists || !embedded.isInterface || embedded.isConstraint {
+			complete = false
+			continue
+		}
+
+		promoted, embeddedComplete := r.interfaceMethodSet(embedded, declarationMap, data, visited)
+		complete = complete && embeddedComplete
+
+		for name, signature := range promoted {
+			methodSet[name] = signature
+		}
+	}
+
+	for _, methodNode := range declaration.methodNodes {
+		name, signature := r.methodSignature(methodNode, data)
+		methodSet[name] = signature
+	}
+
+	return methodSet, complete
+}
+
+func (r *goResolvers) satisfies(methodSet, interfaceMethodSet map[string]string) bool {
+	for name, signature := range interfaceMethodSet {
+		if methodSet[name] != signature {
+			return false
+		}
+	}
+
+	return true
+}
+
+// methodSignature returns the name and the signature of a method declaration
+// or an interface method element made of the types of its parameters and
+// results eg. `Read(p []byte) (n int, err error)` -> Read([]byte)(int,error)
+func (r *goResolvers) methodSignature(methodNode *sitter.Node, data []byte) (string, string) {
+	name := ""
+	if nameNode := methodNode.ChildByFieldName("name"); nameNode != nil {
+		name = nameNode.Content(data)
+	}
+
+	parameterTypes := r.parameterTypes(methodNode.ChildByFieldName("parameters"), data)
+	signature := name + "(" + strings.Join(parameterTypes, ",") + ")"
+
+	resultNode := methodNode.ChildByFieldName("result")
+	if resultNode == nil {
+		return name, signature
+	}
+
+	if resultNode.Type() != "parameter_list" {
+		return name, signature + goTypeContent(resultNode, data)
+	}
+
+	resultTypes := r.parameterTypes(resultNode, data)
+	if len(resultTypes) == 1 {
+		return name, signature + resultTypes[0]
+	}
+
+	return name, signature + "(" + strings.Join(resultTypes, ",") + ")"
+}
+
+// parameterTypes returns the type of every parameter in a parameter list,
+// parameters sharing a type eg. `a, b int` are expanded
+func (r *goResolvers) parameterTypes(parametersNode *sitter.Node, data []byte) []string {
+	var types []string
+	for _, paramNode := range r.extractGoParameterNodes(parametersNode) {
+		typeNode := paramNode.ChildByFieldName("type")
+		if typeNode == nil {
+			continue
+		}
+
+		paramType := goTypeContent(typeNode, data)
+		if paramNode.Type() == "variadic_parameter_declaration" {
+			paramType = "..." + paramType
+		}
+
+		names := 0
+		for i := 0; i < int(paramNode.ChildCount()); i++ {
+			if paramNode.FieldNameForChild(i) == "name" {
+				names++
+			}
+		}
+
+		for i := 0; i < max(names, 1); i++ {
+			types = append(types, paramType)
+		}
+	}
+
+	return types
+}
+
+func (r *goResolvers) receiverTypeName(methodNode *sitter.Node, data []byte) string {
+	receiverNode := methodNode.ChildByFieldName("receiver")
+	if receiverNode == nil {
+		return ""
+	}
+
+	for i := 0; i < int(receiverNode.NamedChildCount()); i++ {
+		paramNode := receiverNode.NamedChild(i)
+		if paramNode.Type() != "parameter_declaration" {
+			continue
+		}
+
+		if typeNode := goTypeNameNode(paramNode.ChildByFieldName("type")); typeNode != nil {
+			return typeNode.Content(data)
+		}
+	}
+
+	return ""
+}
+
+// returnsType checks if the first result of a function is the type or a pointer to it
+func (r *goResolvers) returnsType(functionNode *sitter.Node, typeName string, data []byte) bool {
+	resultNode := functionNode.ChildByFieldName("result")
+	if resultNode != nil && resultNode.Type() == "parameter_list" {
+		params := r.extractGoParameterNodes(resultNode)
+		if len(params) == 0 {
+			return false
+		}
+
+		resultNode = params[0].ChildByFieldName("type")
+	}
+
+	typeNode := goTypeNameNode(resultNode)
+	return typeNode != nil && typeNode.Content(data) == typeName
+}
+
+// goTypeNameNode returns the node naming a type, stripping pointers and
+// type arguments of generic types eg. `*Stack[T]` -> Stack
+func goTypeNameNode(node *sitter.Node) *sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	switch node.Type() {
+	case "type_identifier", "qualified_type":
+		return node
+	case "generic_type":
+		return goTypeNameNode(node.ChildByFieldName("type"))
+	case "pointer_type":
+		return goTypeNameNode(node.NamedChild(0))
+	}
+
+	return nil
+}
+
+// goTypeContent returns the content of a type without whitespace
+// eg. `map[string] int` -> map[string]int
+func goTypeContent(node *sitter.Node, data []byte) string {
+	return strings.Join(strings.Fields(node.Content(data)), "")
+}
@@ -134,4 +134,96 @@ func TestGoLanguageResolvers(t *testing.T) {
 		})
 		assert.NoError(t, err)
 	})
+
+	t.Run("ResolveClasses", func(t *testing.T) {
+		goLanguage, err := lang.NewGoLanguage()
+		assert.NoError(t, err)
+
+		fileParser, err := parser.NewParser([]core.Language{goLanguage})
+		assert.NoError(t, err)
+
+		fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+			AppDirectories: []string{"fixtures/go_class_hierarchy.go"},
+		})
+		assert.NoError(t, err)
+
+		err = fileSystem.EnumerateApp(context.Background(), func(f core.File) error {
+			parseTree, err := fileParser.Parse(context.Background(), f)
+			assert.NoError(t, err)
+
+			resolvers := goLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			classes, err := resolvers.ResolveClasses(parseTree)
+			assert.NoError(t, err)
+
+			found := map[string]string{}
+			for _, class := range classes {
+				found[class.ClassName()] = fmt.Sprintf("bases=%v methods=%d fields=%d constructor=%t abstract=%t access=%s",
+					class.BaseClasses(), len(class.Methods()), len(class.Fields()),
+					class.Constructor() != "", class.IsAbstract(), class.AccessModifier())
+			}
+
+			assert.Equal(t, map[string]string{
+				"Shape":     "bases=[] methods=2 fields=0 constructor=false abstract=true access=public",
+				"Solid":     "bases=[Shape] methods=1 fields=0 constructor=false abstract=true access=public",
+				"namer":     "bases=[fmt.Stringer] methods=1 fields=0 constructor=false abstract=true access=package",
+				"Number":    "bases=[] methods=0 fields=0 constructor=false abstract=true access=public",
+				"Base":      "bases=[] methods=2 fields=1 constructor=false abstract=false access=public",
+				"Rectangle": "bases=[Base] methods=2 fields=1 constructor=true abstract=false access=public",
+				"Cube":      "bases=[Rectangle io.Writer] methods=1 fields=1 constructor=false abstract=false access=public",
+				"Circle":    "bases=[] methods=2 fields=1 constructor=false abstract=false access=public",
+				"Stack":     "bases=[] methods=1 fields=1 constructor=false abstract=false access=public",
+				"Meters":    "bases=[] methods=2 fields=0 constructor=false abstract=false access=public",
+			}, found)
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("ResolveInheritance", func(t *testing.T) {
+		goLanguage, err := lang.NewGoLanguage()
+		assert.NoError(t, err)
+
+		fileParser, err := parser.NewParser([]core.Language{goLanguage})
+		assert.NoError(t, err)
+
+		fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+			AppDirectories: []string{"fixtures/go_class_hierarchy.go"},
+		})
+		assert.NoError(t, err)
+
+		err = fileSystem.EnumerateApp(context.Background(), func(f core.File) error {
+			parseTree, err := fileParser.Parse(context.Background(), f)
+			assert.NoError(t, err)
+
+			resolvers := goLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			graph, err := resolvers.ResolveInheritance(parseTree)
+			assert.NoError(t, err)
+
+			var relationships []string
+			for _, class := range graph.GetAllClasses() {
+				for _, rel := range graph.GetDirectParents(class) {
+					relationships = append(relationships, fmt.Sprintf("%s %s %s", rel.ChildClassName,
+						rel.RelationshipType, rel.ParentClassName))
+				}
+			}
+
+			assert.ElementsMatch(t, []string{
+				"Solid extends Shape",
+				"namer extends fmt.Stringer",
+				"Rectangle extends Base",
+				"Rectangle implements Shape",
+				"Cube extends Rectangle",
+				"Cube extends io.Writer",
+				"Cube implements Shape",
+				"Cube implements Solid",
+			}, relationships)
+
+			assert.True(t, graph.IsAncestor("Shape", "Cube"))
+			assert.False(t, graph.IsAncestor("Shape", "Circle"))
+			return nil
+		})
+		assert.NoError(t, err)
+	})
 }
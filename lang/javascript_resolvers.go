@@ -3,6 +3,7 @@ package lang
 import (
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/safedep/code/core"
 	"github.com/safedep/code/core/ast"
@@ -15,6 +16,8 @@ type javascriptResolvers struct {
 }
 
 var _ core.LanguageResolvers = (*javascriptResolvers)(nil)
+var _ core.ObjectOrientedLanguageResolvers = (*javascriptResolvers)(nil)
+var _ core.DynamicImportLanguageResolvers = (*javascriptResolvers)(nil)
 
 const jsWholeModuleImportQuery = `
 	(import_statement
@@ -82,6 +85,8 @@ func (r *javascriptResolvers) ResolveImports(tree core.ParseTree) ([]*ast.Import
 
 	var imports []*ast.ImportNode
 
+	stringConstants := jsStringConstants(tree.Tree().RootNode(), *data)
+
 	queryRequestItems := []ts.QueryItem{
 		ts.NewQueryItem(jsWholeModuleImportQuery, func(m *sitter.QueryMatch) error {
 			node := ast.NewImportNode(data)
@@ -110,48 +115,327 @@ func (r *javascriptResolvers) ResolveImports(tree core.ParseTree) ([]*ast.Import
 			return nil
 		}),
 		ts.NewQueryItem(jsRequireModuleQuery, func(m *sitter.QueryMatch) error {
-			if len(m.Captures) < 3 {
-				return nil
+			if node := newRequireImportNode(data, m); node != nil {
+				imports = append(imports, node)
 			}
 
-			node := ast.NewImportNode(data)
+			return nil
+		}),
+		ts.NewQueryItem(jsDynamicImportQuery, func(m *sitter.QueryMatch) error {
+			nodes, _ := newJsDynamicImportNodes(data, m.Captures[0].Node, stringConstants)
+			imports = append(imports, nodes...)
+			return nil
+		}),
+	}
 
-			identifierCaptures := []sitter.QueryCapture{}
-			for _, capture := range m.Captures {
-				switch capture.Node.Type() {
-				case "string_fragment":
-					node.SetModuleNameNode(capture.Node)
-				case "identifier", "shorthand_property_identifier_pattern", "property_identifier":
-					identifierCaptures = append(identifierCaptures, capture)
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, err
+}
+
+// ResolveUnresolvableImports resolves `require()` and `import()` calls having
+// a module name computed at runtime eg. `require(process.env.PLUGIN)`
+func (r *javascriptResolvers) ResolveUnresolvableImports(tree core.ParseTree) ([]*ast.UnresolvableImportNode, error) {
+	return resolveJsUnresolvableImports(r.language, tree)
+}
+
+// newRequireImportNode creates an import node from a match of jsRequireModuleQuery.
+// It returns nil when the matched call is not a call to require
+func newRequireImportNode(data *[]byte, m *sitter.QueryMatch) *ast.ImportNode {
+	if len(m.Captures) < 3 {
+		return nil
+	}
+
+	node := ast.NewImportNode(data)
+
+	identifierCaptures := []sitter.QueryCapture{}
+	for _, capture := range m.Captures {
+		switch capture.Node.Type() {
+		case "string_fragment":
+			node.SetModuleNameNode(capture.Node)
+		case "identifier", "shorthand_property_identifier_pattern", "property_identifier":
+			identifierCaptures = append(identifierCaptures, capture)
+		}
+	}
+
+	if len(identifierCaptures) < 2 || identifierCaptures[len(identifierCaptures)-1].Node.Content(*data) != "require" {
+		return nil
+	}
+
+	// Skip the last identifier ie. require
+	for _, capture := range identifierCaptures[:len(identifierCaptures)-1] {
+		switch capture.Node.Type() {
+		case "identifier":
+			node.SetModuleAliasNode(capture.Node)
+		case "shorthand_property_identifier_pattern", "property_identifier":
+			node.SetModuleItemNode(capture.Node)
+			node.SetModuleAliasNode(capture.Node)
+		}
+	}
+
+	return node
+}
+
+// Matches `require()` and `import()` calls, calls having a string literal
+// module name are resolved by the import queries
+const jsDynamicImportQuery = `
+	(call_expression
+		function: [(identifier) (import)]
+		arguments: (arguments)) @call
+`
+
+// Placeholder for the parts of a module name computed at runtime
+// eg. `./locale/${lang}.js` imports ./locale/*.js
+const jsDynamicModuleNamePart = "*"
+
+// newJsDynamicImportNodes creates the import nodes of a `require()` or
+// `import()` call whose module name is not a string literal. Module names
+// composed from template literals and const strings of the file are folded
+// eg. `${PLUGINS}/auth` -> ./plugins/auth given `const PLUGINS = "./plugins"`.
+// Modules bound to a name are aliased by the name, destructured modules are
+// imported by item and other modules are wildcard imports since they are
+// loaded for their side effects.
+//
+// It returns an unresolvable import node when the module name is computed
+// at runtime, both are nil when the call does not import a module
+func newJsDynamicImportNodes(data *[]byte, callNode *sitter.Node,
+	stringConstants map[string]string) ([]*ast.ImportNode, *ast.UnresolvableImportNode) {
+	functionNode := callNode.ChildByFieldName("function")
+	if functionNode.Type() != "import" && functionNode.Content(*data) != "require" {
+		return nil, nil
+	}
+
+	arguments := callNode.ChildByFieldName("arguments")
+	if arguments.NamedChildCount() == 0 {
+		return nil, nil
+	}
+
+	specifierNode := arguments.NamedChild(0)
+	if specifierNode.Type() == "string" {
+		return nil, nil
+	}
+
+	moduleName, resolved := jsStringExpression(specifierNode, *data, stringConstants)
+	if !resolved {
+		node := ast.NewUnresolvableImportNode(data)
+		node.SetCallNode(callNode)
+		node.SetSpecifierNode(specifierNode)
+		node.SetSpecifierPattern(moduleName)
+		return nil, node
+	}
+
+	newImportNode := func() *ast.ImportNode {
+		node := ast.NewImportNode(data)
+		node.SetModuleNameNode(specifierNode)
+		node.SetModuleName(moduleName)
+		node.SetIsDynamicImport(true)
+		return node
+	}
+
+	bindingNode := callNode.Parent()
+	if bindingNode != nil && bindingNode.Type() == "await_expression" {
+		bindingNode = bindingNode.Parent()
+	}
+
+	var nameNode *sitter.Node
+	if bindingNode != nil && bindingNode.Type() == "variable_declarator" {
+		nameNode = bindingNode.ChildByFieldName("name")
+	}
+
+	if nameNode != nil && nameNode.Type() == "identifier" {
+		node := newImportNode()
+		node.SetModuleAliasNode(nameNode)
+		return []*ast.ImportNode{node}, nil
+	}
+
+	if nameNode != nil && nameNode.Type() == "object_pattern" {
+		var nodes []*ast.ImportNode
+		for i := 0; i < int(nameNode.NamedChildCount()); i++ {
+			propertyNode := nameNode.NamedChild(i)
+			switch propertyNode.Type() {
+			case "shorthand_property_identifier_pattern":
+				node := newImportNode()
+				node.SetModuleItemNode(propertyNode)
+				node.SetModuleAliasNode(propertyNode)
+				nodes = append(nodes, node)
+			case "pair_pattern":
+				valueNode := propertyNode.ChildByFieldName("value")
+				if valueNode == nil || valueNode.Type() != "identifier" {
+					continue
 				}
-			}
 
-			if len(identifierCaptures) < 2 || identifierCaptures[len(identifierCaptures)-1].Node.Content(*data) != "require" {
-				return nil
+				node := newImportNode()
+				node.SetModuleItemNode(propertyNode.ChildByFieldName("key"))
+				node.SetModuleAliasNode(valueNode)
+				nodes = append(nodes, node)
 			}
+		}
 
-			// Skip the last identifier ie. require
-			for _, capture := range identifierCaptures[:len(identifierCaptures)-1] {
-				switch capture.Node.Type() {
-				case "identifier":
-					node.SetModuleAliasNode(capture.Node)
-				case "shorthand_property_identifier_pattern", "property_identifier":
-					node.SetModuleItemNode(capture.Node)
-					node.SetModuleAliasNode(capture.Node)
-				}
+		return nodes, nil
+	}
+
+	node := newImportNode()
+	node.SetIsWildcardImport(true)
+	return []*ast.ImportNode{node}, nil
+}
+
+// resolveJsUnresolvableImports resolves the dynamic imports having a module
+// name computed at runtime, shared by JavaScript and TypeScript
+func resolveJsUnresolvableImports(language core.Language, tree core.ParseTree) ([]*ast.UnresolvableImportNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var unresolvableImports []*ast.UnresolvableImportNode
+
+	stringConstants := jsStringConstants(tree.Tree().RootNode(), *data)
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(jsDynamicImportQuery, func(m *sitter.QueryMatch) error {
+			if _, node := newJsDynamicImportNodes(data, m.Captures[0].Node, stringConstants); node != nil {
+				unresolvableImports = append(unresolvableImports, node)
 			}
 
-			imports = append(imports, node)
 			return nil
 		}),
 	}
 
-	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(language, queryRequestItems), data, tree)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to resolve unresolvable imports: %w", err)
 	}
 
-	return imports, err
+	return unresolvableImports, nil
+}
+
+// jsStringExpression folds an expression computing a string, where the parts
+// computed at runtime are replaced by a placeholder eg. `"./" + name` -> ./*.
+// It returns false when a part of the string is computed at runtime
+func jsStringExpression(node *sitter.Node, data []byte, stringConstants map[string]string) (string, bool) {
+	switch node.Type() {
+	case "string", "template_string":
+		var value strings.Builder
+		resolved := true
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			child := node.NamedChild(i)
+			switch child.Type() {
+			case "string_fragment", "escape_sequence":
+				value.WriteString(child.Content(data))
+			case "template_substitution":
+				if child.NamedChildCount() != 1 {
+					value.WriteString(jsDynamicModuleNamePart)
+					resolved = false
+					continue
+				}
+
+				part, ok := jsStringExpression(child.NamedChild(0), data, stringConstants)
+				value.WriteString(part)
+				resolved = resolved && ok
+			}
+		}
+
+		return value.String(), resolved
+	case "binary_expression":
+		if node.ChildByFieldName("operator").Type() != "+" {
+			return jsDynamicModuleNamePart, false
+		}
+
+		left, leftResolved := jsStringExpression(node.ChildByFieldName("left"), data, stringConstants)
+		right, rightResolved := jsStringExpression(node.ChildByFieldName("right"), data, stringConstants)
+		if strings.HasSuffix(left, jsDynamicModuleNamePart) && strings.HasPrefix(right, jsDynamicModuleNamePart) {
+			right = strings.TrimPrefix(right, jsDynamicModuleNamePart)
+		}
+
+		return left + right, leftResolved && rightResolved
+	case "parenthesized_expression":
+		if node.NamedChildCount() == 1 {
+			return jsStringExpression(node.NamedChild(0), data, stringConstants)
+		}
+	case "identifier":
+		if value, ok := stringConstants[node.Content(data)]; ok && !jsIsParameter(node, data) {
+			return value, true
+		}
+	}
+
+	return jsDynamicModuleNamePart, false
+}
+
+// jsIsParameter checks whether the identifier refers to a parameter of an
+// enclosing function, which shadows the constants of the outer scopes
+func jsIsParameter(identifierNode *sitter.Node, data []byte) bool {
+	name := identifierNode.Content(data)
+
+	for current := identifierNode.Parent(); current != nil; current = current.Parent() {
+		if parameterNode := current.ChildByFieldName("parameter"); parameterNode != nil &&
+			parameterNode.Type() == "identifier" && parameterNode.Content(data) == name {
+			return true
+		}
+
+		parametersNode := current.ChildByFieldName("parameters")
+		if parametersNode == nil || parametersNode.Type() != "formal_parameters" {
+			continue
+		}
+
+		for i := 0; i < int(parametersNode.NamedChildCount()); i++ {
+			parameterNode := parametersNode.NamedChild(i)
+			if patternNode := parameterNode.ChildByFieldName("pattern"); patternNode != nil {
+				parameterNode = patternNode
+			}
+
+			if parameterNode.Type() == "identifier" && parameterNode.Content(data) == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// jsStringConstants returns the names declared once in the file, bound to a
+// string by a const declaration eg. `const PLUGINS = "./plugins"`. Strings
+// composed from earlier constants are folded eg. `${PLUGINS}/auth`. Names
+// declared more than once eg. in nested scopes are not constants
+func jsStringConstants(rootNode *sitter.Node, data []byte) map[string]string {
+	constants := make(map[string]string)
+	declarations := make(map[string]int)
+
+	var visit func(node *sitter.Node)
+	visit = func(node *sitter.Node) {
+		if node.Type() == "variable_declarator" {
+			nameNode := node.ChildByFieldName("name")
+			if nameNode != nil && nameNode.Type() == "identifier" {
+				name := nameNode.Content(data)
+				declarations[name]++
+
+				declaration := node.Parent()
+				valueNode := node.ChildByFieldName("value")
+				if declaration.Type() == "lexical_declaration" && declaration.Child(0).Type() == "const" &&
+					valueNode != nil {
+					if value, ok := jsStringExpression(valueNode, data, constants); ok {
+						constants[name] = value
+					}
+				}
+			}
+		}
+
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			visit(node.NamedChild(i))
+		}
+	}
+
+	visit(rootNode)
+
+	for name, count := range declarations {
+		if count > 1 {
+			delete(constants, name)
+		}
+	}
+
+	return constants
 }
 
 // Tree-Sitter queries for JavaScript function definitions based on actual grammar
@@ -617,3 +901,334 @@ func (r *javascriptResolvers) generateJSFunctionKey(functionNameNode *sitter.Nod
 	lineNumber := functionNameNode.StartPoint().Row
 	return fmt.Sprintf("%s:%d", functionName, lineNumber)
 }
+
+const jsClassDefinitionQuery = `
+	(class_declaration
+		name: (identifier)) @class
+
+	(variable_declarator
+		name: (identifier)
+		value: (class)) @class
+`
+
+// ResolveClasses extracts ES class declarations and class expressions assigned
+// to variables eg. `const Cat = class extends Animal {}` from JavaScript parse tree.
+// Static members, accessors and private #members are reported as methods and
+// fields of the class
+func (r *javascriptResolvers) ResolveClasses(tree core.ParseTree) ([]*ast.ClassDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var classes []*ast.ClassDeclarationNode
+
+	err = r.visitClassDeclarations(data, tree, func(declarationNode, nameNode *sitter.Node) {
+		classNode := ast.NewClassDeclarationNode(ast.ToContent(*data))
+		classNode.SetClassNameNode(nameNode)
+		classNode.SetAccessModifier(ast.AccessModifierPublic)
+
+		if parentNode := jsHeritageNode(declarationNode); parentNode != nil && jsHeritageName(parentNode, *data) != "" {
+			classNode.AddBaseClassNode(parentNode)
+		}
+
+		for i := 0; i < int(declarationNode.NamedChildCount()); i++ {
+			child := declarationNode.NamedChild(i)
+			if child.Type() == "decorator" {
+				classNode.AddDecoratorNode(child)
+			}
+		}
+
+		bodyNode := declarationNode.ChildByFieldName("body")
+		if bodyNode == nil {
+			classes = append(classes, classNode)
+			return
+		}
+
+		for i := 0; i < int(bodyNode.NamedChildCount()); i++ {
+			member := bodyNode.NamedChild(i)
+			switch member.Type() {
+			case "method_definition":
+				nameNode := member.ChildByFieldName("name")
+				if nameNode != nil && nameNode.Content(*data) == "constructor" {
+					classNode.SetConstructorNode(member)
+				} else {
+					classNode.AddMethodNode(member)
+				}
+			case "field_definition":
+				classNode.AddFieldNode(member)
+			}
+		}
+
+		classes = append(classes, classNode)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract class definitions: %w", err)
+	}
+
+	return classes, nil
+}
+
+const jsPrototypeInheritanceQuery = `
+	(call_expression
+		function: [(identifier) (member_expression)]
+		arguments: (arguments)) @call
+
+	(assignment_expression
+		left: (member_expression
+			property: (property_identifier))
+		right: (call_expression)) @assignment
+`
+
+// ResolveInheritance builds inheritance graph from JavaScript classes. Parents
+// required inline eg. `extends require('stream').Transform` are named by the
+// module eg. stream.Transform. Prototype inheritance of constructor functions
+// is resolved where it is statically recognizable, ie. `util.inherits(Child, Parent)`,
+// `Object.setPrototypeOf(Child.prototype, Parent.prototype)` and
+// `Child.prototype = Object.create(Parent.prototype)`
+func (r *javascriptResolvers) ResolveInheritance(tree core.ParseTree) (*ast.InheritanceGraph, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	filename := ""
+	if file, err := tree.File(); err == nil && file != nil {
+		filename = file.Name()
+	}
+
+	inheritanceGraph := ast.NewInheritanceGraph()
+
+	err = r.visitClassDeclarations(data, tree, func(declarationNode, nameNode *sitter.Node) {
+		parentNode := jsHeritageNode(declarationNode)
+		if parentNode == nil {
+			return
+		}
+
+		if parentName := jsHeritageName(parentNode, *data); parentName != "" {
+			inheritanceGraph.AddRelationship(nameNode.Content(*data), parentName,
+				ast.RelationshipTypeExtends, filename, nameNode.StartPoint().Row+1)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute inheritance queries: %w", err)
+	}
+
+	// Prototypes are commonly set up by more than one statement
+	// eg. Object.setPrototypeOf for both the prototype and the constructor
+	relationships := make(map[string]bool)
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(jsPrototypeInheritanceQuery, func(m *sitter.QueryMatch) error {
+			node := m.Captures[0].Node
+
+			childName, parentName := "", ""
+			switch node.Type() {
+			case "call_expression":
+				childName, parentName = r.prototypeInheritanceCall(node, *data)
+			case "assignment_expression":
+				childName, parentName = r.prototypeInheritanceAssignment(node, *data)
+			}
+
+			if childName == "" || parentName == "" || relationships[childName+"->"+parentName] {
+				return nil
+			}
+
+			relationships[childName+"->"+parentName] = true
+			inheritanceGraph.AddRelationship(childName, parentName,
+				ast.RelationshipTypeExtends, filename, node.StartPoint().Row+1)
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute inheritance queries: %w", err)
+	}
+
+	return inheritanceGraph, nil
+}
+
+func (r *javascriptResolvers) visitClassDeclarations(data *[]byte, tree core.ParseTree,
+	visitor func(declarationNode, nameNode *sitter.Node)) error {
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(jsClassDefinitionQuery, func(m *sitter.QueryMatch) error {
+			node := m.Captures[0].Node
+			nameNode := node.ChildByFieldName("name")
+
+			// The name of a class expression is only visible within the class
+			// eg. `const Cat = class Feline {}` is known as Cat
+			declarationNode := node
+			if node.Type() == "variable_declarator" {
+				declarationNode = node.ChildByFieldName("value")
+			}
+
+			if nameNode == nil || declarationNode == nil {
+				return nil
+			}
+
+			visitor(declarationNode, nameNode)
+			return nil
+		}),
+	}
+
+	return ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+}
+
+// prototypeInheritanceCall resolves the constructors related by
+// `util.inherits(Child, Parent)` or `Object.setPrototypeOf(Child.prototype, Parent.prototype)`
+func (r *javascriptResolvers) prototypeInheritanceCall(callNode *sitter.Node, data []byte) (string, string) {
+	functionNode := callNode.ChildByFieldName("function")
+	argumentsNode := callNode.ChildByFieldName("arguments")
+	if functionNode == nil || argumentsNode == nil || argumentsNode.NamedChildCount() < 2 {
+		return "", ""
+	}
+
+	childNode, parentNode := argumentsNode.NamedChild(0), argumentsNode.NamedChild(1)
+
+	switch {
+	case jsIsInheritsFunction(functionNode, data):
+		return jsConstructorName(childNode, data), jsConstructorName(parentNode, data)
+	case functionNode.Content(data) == "Object.setPrototypeOf":
+		if childName, ok := jsPrototypeOwner(childNode, data); ok {
+			parentName, _ := jsPrototypeOwner(parentNode, data)
+			return childName, parentName
+		}
+
+		// Static members are inherited by setting the prototype of the constructor
+		return jsConstructorName(childNode, data), jsConstructorName(parentNode, data)
+	}
+
+	return "", ""
+}
+
+// prototypeInheritanceAssignment resolves the constructors related by
+// `Child.prototype = Object.create(Parent.prototype)`
+func (r *javascriptResolvers) prototypeInheritanceAssignment(assignmentNode *sitter.Node, data []byte) (string, string) {
+	childName, ok := jsPrototypeOwner(assignmentNode.ChildByFieldName("left"), data)
+	if !ok {
+		return "", ""
+	}
+
+	callNode := assignmentNode.ChildByFieldName("right")
+	functionNode := callNode.ChildByFieldName("function")
+	argumentsNode := callNode.ChildByFieldName("arguments")
+	if functionNode == nil || functionNode.Content(data) != "Object.create" ||
+		argumentsNode == nil || argumentsNode.NamedChildCount() == 0 {
+		return "", ""
+	}
+
+	parentName, _ := jsPrototypeOwner(argumentsNode.NamedChild(0), data)
+	return childName, parentName
+}
+
+// jsIsInheritsFunction checks if the function is util.inherits or inherits
+// imported from the util or the inherits module
+func jsIsInheritsFunction(functionNode *sitter.Node, data []byte) bool {
+	if functionNode.Type() == "identifier" {
+		return functionNode.Content(data) == "inherits"
+	}
+
+	propertyNode := functionNode.ChildByFieldName("property")
+	objectNode := functionNode.ChildByFieldName("object")
+	if propertyNode == nil || objectNode == nil || propertyNode.Content(data) != "inherits" {
+		return false
+	}
+
+	return objectNode.Content(data) == "util" || jsRequiredModule(objectNode, data) == "util"
+}
+
+// jsHeritageNode returns the expression a class extends, if any
+func jsHeritageNode(declarationNode *sitter.Node) *sitter.Node {
+	for i := 0; i < int(declarationNode.NamedChildCount()); i++ {
+		child := declarationNode.NamedChild(i)
+		if child.Type() == "class_heritage" && child.NamedChildCount() > 0 {
+			return child.NamedChild(0)
+		}
+	}
+
+	return nil
+}
+
+// jsHeritageName returns the name of the parent of a class. Parents required
+// inline are named by the module eg. `require('events')` -> events and
+// `require('stream').Transform` -> stream.Transform. Parents computed at
+// runtime eg. `extends mixin(Base)` are not statically recognizable
+func jsHeritageName(node *sitter.Node, data []byte) string {
+	if moduleName := jsRequiredModule(node, data); moduleName != "" {
+		return moduleName
+	}
+
+	if node.Type() == "member_expression" {
+		objectNode := node.ChildByFieldName("object")
+		propertyNode := node.ChildByFieldName("property")
+		if objectNode != nil && propertyNode != nil {
+			if moduleName := jsRequiredModule(objectNode, data); moduleName != "" {
+				return moduleName + "." + propertyNode.Content(data)
+			}
+		}
+	}
+
+	return jsConstructorName(node, data)
+}
+
+// jsConstructorName returns the name of a constructor referenced by an
+// identifier or a property path eg. `stream.Readable`
+func jsConstructorName(node *sitter.Node, data []byte) string {
+	if node == nil {
+		return ""
+	}
+
+	switch node.Type() {
+	case "identifier":
+		return node.Content(data)
+	case "member_expression":
+		objectNode := node.ChildByFieldName("object")
+		propertyNode := node.ChildByFieldName("property")
+		if propertyNode == nil || propertyNode.Type() != "property_identifier" ||
+			propertyNode.Content(data) == "prototype" || jsConstructorName(objectNode, data) == "" {
+			return ""
+		}
+
+		return node.Content(data)
+	}
+
+	return ""
+}
+
+// jsPrototypeOwner returns the name of the constructor whose prototype is
+// referenced eg. `Parent.prototype` -> Parent
+func jsPrototypeOwner(node *sitter.Node, data []byte) (string, bool) {
+	if node == nil || node.Type() != "member_expression" {
+		return "", false
+	}
+
+	propertyNode := node.ChildByFieldName("property")
+	if propertyNode == nil || propertyNode.Content(data) != "prototype" {
+		return "", false
+	}
+
+	name := jsConstructorName(node.ChildByFieldName("object"), data)
+	return name, name != ""
+}
+
+// jsRequiredModule returns the module of a `require('module')` call
+func jsRequiredModule(node *sitter.Node, data []byte) string {
+	if node.Type() != "call_expression" {
+		return ""
+	}
+
+	functionNode := node.ChildByFieldName("function")
+	argumentsNode := node.ChildByFieldName("arguments")
+	if functionNode == nil || functionNode.Content(data) != "require" ||
+		argumentsNode == nil || argumentsNode.NamedChildCount() != 1 {
+		return ""
+	}
+
+	moduleNode := argumentsNode.NamedChild(0)
+	if moduleNode.Type() != "string" || moduleNode.NamedChildCount() != 1 {
+		return ""
+	}
+
+	return moduleNode.NamedChild(0).Content(data)
+}
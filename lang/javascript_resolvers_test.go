@@ -13,6 +13,16 @@ import (
 )
 
 var javascriptImportExpectations = []ImportExpectations{
+	{
+		filePath: "fixtures/dynamic_imports.js",
+		imports: []string{
+			"ImportNode{ModuleName: ./plugins/auth, ModuleItem: , ModuleAlias: auth, WildcardImport: false}",
+			"ImportNode{ModuleName: ./plugins/session, ModuleItem: session, ModuleAlias: session, WildcardImport: false}",
+			"ImportNode{ModuleName: ./plugins/session, ModuleItem: store, ModuleAlias: sessionStore, WildcardImport: false}",
+			"ImportNode{ModuleName: ./plugins/metrics.js, ModuleItem: , ModuleAlias: metrics, WildcardImport: false}",
+			"ImportNode{ModuleName: ./polyfills, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+		},
+	},
 	{
 		filePath: "fixtures/imports.js",
 		imports: []string{
@@ -53,6 +63,14 @@ var javascriptImportExpectations = []ImportExpectations{
 	},
 }
 
+var javascriptUnresolvableImportExpectations = []string{
+	"UnresolvableImportNode{Specifier: process.env.PLUGIN, SpecifierPattern: *, Line: 11}",
+	"UnresolvableImportNode{Specifier: `./locale/${navigator.language}.js`, SpecifierPattern: ./locale/*.js, Line: 12}",
+	"UnresolvableImportNode{Specifier: name, SpecifierPattern: *, Line: 15}",
+	"UnresolvableImportNode{Specifier: PLUGINS + '/index.js', SpecifierPattern: */index.js, Line: 20}",
+	"UnresolvableImportNode{Specifier: ['child', 'process'].join('_'), SpecifierPattern: *, Line: 23}",
+}
+
 var javascriptFunctionExpectations = map[string][]string{
 	"fixtures/functions.js": {
 		"FunctionDeclarationNode{Name: declaredFunction, Type: function, Access: public, ParentClass: }",
@@ -114,6 +132,46 @@ func TestJavascriptLanguageResolvers(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("ResolveUnresolvableImports", func(t *testing.T) {
+		javascriptLanguage, err := lang.NewJavascriptLanguage()
+		assert.NoError(t, err)
+
+		resolvers, ok := javascriptLanguage.Resolvers().(core.DynamicImportLanguageResolvers)
+		assert.True(t, ok)
+
+		fileParser, err := parser.NewParser([]core.Language{javascriptLanguage})
+		assert.NoError(t, err)
+
+		fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+			AppDirectories: []string{"fixtures/dynamic_imports.js"},
+		})
+		assert.NoError(t, err)
+
+		err = fileSystem.EnumerateApp(context.Background(), func(f core.File) error {
+			parseTree, err := fileParser.Parse(context.Background(), f)
+			assert.NoError(t, err)
+
+			imports, err := javascriptLanguage.Resolvers().ResolveImports(parseTree)
+			assert.NoError(t, err)
+
+			for _, imp := range imports {
+				assert.True(t, imp.IsDynamicImport())
+			}
+
+			unresolvableImports, err := resolvers.ResolveUnresolvableImports(parseTree)
+			assert.NoError(t, err)
+
+			var foundImports []string
+			for _, unresolvableImport := range unresolvableImports {
+				foundImports = append(foundImports, unresolvableImport.String())
+			}
+
+			assert.Equal(t, javascriptUnresolvableImportExpectations, foundImports)
+			return err
+		})
+		assert.NoError(t, err)
+	})
+
 	t.Run("ResolveFunctions", func(t *testing.T) {
 		var filePaths []string
 		for path := range javascriptFunctionExpectations {
@@ -154,4 +212,91 @@ func TestJavascriptLanguageResolvers(t *testing.T) {
 		})
 		assert.NoError(t, err)
 	})
+
+	t.Run("ResolveClasses", func(t *testing.T) {
+		javascriptLanguage, err := lang.NewJavascriptLanguage()
+		assert.NoError(t, err)
+
+		fileParser, err := parser.NewParser([]core.Language{javascriptLanguage})
+		assert.NoError(t, err)
+
+		fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+			AppDirectories: []string{"fixtures/javascript_class_hierarchy.js"},
+		})
+		assert.NoError(t, err)
+
+		err = fileSystem.EnumerateApp(context.Background(), func(f core.File) error {
+			parseTree, err := fileParser.Parse(context.Background(), f)
+			assert.NoError(t, err)
+
+			resolvers := javascriptLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			classes, err := resolvers.ResolveClasses(parseTree)
+			assert.NoError(t, err)
+
+			found := map[string]string{}
+			for _, class := range classes {
+				found[class.ClassName()] = fmt.Sprintf("bases=%v methods=%d fields=%d constructor=%t",
+					class.BaseClasses(), len(class.Methods()), len(class.Fields()), class.Constructor() != "")
+			}
+
+			assert.Equal(t, map[string]string{
+				"Animal":    "bases=[EventEmitter] methods=5 fields=3 constructor=true",
+				"Dog":       "bases=[Animal] methods=1 fields=0 constructor=false",
+				"Transport": "bases=[require('stream').Transform] methods=1 fields=0 constructor=false",
+				"Cat":       "bases=[Animal] methods=1 fields=0 constructor=false",
+				"Base":      "bases=[] methods=0 fields=0 constructor=false",
+			}, found)
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("ResolveInheritance", func(t *testing.T) {
+		javascriptLanguage, err := lang.NewJavascriptLanguage()
+		assert.NoError(t, err)
+
+		fileParser, err := parser.NewParser([]core.Language{javascriptLanguage})
+		assert.NoError(t, err)
+
+		fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+			AppDirectories: []string{"fixtures/javascript_class_hierarchy.js"},
+		})
+		assert.NoError(t, err)
+
+		err = fileSystem.EnumerateApp(context.Background(), func(f core.File) error {
+			parseTree, err := fileParser.Parse(context.Background(), f)
+			assert.NoError(t, err)
+
+			resolvers := javascriptLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			graph, err := resolvers.ResolveInheritance(parseTree)
+			assert.NoError(t, err)
+
+			var relationships []string
+			for _, class := range graph.GetAllClasses() {
+				for _, rel := range graph.GetDirectParents(class) {
+					relationships = append(relationships, fmt.Sprintf("%s %s %s", rel.ChildClassName,
+						rel.RelationshipType, rel.ParentClassName))
+				}
+			}
+
+			assert.ElementsMatch(t, []string{
+				"Animal extends EventEmitter",
+				"Dog extends Animal",
+				"Transport extends stream.Transform",
+				"Cat extends Animal",
+				"Stream extends EventEmitter",
+				"Readable extends Stream",
+				"Circle extends Shape",
+				"Square extends Shape",
+			}, relationships)
+
+			assert.Equal(t, []string{"Dog", "Animal", "EventEmitter"}, graph.GetMethodResolutionOrder("Dog"))
+			assert.True(t, graph.IsAncestor("EventEmitter", "Readable"))
+			assert.Empty(t, graph.DetectCircularInheritance())
+			return nil
+		})
+		assert.NoError(t, err)
+	})
 }
@@ -0,0 +1,504 @@
+package lang
+
+import (
+	"fmt"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/core/ast"
+	"github.com/safedep/code/pkg/ts"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type kotlinResolvers struct {
+	language *kotlinLanguage
+}
+
+var _ core.LanguageResolvers = (*kotlinResolvers)(nil)
+var _ core.ObjectOrientedLanguageResolvers = (*kotlinResolvers)(nil)
+
+const kotlinImportQuery = `
+	(import_header) @import
+`
+
+// ResolveImports resolves import headers the same way as Java imports, the
+// imported name is the module name and the alias is its last segment unless
+// renamed with `as` eg. `import okhttp3.Request as HttpRequest`
+func (r *kotlinResolvers) ResolveImports(tree core.ParseTree) ([]*ast.ImportNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var imports []*ast.ImportNode
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(kotlinImportQuery, func(m *sitter.QueryMatch) error {
+			headerNode := m.Captures[0].Node
+
+			moduleNameNode := kotlinChildOfType(headerNode, "identifier")
+			if moduleNameNode == nil {
+				return nil
+			}
+
+			node := ast.NewImportNode(data)
+			node.SetModuleNameNode(moduleNameNode)
+
+			if kotlinChildOfType(headerNode, "wildcard_import") != nil {
+				node.SetIsWildcardImport(true)
+			} else if aliasNode := kotlinChildOfType(headerNode, "import_alias"); aliasNode != nil {
+				node.SetModuleAliasNode(kotlinChildOfType(aliasNode, "type_identifier"))
+			} else if moduleNameNode.NamedChildCount() > 0 {
+				node.SetModuleAliasNode(moduleNameNode.NamedChild(int(moduleNameNode.NamedChildCount()) - 1))
+			}
+
+			imports = append(imports, node)
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+const kotlinFunctionQuery = `
+	(function_declaration) @function
+	(secondary_constructor) @function
+`
+
+// ResolveFunctions extracts top-level functions, extension functions, methods
+// and secondary constructors from Kotlin parse tree. Declarations are public
+// unless declared with a visibility modifier. Members of objects and companion
+// objects are static methods, companion members belong to the enclosing class.
+// Extension functions are methods of their receiver type eg. `fun String.shout()`
+// is a method of String. Local functions and lambdas are not reported
+func (r *kotlinResolvers) ResolveFunctions(tree core.ParseTree) ([]*ast.FunctionDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var functions []*ast.FunctionDeclarationNode
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(kotlinFunctionQuery, func(m *sitter.QueryMatch) error {
+			if functionNode := r.newFunctionNode(data, m.Captures[0].Node); functionNode != nil {
+				functions = append(functions, functionNode)
+			}
+
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract Kotlin functions: %w", err)
+	}
+
+	return functions, nil
+}
+
+func (r *kotlinResolvers) newFunctionNode(data *[]byte, node *sitter.Node) *ast.FunctionDeclarationNode {
+	// Local functions are declared within statements
+	if parent := node.Parent(); parent == nil || (parent.Type() != "source_file" && parent.Type() != "class_body") {
+		return nil
+	}
+
+	functionNode := ast.NewFunctionDeclarationNode(data)
+	kotlinVisitAnnotations(node, functionNode.AddDecoratorNode)
+	functionNode.SetAccessModifier(kotlinVisibility(data, node))
+	functionNode.SetIsAsync(kotlinHasModifier(data, node, "suspend"))
+
+	var receiverNode, nameNode, returnTypeNode, bodyNode *sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		switch child.Type() {
+		case "modifiers", "type_parameters", "type_constraints":
+		case "simple_identifier":
+			nameNode = child
+		case "function_value_parameters":
+			functionNode.SetFunctionParameterNodes(kotlinParameterNodes(child))
+		case "function_body":
+			bodyNode = child
+		default:
+			// Types before the name are receivers of extension functions
+			if nameNode == nil {
+				receiverNode = child
+			} else if returnTypeNode == nil {
+				returnTypeNode = child
+			}
+		}
+	}
+
+	ownerNode := kotlinOwnerNode(node)
+
+	if node.Type() == "secondary_constructor" {
+		for i := 0; i < int(node.ChildCount()); i++ {
+			if child := node.Child(i); child.Type() == "constructor" {
+				nameNode = child
+				break
+			}
+		}
+
+		if nameNode == nil || ownerNode == nil {
+			return nil
+		}
+
+		functionNode.SetFunctionNameNode(nameNode)
+		functionNode.SetFunctionType(ast.FunctionTypeConstructor)
+		functionNode.SetParentClassName(kotlinDeclarationName(data, ownerNode))
+		if blockNode := kotlinChildOfType(node, "statements"); blockNode != nil {
+			functionNode.SetFunctionBodyNode(blockNode)
+		}
+
+		return functionNode
+	}
+
+	if nameNode == nil {
+		return nil
+	}
+
+	functionNode.SetFunctionNameNode(nameNode)
+	if returnTypeNode != nil {
+		functionNode.SetFunctionReturnTypeNode(returnTypeNode)
+	}
+
+	if bodyNode != nil {
+		functionNode.SetFunctionBodyNode(bodyNode)
+	}
+
+	switch {
+	case ownerNode != nil && ownerNode.Type() == "companion_object":
+		functionNode.SetFunctionType(ast.FunctionTypeStaticMethod)
+		functionNode.SetIsStatic(true)
+		if classNode := kotlinOwnerNode(ownerNode); classNode != nil {
+			functionNode.SetParentClassName(kotlinDeclarationName(data, classNode))
+		}
+	case ownerNode != nil && ownerNode.Type() == "object_declaration":
+		functionNode.SetFunctionType(ast.FunctionTypeStaticMethod)
+		functionNode.SetIsStatic(true)
+		functionNode.SetParentClassName(kotlinDeclarationName(data, ownerNode))
+	case ownerNode != nil:
+		functionNode.SetFunctionType(ast.FunctionTypeMethod)
+		functionNode.SetParentClassName(kotlinDeclarationName(data, ownerNode))
+		functionNode.SetIsAbstract(kotlinHasModifier(data, node, "abstract") ||
+			(kotlinIsInterface(ownerNode) && bodyNode == nil))
+	case receiverNode != nil:
+		functionNode.SetFunctionType(ast.FunctionTypeMethod)
+		functionNode.SetParentClassName(kotlinTypeNameNode(receiverNode).Content(*data))
+	default:
+		functionNode.SetFunctionType(ast.FunctionTypeFunction)
+	}
+
+	return functionNode
+}
+
+const kotlinClassQuery = `
+	(class_declaration) @class
+	(object_declaration) @class
+`
+
+// ResolveClasses extracts classes, interfaces, enum classes and objects from
+// Kotlin parse tree. Interfaces and sealed classes are reported as abstract
+// classes. The primary constructor is the constructor of a class, if any, and
+// properties declared in it are fields. Methods of companion objects are
+// methods of the enclosing class
+func (r *kotlinResolvers) ResolveClasses(tree core.ParseTree) ([]*ast.ClassDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var classes []*ast.ClassDeclarationNode
+
+	err = r.visitClasses(data, tree, func(classNode *sitter.Node) {
+		nameNode := kotlinChildOfType(classNode, "type_identifier")
+		if nameNode == nil {
+			return
+		}
+
+		classDeclaration := ast.NewClassDeclarationNode(ast.ToContent(*data))
+		classDeclaration.SetClassNameNode(nameNode)
+		classDeclaration.SetAccessModifier(kotlinVisibility(data, classNode))
+		classDeclaration.SetIsAbstract(kotlinIsInterface(classNode) ||
+			kotlinHasModifier(data, classNode, "abstract") ||
+			kotlinHasModifier(data, classNode, "sealed"))
+
+		kotlinVisitAnnotations(classNode, classDeclaration.AddDecoratorNode)
+		kotlinVisitBaseClasses(classNode, func(_ ast.RelationshipType, baseNode *sitter.Node) {
+			classDeclaration.AddBaseClassNode(baseNode)
+		})
+
+		if constructorNode := kotlinChildOfType(classNode, "primary_constructor"); constructorNode != nil {
+			classDeclaration.SetConstructorNode(constructorNode)
+
+			for i := 0; i < int(constructorNode.NamedChildCount()); i++ {
+				paramNode := constructorNode.NamedChild(i)
+				if paramNode.Type() != "class_parameter" || kotlinChildOfType(paramNode, "binding_pattern_kind") == nil {
+					continue
+				}
+
+				if paramNameNode := kotlinChildOfType(paramNode, "simple_identifier"); paramNameNode != nil {
+					classDeclaration.AddFieldNode(paramNameNode)
+				}
+			}
+		}
+
+		if bodyNode := kotlinChildOfType(classNode, "class_body", "enum_class_body"); bodyNode != nil {
+			r.addMembers(classDeclaration, bodyNode)
+		}
+
+		classes = append(classes, classDeclaration)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract class definitions: %w", err)
+	}
+
+	return classes, nil
+}
+
+// addMembers adds the methods, the first secondary constructor of classes
+// without a primary constructor and the properties declared in a class body
+func (r *kotlinResolvers) addMembers(classDeclaration *ast.ClassDeclarationNode, bodyNode *sitter.Node) {
+	for i := 0; i < int(bodyNode.NamedChildCount()); i++ {
+		member := bodyNode.NamedChild(i)
+		switch member.Type() {
+		case "function_declaration":
+			classDeclaration.AddMethodNode(member)
+		case "secondary_constructor":
+			if classDeclaration.GetConstructorNode() == nil {
+				classDeclaration.SetConstructorNode(member)
+			}
+		case "property_declaration":
+			if declarationNode := kotlinChildOfType(member, "variable_declaration"); declarationNode != nil {
+				if nameNode := kotlinChildOfType(declarationNode, "simple_identifier"); nameNode != nil {
+					classDeclaration.AddFieldNode(nameNode)
+				}
+			}
+		case "companion_object":
+			if companionBodyNode := kotlinChildOfType(member, "class_body"); companionBodyNode != nil {
+				for j := 0; j < int(companionBodyNode.NamedChildCount()); j++ {
+					if companionMember := companionBodyNode.NamedChild(j); companionMember.Type() == "function_declaration" {
+						classDeclaration.AddMethodNode(companionMember)
+					}
+				}
+			}
+		}
+	}
+}
+
+// ResolveInheritance builds inheritance graph from Kotlin classes, interfaces
+// and objects. Supertypes initialised with a constructor call are extended
+// classes, other supertypes are implemented interfaces. Interfaces extend
+// their super interfaces
+func (r *kotlinResolvers) ResolveInheritance(tree core.ParseTree) (*ast.InheritanceGraph, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	filename := ""
+	if file, err := tree.File(); err == nil && file != nil {
+		filename = file.Name()
+	}
+
+	inheritanceGraph := ast.NewInheritanceGraph()
+
+	err = r.visitClasses(data, tree, func(classNode *sitter.Node) {
+		nameNode := kotlinChildOfType(classNode, "type_identifier")
+		if nameNode == nil {
+			return
+		}
+
+		className := nameNode.Content(*data)
+		kotlinVisitBaseClasses(classNode, func(relationshipType ast.RelationshipType, baseNode *sitter.Node) {
+			inheritanceGraph.AddRelationship(className, baseNode.Content(*data),
+				relationshipType, filename, baseNode.StartPoint().Row+1)
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute inheritance queries: %w", err)
+	}
+
+	return inheritanceGraph, nil
+}
+
+func (r *kotlinResolvers) visitClasses(data *[]byte, tree core.ParseTree, visitor func(classNode *sitter.Node)) error {
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(kotlinClassQuery, func(m *sitter.QueryMatch) error {
+			visitor(m.Captures[0].Node)
+			return nil
+		}),
+	}
+
+	return ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+}
+
+// kotlinVisitBaseClasses calls the visitor with the supertypes of a class
+// eg. `Entity` and `Auditable` in `class Customer : Entity(id), Auditable`
+func kotlinVisitBaseClasses(classNode *sitter.Node, visitor func(relationshipType ast.RelationshipType, baseNode *sitter.Node)) {
+	isInterface := kotlinIsInterface(classNode)
+
+	for i := 0; i < int(classNode.NamedChildCount()); i++ {
+		specifierNode := classNode.NamedChild(i)
+		if specifierNode.Type() != "delegation_specifier" || specifierNode.NamedChildCount() == 0 {
+			continue
+		}
+
+		relationshipType := ast.RelationshipTypeImplements
+		typeNode := specifierNode.NamedChild(0)
+		switch typeNode.Type() {
+		case "constructor_invocation":
+			relationshipType = ast.RelationshipTypeExtends
+			typeNode = kotlinChildOfType(typeNode, "user_type")
+		case "explicit_delegation":
+			// Interfaces implemented by delegation eg. `Repository by store`
+			typeNode = kotlinChildOfType(typeNode, "user_type")
+		case "user_type":
+		default:
+			continue
+		}
+
+		if typeNode == nil {
+			continue
+		}
+
+		if isInterface {
+			relationshipType = ast.RelationshipTypeExtends
+		}
+
+		visitor(relationshipType, kotlinTypeNameNode(typeNode))
+	}
+}
+
+// kotlinVisitAnnotations calls the visitor with the annotations of a declaration
+func kotlinVisitAnnotations(node *sitter.Node, visitor func(annotationNode *sitter.Node)) {
+	modifiersNode := kotlinChildOfType(node, "modifiers")
+	if modifiersNode == nil {
+		return
+	}
+
+	for i := 0; i < int(modifiersNode.NamedChildCount()); i++ {
+		if child := modifiersNode.NamedChild(i); child.Type() == "annotation" {
+			visitor(child)
+		}
+	}
+}
+
+// kotlinParameterNodes returns the parameters of a function, default
+// values and modifiers like vararg are siblings of the parameters
+func kotlinParameterNodes(parametersNode *sitter.Node) []*sitter.Node {
+	var paramNodes []*sitter.Node
+	for i := 0; i < int(parametersNode.NamedChildCount()); i++ {
+		if child := parametersNode.NamedChild(i); child.Type() == "parameter" {
+			paramNodes = append(paramNodes, child)
+		}
+	}
+
+	return paramNodes
+}
+
+// kotlinOwnerNode returns the class, object or companion object declaring a member
+func kotlinOwnerNode(node *sitter.Node) *sitter.Node {
+	bodyNode := node.Parent()
+	if bodyNode == nil || (bodyNode.Type() != "class_body" && bodyNode.Type() != "enum_class_body") {
+		return nil
+	}
+
+	return bodyNode.Parent()
+}
+
+// kotlinDeclarationName returns the name of a class or object, companion
+// objects without a name are named Companion
+func kotlinDeclarationName(data *[]byte, node *sitter.Node) string {
+	if nameNode := kotlinChildOfType(node, "type_identifier"); nameNode != nil {
+		return nameNode.Content(*data)
+	}
+
+	if node.Type() == "companion_object" {
+		return "Companion"
+	}
+
+	return ""
+}
+
+// kotlinTypeNameNode returns the simple name of a type
+// eg. `HashMap` in `java.util.HashMap<String, Entity>` or `String` in `String?`
+func kotlinTypeNameNode(node *sitter.Node) *sitter.Node {
+	if node.Type() == "nullable_type" && node.NamedChildCount() > 0 {
+		node = node.NamedChild(0)
+	}
+
+	var nameNode *sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		if child := node.NamedChild(i); child.Type() == "type_identifier" {
+			nameNode = child
+		}
+	}
+
+	if nameNode == nil {
+		return node
+	}
+
+	return nameNode
+}
+
+func kotlinIsInterface(node *sitter.Node) bool {
+	for i := 0; i < int(node.ChildCount()); i++ {
+		if node.Child(i).Type() == "interface" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// kotlinHasModifier checks the visibility, inheritance, class, member and
+// function modifiers of a declaration eg. abstract, sealed or suspend
+func kotlinHasModifier(data *[]byte, node *sitter.Node, modifier string) bool {
+	modifiersNode := kotlinChildOfType(node, "modifiers")
+	if modifiersNode == nil {
+		return false
+	}
+
+	for i := 0; i < int(modifiersNode.NamedChildCount()); i++ {
+		if modifiersNode.NamedChild(i).Content(*data) == modifier {
+			return true
+		}
+	}
+
+	return false
+}
+
+// kotlinVisibility returns the visibility of a declaration, declarations are
+// public by default and internal declarations are visible within the module
+func kotlinVisibility(data *[]byte, node *sitter.Node) ast.AccessModifier {
+	switch {
+	case kotlinHasModifier(data, node, "private"):
+		return ast.AccessModifierPrivate
+	case kotlinHasModifier(data, node, "protected"):
+		return ast.AccessModifierProtected
+	case kotlinHasModifier(data, node, "internal"):
+		return ast.AccessModifierPackage
+	}
+
+	return ast.AccessModifierPublic
+}
+
+// kotlinChildOfType returns the first named child of any of the given types,
+// the grammar does not name the children of declarations with fields
+func kotlinChildOfType(node *sitter.Node, types ...string) *sitter.Node {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		for _, t := range types {
+			if child.Type() == t {
+				return child
+			}
+		}
+	}
+
+	return nil
+}
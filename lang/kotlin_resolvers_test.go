@@ -0,0 +1,208 @@
+package lang_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/core/ast"
+	"github.com/safedep/code/fs"
+	"github.com/safedep/code/lang"
+	"github.com/safedep/code/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+var kotlinImportExpectations = []ImportExpectations{
+	{
+		filePath: "fixtures/imports.kt",
+		imports: []string{
+			"ImportNode{ModuleName: okhttp3.OkHttpClient, ModuleItem: , ModuleAlias: OkHttpClient, WildcardImport: false}",
+			"ImportNode{ModuleName: okhttp3.Request, ModuleItem: , ModuleAlias: HttpRequest, WildcardImport: false}",
+			"ImportNode{ModuleName: javax.crypto.Cipher, ModuleItem: , ModuleAlias: Cipher, WildcardImport: false}",
+			"ImportNode{ModuleName: kotlinx.coroutines, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: com.squareup.moshi.Moshi.Builder, ModuleItem: , ModuleAlias: Builder, WildcardImport: false}",
+			"ImportNode{ModuleName: java.util.concurrent.TimeUnit.SECONDS, ModuleItem: , ModuleAlias: Seconds, WildcardImport: false}",
+		},
+	},
+}
+
+var kotlinFunctionExpectations = map[string][]string{
+	"fixtures/functions.kt": {
+		"FunctionDeclarationNode{Name: topLevel, Type: function, Access: public, ParentClass: }",
+		"FunctionDeclarationNode{Name: fetch, Type: function, Access: private, ParentClass: }",
+		"FunctionDeclarationNode{Name: shout, Type: method, Access: public, ParentClass: String}",
+		"FunctionDeclarationNode{Name: parse, Type: function, Access: package, ParentClass: }",
+		"FunctionDeclarationNode{Name: configure, Type: method, Access: protected, ParentClass: Service}",
+		"FunctionDeclarationNode{Name: constructor, Type: constructor, Access: public, ParentClass: Service}",
+		"FunctionDeclarationNode{Name: run, Type: method, Access: public, ParentClass: Service}",
+		"FunctionDeclarationNode{Name: create, Type: static_method, Access: public, ParentClass: Service}",
+		"FunctionDeclarationNode{Name: register, Type: static_method, Access: public, ParentClass: Registry}",
+		"FunctionDeclarationNode{Name: find, Type: method, Access: public, ParentClass: Repository}",
+		"FunctionDeclarationNode{Name: constructor, Type: constructor, Access: public, ParentClass: Client}",
+		"FunctionDeclarationNode{Name: send, Type: method, Access: package, ParentClass: Client}",
+	},
+}
+
+func parseKotlinFixtures(t *testing.T, filePaths []string, visitor func(core.ParseTree, core.File)) {
+	t.Helper()
+
+	kotlinLanguage, err := lang.NewKotlinLanguage()
+	assert.NoError(t, err)
+
+	fileParser, err := parser.NewParser([]core.Language{kotlinLanguage})
+	assert.NoError(t, err)
+
+	fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+		AppDirectories: filePaths,
+	})
+	assert.NoError(t, err)
+
+	err = fileSystem.EnumerateApp(context.Background(), func(f core.File) error {
+		parseTree, err := fileParser.Parse(context.Background(), f)
+		assert.NoError(t, err)
+
+		visitor(parseTree, f)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestKotlinLanguageResolvers(t *testing.T) {
+	kotlinLanguage, err := lang.NewKotlinLanguage()
+	assert.NoError(t, err)
+
+	t.Run("ResolversExists", func(t *testing.T) {
+		resolvers := kotlinLanguage.Resolvers()
+		assert.NotNil(t, resolvers)
+
+		_, ok := resolvers.(core.ObjectOrientedLanguageResolvers)
+		assert.True(t, ok)
+	})
+
+	t.Run("ResolveImports", func(t *testing.T) {
+		importExpectationsMapper := make(map[string][]string)
+		importFilePaths := []string{}
+		for _, ie := range kotlinImportExpectations {
+			importFilePaths = append(importFilePaths, ie.filePath)
+			importExpectationsMapper[ie.filePath] = ie.imports
+		}
+
+		parseKotlinFixtures(t, importFilePaths, func(parseTree core.ParseTree, f core.File) {
+			imports, err := kotlinLanguage.Resolvers().ResolveImports(parseTree)
+			assert.NoError(t, err)
+
+			expectedImports, ok := importExpectationsMapper[f.Name()]
+			assert.True(t, ok)
+
+			assert.Equal(t, len(expectedImports), len(imports))
+			for i, expectedImport := range expectedImports {
+				assert.Equal(t, expectedImport, imports[i].String())
+			}
+		})
+	})
+
+	t.Run("ResolveFunctions", func(t *testing.T) {
+		var filePaths []string
+		for path := range kotlinFunctionExpectations {
+			filePaths = append(filePaths, path)
+		}
+
+		parseKotlinFixtures(t, filePaths, func(parseTree core.ParseTree, f core.File) {
+			functions, err := kotlinLanguage.Resolvers().ResolveFunctions(parseTree)
+			assert.NoError(t, err)
+
+			expectedFunctions, ok := kotlinFunctionExpectations[f.Name()]
+			assert.True(t, ok)
+
+			var foundFunctions []string
+			for _, fun := range functions {
+				foundFunctions = append(foundFunctions,
+					fmt.Sprintf("FunctionDeclarationNode{Name: %s, Type: %s, Access: %s, ParentClass: %s}",
+						fun.FunctionName(), fun.GetFunctionType(), fun.GetAccessModifier(), fun.GetParentClassName()))
+
+				switch fun.FunctionName() {
+				case "topLevel":
+					assert.Equal(t, []string{"a: Int", "b: String", "rest: Int"}, fun.Parameters())
+					assert.Equal(t, "Int", fun.ReturnType())
+				case "fetch":
+					assert.True(t, fun.IsAsync())
+				case "configure", "find":
+					assert.True(t, fun.IsAbstract())
+				case "run":
+					assert.False(t, fun.IsAbstract())
+					assert.Equal(t, []string{`@Deprecated("use start")`}, fun.Decorators())
+				case "create":
+					assert.True(t, fun.IsStatic())
+					assert.Equal(t, []string{"@JvmStatic"}, fun.Decorators())
+				}
+			}
+
+			assert.ElementsMatch(t, expectedFunctions, foundFunctions)
+		})
+	})
+
+	t.Run("ResolveClasses", func(t *testing.T) {
+		parseKotlinFixtures(t, []string{"fixtures/kotlin_class_hierarchy.kt"}, func(parseTree core.ParseTree, _ core.File) {
+			resolvers := kotlinLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			classes, err := resolvers.ResolveClasses(parseTree)
+			assert.NoError(t, err)
+
+			found := map[string]string{}
+			for _, class := range classes {
+				found[class.ClassName()] = fmt.Sprintf("bases=%v methods=%d fields=%v constructor=%t abstract=%t",
+					class.BaseClasses(), len(class.Methods()), class.Fields(),
+					class.Constructor() != "", class.IsAbstract())
+
+				if class.ClassName() == "Customer" {
+					assert.Equal(t, []string{"@Entity"}, class.Decorators())
+					assert.Equal(t, ast.AccessModifierPublic, class.AccessModifier())
+				}
+			}
+
+			assert.Equal(t, map[string]string{
+				"Identifiable": "bases=[] methods=0 fields=[id] constructor=false abstract=true",
+				"Auditable":    "bases=[Identifiable Serializable] methods=0 fields=[] constructor=false abstract=true",
+				"Entity":       "bases=[Identifiable] methods=1 fields=[id] constructor=true abstract=true",
+				"Customer":     "bases=[Entity Auditable] methods=2 fields=[id name email active] constructor=true abstract=false",
+				"Result":       "bases=[] methods=0 fields=[] constructor=false abstract=true",
+				"Success":      "bases=[Result] methods=0 fields=[value] constructor=true abstract=false",
+				"Failure":      "bases=[Result] methods=0 fields=[] constructor=false abstract=false",
+				"Status":       "bases=[Serializable] methods=0 fields=[] constructor=false abstract=false",
+				"Registry":     "bases=[HashMap] methods=0 fields=[] constructor=false abstract=false",
+			}, found)
+		})
+	})
+
+	t.Run("ResolveInheritance", func(t *testing.T) {
+		parseKotlinFixtures(t, []string{"fixtures/kotlin_class_hierarchy.kt"}, func(parseTree core.ParseTree, _ core.File) {
+			resolvers := kotlinLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			graph, err := resolvers.ResolveInheritance(parseTree)
+			assert.NoError(t, err)
+
+			var relationships []string
+			for _, class := range graph.GetAllClasses() {
+				for _, rel := range graph.GetDirectParents(class) {
+					relationships = append(relationships, fmt.Sprintf("%s %s %s", rel.ChildClassName,
+						rel.RelationshipType, rel.ParentClassName))
+				}
+			}
+
+			assert.ElementsMatch(t, []string{
+				"Auditable extends Identifiable",
+				"Auditable extends Serializable",
+				"Entity implements Identifiable",
+				"Customer extends Entity",
+				"Customer implements Auditable",
+				"Success extends Result",
+				"Failure extends Result",
+				"Status implements Serializable",
+				"Registry extends HashMap",
+			}, relationships)
+
+			assert.True(t, graph.IsAncestor("Identifiable", "Customer"))
+		})
+	})
+}
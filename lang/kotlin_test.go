@@ -0,0 +1,25 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKotlinLanguageMeta(t *testing.T) {
+	t.Run("Name", func(t *testing.T) {
+		l := &kotlinLanguage{}
+		assert.Equal(t, kotlinLanguageName, l.Name())
+	})
+
+	t.Run("Code", func(t *testing.T) {
+		l := &kotlinLanguage{}
+		assert.Equal(t, core.LanguageCodeKotlin, l.Meta().Code)
+	})
+
+	t.Run("ObjectOriented", func(t *testing.T) {
+		l := &kotlinLanguage{}
+		assert.True(t, l.Meta().ObjectOriented)
+	})
+}
@@ -0,0 +1,537 @@
+package lang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/core/ast"
+	"github.com/safedep/code/pkg/ts"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type phpResolvers struct {
+	language *phpLanguage
+}
+
+var _ core.LanguageResolvers = (*phpResolvers)(nil)
+var _ core.ObjectOrientedLanguageResolvers = (*phpResolvers)(nil)
+
+const phpNamespaceSeparator = "\\"
+
+const phpConstructorName = "__construct"
+
+const phpImportQuery = `
+	(namespace_use_declaration) @import
+	(require_expression) @import
+	(require_once_expression) @import
+	(include_expression) @import
+	(include_once_expression) @import
+`
+
+// ResolveImports resolves use declarations including grouped, aliased and
+// function or const imports eg. `use function App\{helper as h}`. The namespace
+// is the module name and the last segment is the imported item. Files loaded
+// with require and include are resolved as wildcard imports when their path is
+// a literal or relative to `__DIR__` eg. `require __DIR__ . '/bootstrap.php'`
+func (r *phpResolvers) ResolveImports(tree core.ParseTree) ([]*ast.ImportNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var imports []*ast.ImportNode
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(phpImportQuery, func(m *sitter.QueryMatch) error {
+			importNode := m.Captures[0].Node
+			if importNode.Type() == "namespace_use_declaration" {
+				imports = append(imports, r.resolveUseDeclaration(data, importNode)...)
+			} else if node := r.resolveFileInclusion(data, importNode); node != nil {
+				imports = append(imports, node)
+			}
+
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+func (r *phpResolvers) resolveUseDeclaration(data *[]byte, declarationNode *sitter.Node) []*ast.ImportNode {
+	var imports []*ast.ImportNode
+
+	var groupPrefixNode *sitter.Node
+	for i := 0; i < int(declarationNode.NamedChildCount()); i++ {
+		child := declarationNode.NamedChild(i)
+		switch child.Type() {
+		case "namespace_use_clause":
+			imports = append(imports, r.newUseClauseImport(data, child))
+		case "namespace_name":
+			groupPrefixNode = child
+		case "namespace_use_group":
+			if groupPrefixNode == nil {
+				continue
+			}
+
+			for j := 0; j < int(child.NamedChildCount()); j++ {
+				clauseNode := child.NamedChild(j)
+				if clauseNode.Type() == "namespace_use_group_clause" {
+					imports = append(imports, r.newUseGroupClauseImport(data, groupPrefixNode, clauseNode))
+				}
+			}
+		}
+	}
+
+	return imports
+}
+
+// newUseClauseImport creates an import of a use clause eg. `GuzzleHttp\Client as Http`
+func (r *phpResolvers) newUseClauseImport(data *[]byte, clauseNode *sitter.Node) *ast.ImportNode {
+	node := ast.NewImportNode(data)
+	node.SetModuleAliasNode(phpAliasNode(clauseNode))
+
+	nameNode := clauseNode.NamedChild(0)
+	if nameNode.Type() != "qualified_name" {
+		node.SetModuleNameNode(nameNode)
+		return node
+	}
+
+	itemNode := nameNode.ChildByFieldName("name")
+	if itemNode == nil {
+		itemNode = nameNode.NamedChild(int(nameNode.NamedChildCount()) - 1)
+	}
+
+	prefixNode := phpNamespacePrefixNode(nameNode)
+	if prefixNode == nil {
+		// Fully qualified name of the global namespace eg. `use \Monolog`
+		node.SetModuleNameNode(itemNode)
+		return node
+	}
+
+	node.SetModuleNameNode(prefixNode)
+	node.SetModuleItemNode(itemNode)
+
+	return node
+}
+
+// newUseGroupClauseImport creates an import of a clause of a group
+// eg. `Command\Command as BaseCommand` in `use Symfony\Console\{Command\Command as BaseCommand}`
+func (r *phpResolvers) newUseGroupClauseImport(data *[]byte, groupPrefixNode *sitter.Node, clauseNode *sitter.Node) *ast.ImportNode {
+	node := ast.NewImportNode(data)
+	node.SetModuleNameNode(groupPrefixNode)
+	node.SetModuleAliasNode(phpAliasNode(clauseNode))
+
+	var nameNode *sitter.Node
+	for i := 0; i < int(clauseNode.NamedChildCount()); i++ {
+		if child := clauseNode.NamedChild(i); child.Type() == "namespace_name" {
+			nameNode = child
+			break
+		}
+	}
+
+	if nameNode == nil || nameNode.NamedChildCount() == 0 {
+		return node
+	}
+
+	segments := []string{groupPrefixNode.Content(*data)}
+	for i := 0; i < int(nameNode.NamedChildCount())-1; i++ {
+		segments = append(segments, nameNode.NamedChild(i).Content(*data))
+	}
+
+	node.SetModuleName(strings.Join(segments, phpNamespaceSeparator))
+	node.SetModuleItemNode(nameNode.NamedChild(int(nameNode.NamedChildCount()) - 1))
+
+	return node
+}
+
+// resolveFileInclusion resolves the path of a file loaded with require or include
+func (r *phpResolvers) resolveFileInclusion(data *[]byte, inclusionNode *sitter.Node) *ast.ImportNode {
+	if inclusionNode.NamedChildCount() == 0 {
+		return nil
+	}
+
+	pathNode := inclusionNode.NamedChild(0)
+	for pathNode.Type() == "parenthesized_expression" && pathNode.NamedChildCount() > 0 {
+		pathNode = pathNode.NamedChild(0)
+	}
+
+	relativeToDir := false
+	if pathNode.Type() == "binary_expression" {
+		leftNode := pathNode.ChildByFieldName("left")
+		if leftNode == nil || leftNode.Type() != "name" || leftNode.Content(*data) != "__DIR__" {
+			return nil
+		}
+
+		relativeToDir = true
+		pathNode = pathNode.ChildByFieldName("right")
+	}
+
+	contentNode := phpStringContentNode(pathNode)
+	if contentNode == nil {
+		return nil
+	}
+
+	node := ast.NewImportNode(data)
+	node.SetModuleNameNode(contentNode)
+	node.SetIsWildcardImport(true)
+
+	if relativeToDir {
+		node.SetModuleName("./" + strings.TrimPrefix(contentNode.Content(*data), "/"))
+	}
+
+	return node
+}
+
+const phpFunctionQuery = `
+	(function_definition) @function
+	(method_declaration) @function
+`
+
+// ResolveFunctions extracts functions and the methods of classes, interfaces,
+// traits and enums from PHP parse tree. Methods are public unless declared with
+// a visibility modifier, methods without a body are abstract. Anonymous
+// functions and arrow functions are not reported
+func (r *phpResolvers) ResolveFunctions(tree core.ParseTree) ([]*ast.FunctionDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var functions []*ast.FunctionDeclarationNode
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(phpFunctionQuery, func(m *sitter.QueryMatch) error {
+			if functionNode := r.newFunctionNode(data, m.Captures[0].Node); functionNode != nil {
+				functions = append(functions, functionNode)
+			}
+
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract PHP functions: %w", err)
+	}
+
+	return functions, nil
+}
+
+func (r *phpResolvers) newFunctionNode(data *[]byte, node *sitter.Node) *ast.FunctionDeclarationNode {
+	nameNode := node.ChildByFieldName("name")
+	if nameNode == nil {
+		return nil
+	}
+
+	functionNode := ast.NewFunctionDeclarationNode(data)
+	functionNode.SetFunctionNameNode(nameNode)
+	functionNode.SetAccessModifier(ast.AccessModifierPublic)
+
+	if paramsNode := node.ChildByFieldName("parameters"); paramsNode != nil {
+		functionNode.SetFunctionParameterNodes(r.extractParameterNodes(paramsNode))
+	}
+
+	if returnTypeNode := node.ChildByFieldName("return_type"); returnTypeNode != nil {
+		functionNode.SetFunctionReturnTypeNode(returnTypeNode)
+	}
+
+	bodyNode := node.ChildByFieldName("body")
+	if bodyNode != nil {
+		functionNode.SetFunctionBodyNode(bodyNode)
+	}
+
+	if node.Type() == "function_definition" {
+		functionNode.SetFunctionType(ast.FunctionTypeFunction)
+		return functionNode
+	}
+
+	if ownerNode := phpOwnerNode(node); ownerNode != nil {
+		if ownerNameNode := ownerNode.ChildByFieldName("name"); ownerNameNode != nil {
+			functionNode.SetParentClassName(ownerNameNode.Content(*data))
+		}
+	}
+
+	functionNode.SetIsAbstract(bodyNode == nil)
+	functionNode.SetFunctionType(ast.FunctionTypeMethod)
+
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		modifierNode := node.NamedChild(i)
+		switch modifierNode.Type() {
+		case "visibility_modifier":
+			functionNode.SetAccessModifier(phpVisibility(modifierNode.Content(*data)))
+		case "static_modifier":
+			functionNode.SetFunctionType(ast.FunctionTypeStaticMethod)
+			functionNode.SetIsStatic(true)
+		}
+	}
+
+	if strings.EqualFold(nameNode.Content(*data), phpConstructorName) {
+		functionNode.SetFunctionType(ast.FunctionTypeConstructor)
+	}
+
+	return functionNode
+}
+
+func (r *phpResolvers) extractParameterNodes(parametersNode *sitter.Node) []*sitter.Node {
+	var paramNodes []*sitter.Node
+	for i := 0; i < int(parametersNode.NamedChildCount()); i++ {
+		child := parametersNode.NamedChild(i)
+		if child.Type() != "comment" {
+			paramNodes = append(paramNodes, child)
+		}
+	}
+
+	return paramNodes
+}
+
+const phpClassQuery = `
+	(class_declaration) @class
+	(interface_declaration) @class
+	(trait_declaration) @class
+	(enum_declaration) @class
+`
+
+// ResolveClasses extracts classes, interfaces, traits and enums from PHP parse
+// tree. Interfaces and traits are reported as abstract classes. Base classes are
+// the extended classes or interfaces, the implemented interfaces and the traits
+// used in the body. Fields are the declared and constructor promoted properties
+func (r *phpResolvers) ResolveClasses(tree core.ParseTree) ([]*ast.ClassDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var classes []*ast.ClassDeclarationNode
+
+	err = r.visitClasses(data, tree, func(classNode *sitter.Node) {
+		nameNode := classNode.ChildByFieldName("name")
+		if nameNode == nil {
+			return
+		}
+
+		classDeclaration := ast.NewClassDeclarationNode(ast.ToContent(*data))
+		classDeclaration.SetClassNameNode(nameNode)
+		classDeclaration.SetAccessModifier(ast.AccessModifierPublic)
+		classDeclaration.SetIsAbstract(classNode.Type() == "interface_declaration" ||
+			classNode.Type() == "trait_declaration" || phpHasModifier(classNode, "abstract_modifier"))
+
+		phpVisitBaseClasses(classNode, func(_ ast.RelationshipType, baseNode *sitter.Node) {
+			classDeclaration.AddBaseClassNode(baseNode)
+		})
+
+		if bodyNode := classNode.ChildByFieldName("body"); bodyNode != nil {
+			r.addMembers(data, classDeclaration, bodyNode)
+		}
+
+		classes = append(classes, classDeclaration)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract class definitions: %w", err)
+	}
+
+	return classes, nil
+}
+
+// addMembers adds the methods and properties declared in a class body
+// along with the properties promoted by the constructor
+func (r *phpResolvers) addMembers(data *[]byte, classDeclaration *ast.ClassDeclarationNode, bodyNode *sitter.Node) {
+	for i := 0; i < int(bodyNode.NamedChildCount()); i++ {
+		member := bodyNode.NamedChild(i)
+		switch member.Type() {
+		case "method_declaration":
+			nameNode := member.ChildByFieldName("name")
+			if nameNode == nil || !strings.EqualFold(nameNode.Content(*data), phpConstructorName) {
+				classDeclaration.AddMethodNode(member)
+				continue
+			}
+
+			classDeclaration.SetConstructorNode(member)
+			if paramsNode := member.ChildByFieldName("parameters"); paramsNode != nil {
+				for j := 0; j < int(paramsNode.NamedChildCount()); j++ {
+					paramNode := paramsNode.NamedChild(j)
+					if paramNode.Type() != "property_promotion_parameter" {
+						continue
+					}
+
+					if variableNode := paramNode.ChildByFieldName("name"); variableNode != nil {
+						classDeclaration.AddFieldNode(variableNode)
+					}
+				}
+			}
+		case "property_declaration":
+			for j := 0; j < int(member.NamedChildCount()); j++ {
+				elementNode := member.NamedChild(j)
+				if elementNode.Type() == "property_element" && elementNode.NamedChildCount() > 0 {
+					classDeclaration.AddFieldNode(elementNode.NamedChild(0))
+				}
+			}
+		}
+	}
+}
+
+// ResolveInheritance builds inheritance graph from PHP classes, interfaces,
+// traits and enums. Classes and interfaces extend their parents, classes and
+// enums implement interfaces and traits used by a class are mixins
+func (r *phpResolvers) ResolveInheritance(tree core.ParseTree) (*ast.InheritanceGraph, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	filename := ""
+	if file, err := tree.File(); err == nil && file != nil {
+		filename = file.Name()
+	}
+
+	inheritanceGraph := ast.NewInheritanceGraph()
+
+	err = r.visitClasses(data, tree, func(classNode *sitter.Node) {
+		nameNode := classNode.ChildByFieldName("name")
+		if nameNode == nil {
+			return
+		}
+
+		className := nameNode.Content(*data)
+		phpVisitBaseClasses(classNode, func(relationshipType ast.RelationshipType, baseNode *sitter.Node) {
+			inheritanceGraph.AddRelationship(className, baseNode.Content(*data),
+				relationshipType, filename, baseNode.StartPoint().Row+1)
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute inheritance queries: %w", err)
+	}
+
+	return inheritanceGraph, nil
+}
+
+func (r *phpResolvers) visitClasses(data *[]byte, tree core.ParseTree, visitor func(classNode *sitter.Node)) error {
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(phpClassQuery, func(m *sitter.QueryMatch) error {
+			visitor(m.Captures[0].Node)
+			return nil
+		}),
+	}
+
+	return ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+}
+
+// phpVisitBaseClasses calls the visitor with the classes or interfaces in the
+// extends clause, the interfaces in the implements clause and the traits used
+// in the body of a class
+func phpVisitBaseClasses(classNode *sitter.Node, visitor func(relationshipType ast.RelationshipType, baseNode *sitter.Node)) {
+	visitNames := func(node *sitter.Node, relationshipType ast.RelationshipType) {
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			nameNode := node.NamedChild(i)
+			if nameNode.Type() == "name" || nameNode.Type() == "qualified_name" {
+				visitor(relationshipType, nameNode)
+			}
+		}
+	}
+
+	for i := 0; i < int(classNode.NamedChildCount()); i++ {
+		child := classNode.NamedChild(i)
+		switch child.Type() {
+		case "base_clause":
+			visitNames(child, ast.RelationshipTypeExtends)
+		case "class_interface_clause":
+			visitNames(child, ast.RelationshipTypeImplements)
+		}
+	}
+
+	bodyNode := classNode.ChildByFieldName("body")
+	if bodyNode == nil {
+		return
+	}
+
+	for i := 0; i < int(bodyNode.NamedChildCount()); i++ {
+		if member := bodyNode.NamedChild(i); member.Type() == "use_declaration" {
+			visitNames(member, ast.RelationshipTypeMixin)
+		}
+	}
+}
+
+// phpOwnerNode returns the class, interface, trait or enum declaring a method
+func phpOwnerNode(node *sitter.Node) *sitter.Node {
+	for current := node.Parent(); current != nil; current = current.Parent() {
+		switch current.Type() {
+		case "class_declaration", "interface_declaration", "trait_declaration", "enum_declaration":
+			return current
+		case "object_creation_expression":
+			// Methods of anonymous classes eg. `new class { ... }`
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// phpNamespacePrefixNode returns the namespace of a qualified name
+// eg. `GuzzleHttp` in `GuzzleHttp\Client`
+func phpNamespacePrefixNode(qualifiedNameNode *sitter.Node) *sitter.Node {
+	for i := 0; i < int(qualifiedNameNode.NamedChildCount()); i++ {
+		prefixNode := qualifiedNameNode.NamedChild(i)
+		if prefixNode.Type() != "namespace_name_as_prefix" {
+			continue
+		}
+
+		for j := 0; j < int(prefixNode.NamedChildCount()); j++ {
+			if namespaceNode := prefixNode.NamedChild(j); namespaceNode.Type() == "namespace_name" {
+				return namespaceNode
+			}
+		}
+	}
+
+	return nil
+}
+
+// phpAliasNode returns the alias of a use clause eg. `Http` in `Client as Http`
+func phpAliasNode(clauseNode *sitter.Node) *sitter.Node {
+	for i := 0; i < int(clauseNode.NamedChildCount()); i++ {
+		aliasingNode := clauseNode.NamedChild(i)
+		if aliasingNode.Type() == "namespace_aliasing_clause" && aliasingNode.NamedChildCount() > 0 {
+			return aliasingNode.NamedChild(0)
+		}
+	}
+
+	return nil
+}
+
+// phpStringContentNode returns the content of a string without interpolation
+func phpStringContentNode(node *sitter.Node) *sitter.Node {
+	if node == nil || (node.Type() != "string" && node.Type() != "encapsed_string") {
+		return nil
+	}
+
+	if node.NamedChildCount() != 1 || node.NamedChild(0).Type() != "string_content" {
+		return nil
+	}
+
+	return node.NamedChild(0)
+}
+
+func phpHasModifier(node *sitter.Node, modifierType string) bool {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		if node.NamedChild(i).Type() == modifierType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func phpVisibility(modifier string) ast.AccessModifier {
+	switch strings.ToLower(modifier) {
+	case "private":
+		return ast.AccessModifierPrivate
+	case "protected":
+		return ast.AccessModifierProtected
+	default:
+		return ast.AccessModifierPublic
+	}
+}
@@ -0,0 +1,200 @@
+package lang_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/fs"
+	"github.com/safedep/code/lang"
+	"github.com/safedep/code/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+var phpImportExpectations = []ImportExpectations{
+	{
+		filePath: "fixtures/imports.php",
+		imports: []string{
+			"ImportNode{ModuleName: GuzzleHttp, ModuleItem: Client, ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: Psr\\Log, ModuleItem: LoggerInterface, ModuleAlias: Logger, WildcardImport: false}",
+			"ImportNode{ModuleName: Monolog, ModuleItem: , ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: Symfony\\Component\\Console, ModuleItem: Application, ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: Symfony\\Component\\Console\\Command, ModuleItem: Command, ModuleAlias: BaseCommand, WildcardImport: false}",
+			"ImportNode{ModuleName: Illuminate\\Support, ModuleItem: collect, ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: App\\Config, ModuleItem: DEFAULT_TIMEOUT, ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: Http\\Adapter, ModuleItem: request, ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: Http\\Adapter, ModuleItem: response, ModuleAlias: respond, WildcardImport: false}",
+			"ImportNode{ModuleName: Carbon, ModuleItem: Carbon, ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: Ramsey\\Uuid, ModuleItem: Uuid, ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: vendor/autoload.php, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: ./bootstrap.php, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: helpers/format.php, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: config.php, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: plugins/cache.php, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+		},
+	},
+}
+
+var phpFunctionExpectations = map[string][]string{
+	"fixtures/functions.php": {
+		"FunctionDeclarationNode{Name: top_level_function, Type: function, Access: public, ParentClass: }",
+		"FunctionDeclarationNode{Name: find, Type: method, Access: public, ParentClass: Repository}",
+		"FunctionDeclarationNode{Name: touch, Type: method, Access: public, ParentClass: Timestamps}",
+		"FunctionDeclarationNode{Name: now, Type: static_method, Access: protected, ParentClass: Timestamps}",
+		"FunctionDeclarationNode{Name: __construct, Type: constructor, Access: public, ParentClass: Model}",
+		"FunctionDeclarationNode{Name: table, Type: method, Access: protected, ParentClass: Model}",
+		"FunctionDeclarationNode{Name: create, Type: static_method, Access: public, ParentClass: Model}",
+		"FunctionDeclarationNode{Name: save, Type: method, Access: public, ParentClass: Model}",
+		"FunctionDeclarationNode{Name: dirty, Type: method, Access: private, ParentClass: Model}",
+	},
+}
+
+func parsePhpFixtures(t *testing.T, filePaths []string, visitor func(core.ParseTree, core.File)) {
+	t.Helper()
+
+	phpLanguage, err := lang.NewPhpLanguage()
+	assert.NoError(t, err)
+
+	fileParser, err := parser.NewParser([]core.Language{phpLanguage})
+	assert.NoError(t, err)
+
+	fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+		AppDirectories: filePaths,
+	})
+	assert.NoError(t, err)
+
+	err = fileSystem.EnumerateApp(context.Background(), func(f core.File) error {
+		parseTree, err := fileParser.Parse(context.Background(), f)
+		assert.NoError(t, err)
+
+		visitor(parseTree, f)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestPhpLanguageResolvers(t *testing.T) {
+	phpLanguage, err := lang.NewPhpLanguage()
+	assert.NoError(t, err)
+
+	t.Run("ResolversExists", func(t *testing.T) {
+		resolvers := phpLanguage.Resolvers()
+		assert.NotNil(t, resolvers)
+
+		_, ok := resolvers.(core.ObjectOrientedLanguageResolvers)
+		assert.True(t, ok)
+	})
+
+	t.Run("ResolveImports", func(t *testing.T) {
+		importExpectationsMapper := make(map[string][]string)
+		importFilePaths := []string{}
+		for _, ie := range phpImportExpectations {
+			importFilePaths = append(importFilePaths, ie.filePath)
+			importExpectationsMapper[ie.filePath] = ie.imports
+		}
+
+		parsePhpFixtures(t, importFilePaths, func(parseTree core.ParseTree, f core.File) {
+			imports, err := phpLanguage.Resolvers().ResolveImports(parseTree)
+			assert.NoError(t, err)
+
+			expectedImports, ok := importExpectationsMapper[f.Name()]
+			assert.True(t, ok)
+
+			assert.Equal(t, len(expectedImports), len(imports))
+			for i, expectedImport := range expectedImports {
+				assert.Equal(t, expectedImport, imports[i].String())
+			}
+		})
+	})
+
+	t.Run("ResolveFunctions", func(t *testing.T) {
+		var filePaths []string
+		for path := range phpFunctionExpectations {
+			filePaths = append(filePaths, path)
+		}
+
+		parsePhpFixtures(t, filePaths, func(parseTree core.ParseTree, f core.File) {
+			functions, err := phpLanguage.Resolvers().ResolveFunctions(parseTree)
+			assert.NoError(t, err)
+
+			expectedFunctions, ok := phpFunctionExpectations[f.Name()]
+			assert.True(t, ok)
+
+			var foundFunctions []string
+			for _, fun := range functions {
+				foundFunctions = append(foundFunctions,
+					fmt.Sprintf("FunctionDeclarationNode{Name: %s, Type: %s, Access: %s, ParentClass: %s}",
+						fun.FunctionName(), fun.GetFunctionType(), fun.GetAccessModifier(), fun.GetParentClassName()))
+
+				switch fun.FunctionName() {
+				case "top_level_function":
+					assert.Equal(t, []string{"int $a", "$b = 1", "string ...$rest"}, fun.Parameters())
+				case "find", "table":
+					assert.True(t, fun.IsAbstract())
+				case "create":
+					assert.True(t, fun.IsStatic())
+					assert.False(t, fun.IsAbstract())
+				}
+			}
+
+			assert.ElementsMatch(t, expectedFunctions, foundFunctions)
+		})
+	})
+
+	t.Run("ResolveClasses", func(t *testing.T) {
+		parsePhpFixtures(t, []string{"fixtures/php_class_hierarchy.php"}, func(parseTree core.ParseTree, _ core.File) {
+			resolvers := phpLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			classes, err := resolvers.ResolveClasses(parseTree)
+			assert.NoError(t, err)
+
+			found := map[string]string{}
+			for _, class := range classes {
+				found[class.ClassName()] = fmt.Sprintf("bases=%v methods=%d fields=%v constructor=%t abstract=%t",
+					class.BaseClasses(), len(class.Methods()), class.Fields(),
+					class.Constructor() != "", class.IsAbstract())
+			}
+
+			assert.Equal(t, map[string]string{
+				"Arrayable":        "bases=[] methods=0 fields=[] constructor=false abstract=true",
+				"JsonSerializable": "bases=[Arrayable \\Countable] methods=0 fields=[] constructor=false abstract=true",
+				"HasTimestamps":    "bases=[] methods=1 fields=[$createdAt] constructor=false abstract=true",
+				"SoftDeletes":      "bases=[] methods=0 fields=[] constructor=false abstract=true",
+				"Model":            "bases=[EloquentModel JsonSerializable HasTimestamps SoftDeletes] methods=2 fields=[$table $attributes $original $defaults $connection] constructor=true abstract=true",
+				"User":             "bases=[Model] methods=1 fields=[] constructor=false abstract=false",
+				"Status":           "bases=[Arrayable] methods=0 fields=[] constructor=false abstract=false",
+			}, found)
+		})
+	})
+
+	t.Run("ResolveInheritance", func(t *testing.T) {
+		parsePhpFixtures(t, []string{"fixtures/php_class_hierarchy.php"}, func(parseTree core.ParseTree, _ core.File) {
+			resolvers := phpLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			graph, err := resolvers.ResolveInheritance(parseTree)
+			assert.NoError(t, err)
+
+			var relationships []string
+			for _, class := range graph.GetAllClasses() {
+				for _, rel := range graph.GetDirectParents(class) {
+					relationships = append(relationships, fmt.Sprintf("%s %s %s", rel.ChildClassName,
+						rel.RelationshipType, rel.ParentClassName))
+				}
+			}
+
+			assert.ElementsMatch(t, []string{
+				"JsonSerializable extends Arrayable",
+				"JsonSerializable extends \\Countable",
+				"Model extends EloquentModel",
+				"Model implements JsonSerializable",
+				"Model mixin HasTimestamps",
+				"Model mixin SoftDeletes",
+				"User extends Model",
+				"Status implements Arrayable",
+			}, relationships)
+
+			assert.True(t, graph.IsAncestor("Arrayable", "User"))
+		})
+	})
+}
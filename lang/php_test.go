@@ -0,0 +1,25 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhpLanguageMeta(t *testing.T) {
+	t.Run("Name", func(t *testing.T) {
+		l := &phpLanguage{}
+		assert.Equal(t, phpLanguageName, l.Name())
+	})
+
+	t.Run("Code", func(t *testing.T) {
+		l := &phpLanguage{}
+		assert.Equal(t, core.LanguageCodePhp, l.Meta().Code)
+	})
+
+	t.Run("ObjectOriented", func(t *testing.T) {
+		l := &phpLanguage{}
+		assert.True(t, l.Meta().ObjectOriented)
+	})
+}
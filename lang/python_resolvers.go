@@ -2,6 +2,8 @@ package lang
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/safedep/code/core"
 	"github.com/safedep/code/core/ast"
@@ -57,6 +59,7 @@ type pythonResolvers struct {
 
 var _ core.LanguageResolvers = (*pythonResolvers)(nil)
 var _ core.ObjectOrientedLanguageResolvers = (*pythonResolvers)(nil)
+var _ core.DynamicImportLanguageResolvers = (*pythonResolvers)(nil)
 
 func (r *pythonResolvers) ResolveImports(tree core.ParseTree) ([]*ast.ImportNode, error) {
 	data, err := tree.Data()
@@ -66,6 +69,8 @@ func (r *pythonResolvers) ResolveImports(tree core.ParseTree) ([]*ast.ImportNode
 
 	var imports []*ast.ImportNode
 
+	dynamicImportResolver := newPyDynamicImportResolver(data, tree.Tree().RootNode())
+
 	queryRequestItems := []ts.QueryItem{
 		ts.NewQueryItem(pyWholeModuleImportQuery, func(m *sitter.QueryMatch) error {
 			node := ast.NewImportNode(data)
@@ -90,6 +95,12 @@ func (r *pythonResolvers) ResolveImports(tree core.ParseTree) ([]*ast.ImportNode
 			imports = append(imports, node)
 			return nil
 		}),
+		ts.NewQueryItem(pyDynamicImportQuery, func(m *sitter.QueryMatch) error {
+			nodes, _ := dynamicImportResolver.resolve(m.Captures[0].Node)
+			imports = append(imports, nodes...)
+
+			return nil
+		}),
 	}
 
 	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
@@ -100,6 +111,436 @@ func (r *pythonResolvers) ResolveImports(tree core.ParseTree) ([]*ast.ImportNode
 	return imports, err
 }
 
+// ResolveUnresolvableImports resolves the calls loading a module whose name is
+// computed at runtime eg. `importlib.import_module(sys.argv[1])`, executing
+// code computed at runtime eg. `exec(code)` or loading entry points
+func (r *pythonResolvers) ResolveUnresolvableImports(tree core.ParseTree) ([]*ast.UnresolvableImportNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var unresolvableImports []*ast.UnresolvableImportNode
+
+	dynamicImportResolver := newPyDynamicImportResolver(data, tree.Tree().RootNode())
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(pyDynamicImportQuery, func(m *sitter.QueryMatch) error {
+			if _, node := dynamicImportResolver.resolve(m.Captures[0].Node); node != nil {
+				unresolvableImports = append(unresolvableImports, node)
+			}
+
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve unresolvable imports: %w", err)
+	}
+
+	return unresolvableImports, nil
+}
+
+const pyDynamicImportQuery = `
+	(call
+		function: [(identifier) (attribute)]
+		arguments: (argument_list)) @call
+`
+
+type pyDynamicImportFunction struct {
+	// Whether the function returns the imported module
+	returnsModule bool
+
+	// Whether the module may be relative to the package argument
+	acceptsPackage bool
+
+	// Whether the function executes source code importing modules
+	// eg. `exec("import yaml")`
+	executesCode bool
+
+	// Whether the function loads the entry points of a group, the modules
+	// are declared by the installed distributions hence never resolvable
+	loadsEntryPoints bool
+}
+
+// Functions loading a module at runtime, where the first argument is the name
+// of the module eg. `importlib.import_module("yaml")`. Entry points are loaded
+// from the distribution named by the first argument. Functions are named by
+// their module such that aliased imports are resolved eg. `il.import_module`
+// given `import importlib as il`
+var pyDynamicImportFunctions = map[string]pyDynamicImportFunction{
+	"importlib.import_module":                {returnsModule: true, acceptsPackage: true},
+	"__import__":                             {returnsModule: true},
+	"builtins.__import__":                    {returnsModule: true},
+	"importlib.__import__":                   {returnsModule: true},
+	"importlib.util.spec_from_file_location": {},
+	"pkg_resources.load_entry_point":         {},
+	"exec":                                   {executesCode: true},
+	"builtins.exec":                          {executesCode: true},
+	"pkg_resources.iter_entry_points":        {loadsEntryPoints: true},
+	"importlib.metadata.entry_points":        {loadsEntryPoints: true},
+	"importlib_metadata.entry_points":        {loadsEntryPoints: true},
+}
+
+// Import statements of source code executed at runtime eg. `exec("import yaml")`
+var pyExecutedImportPattern = regexp.MustCompile(`(?m)^[ \t]*(?:from[ \t]+([\w.]+)[ \t]+import\b|import[ \t]+([\w., \t]+))`)
+
+// Separators of the statements of source code executed at runtime
+var pyExecutedStatementSeparators = strings.NewReplacer(`\n`, "\n", ";", "\n")
+
+// Placeholder for the parts of a module name computed at runtime
+// eg. `import_module(f"plugins.{name}")` imports plugins.*
+const pyDynamicModuleNamePart = "*"
+
+// pyImportBindings returns the qualified names bound by the import statements
+// of a file eg. `il` -> importlib given `import importlib as il` and
+// `im` -> importlib.import_module given `from importlib import import_module as im`,
+// along with the modules imported by wildcard imports
+func pyImportBindings(rootNode *sitter.Node, data []byte) (map[string]string, []string) {
+	bindings := make(map[string]string)
+	var wildcardModules []string
+
+	walkNamedNodes(rootNode, func(node *sitter.Node) {
+		switch node.Type() {
+		case "import_statement":
+			for i := 0; i < int(node.NamedChildCount()); i++ {
+				name := node.NamedChild(i)
+				switch name.Type() {
+				case "dotted_name":
+					// `import a.b` binds a
+					moduleName := name.Content(data)
+					root := strings.Split(moduleName, ".")[0]
+					bindings[root] = root
+				case "aliased_import":
+					bindings[name.ChildByFieldName("alias").Content(data)] =
+						name.ChildByFieldName("name").Content(data)
+				}
+			}
+		case "import_from_statement":
+			moduleNameNode := node.ChildByFieldName("module_name")
+			if moduleNameNode == nil || moduleNameNode.Type() != "dotted_name" {
+				return
+			}
+
+			moduleName := moduleNameNode.Content(data)
+			for i := 0; i < int(node.NamedChildCount()); i++ {
+				name := node.NamedChild(i)
+				if name.StartByte() == moduleNameNode.StartByte() {
+					continue
+				}
+
+				switch name.Type() {
+				case "dotted_name":
+					bindings[name.Content(data)] = moduleName + "." + name.Content(data)
+				case "aliased_import":
+					bindings[name.ChildByFieldName("alias").Content(data)] =
+						moduleName + "." + name.ChildByFieldName("name").Content(data)
+				case "wildcard_import":
+					wildcardModules = append(wildcardModules, moduleName)
+				}
+			}
+		}
+	})
+
+	return bindings, wildcardModules
+}
+
+// pyDynamicImportFunctionOf resolves the function called by a call node
+// through the import bindings of the file
+func pyDynamicImportFunctionOf(functionName string, bindings map[string]string,
+	wildcardModules []string) (pyDynamicImportFunction, bool) {
+	root, rest, qualified := strings.Cut(functionName, ".")
+	if boundName, ok := bindings[root]; ok {
+		root = boundName
+	} else if !qualified {
+		for _, moduleName := range wildcardModules {
+			if function, ok := pyDynamicImportFunctions[moduleName+"."+root]; ok {
+				return function, true
+			}
+		}
+	}
+
+	if qualified {
+		root = root + "." + rest
+	}
+
+	function, ok := pyDynamicImportFunctions[root]
+	return function, ok
+}
+
+// pyDynamicImportResolver resolves the calls of a file loading modules at runtime
+type pyDynamicImportResolver struct {
+	data            *[]byte
+	stringConstants map[string]string
+	bindings        map[string]string
+	wildcardModules []string
+}
+
+func newPyDynamicImportResolver(data *[]byte, rootNode *sitter.Node) *pyDynamicImportResolver {
+	bindings, wildcardModules := pyImportBindings(rootNode, *data)
+	return &pyDynamicImportResolver{
+		data:            data,
+		stringConstants: pyStringConstants(rootNode, *data),
+		bindings:        bindings,
+		wildcardModules: wildcardModules,
+	}
+}
+
+// resolve creates the dynamic import nodes from a call loading modules at
+// runtime. Modules returned by the call and assigned to a name eg.
+// `yaml = importlib.import_module("yaml")` are aliased by the name, other
+// modules are wildcard imports since they are loaded for their side effects.
+//
+// It returns an unresolvable import node when the module name is computed
+// entirely at runtime eg. `import_module(sys.argv[1])` or the modules are
+// loaded from entry points, both are nil when the call does not load a module
+func (r *pyDynamicImportResolver) resolve(callNode *sitter.Node) ([]*ast.ImportNode, *ast.UnresolvableImportNode) {
+	data := r.data
+	functionName := strings.Join(strings.Fields(callNode.ChildByFieldName("function").Content(*data)), "")
+	function, ok := pyDynamicImportFunctionOf(functionName, r.bindings, r.wildcardModules)
+	if !ok {
+		return nil, nil
+	}
+
+	var moduleNameNode, packageNode *sitter.Node
+
+	arguments := callNode.ChildByFieldName("arguments")
+	for i, position := 0, 0; i < int(arguments.NamedChildCount()); i++ {
+		argument := arguments.NamedChild(i)
+		if argument.Type() == "keyword_argument" {
+			switch argument.ChildByFieldName("name").Content(*data) {
+			case "name", "dist", "source", "group":
+				moduleNameNode = argument.ChildByFieldName("value")
+			case "package":
+				packageNode = argument.ChildByFieldName("value")
+			}
+
+			continue
+		}
+
+		switch position {
+		case 0:
+			moduleNameNode = argument
+		case 1:
+			packageNode = argument
+		}
+
+		position++
+	}
+
+	newUnresolvableImportNode := func(specifierNode *sitter.Node) *ast.UnresolvableImportNode {
+		node := ast.NewUnresolvableImportNode(data)
+		node.SetCallNode(callNode)
+		node.SetSpecifierNode(specifierNode)
+		node.SetSpecifierPattern(pyDynamicModuleNamePart)
+		return node
+	}
+
+	if function.loadsEntryPoints {
+		if moduleNameNode == nil {
+			moduleNameNode = callNode
+		}
+
+		return nil, newUnresolvableImportNode(moduleNameNode)
+	}
+
+	if moduleNameNode == nil {
+		return nil, nil
+	}
+
+	if function.executesCode {
+		return r.resolveExecutedCode(moduleNameNode, newUnresolvableImportNode)
+	}
+
+	moduleName := pyModuleNameExpression(moduleNameNode, *data, r.stringConstants)
+	if strings.Trim(moduleName, pyDynamicModuleNamePart+".") == "" {
+		return nil, newUnresolvableImportNode(moduleNameNode)
+	}
+
+	if function.acceptsPackage && strings.HasPrefix(moduleName, ".") && packageNode != nil {
+		packageName := pyModuleNameExpression(packageNode, *data, r.stringConstants)
+		moduleName = pyResolveRelativeModuleName(moduleName, packageName)
+	}
+
+	node := ast.NewImportNode(data)
+	node.SetModuleNameNode(moduleNameNode)
+	node.SetModuleName(moduleName)
+	node.SetIsDynamicImport(true)
+
+	parent := callNode.Parent()
+	if function.returnsModule && parent != nil && parent.Type() == "assignment" &&
+		parent.ChildByFieldName("left").Type() == "identifier" {
+		node.SetModuleAliasNode(parent.ChildByFieldName("left"))
+	} else {
+		node.SetIsWildcardImport(true)
+	}
+
+	return []*ast.ImportNode{node}, nil
+}
+
+// resolveExecutedCode resolves the import statements of source code executed
+// at runtime eg. `exec("import yaml")`, the modules are wildcard imports since
+// the names bound by the executed code are not known statically. Code computed
+// at runtime is unresolvable since it may import any module
+func (r *pyDynamicImportResolver) resolveExecutedCode(codeNode *sitter.Node,
+	newUnresolvableImportNode func(*sitter.Node) *ast.UnresolvableImportNode) ([]*ast.ImportNode, *ast.UnresolvableImportNode) {
+	code := pyModuleNameExpression(codeNode, *r.data, r.stringConstants)
+	if strings.Contains(code, pyDynamicModuleNamePart) {
+		return nil, newUnresolvableImportNode(codeNode)
+	}
+
+	code = pyExecutedStatementSeparators.Replace(code)
+
+	var moduleNames []string
+	for _, match := range pyExecutedImportPattern.FindAllStringSubmatch(code, -1) {
+		if match[1] != "" {
+			moduleNames = append(moduleNames, match[1])
+			continue
+		}
+
+		// `import a, b as c`
+		for _, name := range strings.Split(match[2], ",") {
+			if fields := strings.Fields(name); len(fields) > 0 {
+				moduleNames = append(moduleNames, fields[0])
+			}
+		}
+	}
+
+	var nodes []*ast.ImportNode
+	for _, moduleName := range moduleNames {
+		node := ast.NewImportNode(r.data)
+		node.SetModuleNameNode(codeNode)
+		node.SetModuleName(moduleName)
+		node.SetIsDynamicImport(true)
+		node.SetIsWildcardImport(true)
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+// pyModuleNameExpression returns the module name computed by an expression,
+// where the parts computed at runtime are replaced by a placeholder eg.
+// `"codecs_" + name` -> codecs_*. Names bound to string constants are resolved
+// eg. `f"{PLUGINS}.{name}"` -> acme_plugins.* given `PLUGINS = "acme_plugins"`
+func pyModuleNameExpression(node *sitter.Node, data []byte, stringConstants map[string]string) string {
+	switch node.Type() {
+	case "string":
+		var moduleName strings.Builder
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			child := node.NamedChild(i)
+			switch child.Type() {
+			case "string_content":
+				moduleName.WriteString(child.Content(data))
+			case "interpolation":
+				moduleName.WriteString(pyModuleNameExpression(child.ChildByFieldName("expression"), data, stringConstants))
+			}
+		}
+
+		return moduleName.String()
+	case "concatenated_string":
+		var moduleName strings.Builder
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			moduleName.WriteString(pyModuleNameExpression(node.NamedChild(i), data, stringConstants))
+		}
+
+		return moduleName.String()
+	case "binary_operator":
+		if node.ChildByFieldName("operator").Type() != "+" {
+			return pyDynamicModuleNamePart
+		}
+
+		left := pyModuleNameExpression(node.ChildByFieldName("left"), data, stringConstants)
+		right := pyModuleNameExpression(node.ChildByFieldName("right"), data, stringConstants)
+		if strings.HasSuffix(left, pyDynamicModuleNamePart) && strings.HasPrefix(right, pyDynamicModuleNamePart) {
+			right = strings.TrimPrefix(right, pyDynamicModuleNamePart)
+		}
+
+		return left + right
+	case "parenthesized_expression":
+		if node.NamedChildCount() == 1 {
+			return pyModuleNameExpression(node.NamedChild(0), data, stringConstants)
+		}
+	case "identifier":
+		if value, ok := stringConstants[node.Content(data)]; ok {
+			return value
+		}
+	}
+
+	return pyDynamicModuleNamePart
+}
+
+// pyResolveRelativeModuleName resolves a module name relative to a package
+// eg. ..handlers relative to acme.plugins -> acme.handlers. The module name
+// is kept relative when the package is computed at runtime
+func pyResolveRelativeModuleName(moduleName string, packageName string) string {
+	if packageName == "" || strings.Contains(packageName, pyDynamicModuleNamePart) {
+		return moduleName
+	}
+
+	name := strings.TrimLeft(moduleName, ".")
+	levels := len(moduleName) - len(name)
+
+	packageParts := strings.Split(packageName, ".")
+	if levels > len(packageParts) {
+		return moduleName
+	}
+
+	parts := packageParts[:len(packageParts)-levels+1]
+	if name != "" {
+		parts = append(parts, name)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// pyStringConstants returns the names bound once to a string literal at the
+// module level eg. `PLUGINS = "acme_plugins"`, such that module names
+// composed from them can be resolved
+func pyStringConstants(rootNode *sitter.Node, data []byte) map[string]string {
+	constants := make(map[string]string)
+	assignments := make(map[string]int)
+
+	for i := 0; i < int(rootNode.NamedChildCount()); i++ {
+		statement := rootNode.NamedChild(i)
+		if statement.Type() != "expression_statement" || statement.NamedChildCount() != 1 {
+			continue
+		}
+
+		assignment := statement.NamedChild(0)
+		if assignment.Type() != "assignment" {
+			continue
+		}
+
+		left := assignment.ChildByFieldName("left")
+		if left == nil || left.Type() != "identifier" {
+			continue
+		}
+
+		name := left.Content(data)
+		assignments[name]++
+
+		right := assignment.ChildByFieldName("right")
+		if right == nil || right.Type() != "string" {
+			continue
+		}
+
+		value := pyModuleNameExpression(right, data, nil)
+		if !strings.Contains(value, pyDynamicModuleNamePart) {
+			constants[name] = value
+		}
+	}
+
+	for name, count := range assignments {
+		if count > 1 {
+			delete(constants, name)
+		}
+	}
+
+	return constants
+}
+
 // Tree-Sitter queries for Python class definitions
 const pyClassDefinitionQuery = `
 	(class_definition
@@ -40,6 +40,32 @@ var pythonImportExpectations = []ImportExpectations{
 	},
 }
 
+// Dynamic imports of fixtures/dynamic_imports.py along with their line
+var pythonDynamicImportExpectations = []string{
+	"ImportNode{ModuleName: requests, ModuleItem: , ModuleAlias: requests, WildcardImport: false} [9]",
+	"ImportNode{ModuleName: yaml.loader, ModuleItem: , ModuleAlias: loader, WildcardImport: false} [10]",
+	"ImportNode{ModuleName: acme.web.handlers, ModuleItem: , ModuleAlias: handlers, WildcardImport: false} [11]",
+	"ImportNode{ModuleName: acme_plugins.*, ModuleItem: , ModuleAlias: plugin, WildcardImport: false} [12]",
+	"ImportNode{ModuleName: codecs_*, ModuleItem: , ModuleAlias: codec, WildcardImport: false} [13]",
+	"ImportNode{ModuleName: socket, ModuleItem: , ModuleAlias: socket, WildcardImport: false} [14]",
+	"ImportNode{ModuleName: ctypes, ModuleItem: , ModuleAlias: , WildcardImport: true} [15]",
+	"ImportNode{ModuleName: payload, ModuleItem: , ModuleAlias: , WildcardImport: true} [17]",
+	"ImportNode{ModuleName: acme-cli, ModuleItem: , ModuleAlias: , WildcardImport: true} [18]",
+	"ImportNode{ModuleName: toml, ModuleItem: , ModuleAlias: toml, WildcardImport: false} [33]",
+	"ImportNode{ModuleName: jinja2, ModuleItem: , ModuleAlias: , WildcardImport: true} [34]",
+	"ImportNode{ModuleName: base64, ModuleItem: , ModuleAlias: , WildcardImport: true} [35]",
+	"ImportNode{ModuleName: marshal, ModuleItem: , ModuleAlias: , WildcardImport: true} [35]",
+	"ImportNode{ModuleName: zlib, ModuleItem: , ModuleAlias: , WildcardImport: true} [35]",
+}
+
+// Unresolvable imports of fixtures/dynamic_imports.py
+var pythonUnresolvableImportExpectations = []string{
+	"UnresolvableImportNode{Specifier: sys.argv[1], SpecifierPattern: *, Line: 21}",
+	"UnresolvableImportNode{Specifier: payload, SpecifierPattern: *, Line: 36}",
+	`UnresolvableImportNode{Specifier: "acme.plugins", SpecifierPattern: *, Line: 38}`,
+	`UnresolvableImportNode{Specifier: "console_scripts", SpecifierPattern: *, Line: 41}`,
+}
+
 var pythonFunctionExpectations = map[string][]string{
 	"fixtures/functions.py": {
 		"FunctionDeclarationNode{Name: simple_function, Type: function, Access: public, ParentClass: }",
@@ -105,6 +131,75 @@ func TestPythonLanguageResolvers(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("ResolveDynamicImports", func(t *testing.T) {
+		pythonLanguage, err := lang.NewPythonLanguage()
+		assert.NoError(t, err)
+
+		fileParser, err := parser.NewParser([]core.Language{pythonLanguage})
+		assert.NoError(t, err)
+
+		fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+			AppDirectories: []string{"fixtures/dynamic_imports.py"},
+		})
+		assert.NoError(t, err)
+
+		err = fileSystem.EnumerateApp(context.Background(), func(f core.File) error {
+			parseTree, err := fileParser.Parse(context.Background(), f)
+			assert.NoError(t, err)
+
+			imports, err := pythonLanguage.Resolvers().ResolveImports(parseTree)
+			assert.NoError(t, err)
+
+			var dynamicImports []string
+			for _, imp := range imports {
+				if imp.IsDynamicImport() {
+					dynamicImports = append(dynamicImports, fmt.Sprintf("%s [%d]",
+						imp.String(), imp.GetModuleNameNode().StartPoint().Row+1))
+				}
+			}
+
+			// Import statements are not dynamic
+			assert.Equal(t, 9, len(imports)-len(dynamicImports))
+			assert.Equal(t, pythonDynamicImportExpectations, dynamicImports)
+			return err
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("ResolveUnresolvableImports", func(t *testing.T) {
+		pythonLanguage, err := lang.NewPythonLanguage()
+		assert.NoError(t, err)
+
+		resolvers, ok := pythonLanguage.Resolvers().(core.DynamicImportLanguageResolvers)
+		assert.True(t, ok)
+
+		fileParser, err := parser.NewParser([]core.Language{pythonLanguage})
+		assert.NoError(t, err)
+
+		fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+			AppDirectories: []string{"fixtures/dynamic_imports.py"},
+		})
+		assert.NoError(t, err)
+
+		err = fileSystem.EnumerateApp(context.Background(), func(f core.File) error {
+			parseTree, err := fileParser.Parse(context.Background(), f)
+			assert.NoError(t, err)
+
+			unresolvableImports, err := resolvers.ResolveUnresolvableImports(parseTree)
+			assert.NoError(t, err)
+
+			var foundImports []string
+			for _, unresolvableImport := range unresolvableImports {
+				foundImports = append(foundImports, unresolvableImport.String())
+			}
+
+			assert.Equal(t, pythonUnresolvableImportExpectations, foundImports)
+			return err
+		})
+		assert.NoError(t, err)
+	})
+
 	t.Run("ResolveFunctions", func(t *testing.T) {
 		pythonLanguage, err := lang.NewPythonLanguage()
 		assert.NoError(t, err)
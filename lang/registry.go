@@ -0,0 +1,222 @@
+package lang
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/safedep/code/core"
+)
+
+// LanguageFactory creates a core.Language implementation. It is called
+// every time the language is resolved eg. from the path of a file
+type LanguageFactory func() (core.Language, error)
+
+// languageRegistry holds the languages resolved by this package, languages
+// may be registered at runtime while files are being parsed
+type languageRegistry struct {
+	m         sync.RWMutex
+	languages map[core.LanguageCode]LanguageFactory
+
+	// Languages by the file extensions of their source files, built on first
+	// use since building it calls the factory of every language. It is reset
+	// when languages change and is never modified once built
+	extensions map[string]core.LanguageCode
+
+	// Incremented when languages change, such that an index built without
+	// holding the lock is kept only if no language changed meanwhile
+	version int
+}
+
+var registry = newLanguageRegistry(builtinLanguages)
+
+func newLanguageRegistry(languages map[core.LanguageCode]LanguageFactory) *languageRegistry {
+	r := &languageRegistry{
+		languages: make(map[core.LanguageCode]LanguageFactory, len(languages)),
+	}
+
+	for code, factory := range languages {
+		r.languages[code] = factory
+	}
+
+	return r
+}
+
+// RegisterLanguage registers a language implementation eg. a language built
+// on a grammar not bundled with this module, such that it is resolved by
+// AllLanguages, GetLanguage and language detection. The factory is called
+// once during registration to validate the language.
+//
+// It returns an error if the language code is already registered or a file
+// extension of the language is claimed by a registered language.
+func RegisterLanguage(factory LanguageFactory) error {
+	if factory == nil {
+		return fmt.Errorf("language factory is nil")
+	}
+
+	language, err := factory()
+	if err != nil {
+		return fmt.Errorf("failed to create language: %w", err)
+	}
+
+	meta := language.Meta()
+	if meta.Code == "" {
+		return fmt.Errorf("language code is empty")
+	}
+
+	for {
+		extensions, version, err := registry.extensionIndex()
+		if err != nil {
+			return err
+		}
+
+		registered, err := registry.add(meta, factory, extensions, version)
+		if err != nil || registered {
+			return err
+		}
+	}
+}
+
+// UnregisterLanguage removes a registered language, including languages
+// implemented in this module eg. to replace them with another implementation.
+//
+// It returns an error if the language is not registered.
+func UnregisterLanguage(code core.LanguageCode) error {
+	registry.m.Lock()
+	defer registry.m.Unlock()
+
+	if _, ok := registry.languages[code]; !ok {
+		return fmt.Errorf("language not registered: %s", code)
+	}
+
+	delete(registry.languages, code)
+	registry.extensions = nil
+	registry.version++
+
+	return nil
+}
+
+// RegisteredLanguages returns the codes of the registered languages in order
+func RegisteredLanguages() []core.LanguageCode {
+	registry.m.RLock()
+	defer registry.m.RUnlock()
+
+	return registry.codes()
+}
+
+func (r *languageRegistry) factory(code core.LanguageCode) (LanguageFactory, bool) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	factory, ok := r.languages[code]
+	return factory, ok
+}
+
+// add registers the factory of a language if the index of extensions is of
+// the current version, it returns false if languages changed since the index
+// was built
+func (r *languageRegistry) add(meta core.LanguageMeta, factory LanguageFactory,
+	extensions map[string]core.LanguageCode, version int) (bool, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if _, ok := r.languages[meta.Code]; ok {
+		return false, fmt.Errorf("language already registered: %s", meta.Code)
+	}
+
+	if r.version != version {
+		return false, nil
+	}
+
+	for _, extension := range meta.SourceFileExtensions {
+		if code, ok := extensions[extension]; ok {
+			return false, fmt.Errorf("file extension %s of language %s is registered by language %s",
+				extension, meta.Code, code)
+		}
+	}
+
+	r.languages[meta.Code] = factory
+	r.extensions = nil
+	r.version++
+
+	return true, nil
+}
+
+// languageForExtension returns the code of the language a file extension
+// belongs to, eg. python for .py
+func (r *languageRegistry) languageForExtension(extension string) (core.LanguageCode, bool) {
+	extensions, _, err := r.extensionIndex()
+	if err != nil {
+		return "", false
+	}
+
+	code, ok := extensions[extension]
+	return code, ok
+}
+
+// extensionIndex returns the languages by file extension along with the
+// version of the languages it was built from, building the index if required.
+// Factories are called without holding the lock such that they may resolve
+// other languages. Extensions claimed by more than a language are indexed by
+// the first language in order
+func (r *languageRegistry) extensionIndex() (map[string]core.LanguageCode, int, error) {
+	r.m.RLock()
+	extensions, version := r.extensions, r.version
+	r.m.RUnlock()
+
+	if extensions != nil {
+		return extensions, version, nil
+	}
+
+	extensions = make(map[string]core.LanguageCode)
+	for _, factory := range r.factories() {
+		language, err := factory()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to create language: %w", err)
+		}
+
+		meta := language.Meta()
+		for _, extension := range meta.SourceFileExtensions {
+			if _, ok := extensions[extension]; !ok {
+				extensions[extension] = meta.Code
+			}
+		}
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if r.version == version {
+		r.extensions = extensions
+	}
+
+	return extensions, version, nil
+}
+
+// factories returns the factories of the registered languages ordered by
+// language code, such that they are called without holding the lock
+func (r *languageRegistry) factories() []LanguageFactory {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	codes := r.codes()
+
+	factories := make([]LanguageFactory, 0, len(codes))
+	for _, code := range codes {
+		factories = append(factories, r.languages[code])
+	}
+
+	return factories
+}
+
+// codes returns the registered language codes in order, the caller must
+// hold the lock
+func (r *languageRegistry) codes() []core.LanguageCode {
+	codes := make([]core.LanguageCode, 0, len(r.languages))
+	for code := range r.languages {
+		codes = append(codes, code)
+	}
+
+	slices.Sort(codes)
+	return codes
+}
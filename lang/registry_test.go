@@ -0,0 +1,192 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/safedep/code/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/toml"
+	"github.com/stretchr/testify/assert"
+)
+
+type toyLanguage struct {
+	code       core.LanguageCode
+	extensions []string
+}
+
+var _ core.Language = (*toyLanguage)(nil)
+
+func (l *toyLanguage) Name() string {
+	return string(l.code)
+}
+
+func (l *toyLanguage) Meta() core.LanguageMeta {
+	return core.LanguageMeta{
+		Name:                 string(l.code),
+		Code:                 l.code,
+		SourceFileExtensions: l.extensions,
+	}
+}
+
+func (l *toyLanguage) Language() *sitter.Language {
+	return toml.GetLanguage()
+}
+
+func (l *toyLanguage) Resolvers() core.LanguageResolvers {
+	return nil
+}
+
+func toyLanguageFactory(code core.LanguageCode, extensions ...string) LanguageFactory {
+	return func() (core.Language, error) {
+		return &toyLanguage{code: code, extensions: extensions}, nil
+	}
+}
+
+func TestLanguageRegistry(t *testing.T) {
+	t.Run("should register a language", func(t *testing.T) {
+		err := RegisterLanguage(toyLanguageFactory("toml", ".toml"))
+		assert.NoError(t, err)
+
+		t.Cleanup(func() {
+			assert.NoError(t, UnregisterLanguage("toml"))
+		})
+
+		assert.Contains(t, RegisteredLanguages(), core.LanguageCode("toml"))
+
+		l, err := GetLanguage("toml")
+		assert.NoError(t, err)
+		assert.Equal(t, core.LanguageCode("toml"), l.Meta().Code)
+
+		l, exists := ResolveLanguageFromPath("config/app.toml")
+		assert.True(t, exists)
+		assert.Equal(t, core.LanguageCode("toml"), l.Meta().Code)
+
+		langs, err := AllLanguages()
+		assert.NoError(t, err)
+		assert.Len(t, langs, len(builtinLanguages)+1)
+	})
+
+	t.Run("should not register a language code twice", func(t *testing.T) {
+		err := RegisterLanguage(toyLanguageFactory(core.LanguageCodePython, ".pyx"))
+		assert.ErrorContains(t, err, "language already registered: python")
+	})
+
+	t.Run("should not register a language with a registered extension", func(t *testing.T) {
+		err := RegisterLanguage(toyLanguageFactory("starlark", ".star", ".py"))
+		assert.ErrorContains(t, err, "file extension .py of language starlark is registered by language python")
+
+		_, err = GetLanguage("starlark")
+		assert.Error(t, err)
+	})
+
+	t.Run("should not register a language without code", func(t *testing.T) {
+		err := RegisterLanguage(toyLanguageFactory("", ".toy"))
+		assert.Error(t, err)
+
+		err = RegisterLanguage(nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("should replace a builtin language", func(t *testing.T) {
+		err := UnregisterLanguage(core.LanguageCodeBash)
+		assert.NoError(t, err)
+
+		assert.NotContains(t, RegisteredLanguages(), core.LanguageCodeBash)
+
+		_, exists := ResolveLanguageFromPath("install.sh")
+		assert.False(t, exists)
+
+		err = UnregisterLanguage(core.LanguageCodeBash)
+		assert.ErrorContains(t, err, "language not registered: bash")
+
+		err = RegisterLanguage(func() (core.Language, error) {
+			return NewBashLanguage()
+		})
+		assert.NoError(t, err)
+
+		l, exists := ResolveLanguageFromPath("install.sh")
+		assert.True(t, exists)
+		assert.Equal(t, core.LanguageCodeBash, l.Meta().Code)
+	})
+
+	t.Run("should create the language of the detected extension only", func(t *testing.T) {
+		calls := 0
+		factory := toyLanguageFactory("toml", ".toml")
+
+		err := RegisterLanguage(func() (core.Language, error) {
+			calls++
+			return factory()
+		})
+		assert.NoError(t, err)
+
+		t.Cleanup(func() {
+			assert.NoError(t, UnregisterLanguage("toml"))
+		})
+
+		// The index of extensions is rebuilt once after languages change
+		_, exists := ResolveLanguageFromPath("main.py")
+		assert.True(t, exists)
+
+		calls = 0
+		for range 3 {
+			_, exists := ResolveLanguageFromPath("main.py")
+			assert.True(t, exists)
+		}
+
+		assert.Equal(t, 0, calls)
+
+		_, exists = ResolveLanguageFromPath("config/app.toml")
+		assert.True(t, exists)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should resolve the extensions of a replaced language", func(t *testing.T) {
+		assert.NoError(t, RegisterLanguage(toyLanguageFactory("toml", ".toml")))
+
+		l, exists := ResolveLanguageFromPath("config/app.toml")
+		assert.True(t, exists)
+		assert.Equal(t, core.LanguageCode("toml"), l.Meta().Code)
+
+		assert.NoError(t, UnregisterLanguage("toml"))
+
+		_, exists = ResolveLanguageFromPath("config/app.toml")
+		assert.False(t, exists)
+
+		assert.NoError(t, RegisterLanguage(toyLanguageFactory("tomlv1", ".toml")))
+
+		t.Cleanup(func() {
+			assert.NoError(t, UnregisterLanguage("tomlv1"))
+		})
+
+		l, exists = ResolveLanguageFromPath("config/app.toml")
+		assert.True(t, exists)
+		assert.Equal(t, core.LanguageCode("tomlv1"), l.Meta().Code)
+	})
+
+	t.Run("should allow factories resolving other languages", func(t *testing.T) {
+		// Factories are called without holding the lock of the registry
+		factory := toyLanguageFactory("starlark", ".star")
+		err := RegisterLanguage(func() (core.Language, error) {
+			if _, err := GetLanguage(string(core.LanguageCodePython)); err != nil {
+				return nil, err
+			}
+
+			return factory()
+		})
+		assert.NoError(t, err)
+
+		t.Cleanup(func() {
+			assert.NoError(t, UnregisterLanguage("starlark"))
+		})
+
+		l, exists := ResolveLanguageFromPath("BUILD.star")
+		assert.True(t, exists)
+		assert.Equal(t, core.LanguageCode("starlark"), l.Meta().Code)
+	})
+
+	t.Run("should list registered languages in order", func(t *testing.T) {
+		codes := RegisteredLanguages()
+		assert.Len(t, codes, len(builtinLanguages))
+		assert.IsNonDecreasing(t, codes)
+	})
+}
@@ -0,0 +1,464 @@
+package lang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/core/ast"
+	"github.com/safedep/code/pkg/ts"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type rubyResolvers struct {
+	language *rubyLanguage
+}
+
+var _ core.LanguageResolvers = (*rubyResolvers)(nil)
+var _ core.ObjectOrientedLanguageResolvers = (*rubyResolvers)(nil)
+
+// Methods of Kernel loading other files, they are plain method calls in the grammar
+var rubyImportMethods = map[string]bool{
+	"require":          true,
+	"require_relative": true,
+	"load":             true,
+}
+
+// Methods of Module mixing in the methods of other modules
+var rubyMixinMethods = map[string]bool{
+	"include": true,
+	"extend":  true,
+	"prepend": true,
+}
+
+// Methods of Module defining attribute accessors
+var rubyAttributeMethods = map[string]bool{
+	"attr_accessor": true,
+	"attr_reader":   true,
+	"attr_writer":   true,
+}
+
+const rubyConstructorName = "initialize"
+
+const rubyImportQuery = `
+	(call
+		method: (identifier) @method
+		arguments: (argument_list . (string . (string_content) @module_name .)))
+`
+
+// ResolveImports resolves require, require_relative and load calls with a
+// literal path eg. `require 'net/http'`. Loading a file defines its constants
+// in the global scope, hence every import is a wildcard import. Paths of
+// require_relative are resolved as relative paths eg. `./helper`
+func (r *rubyResolvers) ResolveImports(tree core.ParseTree) ([]*ast.ImportNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var imports []*ast.ImportNode
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(rubyImportQuery, func(m *sitter.QueryMatch) error {
+			methodNode := m.Captures[0].Node
+			moduleNameNode := m.Captures[1].Node
+
+			method := methodNode.Content(*data)
+			if !rubyImportMethods[method] || methodNode.Parent().ChildByFieldName("receiver") != nil {
+				return nil
+			}
+
+			node := ast.NewImportNode(data)
+			node.SetModuleNameNode(moduleNameNode)
+			node.SetIsWildcardImport(true)
+
+			modulePath := moduleNameNode.Content(*data)
+			if method == "require_relative" && !strings.HasPrefix(modulePath, ".") {
+				node.SetModuleName("./" + modulePath)
+			}
+
+			imports = append(imports, node)
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+const rubyFunctionQuery = `
+	(method) @function
+	(singleton_method) @function
+`
+
+// ResolveFunctions extracts methods from Ruby parse tree. Methods defined at
+// the top level are private methods of Object and are reported as functions.
+// Singleton methods eg. `def self.create` and methods of `class << self` are
+// reported as static methods
+func (r *rubyResolvers) ResolveFunctions(tree core.ParseTree) ([]*ast.FunctionDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var functions []*ast.FunctionDeclarationNode
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(rubyFunctionQuery, func(m *sitter.QueryMatch) error {
+			if functionNode := r.newFunctionNode(data, m.Captures[0].Node); functionNode != nil {
+				functions = append(functions, functionNode)
+			}
+
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract Ruby functions: %w", err)
+	}
+
+	return functions, nil
+}
+
+func (r *rubyResolvers) newFunctionNode(data *[]byte, node *sitter.Node) *ast.FunctionDeclarationNode {
+	nameNode := node.ChildByFieldName("name")
+	if nameNode == nil {
+		return nil
+	}
+
+	functionNode := ast.NewFunctionDeclarationNode(data)
+	functionNode.SetFunctionNameNode(nameNode)
+
+	if paramsNode := node.ChildByFieldName("parameters"); paramsNode != nil {
+		functionNode.SetFunctionParameterNodes(r.extractParameterNodes(paramsNode))
+	}
+
+	if bodyNode := node.ChildByFieldName("body"); bodyNode != nil {
+		functionNode.SetFunctionBodyNode(bodyNode)
+	}
+
+	ownerNode, isSingleton := rubyOwnerNode(node)
+	if ownerNode == nil {
+		functionNode.SetFunctionType(ast.FunctionTypeFunction)
+		functionNode.SetAccessModifier(ast.AccessModifierPrivate)
+		return functionNode
+	}
+
+	functionNode.SetParentClassName(rubyDefinitionName(ownerNode, *data))
+	functionNode.SetAccessModifier(rubyAccessModifier(node, *data))
+
+	switch {
+	case node.Type() == "singleton_method" || isSingleton:
+		functionNode.SetFunctionType(ast.FunctionTypeStaticMethod)
+		functionNode.SetIsStatic(true)
+	case nameNode.Content(*data) == rubyConstructorName:
+		// initialize is private, instances are created by the public `new`
+		functionNode.SetFunctionType(ast.FunctionTypeConstructor)
+		functionNode.SetAccessModifier(ast.AccessModifierPublic)
+	default:
+		functionNode.SetFunctionType(ast.FunctionTypeMethod)
+	}
+
+	return functionNode
+}
+
+func (r *rubyResolvers) extractParameterNodes(parametersNode *sitter.Node) []*sitter.Node {
+	var paramNodes []*sitter.Node
+	for i := 0; i < int(parametersNode.NamedChildCount()); i++ {
+		child := parametersNode.NamedChild(i)
+		if child.Type() != "comment" {
+			paramNodes = append(paramNodes, child)
+		}
+	}
+
+	return paramNodes
+}
+
+const rubyClassQuery = `
+	(class) @class
+	(module) @class
+`
+
+// ResolveClasses extracts classes and modules from Ruby parse tree. Modules
+// are reported as abstract classes. Base classes are the superclass along with
+// the modules mixed in using include, extend and prepend. Fields are the
+// attribute accessors and the instance and class variables assigned in the body
+func (r *rubyResolvers) ResolveClasses(tree core.ParseTree) ([]*ast.ClassDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var classes []*ast.ClassDeclarationNode
+
+	err = r.visitClasses(data, tree, func(classNode *sitter.Node) {
+		nameNode := classNode.ChildByFieldName("name")
+		if nameNode == nil {
+			return
+		}
+
+		classDeclaration := ast.NewClassDeclarationNode(ast.ToContent(*data))
+		classDeclaration.SetClassNameNode(nameNode)
+		classDeclaration.SetAccessModifier(ast.AccessModifierPublic)
+		classDeclaration.SetIsAbstract(classNode.Type() == "module")
+
+		if superclassNode := rubySuperclassNode(classNode); superclassNode != nil {
+			classDeclaration.AddBaseClassNode(superclassNode)
+		}
+
+		bodyNode := classNode.ChildByFieldName("body")
+		if bodyNode == nil {
+			classes = append(classes, classDeclaration)
+			return
+		}
+
+		r.visitMixins(data, bodyNode, func(_ string, moduleNode *sitter.Node) {
+			classDeclaration.AddBaseClassNode(moduleNode)
+		})
+
+		r.addMembers(data, classDeclaration, bodyNode)
+		r.addFields(data, classDeclaration, bodyNode, make(map[string]bool))
+
+		classes = append(classes, classDeclaration)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract class definitions: %w", err)
+	}
+
+	return classes, nil
+}
+
+// addMembers adds the methods defined in a class body, including the methods
+// of `class << self` and of visibility calls eg. `private def helper`
+func (r *rubyResolvers) addMembers(data *[]byte, classDeclaration *ast.ClassDeclarationNode, bodyNode *sitter.Node) {
+	for i := 0; i < int(bodyNode.NamedChildCount()); i++ {
+		member := bodyNode.NamedChild(i)
+		switch member.Type() {
+		case "method":
+			nameNode := member.ChildByFieldName("name")
+			if nameNode != nil && nameNode.Content(*data) == rubyConstructorName {
+				classDeclaration.SetConstructorNode(member)
+			} else {
+				classDeclaration.AddMethodNode(member)
+			}
+		case "singleton_method":
+			classDeclaration.AddMethodNode(member)
+		case "singleton_class":
+			if singletonBodyNode := member.ChildByFieldName("body"); singletonBodyNode != nil {
+				r.addMembers(data, classDeclaration, singletonBodyNode)
+			}
+		case "call":
+			if argumentsNode := member.ChildByFieldName("arguments"); argumentsNode != nil {
+				r.addMembers(data, classDeclaration, argumentsNode)
+			}
+		}
+	}
+}
+
+// addFields adds the attribute accessors and the instance and class variables
+// assigned anywhere in a class body, nested classes and modules excluded
+func (r *rubyResolvers) addFields(data *[]byte, classDeclaration *ast.ClassDeclarationNode,
+	node *sitter.Node, seen map[string]bool) {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		switch child.Type() {
+		case "class", "module":
+			continue
+		case "assignment", "operator_assignment":
+			leftNode := child.ChildByFieldName("left")
+			if leftNode != nil && (leftNode.Type() == "instance_variable" || leftNode.Type() == "class_variable") {
+				name := leftNode.Content(*data)
+				if !seen[name] {
+					seen[name] = true
+					classDeclaration.AddFieldNode(leftNode)
+				}
+			}
+		case "call":
+			methodNode := child.ChildByFieldName("method")
+			argumentsNode := child.ChildByFieldName("arguments")
+			if child.ChildByFieldName("receiver") == nil && methodNode != nil && argumentsNode != nil &&
+				rubyAttributeMethods[methodNode.Content(*data)] {
+				for j := 0; j < int(argumentsNode.NamedChildCount()); j++ {
+					symbolNode := argumentsNode.NamedChild(j)
+					name := "@" + strings.TrimPrefix(symbolNode.Content(*data), ":")
+					if symbolNode.Type() == "simple_symbol" && !seen[name] {
+						seen[name] = true
+						classDeclaration.AddFieldNode(symbolNode)
+					}
+				}
+
+				continue
+			}
+		}
+
+		r.addFields(data, classDeclaration, child, seen)
+	}
+}
+
+// ResolveInheritance builds inheritance graph from Ruby classes and modules.
+// Classes inherit their superclass and modules are mixed in using include,
+// extend and prepend
+func (r *rubyResolvers) ResolveInheritance(tree core.ParseTree) (*ast.InheritanceGraph, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	filename := ""
+	if file, err := tree.File(); err == nil && file != nil {
+		filename = file.Name()
+	}
+
+	inheritanceGraph := ast.NewInheritanceGraph()
+
+	err = r.visitClasses(data, tree, func(classNode *sitter.Node) {
+		nameNode := classNode.ChildByFieldName("name")
+		if nameNode == nil {
+			return
+		}
+
+		className := nameNode.Content(*data)
+		if superclassNode := rubySuperclassNode(classNode); superclassNode != nil {
+			inheritanceGraph.AddRelationship(className, superclassNode.Content(*data),
+				ast.RelationshipTypeInherits, filename, nameNode.StartPoint().Row+1)
+		}
+
+		if bodyNode := classNode.ChildByFieldName("body"); bodyNode != nil {
+			r.visitMixins(data, bodyNode, func(_ string, moduleNode *sitter.Node) {
+				inheritanceGraph.AddRelationship(className, moduleNode.Content(*data),
+					ast.RelationshipTypeMixin, filename, moduleNode.StartPoint().Row+1)
+			})
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute inheritance queries: %w", err)
+	}
+
+	return inheritanceGraph, nil
+}
+
+func (r *rubyResolvers) visitClasses(data *[]byte, tree core.ParseTree, visitor func(classNode *sitter.Node)) error {
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(rubyClassQuery, func(m *sitter.QueryMatch) error {
+			visitor(m.Captures[0].Node)
+			return nil
+		}),
+	}
+
+	return ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+}
+
+// visitMixins calls the visitor with every module mixed in by the
+// statements of a class body eg. `include Comparable`
+func (r *rubyResolvers) visitMixins(data *[]byte, bodyNode *sitter.Node, visitor func(method string, moduleNode *sitter.Node)) {
+	for i := 0; i < int(bodyNode.NamedChildCount()); i++ {
+		callNode := bodyNode.NamedChild(i)
+		if callNode.Type() != "call" || callNode.ChildByFieldName("receiver") != nil {
+			continue
+		}
+
+		methodNode := callNode.ChildByFieldName("method")
+		argumentsNode := callNode.ChildByFieldName("arguments")
+		if methodNode == nil || argumentsNode == nil || !rubyMixinMethods[methodNode.Content(*data)] {
+			continue
+		}
+
+		for j := 0; j < int(argumentsNode.NamedChildCount()); j++ {
+			moduleNode := argumentsNode.NamedChild(j)
+			if moduleNode.Type() == "constant" || moduleNode.Type() == "scope_resolution" {
+				visitor(methodNode.Content(*data), moduleNode)
+			}
+		}
+	}
+}
+
+// rubySuperclassNode returns the superclass of a class. For superclasses
+// created by a call eg. `Struct.new(:x, :y)` the receiver is returned
+func rubySuperclassNode(classNode *sitter.Node) *sitter.Node {
+	superclassNode := classNode.ChildByFieldName("superclass")
+	if superclassNode == nil || superclassNode.NamedChildCount() == 0 {
+		return nil
+	}
+
+	expressionNode := superclassNode.NamedChild(0)
+	for expressionNode != nil && expressionNode.Type() == "call" {
+		expressionNode = expressionNode.ChildByFieldName("receiver")
+	}
+
+	if expressionNode == nil || (expressionNode.Type() != "constant" && expressionNode.Type() != "scope_resolution") {
+		return nil
+	}
+
+	return expressionNode
+}
+
+// rubyOwnerNode returns the class or module defining a method, if any, and
+// whether the method is defined in a singleton class eg. `class << self`
+func rubyOwnerNode(node *sitter.Node) (*sitter.Node, bool) {
+	isSingleton := false
+	for current := node.Parent(); current != nil; current = current.Parent() {
+		switch current.Type() {
+		case "class", "module":
+			return current, isSingleton
+		case "singleton_class":
+			isSingleton = true
+		}
+	}
+
+	return nil, false
+}
+
+// rubyDefinitionName returns the name of a class or module as written
+// in its definition eg. `Admin::User`
+func rubyDefinitionName(node *sitter.Node, data []byte) string {
+	if nameNode := node.ChildByFieldName("name"); nameNode != nil {
+		return nameNode.Content(data)
+	}
+
+	return ""
+}
+
+// rubyAccessModifier resolves the visibility of a method. Methods are public
+// unless defined within a visibility call eg. `private def helper` or after
+// a bare visibility call in the same body eg. `private`
+func rubyAccessModifier(node *sitter.Node, data []byte) ast.AccessModifier {
+	if argumentsNode := node.Parent(); argumentsNode != nil && argumentsNode.Type() == "argument_list" {
+		if callNode := argumentsNode.Parent(); callNode != nil && callNode.Type() == "call" {
+			if methodNode := callNode.ChildByFieldName("method"); methodNode != nil {
+				if modifier, ok := rubyVisibility(methodNode.Content(data)); ok {
+					return modifier
+				}
+			}
+		}
+	}
+
+	for sibling := node.PrevNamedSibling(); sibling != nil; sibling = sibling.PrevNamedSibling() {
+		if sibling.Type() != "identifier" {
+			continue
+		}
+
+		if modifier, ok := rubyVisibility(sibling.Content(data)); ok {
+			return modifier
+		}
+	}
+
+	return ast.AccessModifierPublic
+}
+
+func rubyVisibility(method string) (ast.AccessModifier, bool) {
+	switch method {
+	case "private":
+		return ast.AccessModifierPrivate, true
+	case "protected":
+		return ast.AccessModifierProtected, true
+	case "public":
+		return ast.AccessModifierPublic, true
+	}
+
+	return "", false
+}
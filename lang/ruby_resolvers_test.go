@@ -0,0 +1,184 @@
+package lang_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/fs"
+	"github.com/safedep/code/lang"
+	"github.com/safedep/code/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+var rubyImportExpectations = []ImportExpectations{
+	{
+		filePath: "fixtures/imports.rb",
+		imports: []string{
+			"ImportNode{ModuleName: json, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: net/http, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: active_support/core_ext/string, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: ./helpers/formatter, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: ../lib/version, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: tasks/setup.rb, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: sidekiq, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+		},
+	},
+}
+
+var rubyFunctionExpectations = map[string][]string{
+	"fixtures/functions.rb": {
+		"FunctionDeclarationNode{Name: top_level_function, Type: function, Access: private, ParentClass: }",
+		"FunctionDeclarationNode{Name: format_name, Type: method, Access: public, ParentClass: Formatting}",
+		"FunctionDeclarationNode{Name: default_format, Type: static_method, Access: public, ParentClass: Formatting}",
+		"FunctionDeclarationNode{Name: initialize, Type: constructor, Access: public, ParentClass: Account}",
+		"FunctionDeclarationNode{Name: open, Type: static_method, Access: public, ParentClass: Account}",
+		"FunctionDeclarationNode{Name: registry, Type: static_method, Access: public, ParentClass: Account}",
+		"FunctionDeclarationNode{Name: balance, Type: method, Access: public, ParentClass: Account}",
+		"FunctionDeclarationNode{Name: ledger, Type: method, Access: protected, ParentClass: Account}",
+		"FunctionDeclarationNode{Name: audit, Type: method, Access: private, ParentClass: Account}",
+		"FunctionDeclarationNode{Name: summary, Type: method, Access: public, ParentClass: Account}",
+	},
+}
+
+func parseRubyFixtures(t *testing.T, filePaths []string, visitor func(core.ParseTree, core.File)) {
+	t.Helper()
+
+	rubyLanguage, err := lang.NewRubyLanguage()
+	assert.NoError(t, err)
+
+	fileParser, err := parser.NewParser([]core.Language{rubyLanguage})
+	assert.NoError(t, err)
+
+	fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+		AppDirectories: filePaths,
+	})
+	assert.NoError(t, err)
+
+	err = fileSystem.EnumerateApp(context.Background(), func(f core.File) error {
+		parseTree, err := fileParser.Parse(context.Background(), f)
+		assert.NoError(t, err)
+
+		visitor(parseTree, f)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestRubyLanguageResolvers(t *testing.T) {
+	rubyLanguage, err := lang.NewRubyLanguage()
+	assert.NoError(t, err)
+
+	t.Run("ResolversExists", func(t *testing.T) {
+		resolvers := rubyLanguage.Resolvers()
+		assert.NotNil(t, resolvers)
+
+		_, ok := resolvers.(core.ObjectOrientedLanguageResolvers)
+		assert.True(t, ok)
+	})
+
+	t.Run("ResolveImports", func(t *testing.T) {
+		importExpectationsMapper := make(map[string][]string)
+		importFilePaths := []string{}
+		for _, ie := range rubyImportExpectations {
+			importFilePaths = append(importFilePaths, ie.filePath)
+			importExpectationsMapper[ie.filePath] = ie.imports
+		}
+
+		parseRubyFixtures(t, importFilePaths, func(parseTree core.ParseTree, f core.File) {
+			imports, err := rubyLanguage.Resolvers().ResolveImports(parseTree)
+			assert.NoError(t, err)
+
+			expectedImports, ok := importExpectationsMapper[f.Name()]
+			assert.True(t, ok)
+
+			assert.Equal(t, len(expectedImports), len(imports))
+			for i, expectedImport := range expectedImports {
+				assert.Equal(t, expectedImport, imports[i].String())
+			}
+		})
+	})
+
+	t.Run("ResolveFunctions", func(t *testing.T) {
+		var filePaths []string
+		for path := range rubyFunctionExpectations {
+			filePaths = append(filePaths, path)
+		}
+
+		parseRubyFixtures(t, filePaths, func(parseTree core.ParseTree, f core.File) {
+			functions, err := rubyLanguage.Resolvers().ResolveFunctions(parseTree)
+			assert.NoError(t, err)
+
+			expectedFunctions, ok := rubyFunctionExpectations[f.Name()]
+			assert.True(t, ok)
+
+			var foundFunctions []string
+			for _, fun := range functions {
+				foundFunctions = append(foundFunctions,
+					fmt.Sprintf("FunctionDeclarationNode{Name: %s, Type: %s, Access: %s, ParentClass: %s}",
+						fun.FunctionName(), fun.GetFunctionType(), fun.GetAccessModifier(), fun.GetParentClassName()))
+
+				if fun.FunctionName() == "top_level_function" {
+					assert.Equal(t, []string{"a", "b = 1", "*rest", "key:", "**opts", "&block"}, fun.Parameters())
+				}
+			}
+
+			assert.ElementsMatch(t, expectedFunctions, foundFunctions)
+		})
+	})
+
+	t.Run("ResolveClasses", func(t *testing.T) {
+		parseRubyFixtures(t, []string{"fixtures/ruby_class_hierarchy.rb"}, func(parseTree core.ParseTree, _ core.File) {
+			resolvers := rubyLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			classes, err := resolvers.ResolveClasses(parseTree)
+			assert.NoError(t, err)
+
+			found := map[string]string{}
+			for _, class := range classes {
+				found[class.ClassName()] = fmt.Sprintf("bases=%v methods=%d fields=%v constructor=%t abstract=%t",
+					class.BaseClasses(), len(class.Methods()), class.Fields(),
+					class.Constructor() != "", class.IsAbstract())
+			}
+
+			assert.Equal(t, map[string]string{
+				"Comparable2":  "bases=[] methods=0 fields=[] constructor=false abstract=true",
+				"Admin":        "bases=[] methods=0 fields=[] constructor=false abstract=true",
+				"Auditable":    "bases=[] methods=1 fields=[] constructor=false abstract=true",
+				"User":         "bases=[ApplicationRecord Auditable Comparable2 Forwardable Admin::Logging] methods=2 fields=[:name :email :role @@count @created_at] constructor=true abstract=false",
+				"Point":        "bases=[Struct] methods=1 fields=[] constructor=false abstract=false",
+				"Admin::Guest": "bases=[Admin::User] methods=0 fields=[] constructor=false abstract=false",
+			}, found)
+		})
+	})
+
+	t.Run("ResolveInheritance", func(t *testing.T) {
+		parseRubyFixtures(t, []string{"fixtures/ruby_class_hierarchy.rb"}, func(parseTree core.ParseTree, _ core.File) {
+			resolvers := rubyLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			graph, err := resolvers.ResolveInheritance(parseTree)
+			assert.NoError(t, err)
+
+			var relationships []string
+			for _, class := range graph.GetAllClasses() {
+				for _, rel := range graph.GetDirectParents(class) {
+					relationships = append(relationships, fmt.Sprintf("%s %s %s", rel.ChildClassName,
+						rel.RelationshipType, rel.ParentClassName))
+				}
+			}
+
+			assert.ElementsMatch(t, []string{
+				"User inherits ApplicationRecord",
+				"User mixin Auditable",
+				"User mixin Comparable2",
+				"User mixin Forwardable",
+				"User mixin Admin::Logging",
+				"Point inherits Struct",
+				"Admin::Guest inherits Admin::User",
+			}, relationships)
+
+			assert.True(t, graph.IsAncestor("ApplicationRecord", "User"))
+		})
+	})
+}
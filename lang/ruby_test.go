@@ -0,0 +1,25 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRubyLanguageMeta(t *testing.T) {
+	t.Run("Name", func(t *testing.T) {
+		l := &rubyLanguage{}
+		assert.Equal(t, rubyLanguageName, l.Name())
+	})
+
+	t.Run("Code", func(t *testing.T) {
+		l := &rubyLanguage{}
+		assert.Equal(t, core.LanguageCodeRuby, l.Meta().Code)
+	})
+
+	t.Run("ObjectOriented", func(t *testing.T) {
+		l := &rubyLanguage{}
+		assert.True(t, l.Meta().ObjectOriented)
+	})
+}
@@ -0,0 +1,42 @@
+package lang
+
+import (
+	"github.com/safedep/code/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/rust"
+)
+
+const rustLanguageName = "rust"
+
+type rustLanguage struct{}
+
+var _ core.Language = (*rustLanguage)(nil)
+
+func NewRustLanguage() (*rustLanguage, error) {
+	return &rustLanguage{}, nil
+}
+
+func (l *rustLanguage) Name() string {
+	return rustLanguageName
+}
+
+// Rust has no classes, structs, enums and traits are reported as classes
+// with traits being the inheritance relation
+func (l *rustLanguage) Meta() core.LanguageMeta {
+	return core.LanguageMeta{
+		Name:                 rustLanguageName,
+		Code:                 core.LanguageCodeRust,
+		ObjectOriented:       true,
+		SourceFileExtensions: []string{".rs"},
+	}
+}
+
+func (l *rustLanguage) Language() *sitter.Language {
+	return rust.GetLanguage()
+}
+
+func (l *rustLanguage) Resolvers() core.LanguageResolvers {
+	return &rustResolvers{
+		language: l,
+	}
+}
@@ -0,0 +1,559 @@
+package lang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/core/ast"
+	"github.com/safedep/code/pkg/ts"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type rustResolvers struct {
+	language *rustLanguage
+}
+
+var _ core.LanguageResolvers = (*rustResolvers)(nil)
+var _ core.ObjectOrientedLanguageResolvers = (*rustResolvers)(nil)
+
+const rustPathSeparator = "::"
+
+const rustImportQuery = `
+	(use_declaration
+		argument: (_) @use_clause)
+
+	(extern_crate_declaration
+		name: (identifier) @crate_name
+		alias: (identifier)? @crate_alias)
+`
+
+// ResolveImports resolves use declarations and extern crates. Use trees are
+// flattened into one import per imported item
+// eg. `use std::{collections::HashMap, io::{self, Read as R}}` is resolved as
+// std::collections -> HashMap, std -> io and std::io -> Read as R
+func (r *rustResolvers) ResolveImports(tree core.ParseTree) ([]*ast.ImportNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var imports []*ast.ImportNode
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(rustImportQuery, func(m *sitter.QueryMatch) error {
+			// Patterns are matched in the order of the query
+			if m.PatternIndex == 0 {
+				r.visitUseClause(m.Captures[0].Node, nil, func(path []*sitter.Node, aliasNode *sitter.Node, isWildcard bool) {
+					if node := r.newImportNode(data, path, aliasNode, isWildcard); node != nil {
+						imports = append(imports, node)
+					}
+				})
+
+				return nil
+			}
+
+			// extern crate serde_json as json;
+			node := ast.NewImportNode(data)
+			node.SetModuleNameNode(m.Captures[0].Node)
+			if len(m.Captures) > 1 {
+				node.SetModuleAliasNode(m.Captures[1].Node)
+			}
+
+			imports = append(imports, node)
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+// visitUseClause walks a use tree calling the visitor with the path of every
+// imported item along with its alias, if any. Glob imports are visited with
+// the path of the module whose items are imported
+func (r *rustResolvers) visitUseClause(node *sitter.Node, prefix []*sitter.Node,
+	visitor func(path []*sitter.Node, aliasNode *sitter.Node, isWildcard bool)) {
+	switch node.Type() {
+	case "scoped_use_list":
+		path := prefix
+		if pathNode := node.ChildByFieldName("path"); pathNode != nil {
+			path = rustPathSegments(pathNode, prefix)
+		}
+
+		if listNode := node.ChildByFieldName("list"); listNode != nil {
+			r.visitUseClause(listNode, path, visitor)
+		}
+	case "use_list":
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			r.visitUseClause(node.NamedChild(i), prefix, visitor)
+		}
+	case "use_as_clause":
+		if pathNode := node.ChildByFieldName("path"); pathNode != nil {
+			visitor(rustPathSegments(pathNode, prefix), node.ChildByFieldName("alias"), false)
+		}
+	case "use_wildcard":
+		path := prefix
+		if node.NamedChildCount() > 0 {
+			path = rustPathSegments(node.NamedChild(0), prefix)
+		}
+
+		visitor(path, nil, true)
+	case "identifier", "scoped_identifier", "crate", "self", "super", "metavariable":
+		visitor(rustPathSegments(node, prefix), nil, false)
+	}
+}
+
+// rustPathSegments appends the segments of a path eg. `std::io::Read` to the prefix
+func rustPathSegments(node *sitter.Node, prefix []*sitter.Node) []*sitter.Node {
+	segments := append([]*sitter.Node{}, prefix...)
+	if node.Type() != "scoped_identifier" {
+		return append(segments, node)
+	}
+
+	if pathNode := node.ChildByFieldName("path"); pathNode != nil {
+		segments = rustPathSegments(pathNode, segments)
+	}
+
+	if nameNode := node.ChildByFieldName("name"); nameNode != nil {
+		segments = append(segments, nameNode)
+	}
+
+	return segments
+}
+
+// newImportNode creates the import of the last segment of the path from the
+// module made of the preceding segments. Glob imports and imports of traits
+// with `as _` are wildcard imports of the entire path
+func (r *rustResolvers) newImportNode(data *[]byte, path []*sitter.Node,
+	aliasNode *sitter.Node, isWildcard bool) *ast.ImportNode {
+	// `use std::io::{self}` imports io from std
+	if len(path) > 0 && path[len(path)-1].Type() == "self" && !isWildcard {
+		path = path[:len(path)-1]
+	}
+
+	if len(path) == 0 {
+		return nil
+	}
+
+	if aliasNode != nil && aliasNode.Content(*data) == "_" {
+		isWildcard = true
+		aliasNode = nil
+	}
+
+	node := ast.NewImportNode(data)
+	node.SetIsWildcardImport(isWildcard)
+	node.SetModuleAliasNode(aliasNode)
+
+	modulePath := path
+	if !isWildcard && len(path) > 1 {
+		modulePath = path[:len(path)-1]
+		node.SetModuleItemNode(path[len(path)-1])
+	}
+
+	node.SetModuleNameNode(modulePath[len(modulePath)-1])
+	if len(modulePath) > 1 {
+		segments := make([]string, 0, len(modulePath))
+		for _, segment := range modulePath {
+			segments = append(segments, segment.Content(*data))
+		}
+
+		node.SetModuleName(strings.Join(segments, rustPathSeparator))
+	}
+
+	return node
+}
+
+const rustFunctionQuery = `
+	(function_item) @function
+	(function_signature_item) @function
+`
+
+// ResolveFunctions extracts functions and methods from Rust parse tree. Functions
+// of impl blocks and traits are methods of the implementing type or the trait
+func (r *rustResolvers) ResolveFunctions(tree core.ParseTree) ([]*ast.FunctionDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var functions []*ast.FunctionDeclarationNode
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(rustFunctionQuery, func(m *sitter.QueryMatch) error {
+			if functionNode := r.newFunctionNode(data, m.Captures[0].Node); functionNode != nil {
+				functions = append(functions, functionNode)
+			}
+
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract Rust functions: %w", err)
+	}
+
+	return functions, nil
+}
+
+func (r *rustResolvers) newFunctionNode(data *[]byte, node *sitter.Node) *ast.FunctionDeclarationNode {
+	nameNode := node.ChildByFieldName("name")
+	if nameNode == nil {
+		return nil
+	}
+
+	functionNode := ast.NewFunctionDeclarationNode(data)
+	functionNode.SetFunctionNameNode(nameNode)
+	functionNode.SetFunctionType(ast.FunctionTypeFunction)
+	functionNode.SetAccessModifier(rustAccessModifier(node, *data))
+	functionNode.SetIsAbstract(node.Type() == "function_signature_item")
+
+	if paramsNode := node.ChildByFieldName("parameters"); paramsNode != nil {
+		functionNode.SetFunctionParameterNodes(r.extractParameterNodes(paramsNode))
+	}
+
+	if returnTypeNode := node.ChildByFieldName("return_type"); returnTypeNode != nil {
+		functionNode.SetFunctionReturnTypeNode(returnTypeNode)
+	}
+
+	if bodyNode := node.ChildByFieldName("body"); bodyNode != nil {
+		functionNode.SetFunctionBodyNode(bodyNode)
+	}
+
+	for _, attributeNode := range rustAttributeNodes(node) {
+		functionNode.AddDecoratorNode(attributeNode)
+	}
+
+	if ownerNode := rustOwnerItem(node); ownerNode != nil {
+		functionNode.SetParentClassName(rustOwnerTypeName(ownerNode, *data))
+
+		// Trait items and implementations of traits are as visible as the trait
+		if ownerNode.Type() == "trait_item" || ownerNode.ChildByFieldName("trait") != nil {
+			functionNode.SetAccessModifier(ast.AccessModifierPublic)
+		}
+
+		if rustHasSelfParameter(node) {
+			functionNode.SetFunctionType(ast.FunctionTypeMethod)
+		} else if nameNode.Content(*data) == rustConstructorName {
+			functionNode.SetFunctionType(ast.FunctionTypeConstructor)
+		} else {
+			functionNode.SetFunctionType(ast.FunctionTypeStaticMethod)
+			functionNode.SetIsStatic(true)
+		}
+	}
+
+	if modifiersNode := rustFunctionModifiers(node); modifiersNode != nil {
+		for i := 0; i < int(modifiersNode.ChildCount()); i++ {
+			if modifiersNode.Child(i).Type() == "async" {
+				functionNode.SetIsAsync(true)
+				if !functionNode.IsConstructor() {
+					functionNode.SetFunctionType(ast.FunctionTypeAsync)
+				}
+			}
+		}
+	}
+
+	return functionNode
+}
+
+// By convention, the associated function `new` constructs the type
+const rustConstructorName = "new"
+
+func (r *rustResolvers) extractParameterNodes(parametersNode *sitter.Node) []*sitter.Node {
+	var paramNodes []*sitter.Node
+	for i := 0; i < int(parametersNode.NamedChildCount()); i++ {
+		child := parametersNode.NamedChild(i)
+		switch child.Type() {
+		case "parameter", "variadic_parameter":
+			paramNodes = append(paramNodes, child)
+		}
+	}
+
+	return paramNodes
+}
+
+const rustItemQuery = `
+	(struct_item) @item
+	(enum_item) @item
+	(union_item) @item
+	(trait_item) @item
+	(impl_item) @item
+`
+
+// ResolveClasses extracts structs, enums, unions and traits from Rust parse tree.
+// Methods and implemented traits are collected from the impl blocks of the type
+// in the same file. Traits are reported as abstract classes
+func (r *rustResolvers) ResolveClasses(tree core.ParseTree) ([]*ast.ClassDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var classes []*ast.ClassDeclarationNode
+	var implNodes []*sitter.Node
+	classMap := make(map[string]*ast.ClassDeclarationNode)
+
+	err = r.visitItems(data, tree, func(itemNode *sitter.Node) {
+		if itemNode.Type() == "impl_item" {
+			implNodes = append(implNodes, itemNode)
+			return
+		}
+
+		nameNode := itemNode.ChildByFieldName("name")
+		if nameNode == nil {
+			return
+		}
+
+		classNode := ast.NewClassDeclarationNode(ast.ToContent(*data))
+		classNode.SetClassNameNode(nameNode)
+		classNode.SetAccessModifier(rustAccessModifier(itemNode, *data))
+		classNode.SetIsAbstract(itemNode.Type() == "trait_item")
+
+		for _, attributeNode := range rustAttributeNodes(itemNode) {
+			classNode.AddDecoratorNode(attributeNode)
+		}
+
+		// Supertraits eg. `trait Shape: Display + Debug`
+		r.visitTraitBounds(itemNode, func(traitNode *sitter.Node) {
+			classNode.AddBaseClassNode(traitNode)
+		})
+
+		if bodyNode := itemNode.ChildByFieldName("body"); bodyNode != nil {
+			r.addMembers(data, classNode, bodyNode)
+		}
+
+		classes = append(classes, classNode)
+		classMap[nameNode.Content(*data)] = classNode
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract class definitions: %w", err)
+	}
+
+	for _, implNode := range implNodes {
+		classNode, exists := classMap[rustOwnerTypeName(implNode, *data)]
+		if !exists {
+			continue
+		}
+
+		if traitNode := rustTypeNameNode(implNode.ChildByFieldName("trait")); traitNode != nil {
+			classNode.AddBaseClassNode(traitNode)
+		}
+
+		if bodyNode := implNode.ChildByFieldName("body"); bodyNode != nil {
+			r.addMembers(data, classNode, bodyNode)
+		}
+	}
+
+	return classes, nil
+}
+
+// addMembers adds the fields, enum variants and functions of an item body to the class
+func (r *rustResolvers) addMembers(data *[]byte, classNode *ast.ClassDeclarationNode, bodyNode *sitter.Node) {
+	for i := 0; i < int(bodyNode.NamedChildCount()); i++ {
+		member := bodyNode.NamedChild(i)
+		switch member.Type() {
+		case "field_declaration", "enum_variant":
+			classNode.AddFieldNode(member)
+		case "function_item", "function_signature_item":
+			nameNode := member.ChildByFieldName("name")
+			if nameNode != nil && nameNode.Content(*data) == rustConstructorName && !rustHasSelfParameter(member) {
+				classNode.SetConstructorNode(member)
+			} else {
+				classNode.AddMethodNode(member)
+			}
+		}
+	}
+}
+
+// ResolveInheritance builds inheritance graph from Rust traits. Types implement
+// traits through impl blocks and traits extend their supertraits
+func (r *rustResolvers) ResolveInheritance(tree core.ParseTree) (*ast.InheritanceGraph, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	filename := ""
+	if file, err := tree.File(); err == nil && file != nil {
+		filename = file.Name()
+	}
+
+	inheritanceGraph := ast.NewInheritanceGraph()
+
+	err = r.visitItems(data, tree, func(itemNode *sitter.Node) {
+		switch itemNode.Type() {
+		case "trait_item":
+			nameNode := itemNode.ChildByFieldName("name")
+			if nameNode == nil {
+				return
+			}
+
+			r.visitTraitBounds(itemNode, func(traitNode *sitter.Node) {
+				inheritanceGraph.AddRelationship(nameNode.Content(*data), traitNode.Content(*data),
+					ast.RelationshipTypeExtends, filename, nameNode.StartPoint().Row+1)
+			})
+		case "impl_item":
+			traitNode := rustTypeNameNode(itemNode.ChildByFieldName("trait"))
+			typeName := rustOwnerTypeName(itemNode, *data)
+			if traitNode == nil || typeName == "" {
+				return
+			}
+
+			inheritanceGraph.AddRelationship(typeName, traitNode.Content(*data),
+				ast.RelationshipTypeImplements, filename, itemNode.StartPoint().Row+1)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute inheritance queries: %w", err)
+	}
+
+	return inheritanceGraph, nil
+}
+
+func (r *rustResolvers) visitItems(data *[]byte, tree core.ParseTree, visitor func(itemNode *sitter.Node)) error {
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(rustItemQuery, func(m *sitter.QueryMatch) error {
+			visitor(m.Captures[0].Node)
+			return nil
+		}),
+	}
+
+	return ts.ExecuteQueries(ts.NewQueriesRequest(r.language, queryRequestItems), data, tree)
+}
+
+// visitTraitBounds calls the visitor with the name node of every supertrait of a trait
+func (r *rustResolvers) visitTraitBounds(itemNode *sitter.Node, visitor func(traitNode *sitter.Node)) {
+	if itemNode.Type() != "trait_item" {
+		return
+	}
+
+	boundsNode := itemNode.ChildByFieldName("bounds")
+	if boundsNode == nil {
+		return
+	}
+
+	for i := 0; i < int(boundsNode.NamedChildCount()); i++ {
+		if traitNode := rustTypeNameNode(boundsNode.NamedChild(i)); traitNode != nil {
+			visitor(traitNode)
+		}
+	}
+}
+
+// rustTypeNameNode returns the node naming a type, stripping type
+// arguments of generic types eg. `Repository<User>`
+func rustTypeNameNode(node *sitter.Node) *sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	switch node.Type() {
+	case "type_identifier", "scoped_type_identifier":
+		return node
+	case "generic_type":
+		return rustTypeNameNode(node.ChildByFieldName("type"))
+	case "reference_type", "pointer_type":
+		return rustTypeNameNode(node.ChildByFieldName("type"))
+	}
+
+	return nil
+}
+
+// rustOwnerItem returns the impl block or trait defining a function, if any
+func rustOwnerItem(node *sitter.Node) *sitter.Node {
+	for current := node.Parent(); current != nil; current = current.Parent() {
+		switch current.Type() {
+		case "impl_item", "trait_item":
+			return current
+		case "function_item", "source_file", "mod_item":
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// rustOwnerTypeName returns the name of the trait or of the type of an impl block
+func rustOwnerTypeName(ownerNode *sitter.Node, data []byte) string {
+	if ownerNode.Type() == "trait_item" {
+		if nameNode := ownerNode.ChildByFieldName("name"); nameNode != nil {
+			return nameNode.Content(data)
+		}
+
+		return ""
+	}
+
+	if typeNode := rustTypeNameNode(ownerNode.ChildByFieldName("type")); typeNode != nil {
+		return typeNode.Content(data)
+	}
+
+	return ""
+}
+
+func rustHasSelfParameter(functionNode *sitter.Node) bool {
+	paramsNode := functionNode.ChildByFieldName("parameters")
+	if paramsNode == nil {
+		return false
+	}
+
+	for i := 0; i < int(paramsNode.NamedChildCount()); i++ {
+		if paramsNode.NamedChild(i).Type() == "self_parameter" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func rustFunctionModifiers(functionNode *sitter.Node) *sitter.Node {
+	for i := 0; i < int(functionNode.NamedChildCount()); i++ {
+		if child := functionNode.NamedChild(i); child.Type() == "function_modifiers" {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// rustAccessModifier maps the visibility of an item. Items are private by
+// default, `pub` items are public and restricted visibility eg. `pub(crate)`
+// is reported as package access
+func rustAccessModifier(node *sitter.Node, data []byte) ast.AccessModifier {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if child.Type() != "visibility_modifier" {
+			continue
+		}
+
+		if child.Content(data) == "pub" {
+			return ast.AccessModifierPublic
+		}
+
+		return ast.AccessModifierPackage
+	}
+
+	return ast.AccessModifierPrivate
+}
+
+// rustAttributeNodes returns the outer attributes of an item eg. `#[derive(Debug)]`
+// Attributes precede the item as siblings in the tree
+func rustAttributeNodes(node *sitter.Node) []*sitter.Node {
+	var attributes []*sitter.Node
+	for sibling := node.PrevNamedSibling(); sibling != nil; sibling = sibling.PrevNamedSibling() {
+		if sibling.Type() == "line_comment" || sibling.Type() == "block_comment" {
+			continue
+		}
+
+		if sibling.Type() != "attribute_item" {
+			break
+		}
+
+		attributes = append([]*sitter.Node{sibling}, attributes...)
+	}
+
+	return attributes
+}
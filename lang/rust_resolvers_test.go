@@ -0,0 +1,193 @@
+package lang_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/fs"
+	"github.com/safedep/code/lang"
+	"github.com/safedep/code/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+var rustImportExpectations = []ImportExpectations{
+	{
+		filePath: "fixtures/imports.rs",
+		imports: []string{
+			"ImportNode{ModuleName: serde_json, ModuleItem: , ModuleAlias: json, WildcardImport: false}",
+			"ImportNode{ModuleName: log, ModuleItem: , ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: std::collections, ModuleItem: HashMap, ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: std::collections::hash_map, ModuleItem: Entry, ModuleAlias: MapEntry, WildcardImport: false}",
+			"ImportNode{ModuleName: std, ModuleItem: io, ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: std::io, ModuleItem: Read, ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: std::fmt, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: reqwest, ModuleItem: , ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: tokio::sync, ModuleItem: Mutex, ModuleAlias: AsyncMutex, WildcardImport: false}",
+			"ImportNode{ModuleName: crate::config, ModuleItem: Settings, ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: super, ModuleItem: utils, ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: self::models::user, ModuleItem: User, ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: self::models::user, ModuleItem: Role, ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: self::models, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: async_trait::async_trait, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+			"ImportNode{ModuleName: anyhow, ModuleItem: Result, ModuleAlias: , WildcardImport: false}",
+		},
+	},
+}
+
+var rustFunctionExpectations = map[string][]string{
+	"fixtures/functions.rs": {
+		"FunctionDeclarationNode{Name: public_function, Type: function, Access: public, ParentClass: }",
+		"FunctionDeclarationNode{Name: private_function, Type: function, Access: private, ParentClass: }",
+		"FunctionDeclarationNode{Name: fetch, Type: async, Access: package, ParentClass: }",
+		"FunctionDeclarationNode{Name: test_function, Type: function, Access: private, ParentClass: }",
+		"FunctionDeclarationNode{Name: nested_helper, Type: function, Access: private, ParentClass: }",
+		"FunctionDeclarationNode{Name: new, Type: constructor, Access: public, ParentClass: Counter}",
+		"FunctionDeclarationNode{Name: increment, Type: method, Access: public, ParentClass: Counter}",
+		"FunctionDeclarationNode{Name: reset, Type: method, Access: private, ParentClass: Counter}",
+		"FunctionDeclarationNode{Name: with_count, Type: static_method, Access: public, ParentClass: Counter}",
+		"FunctionDeclarationNode{Name: fmt, Type: method, Access: public, ParentClass: Counter}",
+		"FunctionDeclarationNode{Name: reset_all, Type: method, Access: public, ParentClass: Resettable}",
+		"FunctionDeclarationNode{Name: describe, Type: method, Access: public, ParentClass: Resettable}",
+	},
+}
+
+func parseRustFixtures(t *testing.T, filePaths []string, visitor func(core.ParseTree, core.File)) {
+	t.Helper()
+
+	rustLanguage, err := lang.NewRustLanguage()
+	assert.NoError(t, err)
+
+	fileParser, err := parser.NewParser([]core.Language{rustLanguage})
+	assert.NoError(t, err)
+
+	fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+		AppDirectories: filePaths,
+	})
+	assert.NoError(t, err)
+
+	err = fileSystem.EnumerateApp(context.Background(), func(f core.File) error {
+		parseTree, err := fileParser.Parse(context.Background(), f)
+		assert.NoError(t, err)
+
+		visitor(parseTree, f)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestRustLanguageResolvers(t *testing.T) {
+	rustLanguage, err := lang.NewRustLanguage()
+	assert.NoError(t, err)
+
+	t.Run("ResolversExists", func(t *testing.T) {
+		resolvers := rustLanguage.Resolvers()
+		assert.NotNil(t, resolvers)
+
+		_, ok := resolvers.(core.ObjectOrientedLanguageResolvers)
+		assert.True(t, ok)
+	})
+
+	t.Run("ResolveImports", func(t *testing.T) {
+		importExpectationsMapper := make(map[string][]string)
+		importFilePaths := []string{}
+		for _, ie := range rustImportExpectations {
+			importFilePaths = append(importFilePaths, ie.filePath)
+			importExpectationsMapper[ie.filePath] = ie.imports
+		}
+
+		parseRustFixtures(t, importFilePaths, func(parseTree core.ParseTree, f core.File) {
+			imports, err := rustLanguage.Resolvers().ResolveImports(parseTree)
+			assert.NoError(t, err)
+
+			expectedImports, ok := importExpectationsMapper[f.Name()]
+			assert.True(t, ok)
+
+			assert.Equal(t, len(expectedImports), len(imports))
+			for i, expectedImport := range expectedImports {
+				assert.Equal(t, expectedImport, imports[i].String())
+			}
+		})
+	})
+
+	t.Run("ResolveFunctions", func(t *testing.T) {
+		var filePaths []string
+		for path := range rustFunctionExpectations {
+			filePaths = append(filePaths, path)
+		}
+
+		parseRustFixtures(t, filePaths, func(parseTree core.ParseTree, f core.File) {
+			functions, err := rustLanguage.Resolvers().ResolveFunctions(parseTree)
+			assert.NoError(t, err)
+
+			expectedFunctions, ok := rustFunctionExpectations[f.Name()]
+			assert.True(t, ok)
+
+			var foundFunctions []string
+			for _, fun := range functions {
+				foundFunctions = append(foundFunctions,
+					fmt.Sprintf("FunctionDeclarationNode{Name: %s, Type: %s, Access: %s, ParentClass: %s}",
+						fun.FunctionName(), fun.GetFunctionType(), fun.GetAccessModifier(), fun.GetParentClassName()))
+
+				if fun.FunctionName() == "test_function" {
+					assert.Equal(t, []string{"#[test]"}, fun.Decorators())
+				}
+			}
+
+			assert.ElementsMatch(t, expectedFunctions, foundFunctions)
+		})
+	})
+
+	t.Run("ResolveClasses", func(t *testing.T) {
+		parseRustFixtures(t, []string{"fixtures/rust_class_hierarchy.rs"}, func(parseTree core.ParseTree, _ core.File) {
+			resolvers := rustLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			classes, err := resolvers.ResolveClasses(parseTree)
+			assert.NoError(t, err)
+
+			found := map[string]string{}
+			for _, class := range classes {
+				found[class.ClassName()] = fmt.Sprintf("bases=%v methods=%d fields=%d constructor=%t abstract=%t decorators=%d access=%s",
+					class.BaseClasses(), len(class.Methods()), len(class.Fields()),
+					class.Constructor() != "", class.IsAbstract(), len(class.Decorators()), class.AccessModifier())
+			}
+
+			assert.Equal(t, map[string]string{
+				"Shape": "bases=[Debug] methods=1 fields=0 constructor=false abstract=true decorators=0 access=public",
+				"Solid": "bases=[Shape Display] methods=1 fields=0 constructor=false abstract=true decorators=0 access=public",
+				"Cube":  "bases=[Shape Solid fmt::Display] methods=3 fields=1 constructor=true abstract=false decorators=1 access=public",
+				"Unit":  "bases=[] methods=0 fields=2 constructor=false abstract=false decorators=0 access=private",
+			}, found)
+		})
+	})
+
+	t.Run("ResolveInheritance", func(t *testing.T) {
+		parseRustFixtures(t, []string{"fixtures/rust_class_hierarchy.rs"}, func(parseTree core.ParseTree, _ core.File) {
+			resolvers := rustLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			graph, err := resolvers.ResolveInheritance(parseTree)
+			assert.NoError(t, err)
+
+			var relationships []string
+			for _, class := range graph.GetAllClasses() {
+				for _, rel := range graph.GetDirectParents(class) {
+					relationships = append(relationships, fmt.Sprintf("%s %s %s", rel.ChildClassName,
+						rel.RelationshipType, rel.ParentClassName))
+				}
+			}
+
+			assert.ElementsMatch(t, []string{
+				"Shape extends Debug",
+				"Solid extends Shape",
+				"Solid extends Display",
+				"Cube implements Shape",
+				"Cube implements Solid",
+				"Cube implements fmt::Display",
+				"Vec implements Shape",
+			}, relationships)
+
+			assert.True(t, graph.IsAncestor("Debug", "Cube"))
+		})
+	})
+}
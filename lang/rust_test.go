@@ -0,0 +1,25 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRustLanguageMeta(t *testing.T) {
+	t.Run("Name", func(t *testing.T) {
+		l := &rustLanguage{}
+		assert.Equal(t, rustLanguageName, l.Name())
+	})
+
+	t.Run("Code", func(t *testing.T) {
+		l := &rustLanguage{}
+		assert.Equal(t, core.LanguageCodeRust, l.Meta().Code)
+	})
+
+	t.Run("ObjectOriented", func(t *testing.T) {
+		l := &rustLanguage{}
+		assert.True(t, l.Meta().ObjectOriented)
+	})
+}
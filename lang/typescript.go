@@ -0,0 +1,57 @@
+package lang
+
+import (
+	"github.com/safedep/code/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+)
+
+const typescriptLanguageName = "typescript"
+
+const tsxFileExtension = ".tsx"
+
+type typescriptLanguage struct {
+	// TSX files use a separate dialect of the grammar which supports
+	// JSX elements but not angle bracket type assertions
+	tsx bool
+}
+
+var _ core.Language = (*typescriptLanguage)(nil)
+
+func NewTypescriptLanguage() (*typescriptLanguage, error) {
+	return &typescriptLanguage{}, nil
+}
+
+func (l *typescriptLanguage) Name() string {
+	return typescriptLanguageName
+}
+
+func (l *typescriptLanguage) Meta() core.LanguageMeta {
+	return core.LanguageMeta{
+		Name:                 typescriptLanguageName,
+		Code:                 core.LanguageCodeTypescript,
+		ObjectOriented:       true,
+		SourceFileExtensions: []string{".ts", ".mts", ".cts", tsxFileExtension},
+	}
+}
+
+func (l *typescriptLanguage) Language() *sitter.Language {
+	if l.tsx {
+		return tsx.GetLanguage()
+	}
+
+	return typescript.GetLanguage()
+}
+
+func (l *typescriptLanguage) Resolvers() core.LanguageResolvers {
+	return &typescriptResolvers{
+		language: l,
+	}
+}
+
+// dialectForExtension returns the language instance whose grammar
+// must be used to parse files with the given extension
+func (l *typescriptLanguage) dialectForExtension(extension string) core.Language {
+	return &typescriptLanguage{tsx: extension == tsxFileExtension}
+}
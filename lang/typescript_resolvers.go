@@ -0,0 +1,522 @@
+package lang
+
+import (
+	"fmt"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/core/ast"
+	"github.com/safedep/code/pkg/ts"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type typescriptResolvers struct {
+	language *typescriptLanguage
+}
+
+var _ core.LanguageResolvers = (*typescriptResolvers)(nil)
+var _ core.ObjectOrientedLanguageResolvers = (*typescriptResolvers)(nil)
+var _ core.DynamicImportLanguageResolvers = (*typescriptResolvers)(nil)
+
+const tsWholeModuleImportQuery = `
+	(import_statement
+		(import_clause
+			(identifier) @module_alias)
+		source: (string (string_fragment) @module_name))
+
+	(import_statement
+		(import_clause
+			(namespace_import (identifier) @module_alias))
+		source: (string (string_fragment) @module_name))
+
+	; import xyz = require('xyz')
+	(import_statement
+		(import_require_clause
+			(identifier) @module_alias
+			source: (string (string_fragment) @module_name)))
+
+	; const xyz = await import('xyz')
+	(lexical_declaration
+		(variable_declarator
+			name: (identifier) @module_alias
+			value: (await_expression
+				(call_expression
+					function: (import)
+					arguments: (arguments (string (string_fragment) @module_name))))))
+`
+
+const tsSpecifiedItemImportQuery = `
+	(import_statement
+		(import_clause
+			(named_imports
+				(import_specifier
+					name: (identifier) @module_item
+					alias: (identifier)? @module_alias)))
+		source: (string (string_fragment) @module_name))
+`
+
+// Matches every import statement with a source, imports having an
+// import clause are filtered out while handling the match
+const tsSideEffectImportQuery = `
+	(import_statement
+		source: (string (string_fragment) @module_name)) @import_statement
+`
+
+func (r *typescriptResolvers) ResolveImports(tree core.ParseTree) ([]*ast.ImportNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var imports []*ast.ImportNode
+
+	stringConstants := jsStringConstants(tree.Tree().RootNode(), *data)
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(tsWholeModuleImportQuery, func(m *sitter.QueryMatch) error {
+			node := ast.NewImportNode(data)
+			node.SetModuleAliasNode(m.Captures[0].Node)
+			node.SetModuleNameNode(m.Captures[1].Node)
+			node.SetIsTypeOnlyImport(tsIsTypeOnlyImport(m.Captures[0].Node))
+			imports = append(imports, node)
+			return nil
+		}),
+		ts.NewQueryItem(tsSpecifiedItemImportQuery, func(m *sitter.QueryMatch) error {
+			node := ast.NewImportNode(data)
+			alreadyEncounteredIdentifier := false
+			for _, capture := range m.Captures {
+				if capture.Node.Type() == "string_fragment" {
+					node.SetModuleNameNode(capture.Node)
+				} else if capture.Node.Type() == "identifier" {
+					if alreadyEncounteredIdentifier {
+						node.SetModuleAliasNode(capture.Node)
+					} else {
+						node.SetModuleItemNode(capture.Node)
+						node.SetModuleAliasNode(capture.Node)
+						node.SetIsTypeOnlyImport(tsIsTypeOnlyImport(capture.Node))
+						alreadyEncounteredIdentifier = true
+					}
+				}
+			}
+			imports = append(imports, node)
+			return nil
+		}),
+		ts.NewQueryItem(tsSideEffectImportQuery, func(m *sitter.QueryMatch) error {
+			var statementNode, moduleNameNode *sitter.Node
+			for _, capture := range m.Captures {
+				switch capture.Node.Type() {
+				case "import_statement":
+					statementNode = capture.Node
+				case "string_fragment":
+					moduleNameNode = capture.Node
+				}
+			}
+
+			if statementNode == nil || moduleNameNode == nil {
+				return nil
+			}
+
+			for i := 0; i < int(statementNode.NamedChildCount()); i++ {
+				if statementNode.NamedChild(i).Type() == "import_clause" {
+					return nil
+				}
+			}
+
+			// import './polyfills' only runs the module for its side effects
+			node := ast.NewImportNode(data)
+			node.SetModuleNameNode(moduleNameNode)
+			imports = append(imports, node)
+			return nil
+		}),
+		ts.NewQueryItem(jsRequireModuleQuery, func(m *sitter.QueryMatch) error {
+			if node := newRequireImportNode(data, m); node != nil {
+				imports = append(imports, node)
+			}
+
+			return nil
+		}),
+		ts.NewQueryItem(jsDynamicImportQuery, func(m *sitter.QueryMatch) error {
+			nodes, _ := newJsDynamicImportNodes(data, m.Captures[0].Node, stringConstants)
+			imports = append(imports, nodes...)
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.queryLanguage(tree), queryRequestItems), data, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, err
+}
+
+// ResolveUnresolvableImports resolves `require()` and `import()` calls having
+// a module name computed at runtime eg. `await import(pluginPath)`
+func (r *typescriptResolvers) ResolveUnresolvableImports(tree core.ParseTree) ([]*ast.UnresolvableImportNode, error) {
+	return resolveJsUnresolvableImports(r.queryLanguage(tree), tree)
+}
+
+// tsIsTypeOnlyImport checks whether the import containing the node is
+// a type only import ie. `import type X from 'x'` or `import { type X } from 'x'`
+func tsIsTypeOnlyImport(node *sitter.Node) bool {
+	for current := node.Parent(); current != nil; current = current.Parent() {
+		switch current.Type() {
+		case "import_specifier", "import_statement":
+			for i := 0; i < int(current.ChildCount()); i++ {
+				child := current.Child(i)
+				if !child.IsNamed() && child.Type() == "type" {
+					return true
+				}
+			}
+
+			if current.Type() == "import_statement" {
+				return false
+			}
+		}
+	}
+
+	return false
+}
+
+const tsFunctionQuery = `
+	(function_declaration) @function
+	(generator_function_declaration) @function
+
+	(variable_declarator
+		name: (identifier)
+		value: [(arrow_function) (function_expression)]) @function
+
+	(method_definition) @function
+	(abstract_method_signature) @function
+`
+
+// ResolveFunctions extracts function declarations from TypeScript parse tree
+func (r *typescriptResolvers) ResolveFunctions(tree core.ParseTree) ([]*ast.FunctionDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var functions []*ast.FunctionDeclarationNode
+
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(tsFunctionQuery, func(m *sitter.QueryMatch) error {
+			if len(m.Captures) == 0 {
+				return nil
+			}
+
+			functionNode := r.newFunctionNode(data, m.Captures[0].Node)
+			if functionNode != nil {
+				functions = append(functions, functionNode)
+			}
+
+			return nil
+		}),
+	}
+
+	err = ts.ExecuteQueries(ts.NewQueriesRequest(r.queryLanguage(tree), queryRequestItems), data, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract TypeScript functions: %w", err)
+	}
+
+	return functions, nil
+}
+
+// ResolveClasses extracts class and interface declarations from TypeScript parse tree.
+// Interfaces and abstract classes are reported as abstract classes
+func (r *typescriptResolvers) ResolveClasses(tree core.ParseTree) ([]*ast.ClassDeclarationNode, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	var classes []*ast.ClassDeclarationNode
+
+	err = r.visitClassDeclarations(data, tree, func(declarationNode, nameNode *sitter.Node) {
+		classNode := ast.NewClassDeclarationNode(ast.ToContent(*data))
+		classNode.SetClassNameNode(nameNode)
+		classNode.SetAccessModifier(ast.AccessModifierPublic)
+		classNode.SetIsAbstract(declarationNode.Type() != "class_declaration")
+
+		r.visitHeritage(declarationNode, func(parentNode *sitter.Node, _ ast.RelationshipType) {
+			classNode.AddBaseClassNode(parentNode)
+		})
+
+		for i := 0; i < int(declarationNode.NamedChildCount()); i++ {
+			child := declarationNode.NamedChild(i)
+			if child.Type() == "decorator" {
+				classNode.AddDecoratorNode(child)
+			}
+		}
+
+		bodyNode := declarationNode.ChildByFieldName("body")
+		if bodyNode == nil {
+			classes = append(classes, classNode)
+			return
+		}
+
+		for i := 0; i < int(bodyNode.NamedChildCount()); i++ {
+			member := bodyNode.NamedChild(i)
+			switch member.Type() {
+			case "method_definition":
+				nameNode := member.ChildByFieldName("name")
+				if nameNode != nil && nameNode.Content(*data) == "constructor" {
+					classNode.SetConstructorNode(member)
+				} else {
+					classNode.AddMethodNode(member)
+				}
+			case "abstract_method_signature", "method_signature":
+				classNode.AddMethodNode(member)
+			case "public_field_definition", "property_signature":
+				classNode.AddFieldNode(member)
+			}
+		}
+
+		classes = append(classes, classNode)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract class definitions: %w", err)
+	}
+
+	return classes, nil
+}
+
+// ResolveInheritance builds inheritance graph from TypeScript classes and interfaces
+func (r *typescriptResolvers) ResolveInheritance(tree core.ParseTree) (*ast.InheritanceGraph, error) {
+	data, err := tree.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data from parse tree: %w", err)
+	}
+
+	filename := ""
+	if file, err := tree.File(); err == nil && file != nil {
+		filename = file.Name()
+	}
+
+	inheritanceGraph := ast.NewInheritanceGraph()
+
+	err = r.visitClassDeclarations(data, tree, func(declarationNode, nameNode *sitter.Node) {
+		className := nameNode.Content(*data)
+		r.visitHeritage(declarationNode, func(parentNode *sitter.Node, relType ast.RelationshipType) {
+			inheritanceGraph.AddRelationship(className, parentNode.Content(*data), relType,
+				filename, nameNode.StartPoint().Row+1)
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute inheritance queries: %w", err)
+	}
+
+	return inheritanceGraph, nil
+}
+
+const tsClassDefinitionQuery = `
+	(class_declaration
+		name: (type_identifier)) @class
+
+	(abstract_class_declaration
+		name: (type_identifier)) @class
+
+	(interface_declaration
+		name: (type_identifier)) @class
+`
+
+func (r *typescriptResolvers) visitClassDeclarations(data *[]byte, tree core.ParseTree,
+	visitor func(declarationNode, nameNode *sitter.Node)) error {
+	queryRequestItems := []ts.QueryItem{
+		ts.NewQueryItem(tsClassDefinitionQuery, func(m *sitter.QueryMatch) error {
+			if len(m.Captures) == 0 {
+				return nil
+			}
+
+			declarationNode := m.Captures[0].Node
+			nameNode := declarationNode.ChildByFieldName("name")
+			if nameNode == nil {
+				return nil
+			}
+
+			visitor(declarationNode, nameNode)
+			return nil
+		}),
+	}
+
+	return ts.ExecuteQueries(ts.NewQueriesRequest(r.queryLanguage(tree), queryRequestItems), data, tree)
+}
+
+// visitHeritage calls the visitor with the name node of every parent type of a class
+// or interface declaration. Classes extend classes and implement interfaces while
+// interfaces can only extend other interfaces
+func (r *typescriptResolvers) visitHeritage(declarationNode *sitter.Node,
+	visitor func(parentNode *sitter.Node, relType ast.RelationshipType)) {
+	for i := 0; i < int(declarationNode.NamedChildCount()); i++ {
+		child := declarationNode.NamedChild(i)
+		switch child.Type() {
+		case "class_heritage":
+			for j := 0; j < int(child.NamedChildCount()); j++ {
+				clause := child.NamedChild(j)
+				switch clause.Type() {
+				case "extends_clause":
+					for k := 0; k < int(clause.NamedChildCount()); k++ {
+						value := clause.NamedChild(k)
+						if value.Type() == "identifier" || value.Type() == "member_expression" {
+							visitor(value, ast.RelationshipTypeExtends)
+						}
+					}
+				case "implements_clause":
+					for k := 0; k < int(clause.NamedChildCount()); k++ {
+						if typeNode := tsTypeNameNode(clause.NamedChild(k)); typeNode != nil {
+							visitor(typeNode, ast.RelationshipTypeImplements)
+						}
+					}
+				}
+			}
+		case "extends_type_clause":
+			for j := 0; j < int(child.NamedChildCount()); j++ {
+				if typeNode := tsTypeNameNode(child.NamedChild(j)); typeNode != nil {
+					visitor(typeNode, ast.RelationshipTypeExtends)
+				}
+			}
+		}
+	}
+}
+
+// tsTypeNameNode returns the node naming a type reference, stripping
+// type arguments of generic types eg. `Repository<User>`
+func tsTypeNameNode(node *sitter.Node) *sitter.Node {
+	switch node.Type() {
+	case "type_identifier", "nested_type_identifier":
+		return node
+	case "generic_type":
+		return node.ChildByFieldName("name")
+	}
+
+	return nil
+}
+
+func (r *typescriptResolvers) newFunctionNode(data *[]byte, node *sitter.Node) *ast.FunctionDeclarationNode {
+	functionNode := ast.NewFunctionDeclarationNode(data)
+	functionNode.SetAccessModifier(ast.AccessModifierPublic)
+
+	switch node.Type() {
+	case "function_declaration", "generator_function_declaration":
+		functionNode.SetFunctionType(ast.FunctionTypeFunction)
+		r.setFunctionParts(functionNode, node, node.ChildByFieldName("name"))
+	case "variable_declarator":
+		valueNode := node.ChildByFieldName("value")
+		if valueNode.Type() == "arrow_function" {
+			functionNode.SetFunctionType(ast.FunctionTypeArrow)
+		} else {
+			functionNode.SetFunctionType(ast.FunctionTypeFunction)
+		}
+
+		r.setFunctionParts(functionNode, valueNode, node.ChildByFieldName("name"))
+	case "method_definition", "abstract_method_signature":
+		nameNode := node.ChildByFieldName("name")
+		if nameNode == nil {
+			return nil
+		}
+
+		if nameNode.Content(*data) == "constructor" {
+			functionNode.SetFunctionType(ast.FunctionTypeConstructor)
+		} else {
+			functionNode.SetFunctionType(ast.FunctionTypeMethod)
+		}
+
+		r.setFunctionParts(functionNode, node, nameNode)
+		functionNode.SetParentClassName(r.findParentClassName(node, *data))
+		functionNode.SetIsAbstract(node.Type() == "abstract_method_signature")
+
+		for i := 0; i < int(node.ChildCount()); i++ {
+			child := node.Child(i)
+			switch child.Type() {
+			case "accessibility_modifier":
+				functionNode.SetAccessModifier(ast.AccessModifier(child.Content(*data)))
+			case "static":
+				functionNode.SetIsStatic(true)
+			case "decorator":
+				functionNode.AddDecoratorNode(child)
+			}
+		}
+	default:
+		return nil
+	}
+
+	if functionNode.GetFunctionNameNode() == nil {
+		return nil
+	}
+
+	return functionNode
+}
+
+// setFunctionParts sets the name, parameters, return type and body of a function
+// node. Async functions are reported with the async function type except for constructors
+func (r *typescriptResolvers) setFunctionParts(functionNode *ast.FunctionDeclarationNode,
+	node *sitter.Node, nameNode *sitter.Node) {
+	if nameNode == nil {
+		return
+	}
+
+	functionNode.SetFunctionNameNode(nameNode)
+
+	if paramsNode := node.ChildByFieldName("parameters"); paramsNode != nil {
+		functionNode.SetFunctionParameterNodes(r.extractParameterNodes(paramsNode))
+	} else if paramNode := node.ChildByFieldName("parameter"); paramNode != nil {
+		// Single parameter arrow function without parentheses
+		functionNode.AddFunctionParameterNode(paramNode)
+	}
+
+	if returnTypeNode := node.ChildByFieldName("return_type"); returnTypeNode != nil {
+		functionNode.SetFunctionReturnTypeNode(returnTypeNode)
+	}
+
+	if bodyNode := node.ChildByFieldName("body"); bodyNode != nil {
+		functionNode.SetFunctionBodyNode(bodyNode)
+	}
+
+	for i := 0; i < int(node.ChildCount()); i++ {
+		if node.Child(i).Type() == "async" {
+			functionNode.SetIsAsync(true)
+			if !functionNode.IsConstructor() {
+				functionNode.SetFunctionType(ast.FunctionTypeAsync)
+			}
+		}
+	}
+}
+
+func (r *typescriptResolvers) extractParameterNodes(parametersNode *sitter.Node) []*sitter.Node {
+	var paramNodes []*sitter.Node
+	for i := 0; i < int(parametersNode.NamedChildCount()); i++ {
+		child := parametersNode.NamedChild(i)
+		switch child.Type() {
+		case "required_parameter", "optional_parameter":
+			paramNodes = append(paramNodes, child)
+		}
+	}
+
+	return paramNodes
+}
+
+func (r *typescriptResolvers) findParentClassName(node *sitter.Node, data []byte) string {
+	for current := node.Parent(); current != nil; current = current.Parent() {
+		switch current.Type() {
+		case "class_declaration", "abstract_class_declaration", "class":
+			if nameNode := current.ChildByFieldName("name"); nameNode != nil {
+				return nameNode.Content(data)
+			}
+
+			return ""
+		}
+	}
+
+	return ""
+}
+
+// queryLanguage returns the dialect the tree was parsed with. Queries
+// must be compiled for the same grammar as the tree they are executed on
+func (r *typescriptResolvers) queryLanguage(tree core.ParseTree) core.Language {
+	if l, err := tree.Language(); err == nil {
+		if tsLanguage, ok := l.(*typescriptLanguage); ok {
+			return tsLanguage
+		}
+	}
+
+	return r.language
+}
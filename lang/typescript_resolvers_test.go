@@ -0,0 +1,278 @@
+package lang_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/fs"
+	"github.com/safedep/code/lang"
+	"github.com/safedep/code/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+var typescriptImportExpectations = []ImportExpectations{
+	{
+		filePath: "fixtures/dynamic_imports.ts",
+		imports: []string{
+			"ImportNode{ModuleName: ./plugins/auth, ModuleItem: , ModuleAlias: auth, WildcardImport: false}",
+			"ImportNode{ModuleName: ./plugins/session, ModuleItem: session, ModuleAlias: session, WildcardImport: false}",
+			"ImportNode{ModuleName: ./plugins/session, ModuleItem: store, ModuleAlias: sessionStore, WildcardImport: false}",
+			"ImportNode{ModuleName: ./plugins/metrics.js, ModuleItem: , ModuleAlias: metrics, WildcardImport: false}",
+			"ImportNode{ModuleName: ./polyfills, ModuleItem: , ModuleAlias: , WildcardImport: true}",
+		},
+	},
+	{
+		filePath: "fixtures/imports.ts",
+		imports: []string{
+			"ImportNode{ModuleName: express, ModuleItem: , ModuleAlias: express, WildcardImport: false}",
+			"ImportNode{ModuleName: path, ModuleItem: , ModuleAlias: path, WildcardImport: false}",
+			"ImportNode{ModuleName: express, ModuleItem: Request, ModuleAlias: Request, WildcardImport: false}",
+			"ImportNode{ModuleName: express, ModuleItem: Response, ModuleAlias: ExpressResponse, WildcardImport: false}",
+			"ImportNode{ModuleName: ./config, ModuleItem: Config, ModuleAlias: Config, WildcardImport: false}",
+			"ImportNode{ModuleName: ./logger, ModuleItem: Logger, ModuleAlias: Logger, WildcardImport: false}",
+			"ImportNode{ModuleName: ./logger, ModuleItem: createLogger, ModuleAlias: createLogger, WildcardImport: false}",
+			"ImportNode{ModuleName: fs, ModuleItem: , ModuleAlias: fs, WildcardImport: false}",
+			"ImportNode{ModuleName: ./polyfills, ModuleItem: , ModuleAlias: , WildcardImport: false}",
+			"ImportNode{ModuleName: @acme/widgets, ModuleItem: , ModuleAlias: Default, WildcardImport: false}",
+			"ImportNode{ModuleName: @acme/widgets, ModuleItem: named, ModuleAlias: named, WildcardImport: false}",
+			"ImportNode{ModuleName: lodash, ModuleItem: , ModuleAlias: lodash, WildcardImport: false}",
+			"ImportNode{ModuleName: uuid, ModuleItem: v4, ModuleAlias: v4, WildcardImport: false}",
+			"ImportNode{ModuleName: ./dynamic-module, ModuleItem: , ModuleAlias: dynamic, WildcardImport: false}",
+		},
+	},
+	{
+		filePath: "fixtures/component.tsx",
+		imports: []string{
+			"ImportNode{ModuleName: react, ModuleItem: , ModuleAlias: React, WildcardImport: false}",
+			"ImportNode{ModuleName: react, ModuleItem: useState, ModuleAlias: useState, WildcardImport: false}",
+			"ImportNode{ModuleName: ./button, ModuleItem: ButtonProps, ModuleAlias: ButtonProps, WildcardImport: false}",
+		},
+	},
+}
+
+var typescriptTypeOnlyImports = map[string][]string{
+	"fixtures/imports.ts":    {"Config", "Logger"},
+	"fixtures/component.tsx": {"ButtonProps"},
+}
+
+var typescriptUnresolvableImportExpectations = []string{
+	"UnresolvableImportNode{Specifier: process.env.PLUGIN as string, SpecifierPattern: *, Line: 11}",
+	"UnresolvableImportNode{Specifier: `./locale/${navigator.language}.js`, SpecifierPattern: ./locale/*.js, Line: 12}",
+	"UnresolvableImportNode{Specifier: name, SpecifierPattern: *, Line: 15}",
+	"UnresolvableImportNode{Specifier: PLUGINS + '/index.js', SpecifierPattern: */index.js, Line: 20}",
+	"UnresolvableImportNode{Specifier: ['child', 'process'].join('_'), SpecifierPattern: *, Line: 23}",
+}
+
+var typescriptFunctionExpectations = map[string][]string{
+	"fixtures/functions.ts": {
+		"FunctionDeclarationNode{Name: declaredFunction, Type: function, Access: public, ParentClass: }",
+		"FunctionDeclarationNode{Name: asyncFunction, Type: async, Access: public, ParentClass: }",
+		"FunctionDeclarationNode{Name: arrowFunction, Type: arrow, Access: public, ParentClass: }",
+		"FunctionDeclarationNode{Name: functionExpression, Type: function, Access: public, ParentClass: }",
+		"FunctionDeclarationNode{Name: overloaded, Type: function, Access: public, ParentClass: }",
+		"FunctionDeclarationNode{Name: constructor, Type: constructor, Access: public, ParentClass: Shape}",
+		"FunctionDeclarationNode{Name: area, Type: method, Access: public, ParentClass: Shape}",
+		"FunctionDeclarationNode{Name: describe, Type: method, Access: public, ParentClass: Shape}",
+		"FunctionDeclarationNode{Name: constructor, Type: constructor, Access: public, ParentClass: Circle}",
+		"FunctionDeclarationNode{Name: area, Type: method, Access: public, ParentClass: Circle}",
+		"FunctionDeclarationNode{Name: unit, Type: method, Access: private, ParentClass: Circle}",
+		"FunctionDeclarationNode{Name: refresh, Type: async, Access: protected, ParentClass: Circle}",
+	},
+	"fixtures/component.tsx": {
+		"FunctionDeclarationNode{Name: Counter, Type: arrow, Access: public, ParentClass: }",
+		"FunctionDeclarationNode{Name: render, Type: method, Access: public, ParentClass: Panel}",
+	},
+}
+
+func parseTypescriptFixtures(t *testing.T, filePaths []string, visitor func(core.ParseTree, core.File)) {
+	t.Helper()
+
+	typescriptLanguage, err := lang.NewTypescriptLanguage()
+	assert.NoError(t, err)
+
+	fileParser, err := parser.NewParser([]core.Language{typescriptLanguage})
+	assert.NoError(t, err)
+
+	fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+		AppDirectories: filePaths,
+	})
+	assert.NoError(t, err)
+
+	err = fileSystem.EnumerateApp(context.Background(), func(f core.File) error {
+		parseTree, err := fileParser.Parse(context.Background(), f)
+		assert.NoError(t, err)
+
+		visitor(parseTree, f)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestTypescriptLanguageResolvers(t *testing.T) {
+	typescriptLanguage, err := lang.NewTypescriptLanguage()
+	assert.NoError(t, err)
+
+	t.Run("ResolversExists", func(t *testing.T) {
+		resolvers := typescriptLanguage.Resolvers()
+		assert.NotNil(t, resolvers)
+
+		_, ok := resolvers.(core.ObjectOrientedLanguageResolvers)
+		assert.True(t, ok)
+	})
+
+	t.Run("ResolveImports", func(t *testing.T) {
+		importExpectationsMapper := make(map[string][]string)
+		importFilePaths := []string{}
+		for _, ie := range typescriptImportExpectations {
+			importFilePaths = append(importFilePaths, ie.filePath)
+			importExpectationsMapper[ie.filePath] = ie.imports
+		}
+
+		parseTypescriptFixtures(t, importFilePaths, func(parseTree core.ParseTree, f core.File) {
+			imports, err := typescriptLanguage.Resolvers().ResolveImports(parseTree)
+			assert.NoError(t, err)
+
+			expectedImports, ok := importExpectationsMapper[f.Name()]
+			assert.True(t, ok)
+
+			var foundImports, typeOnlyImports []string
+			for _, imp := range imports {
+				foundImports = append(foundImports, imp.String())
+				if imp.IsTypeOnlyImport() {
+					typeOnlyImports = append(typeOnlyImports, imp.ModuleAlias())
+				}
+			}
+
+			assert.ElementsMatch(t, expectedImports, foundImports)
+			assert.ElementsMatch(t, typescriptTypeOnlyImports[f.Name()], typeOnlyImports)
+		})
+	})
+
+	t.Run("ResolveUnresolvableImports", func(t *testing.T) {
+		typescriptLanguage, err := lang.NewTypescriptLanguage()
+		assert.NoError(t, err)
+
+		resolvers, ok := typescriptLanguage.Resolvers().(core.DynamicImportLanguageResolvers)
+		assert.True(t, ok)
+
+		fileParser, err := parser.NewParser([]core.Language{typescriptLanguage})
+		assert.NoError(t, err)
+
+		fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+			AppDirectories: []string{"fixtures/dynamic_imports.ts"},
+		})
+		assert.NoError(t, err)
+
+		err = fileSystem.EnumerateApp(context.Background(), func(f core.File) error {
+			parseTree, err := fileParser.Parse(context.Background(), f)
+			assert.NoError(t, err)
+
+			imports, err := typescriptLanguage.Resolvers().ResolveImports(parseTree)
+			assert.NoError(t, err)
+
+			for _, imp := range imports {
+				assert.True(t, imp.IsDynamicImport())
+			}
+
+			unresolvableImports, err := resolvers.ResolveUnresolvableImports(parseTree)
+			assert.NoError(t, err)
+
+			var foundImports []string
+			for _, unresolvableImport := range unresolvableImports {
+				foundImports = append(foundImports, unresolvableImport.String())
+			}
+
+			assert.Equal(t, typescriptUnresolvableImportExpectations, foundImports)
+			return err
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("ResolveFunctions", func(t *testing.T) {
+		var filePaths []string
+		for path := range typescriptFunctionExpectations {
+			filePaths = append(filePaths, path)
+		}
+
+		parseTypescriptFixtures(t, filePaths, func(parseTree core.ParseTree, f core.File) {
+			functions, err := typescriptLanguage.Resolvers().ResolveFunctions(parseTree)
+			assert.NoError(t, err)
+
+			expectedFunctions, ok := typescriptFunctionExpectations[f.Name()]
+			assert.True(t, ok)
+
+			var foundFunctions []string
+			for _, fun := range functions {
+				foundFunctions = append(foundFunctions,
+					fmt.Sprintf("FunctionDeclarationNode{Name: %s, Type: %s, Access: %s, ParentClass: %s}",
+						fun.FunctionName(), fun.GetFunctionType(), fun.GetAccessModifier(), fun.GetParentClassName()))
+			}
+
+			assert.ElementsMatch(t, expectedFunctions, foundFunctions)
+		})
+	})
+
+	t.Run("ResolveClasses", func(t *testing.T) {
+		parseTypescriptFixtures(t, []string{"fixtures/typescript_class_hierarchy.ts"}, func(parseTree core.ParseTree, _ core.File) {
+			resolvers := typescriptLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			classes, err := resolvers.ResolveClasses(parseTree)
+			assert.NoError(t, err)
+
+			found := map[string]string{}
+			for _, class := range classes {
+				found[class.ClassName()] = fmt.Sprintf("bases=%v methods=%d fields=%d constructor=%t abstract=%t decorators=%d",
+					class.BaseClasses(), len(class.Methods()), len(class.Fields()),
+					class.Constructor() != "", class.IsAbstract(), len(class.Decorators()))
+			}
+
+			assert.Equal(t, map[string]string{
+				"Entity":     "bases=[] methods=0 fields=1 constructor=false abstract=true decorators=0",
+				"Auditable":  "bases=[Entity] methods=1 fields=1 constructor=false abstract=true decorators=0",
+				"Repository": "bases=[] methods=1 fields=0 constructor=false abstract=true decorators=0",
+				"BaseModel":  "bases=[Entity] methods=1 fields=1 constructor=false abstract=true decorators=0",
+				"User":       "bases=[BaseModel Auditable Repository] methods=3 fields=1 constructor=true abstract=false decorators=1",
+				"Admin":      "bases=[User] methods=0 fields=0 constructor=false abstract=false decorators=0",
+			}, found)
+		})
+	})
+
+	t.Run("ResolveInheritance", func(t *testing.T) {
+		parseTypescriptFixtures(t, []string{"fixtures/typescript_class_hierarchy.ts", "fixtures/component.tsx"}, func(parseTree core.ParseTree, f core.File) {
+			resolvers := typescriptLanguage.Resolvers().(core.ObjectOrientedLanguageResolvers)
+
+			graph, err := resolvers.ResolveInheritance(parseTree)
+			assert.NoError(t, err)
+
+			var relationships []string
+			for _, class := range graph.GetAllClasses() {
+				for _, rel := range graph.GetDirectParents(class) {
+					relationships = append(relationships, fmt.Sprintf("%s %s %s", rel.ChildClassName,
+						rel.RelationshipType, rel.ParentClassName))
+				}
+			}
+
+			if f.Name() == "fixtures/component.tsx" {
+				assert.ElementsMatch(t, []string{
+					"CounterProps extends ButtonProps",
+					"Panel extends React.Component",
+				}, relationships)
+				return
+			}
+
+			assert.ElementsMatch(t, []string{
+				"Auditable extends Entity",
+				"BaseModel implements Entity",
+				"User extends BaseModel",
+				"User implements Auditable",
+				"User implements Repository",
+				"Admin extends User",
+			}, relationships)
+
+			assert.True(t, graph.IsAncestor("Entity", "Admin"))
+			assert.True(t, graph.IsAncestor("Repository", "Admin"))
+		})
+	})
+}
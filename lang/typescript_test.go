@@ -0,0 +1,37 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypescriptLanguageMeta(t *testing.T) {
+	t.Run("Name", func(t *testing.T) {
+		l := &typescriptLanguage{}
+		assert.Equal(t, typescriptLanguageName, l.Name())
+	})
+
+	t.Run("Code", func(t *testing.T) {
+		l := &typescriptLanguage{}
+		assert.Equal(t, core.LanguageCodeTypescript, l.Meta().Code)
+	})
+
+	t.Run("ObjectOriented", func(t *testing.T) {
+		l := &typescriptLanguage{}
+		assert.True(t, l.Meta().ObjectOriented)
+	})
+
+	t.Run("Dialect", func(t *testing.T) {
+		l, exists := ResolveLanguageFromPath("component.tsx")
+		assert.True(t, exists)
+		assert.Equal(t, tsx.GetLanguage().SymbolCount(), l.Language().SymbolCount())
+
+		l, exists = ResolveLanguageFromPath("index.ts")
+		assert.True(t, exists)
+		assert.Equal(t, typescript.GetLanguage().SymbolCount(), l.Language().SymbolCount())
+	})
+}
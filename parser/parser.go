@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
+	"path/filepath"
 
 	"github.com/safedep/code/core"
+	"github.com/safedep/code/fs"
 	"github.com/safedep/code/lang"
 	sitter "github.com/smacker/go-tree-sitter"
 )
@@ -23,7 +26,7 @@ type parseTree struct {
 	lang core.Language
 }
 
-var _ core.Parser = (*parserWrapper)(nil)
+var _ core.MultiLanguageParser = (*parserWrapper)(nil)
 var _ core.ParseTree = (*parseTree)(nil)
 
 // NewParser creates a new parserWrapper which can parse files only for the given languages using TreeSitter
@@ -41,6 +44,16 @@ func NewParser(languages []core.Language) (*parserWrapper, error) {
 }
 
 func (p *parserWrapper) Parse(ctx context.Context, file core.File) (core.ParseTree, error) {
+	// Notebooks are parsed as the source of their code cells
+	if _, ok := file.(core.NotebookFile); !ok && filepath.Ext(file.Name()) == ".ipynb" {
+		notebookFile, err := fs.NewNotebookFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read notebook: %w", err)
+		}
+
+		file = notebookFile
+	}
+
 	r, err := file.Reader()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get reader for file: %w", err)
@@ -51,9 +64,9 @@ func (p *parserWrapper) Parse(ctx context.Context, file core.File) (core.ParseTr
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	language, exists := lang.ResolveLanguageFromPath(file.Name())
+	language, exists := fileLanguage(file, data)
 	if !exists {
-		return nil, fmt.Errorf("failed to resolve language from file path")
+		return nil, fmt.Errorf("failed to resolve language of file")
 	}
 
 	parser, exists := p.langParsers[language.Meta().Code]
@@ -61,6 +74,10 @@ func (p *parserWrapper) Parse(ctx context.Context, file core.File) (core.ParseTr
 		return nil, fmt.Errorf("language not provisioned for parsing")
 	}
 
+	// Languages with multiple dialects (eg. TypeScript and TSX) share the
+	// parser for their language code, so the grammar is picked per file
+	parser.SetLanguage(language.Language())
+
 	tree, err := parser.ParseCtx(ctx, nil, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse file: %w", err)
@@ -75,6 +92,104 @@ func (p *parserWrapper) Parse(ctx context.Context, file core.File) (core.ParseTr
 	}, nil
 }
 
+// ParseAll parses a file into a tree per source, host documents eg. HTML
+// files are parsed into a tree per embedded source in a provisioned language
+// using the ranges of the source in the host document
+func (p *parserWrapper) ParseAll(ctx context.Context, file core.File) ([]core.ParseTree, error) {
+	if !lang.IsHostDocument(file.Name()) {
+		tree, err := p.Parse(ctx, file)
+		if err != nil {
+			return nil, err
+		}
+
+		return []core.ParseTree{tree}, nil
+	}
+
+	r, err := file.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reader for file: %w", err)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	sources, err := embeddedSources(ctx, file, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var trees []core.ParseTree
+	for _, source := range sources {
+		parser, exists := p.langParsers[source.Language.Meta().Code]
+		if !exists {
+			continue
+		}
+
+		tree, err := parseRanges(ctx, parser, source, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse embedded source: %w", err)
+		}
+
+		trees = append(trees, &parseTree{
+			tree: tree,
+			data: &data,
+			file: file,
+			lang: source.Language,
+		})
+	}
+
+	return trees, nil
+}
+
+// embeddedSources returns the sources embedded in a host document, resolved
+// by the source walker if available
+func embeddedSources(ctx context.Context, file core.File, data []byte) ([]lang.EmbeddedSource, error) {
+	if embeddedSourceFile, ok := file.(lang.EmbeddedSourceFile); ok {
+		return embeddedSourceFile.EmbeddedSources(), nil
+	}
+
+	sources, err := lang.ResolveEmbeddedSources(ctx, file.Name(), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve embedded sources: %w", err)
+	}
+
+	return sources, nil
+}
+
+// wholeDocumentRange is the default included range of a parser
+var wholeDocumentRange = sitter.Range{
+	EndPoint: sitter.Point{Row: math.MaxUint32, Column: math.MaxUint32},
+	EndByte:  math.MaxUint32,
+}
+
+// parseRanges parses the ranges of an embedded source with the parser of its
+// language, the parser is reset to parse whole files afterwards
+func parseRanges(ctx context.Context, parser *sitter.Parser, source lang.EmbeddedSource,
+	data []byte) (*sitter.Tree, error) {
+	parser.SetLanguage(source.Language.Language())
+	parser.SetIncludedRanges(source.Ranges)
+	defer parser.SetIncludedRanges([]sitter.Range{wholeDocumentRange})
+
+	return parser.ParseCtx(ctx, nil, data)
+}
+
+// fileLanguage returns the language of a file, detected by the source walker
+// if available otherwise detected from the path and the content of the file
+func fileLanguage(file core.File, data []byte) (core.Language, bool) {
+	if languageAwareFile, ok := file.(core.LanguageAwareFile); ok {
+		return languageAwareFile.Language(), true
+	}
+
+	detection, ok := lang.DetectLanguage(file.Name(), data)
+	if !ok {
+		return nil, false
+	}
+
+	return detection.Language, true
+}
+
 func (t *parseTree) Tree() *sitter.Tree {
 	return t.tree
 }
@@ -36,10 +36,31 @@ type sourceVisitor struct {
 }
 
 func (v *sourceVisitor) VisitFile(f core.File) error {
-	parseTree, err := v.parser.Parse(context.Background(), f)
+	parseTrees, err := ParseAll(context.Background(), v.parser, f)
 	if err != nil {
 		return fmt.Errorf("failed to parse file: %w", err)
 	}
 
-	return v.visitor.VisitTree(parseTree)
+	for _, parseTree := range parseTrees {
+		if err := v.visitor.VisitTree(parseTree); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ParseAll parses a file into its trees using a core.MultiLanguageParser,
+// other parsers parse the file into a single tree
+func ParseAll(ctx context.Context, parser core.Parser, file core.File) ([]core.ParseTree, error) {
+	if multiLanguageParser, ok := parser.(core.MultiLanguageParser); ok {
+		return multiLanguageParser.ParseAll(ctx, file)
+	}
+
+	parseTree, err := parser.Parse(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+
+	return []core.ParseTree{parseTree}, nil
 }
@@ -0,0 +1,398 @@
+package helpers
+
+// PythonStdLibs is a map of top level modules of the Python standard library
+var PythonStdLibs = map[string]bool{
+	"__future__":      true,
+	"_thread":         true,
+	"abc":             true,
+	"aifc":            true,
+	"argparse":        true,
+	"array":           true,
+	"ast":             true,
+	"asynchat":        true,
+	"asyncio":         true,
+	"asyncore":        true,
+	"atexit":          true,
+	"audioop":         true,
+	"base64":          true,
+	"bdb":             true,
+	"binascii":        true,
+	"bisect":          true,
+	"builtins":        true,
+	"bz2":             true,
+	"calendar":        true,
+	"cgi":             true,
+	"cgitb":           true,
+	"chunk":           true,
+	"cmath":           true,
+	"cmd":             true,
+	"code":            true,
+	"codecs":          true,
+	"codeop":          true,
+	"collections":     true,
+	"colorsys":        true,
+	"compileall":      true,
+	"concurrent":      true,
+	"configparser":    true,
+	"contextlib":      true,
+	"contextvars":     true,
+	"copy":            true,
+	"copyreg":         true,
+	"cProfile":        true,
+	"crypt":           true,
+	"csv":             true,
+	"ctypes":          true,
+	"curses":          true,
+	"dataclasses":     true,
+	"datetime":        true,
+	"dbm":             true,
+	"decimal":         true,
+	"difflib":         true,
+	"dis":             true,
+	"distutils":       true,
+	"doctest":         true,
+	"email":           true,
+	"encodings":       true,
+	"ensurepip":       true,
+	"enum":            true,
+	"errno":           true,
+	"faulthandler":    true,
+	"fcntl":           true,
+	"filecmp":         true,
+	"fileinput":       true,
+	"fnmatch":         true,
+	"fractions":       true,
+	"ftplib":          true,
+	"functools":       true,
+	"gc":              true,
+	"getopt":          true,
+	"getpass":         true,
+	"gettext":         true,
+	"glob":            true,
+	"graphlib":        true,
+	"grp":             true,
+	"gzip":            true,
+	"hashlib":         true,
+	"heapq":           true,
+	"hmac":            true,
+	"html":            true,
+	"http":            true,
+	"idlelib":         true,
+	"imaplib":         true,
+	"imghdr":          true,
+	"imp":             true,
+	"importlib":       true,
+	"inspect":         true,
+	"io":              true,
+	"ipaddress":       true,
+	"itertools":       true,
+	"json":            true,
+	"keyword":         true,
+	"lib2to3":         true,
+	"linecache":       true,
+	"locale":          true,
+	"logging":         true,
+	"lzma":            true,
+	"mailbox":         true,
+	"mailcap":         true,
+	"marshal":         true,
+	"math":            true,
+	"mimetypes":       true,
+	"mmap":            true,
+	"modulefinder":    true,
+	"msilib":          true,
+	"msvcrt":          true,
+	"multiprocessing": true,
+	"netrc":           true,
+	"nis":             true,
+	"nntplib":         true,
+	"ntpath":          true,
+	"numbers":         true,
+	"opcode":          true,
+	"operator":        true,
+	"optparse":        true,
+	"os":              true,
+	"ossaudiodev":     true,
+	"pathlib":         true,
+	"pdb":             true,
+	"pickle":          true,
+	"pickletools":     true,
+	"pipes":           true,
+	"pkgutil":         true,
+	"platform":        true,
+	"plistlib":        true,
+	"poplib":          true,
+	"posix":           true,
+	"posixpath":       true,
+	"pprint":          true,
+	"profile":         true,
+	"pstats":          true,
+	"pty":             true,
+	"pwd":             true,
+	"py_compile":      true,
+	"pyclbr":          true,
+	"pydoc":           true,
+	"queue":           true,
+	"quopri":          true,
+	"random":          true,
+	"re":              true,
+	"readline":        true,
+	"reprlib":         true,
+	"resource":        true,
+	"rlcompleter":     true,
+	"runpy":           true,
+	"sched":           true,
+	"secrets":         true,
+	"select":          true,
+	"selectors":       true,
+	"shelve":          true,
+	"shlex":           true,
+	"shutil":          true,
+	"signal":          true,
+	"site":            true,
+	"smtpd":           true,
+	"smtplib":         true,
+	"sndhdr":          true,
+	"socket":          true,
+	"socketserver":    true,
+	"spwd":            true,
+	"sqlite3":         true,
+	"sre_compile":     true,
+	"sre_constants":   true,
+	"sre_parse":       true,
+	"ssl":             true,
+	"stat":            true,
+	"statistics":      true,
+	"string":          true,
+	"stringprep":      true,
+	"struct":          true,
+	"subprocess":      true,
+	"sunau":           true,
+	"symtable":        true,
+	"sys":             true,
+	"sysconfig":       true,
+	"syslog":          true,
+	"tabnanny":        true,
+	"tarfile":         true,
+	"telnetlib":       true,
+	"tempfile":        true,
+	"termios":         true,
+	"textwrap":        true,
+	"threading":       true,
+	"time":            true,
+	"timeit":          true,
+	"tkinter":         true,
+	"token":           true,
+	"tokenize":        true,
+	"tomllib":         true,
+	"trace":           true,
+	"traceback":       true,
+	"tracemalloc":     true,
+	"tty":             true,
+	"turtle":          true,
+	"turtledemo":      true,
+	"types":           true,
+	"typing":          true,
+	"unicodedata":     true,
+	"unittest":        true,
+	"urllib":          true,
+	"uu":              true,
+	"uuid":            true,
+	"venv":            true,
+	"warnings":        true,
+	"wave":            true,
+	"weakref":         true,
+	"webbrowser":      true,
+	"winreg":          true,
+	"winsound":        true,
+	"wsgiref":         true,
+	"xdrlib":          true,
+	"xml":             true,
+	"xmlrpc":          true,
+	"zipapp":          true,
+	"zipfile":         true,
+	"zipimport":       true,
+	"zlib":            true,
+	"zoneinfo":        true,
+}
+
+// NodeBuiltinModules is a map of Node.js builtin modules. Builtins may also
+// be imported with the node: scheme eg. node:fs
+var NodeBuiltinModules = map[string]bool{
+	"assert":              true,
+	"async_hooks":         true,
+	"buffer":              true,
+	"child_process":       true,
+	"cluster":             true,
+	"console":             true,
+	"constants":           true,
+	"crypto":              true,
+	"dgram":               true,
+	"diagnostics_channel": true,
+	"dns":                 true,
+	"domain":              true,
+	"events":              true,
+	"fs":                  true,
+	"http":                true,
+	"http2":               true,
+	"https":               true,
+	"inspector":           true,
+	"module":              true,
+	"net":                 true,
+	"os":                  true,
+	"path":                true,
+	"perf_hooks":          true,
+	"process":             true,
+	"punycode":            true,
+	"querystring":         true,
+	"readline":            true,
+	"repl":                true,
+	"stream":              true,
+	"string_decoder":      true,
+	"sys":                 true,
+	"timers":              true,
+	"tls":                 true,
+	"trace_events":        true,
+	"tty":                 true,
+	"url":                 true,
+	"util":                true,
+	"v8":                  true,
+	"vm":                  true,
+	"wasi":                true,
+	"worker_threads":      true,
+	"zlib":                true,
+}
+
+// RubyStdLibs is a map of top level paths of the Ruby standard library,
+// including the default gems shipped with Ruby
+var RubyStdLibs = map[string]bool{
+	"abbrev":         true,
+	"base64":         true,
+	"benchmark":      true,
+	"bigdecimal":     true,
+	"cgi":            true,
+	"continuation":   true,
+	"coverage":       true,
+	"csv":            true,
+	"date":           true,
+	"delegate":       true,
+	"did_you_mean":   true,
+	"digest":         true,
+	"drb":            true,
+	"English":        true,
+	"erb":            true,
+	"etc":            true,
+	"fcntl":          true,
+	"fiber":          true,
+	"fiddle":         true,
+	"fileutils":      true,
+	"find":           true,
+	"forwardable":    true,
+	"getoptlong":     true,
+	"io":             true,
+	"ipaddr":         true,
+	"irb":            true,
+	"json":           true,
+	"logger":         true,
+	"matrix":         true,
+	"mkmf":           true,
+	"monitor":        true,
+	"mutex_m":        true,
+	"net":            true,
+	"objspace":       true,
+	"observer":       true,
+	"open-uri":       true,
+	"open3":          true,
+	"openssl":        true,
+	"optparse":       true,
+	"ostruct":        true,
+	"pathname":       true,
+	"pp":             true,
+	"prettyprint":    true,
+	"prime":          true,
+	"pstore":         true,
+	"psych":          true,
+	"pty":            true,
+	"racc":           true,
+	"rbconfig":       true,
+	"rdoc":           true,
+	"readline":       true,
+	"reline":         true,
+	"resolv":         true,
+	"ripper":         true,
+	"ruby2_keywords": true,
+	"securerandom":   true,
+	"set":            true,
+	"shellwords":     true,
+	"singleton":      true,
+	"socket":         true,
+	"stringio":       true,
+	"strscan":        true,
+	"syslog":         true,
+	"tempfile":       true,
+	"thread":         true,
+	"time":           true,
+	"timeout":        true,
+	"tmpdir":         true,
+	"tsort":          true,
+	"un":             true,
+	"uri":            true,
+	"weakref":        true,
+	"yaml":           true,
+	"zlib":           true,
+}
+
+// PhpBuiltinNamespaces is a map of top level names of the classes, interfaces
+// and namespaces provided by PHP and its bundled extensions eg. use DateTime
+var PhpBuiltinNamespaces = map[string]bool{
+	"ArrayAccess":              true,
+	"ArrayIterator":            true,
+	"ArrayObject":              true,
+	"BackedEnum":               true,
+	"Closure":                  true,
+	"Countable":                true,
+	"CURLFile":                 true,
+	"DateInterval":             true,
+	"DatePeriod":               true,
+	"DateTime":                 true,
+	"DateTimeImmutable":        true,
+	"DateTimeInterface":        true,
+	"DateTimeZone":             true,
+	"Dom":                      true,
+	"DOMDocument":              true,
+	"DOMElement":               true,
+	"DOMXPath":                 true,
+	"Error":                    true,
+	"ErrorException":           true,
+	"Exception":                true,
+	"FFI":                      true,
+	"Fiber":                    true,
+	"Generator":                true,
+	"InvalidArgumentException": true,
+	"Iterator":                 true,
+	"IteratorAggregate":        true,
+	"JsonException":            true,
+	"JsonSerializable":         true,
+	"LogicException":           true,
+	"PDO":                      true,
+	"PDOException":             true,
+	"PDOStatement":             true,
+	"Random":                   true,
+	"ReflectionClass":          true,
+	"ReflectionMethod":         true,
+	"RuntimeException":         true,
+	"SimpleXMLElement":         true,
+	"SplFileInfo":              true,
+	"SplObjectStorage":         true,
+	"SplQueue":                 true,
+	"SplStack":                 true,
+	"Stringable":               true,
+	"Throwable":                true,
+	"Traversable":              true,
+	"TypeError":                true,
+	"UnitEnum":                 true,
+	"ValueError":               true,
+	"WeakMap":                  true,
+	"WeakReference":            true,
+	"ZipArchive":               true,
+}
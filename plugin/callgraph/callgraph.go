@@ -57,6 +57,10 @@ type TreeNodeMetadata struct {
 	EndLine     uint32
 	StartColumn uint32
 	EndColumn   uint32
+
+	// Position of the start line in the cells of a notebook, it is nil
+	// for files other than notebooks
+	NotebookPosition *core.NotebookPosition
 }
 
 // If tree sitter node is nil, it returns false indicating that the content details are not available
@@ -96,10 +100,16 @@ type CallGraph struct {
 	Nodes             map[string]*CallGraphNode
 	RootNode          *CallGraphNode
 	Tree              core.ParseTree
+	imports           []*ast.ImportNode
 	assignmentGraph   assignmentGraph
 	classConstructors map[string]bool
 	nodeCount         int  // Track total nodes added
 	limitExceeded     bool // Flag to indicate if processing was truncated
+
+	// Namespaces of modules imported only at runtime and the namespaces
+	// imported by each call, keyed by the start byte of the call
+	dynamicImportNamespaces map[string]bool
+	dynamicImportCalls      map[uint32]string
 }
 
 func newCallGraph(fileName string, rootNode *sitter.Node, imports []*ast.ImportNode, tree core.ParseTree) (*CallGraph, error) {
@@ -114,6 +124,7 @@ func newCallGraph(fileName string, rootNode *sitter.Node, imports []*ast.ImportN
 		FileName:          fileName,
 		Nodes:             make(map[string]*CallGraphNode),
 		Tree:              tree,
+		imports:           imports,
 		assignmentGraph:   *newAssignmentGraph(),
 		classConstructors: make(map[string]bool),
 	}
@@ -124,6 +135,7 @@ func newCallGraph(fileName string, rootNode *sitter.Node, imports []*ast.ImportN
 	// Required to map identifiers to imported modules as assignments
 	// and register default calls for wildcard imports
 	importedIdentifiers, wildcardImports := parseImports(imports, language)
+	cg.dynamicImportNamespaces, cg.dynamicImportCalls = parseDynamicImports(imports, language)
 
 	for _, wildcardImport := range wildcardImports {
 		// For wildcard imports, we add a call to importeditem//*
@@ -400,3 +412,17 @@ func (cg *CallGraph) getInstanceKeyword() (string, bool) {
 func (cg *CallGraph) LimitExceeded() bool {
 	return cg.limitExceeded
 }
+
+// IsDynamicImport returns true if the namespace is, or is under, the namespace
+// of a module imported only at runtime eg. requests//get given
+// importlib.import_module("requests")
+func (cg *CallGraph) IsDynamicImport(namespace string) bool {
+	for dynamicImportNamespace := range cg.dynamicImportNamespaces {
+		if namespace == dynamicImportNamespace ||
+			strings.HasPrefix(namespace, dynamicImportNamespace+namespaceSeparator) {
+			return true
+		}
+	}
+
+	return false
+}
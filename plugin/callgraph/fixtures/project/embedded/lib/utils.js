@@ -0,0 +1,5 @@
+const fs = require('fs');
+
+export function format(value) {
+  fs.writeFileSync('/tmp/out', value);
+}
@@ -0,0 +1,3 @@
+export function render(value) {
+  console.log(value);
+}
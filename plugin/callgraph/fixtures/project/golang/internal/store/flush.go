@@ -0,0 +1,7 @@
+package store
+
+import "os"
+
+func flush() {
+	os.Remove("/tmp/store.lock")
+}
@@ -0,0 +1,8 @@
+package store
+
+import "os"
+
+func Save(value string) {
+	os.WriteFile("/tmp/store", []byte(value), 0o600)
+	flush()
+}
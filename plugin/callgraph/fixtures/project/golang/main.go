@@ -0,0 +1,12 @@
+package main
+
+import (
+	"github.com/acme/logger"
+	"github.com/acme/project/internal/store"
+)
+
+func main() {
+	store.Save("payload")
+	logger.Info("saved")
+	helper()
+}
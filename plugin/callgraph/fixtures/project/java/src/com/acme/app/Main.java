@@ -0,0 +1,9 @@
+package com.acme.app;
+
+import com.acme.util.Helper;
+
+public class Main {
+    public static void main(String[] args) {
+        Helper.run("payload");
+    }
+}
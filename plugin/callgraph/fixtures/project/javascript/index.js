@@ -0,0 +1,9 @@
+const utils = require('./lib/utils');
+import { render } from './lib/view.js';
+
+function main() {
+  const value = utils.format('payload');
+  render(value);
+}
+
+main();
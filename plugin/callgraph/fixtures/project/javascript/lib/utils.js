@@ -0,0 +1,8 @@
+const fs = require('fs');
+
+function format(value) {
+  fs.writeFileSync('/tmp/out', value);
+  return value.trim();
+}
+
+module.exports = { format };
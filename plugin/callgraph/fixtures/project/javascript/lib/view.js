@@ -0,0 +1,5 @@
+import { format } from './utils';
+
+export function render(value) {
+  console.log(format(value));
+}
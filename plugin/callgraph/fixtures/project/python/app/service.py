@@ -0,0 +1,9 @@
+from .helpers import normalize
+from . import storage
+import requests
+
+
+def process(data):
+    value = normalize(data)
+    storage.save(value)
+    requests.post("https://example.com", data=value)
@@ -0,0 +1,5 @@
+import os
+
+
+def save(value):
+    os.system("echo " + value)
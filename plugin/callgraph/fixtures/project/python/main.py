@@ -0,0 +1,12 @@
+from app import service
+from app.helpers import normalize
+import requests
+
+
+def run():
+    data = normalize("payload")
+    service.process(data)
+
+
+run()
+requests.get("https://example.com")
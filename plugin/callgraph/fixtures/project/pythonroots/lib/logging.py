@@ -0,0 +1,2 @@
+def info(message):
+    pass
@@ -0,0 +1,5 @@
+import logging
+
+
+def log(message):
+    logging.info(message)
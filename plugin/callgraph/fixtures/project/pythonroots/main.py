@@ -0,0 +1,5 @@
+import json
+from lib import util
+
+json.dumps({})
+util.log("started")
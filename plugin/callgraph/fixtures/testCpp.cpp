@@ -0,0 +1,45 @@
+#include <cstdlib>
+#include <string>
+#include "crypto.hpp"
+
+namespace native {
+
+int execute(const std::string &command)
+{
+    return std::system(command.c_str());
+}
+
+class Runner {
+public:
+    explicit Runner(const std::string &shell) : shell_(shell) {}
+
+    int run(const std::string &command)
+    {
+        log(command);
+        return execute(shell_ + " -c " + command);
+    }
+
+    static Runner *create();
+
+private:
+    void log(const std::string &message) { ::puts(message.c_str()); }
+
+    std::string shell_;
+};
+
+Runner *Runner::create()
+{
+    return new Runner("/bin/sh");
+}
+
+} // namespace native
+
+int main()
+{
+    native::Runner runner("/bin/bash");
+    runner.run("id");
+
+    native::Runner *created = native::Runner::create();
+    created->run("whoami");
+    return 0;
+}
@@ -0,0 +1,40 @@
+using System;
+using Amazon.S3;
+using Json = Newtonsoft.Json.JsonConvert;
+
+namespace Acme.Storage
+{
+    public class Uploader
+    {
+        private AmazonS3Client client;
+
+        public Uploader(string region)
+        {
+            this.client = new AmazonS3Client(region);
+        }
+
+        public static Uploader Create(string region) => new Uploader(region);
+
+        public void Upload(string bucket, object payload)
+        {
+            var body = Json.SerializeObject(payload);
+            this.client.PutObject(bucket, body);
+            Log("uploaded");
+        }
+
+        private void Log(string message)
+        {
+            Console.WriteLine(message);
+        }
+    }
+
+    public static class Program
+    {
+        public static void Main(string[] args)
+        {
+            var uploader = new Uploader("us-east-1");
+            uploader.Upload("reports", args);
+            Uploader.Create("eu-west-1").Upload("archive", args);
+        }
+    }
+}
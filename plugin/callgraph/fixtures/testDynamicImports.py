@@ -0,0 +1,13 @@
+import importlib
+import subprocess
+
+# Modules imported at runtime are resolved as imports
+http = importlib.import_module("requests")
+http.get("https://example.com")
+
+ctypes = __import__("ctypes")
+ctypes.CDLL("libc.so.6")
+
+__import__("colorama")
+
+subprocess.run(["ls"])
@@ -0,0 +1,36 @@
+package com.acme.gateway
+
+import okhttp3.OkHttpClient
+import okhttp3.Request as HttpRequest
+import javax.crypto.Cipher
+import kotlinx.coroutines.*
+
+class Gateway(private val baseUrl: String) {
+    private val client = OkHttpClient()
+
+    fun fetch(path: String): String {
+        val request = HttpRequest.Builder().url(baseUrl + path).build()
+        log("fetching")
+        return this.client.newCall(request).execute().toString()
+    }
+
+    private fun log(message: String) {
+        println(message)
+    }
+
+    companion object {
+        fun create(url: String) = Gateway(url)
+    }
+}
+
+fun encrypt(data: ByteArray): ByteArray {
+    val cipher = Cipher.getInstance("AES")
+    return cipher.doFinal(data)
+}
+
+fun main() {
+    val gateway = Gateway("https://example.com")
+    gateway.fetch("/status")
+    Gateway.create("https://backup.example.com").fetch("/health")
+    encrypt(ByteArray(16))
+}
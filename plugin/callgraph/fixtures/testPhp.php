@@ -0,0 +1,56 @@
+<?php
+
+namespace App\Http;
+
+use GuzzleHttp\Client;
+use Psr\Log\LoggerInterface as Logger;
+use function Illuminate\Support\collect;
+
+require_once __DIR__ . '/helpers.php';
+
+class Gateway
+{
+    private $client;
+
+    public function __construct(string $baseUrl)
+    {
+        $this->client = new Client($baseUrl);
+    }
+
+    public static function create(string $url)
+    {
+        return new static($url);
+    }
+
+    public function fetch(string $path)
+    {
+        $response = $this->client->get($this->urlFor($path));
+        self::log("fetched");
+        return json_decode($response);
+    }
+
+    private function urlFor(string $path)
+    {
+        return sprintf("%s/%s", static::BASE, $path);
+    }
+
+    private static function log(string $message)
+    {
+        echo $message;
+    }
+}
+
+function encrypt(string $payload, string $key)
+{
+    return openssl_encrypt($payload, "aes-256-cbc", $key);
+}
+
+function run()
+{
+    $gateway = new Gateway("https://example.com");
+    $gateway->fetch("status");
+    collect([1, 2])->map(fn ($x) => $x * 2);
+    encrypt("secret", "key");
+}
+
+run();
@@ -0,0 +1,44 @@
+require 'json'
+require 'net/http'
+require_relative 'helpers/formatter'
+
+module Api
+  class Client
+    def initialize(base_url)
+      @base_url = base_url
+      @http = Net::HTTP.new(base_url)
+    end
+
+    def self.build(url)
+      new(url)
+    end
+
+    def fetch(path)
+      response = @http.get(url_for(path))
+      log("fetched")
+      JSON.parse(response)
+    end
+
+    private
+
+    def url_for(path)
+      format("%s/%s", @base_url, path)
+    end
+
+    def log(message)
+      puts message
+    end
+  end
+end
+
+def run
+  client = Api::Client.new("https://example.com")
+  client.fetch("status")
+  encode({ status: :ok })
+end
+
+def encode(payload)
+  payload.to_json
+end
+
+run()
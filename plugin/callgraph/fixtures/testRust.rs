@@ -0,0 +1,47 @@
+use reqwest;
+use serde_json as json;
+use std::collections::HashMap;
+use std::io::*;
+
+struct Client {
+    base_url: String,
+}
+
+impl Client {
+    pub fn new(base_url: &str) -> Self {
+        Client { base_url: base_url.to_string() }
+    }
+
+    pub fn fetch(&self, path: &str) -> String {
+        let url = self.url(path);
+        let body = reqwest::blocking::get(url);
+        Self::log("fetched");
+        body
+    }
+
+    fn url(&self, path: &str) -> String {
+        format!("{}/{}", self.base_url, path)
+    }
+
+    fn log(message: &str) {
+        println!("{}", message);
+    }
+}
+
+fn encode(values: &HashMap<String, String>) -> String {
+    json::to_string(values).unwrap()
+}
+
+fn run() {
+    let mut values = HashMap::new();
+    let client = Client::new("https://example.com");
+    let body = client.fetch("status");
+    let encoded = encode(&values);
+    std::fs::write("out.json", encoded);
+    let count = "42".parse::<u32>();
+}
+
+fn main() {
+    run();
+    reqwest::get("https://example.com");
+}
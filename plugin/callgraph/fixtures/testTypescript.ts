@@ -0,0 +1,54 @@
+// Import statements
+import axios from 'axios';
+import { log, warn } from 'console';
+import type { AxiosResponse } from 'axios';
+import fs = require('fs');
+
+interface Greeter {
+    greet(name: string): string;
+}
+
+// Simple function declaration with type annotations
+function simpleFunction(param1: number, param2: number): number {
+    log("Simple function called");
+    return param1 + param2;
+}
+
+// Arrow function
+const arrowFunc = (x: number): number => {
+    warn("Arrow function called");
+    return x * 2;
+};
+
+abstract class BaseService {
+    abstract fetch(url: string): Promise<AxiosResponse>;
+
+    protected describe(): string {
+        log("BaseService describe");
+        return "base";
+    }
+}
+
+class HttpService extends BaseService implements Greeter {
+    constructor(private readonly name: string) {
+        super();
+        log("HttpService constructor");
+    }
+
+    async fetch(url: string): Promise<AxiosResponse> {
+        return axios.get(url);
+    }
+
+    greet(name: string): string {
+        this.describe();
+        return name;
+    }
+}
+
+const service = new HttpService("svc");
+service.greet("world");
+
+simpleFunction(1, 2);
+arrowFunc(5);
+
+fs.readFileSync("file.txt");
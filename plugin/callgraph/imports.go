@@ -25,6 +25,44 @@ type wildcardImport struct {
 	NamespaceTreeNode *sitter.Node
 }
 
+// Node types of calls importing a module at runtime eg. importlib.import_module("yaml")
+var dynamicImportCallNodeTypes = map[string]bool{
+	"call":            true,
+	"call_expression": true,
+}
+
+// Parses the namespaces of modules imported only at runtime eg. importlib.import_module("yaml")
+// along with the namespaces imported by the calls, keyed by the start byte of the call
+func parseDynamicImports(imports []*ast.ImportNode, lang core.Language) (map[string]bool, map[uint32]string) {
+	dynamicImportNamespaces := make(map[string]bool)
+	dynamicImportCalls := make(map[uint32]string)
+	staticImportNamespaces := make(map[string]bool)
+
+	for _, imp := range imports {
+		moduleNamespace := resolveNamespaceWithSeparator(imp.ModuleName(), lang)
+		if !imp.IsDynamicImport() {
+			staticImportNamespaces[moduleNamespace] = true
+			continue
+		}
+
+		dynamicImportNamespaces[moduleNamespace] = true
+
+		for node := imp.GetModuleNameNode(); node != nil; node = node.Parent() {
+			if dynamicImportCallNodeTypes[node.Type()] {
+				dynamicImportCalls[node.StartByte()] = moduleNamespace
+				break
+			}
+		}
+	}
+
+	// Modules also imported by import statements are not labelled as dynamic
+	for namespace := range staticImportNamespaces {
+		delete(dynamicImportNamespaces, namespace)
+	}
+
+	return dynamicImportNamespaces, dynamicImportCalls
+}
+
 // Parses the imports from AST and returns map of identified imports and a list of wildcard imports.
 func parseImports(imports []*ast.ImportNode, lang core.Language) (map[string]parsedImport, []wildcardImport) {
 	importedIdentifierNamespaces := make(map[string]parsedImport)
@@ -85,6 +123,14 @@ var submoduleSeparator = map[core.LanguageCode]string{
 	core.LanguageCodeJavascript: "/",
 	core.LanguageCodePython:     ".",
 	core.LanguageCodeJava:       ".",
+	core.LanguageCodeTypescript: "/",
+	core.LanguageCodeRust:       "::",
+	core.LanguageCodeRuby:       "/",
+	core.LanguageCodePhp:        "\\",
+	core.LanguageCodeCsharp:     ".",
+	core.LanguageCodeKotlin:     ".",
+	core.LanguageCodeC:          "/",
+	core.LanguageCodeCpp:        "::",
 }
 
 func resolveNamespaceWithSeparator(moduleName string, lang core.Language) string {
@@ -93,6 +139,16 @@ func resolveNamespaceWithSeparator(moduleName string, lang core.Language) string
 		moduleName = strings.Trim(moduleName, "\"")
 	}
 
+	// Strip the leading separator of Rust paths relative to the extern prelude eg. ::tokio::sync
+	if lang.Meta().Code == core.LanguageCodeRust {
+		moduleName = strings.TrimPrefix(moduleName, "::")
+	}
+
+	// Strip the leading separator of fully qualified PHP names eg. \GuzzleHttp\Client
+	if lang.Meta().Code == core.LanguageCodePhp {
+		moduleName = strings.TrimPrefix(moduleName, "\\")
+	}
+
 	separator, exists := submoduleSeparator[lang.Meta().Code]
 	if exists {
 		return strings.Join(strings.Split(moduleName, separator), namespaceSeparator)
@@ -28,6 +28,27 @@ var literalNodeTypes = map[string]bool{
 	"rune_literal":               true,
 	"raw_string_literal":         true,
 	"interpreted_string_literal": true,
+	// Ruby literals
+	"simple_symbol": true,
+	// PHP literals
+	"encapsed_string": true,
+	// Kotlin literals
+	"integer_literal":  true,
+	"long_literal":     true,
+	"hex_literal":      true,
+	"bin_literal":      true,
+	"unsigned_literal": true,
+	"real_literal":     true,
+	"boolean_literal":  true,
+	// C and C++ literals
+	"number_literal":      true,
+	"char_literal":        true,
+	"concatenated_string": true,
+	"nullptr":             true,
+	// Bash literals
+	"word":          true,
+	"raw_string":    true,
+	"ansi_c_string": true,
 }
 
 var initialisedDataStructures = map[string]bool{
@@ -41,4 +62,8 @@ var initialisedDataStructures = map[string]bool{
 	// Go data structures
 	"composite_literal": true,
 	"slice_expression":  true,
+	// Ruby data structures
+	"hash": true,
+	// PHP data structures
+	"array_creation_expression": true,
 }
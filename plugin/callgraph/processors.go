@@ -2,8 +2,11 @@ package callgraph
 
 import (
 	"fmt"
+	"path"
 	"regexp"
+	"slices"
 	"strings"
+	"unicode"
 
 	"github.com/safedep/code/core"
 	"github.com/safedep/dry/log"
@@ -66,14 +69,14 @@ var nodeProcessors map[string]nodeProcessor
 
 func init() {
 	nodeProcessors = map[string]nodeProcessor{
-		"module":               emptyProcessor,
+		"module":               moduleProcessorWrapper,
 		"program":              emptyProcessor,
 		"expression_statement": expressionStatementProcessorWrapper,
 		"binary_operator":      binaryOperatorProcessor,
 		"identifier":           identifierProcessor,
 		"class_definition":     classDefinitionProcessor,
-		"function_definition":  functionDefinitionProcessor,
-		"call":                 callProcessor,
+		"function_definition":  functionDefinitionProcessorWrapper,
+		"call":                 callProcessorWrapper,
 		"block":                emptyProcessor,
 		"try_statement":        emptyProcessor,
 		"catch_clause":         emptyProcessor,
@@ -83,7 +86,7 @@ func init() {
 		"arguments":            emptyProcessor,
 		"argument_list":        emptyProcessor,
 		"attribute":            attributeProcessor,
-		"assignment":           assignmentProcessor,
+		"assignment":           assignmentProcessorWrapper,
 		"subscript":            skippedProcessor,
 		"ternary_expression":   ternaryExpressionProcessor,
 
@@ -91,11 +94,11 @@ func init() {
 		"consequence":                skipResultsProcessor,
 		"alternative":                skipResultsProcessor,
 		"method_invocation":          methodInvocationProcessor,
-		"class_declaration":          classDefinitionProcessor,
+		"class_declaration":          classDeclarationProcessorWrapper,
 		"scoped_type_identifier":     scopedIdentifierProcessor,
-		"variable_declarator":        variableDeclaratorProcessor,
+		"variable_declarator":        variableDeclaratorProcessorWrapper,
 		"local_variable_declaration": localVariableDeclarationProcessor,
-		"object_creation_expression": objectCreationExpressionProcessor,
+		"object_creation_expression": objectCreationExpressionProcessorWrapper,
 		"method_declaration":         goMethodDeclarationProcessorWrapper,
 		"assignment_expression":      assignmentProcessor,
 
@@ -108,8 +111,63 @@ func init() {
 		"member_expression":   memberExpressionProcessor,
 		"arrow_function":      arrowFunctionProcessor,
 		"method_definition":   methodDefinitionProcessor,
-		"new_expression":      jsNewExpressionProcessor,
+		"new_expression":      newExpressionProcessorWrapper,
 		"lexical_declaration": lexicalDeclarationProcessor,
+
+		// TypeScript-specific
+		"abstract_class_declaration": classDefinitionProcessor,
+
+		// Rust-specific
+		"function_item":   rustFunctionItemProcessor,
+		"impl_item":       rustImplItemProcessor,
+		"trait_item":      rustImplItemProcessor,
+		"let_declaration": rustLetDeclarationProcessor,
+
+		// Ruby-specific
+		"class":             rubyClassProcessorWrapper,
+		"method":            functionDefinitionProcessor,
+		"singleton_method":  functionDefinitionProcessor,
+		"instance_variable": rubyInstanceVariableProcessor,
+
+		// PHP-specific
+		"interface_declaration":           typeDeclarationProcessorWrapper,
+		"trait_declaration":               typeDeclarationProcessorWrapper,
+		"enum_declaration":                typeDeclarationProcessorWrapper,
+		"function_call_expression":        phpFunctionCallProcessor,
+		"member_call_expression":          phpMemberCallProcessor,
+		"nullsafe_member_call_expression": phpMemberCallProcessor,
+		"scoped_call_expression":          phpScopedCallProcessor,
+		"member_access_expression":        memberAccessExpressionProcessorWrapper,
+		"variable_name":                   phpVariableNameProcessor,
+
+		// C#-specific
+		"struct_declaration":                typeDeclarationProcessorWrapper,
+		"record_declaration":                typeDeclarationProcessorWrapper,
+		"constructor_declaration":           constructorDeclarationProcessorWrapper,
+		"variable_declaration":              variableDeclarationProcessorWrapper,
+		"namespace_declaration":             csharpNamespaceDeclarationProcessor,
+		"file_scoped_namespace_declaration": csharpNamespaceDeclarationProcessor,
+		"invocation_expression":             csharpInvocationProcessor,
+
+		// Kotlin-specific
+		"object_declaration":    kotlinClassProcessor,
+		"companion_object":      kotlinCompanionObjectProcessor,
+		"secondary_constructor": kotlinFunctionDeclarationProcessor,
+		"property_declaration":  kotlinPropertyDeclarationProcessor,
+		"navigation_expression": kotlinNavigationExpressionProcessor,
+		"simple_identifier":     identifierProcessor,
+
+		// C and C++-specific
+		"namespace_definition": cppNamespaceDefinitionProcessor,
+		"class_specifier":      cppClassSpecifierProcessor,
+		"struct_specifier":     cppClassSpecifierProcessor,
+		"declaration":          cDeclarationProcessor,
+
+		// Bash-specific
+		"command":             bashCommandProcessor,
+		"variable_assignment": bashVariableAssignmentProcessor,
+		"simple_expansion":    bashExpansionProcessor,
+		"expansion":           bashExpansionProcessor,
 	}
 
 	for literalNodeType := range literalNodeTypes {
@@ -125,8 +183,12 @@ func init() {
 	skippedNodeTypes := []string{
 		// Imports
 		"import_statement", "import", "import_from_statement", "import_declaration",
+		"use_declaration", "extern_crate_declaration",
+		"namespace_use_declaration", "require_expression", "require_once_expression",
+		"include_expression", "include_once_expression", "using_directive",
+		"import_list", "package_header", "preproc_include",
 		// Comments and fillers
-		"comment", "whitespace", "newline", "line_comment",
+		"comment", "whitespace", "newline", "line_comment", "block_comment",
 		// Operators
 		"+", "-", "*", "/", "%", "**", "//", "=", "+=", "-=", "*=", "/=", "%=",
 		// Symbols
@@ -309,8 +371,11 @@ func functionDefinitionProcessor(functionDefNode *sitter.Node, treeData []byte,
 				log.Debugf("Register instance member function definition for %s - %s\n", funcName, instanceNamespace)
 			}
 
-			// Python - Register direct call from current namespace to class constructor
-			if treeLanguage.Meta().Code == core.LanguageCodePython && funcName == "__init__" {
+			// Python, Ruby, PHP and C# - Register direct call from current namespace to class constructor
+			if treeLanguage.Meta().Code == core.LanguageCodePython && funcName == "__init__" ||
+				treeLanguage.Meta().Code == core.LanguageCodeRuby && funcName == "initialize" ||
+				treeLanguage.Meta().Code == core.LanguageCodePhp && strings.EqualFold(funcName, "__construct") ||
+				treeLanguage.Meta().Code == core.LanguageCodeCsharp && functionDefNode.Type() == "constructor_declaration" {
 				callGraph.addEdge(
 					currentNamespace, nil, nil,
 					functionNamespace, functionDefNode,
@@ -376,6 +441,24 @@ func assignmentProcessor(node *sitter.Node, treeData []byte, currentNamespace st
 		assigneeNodes = attributeResult.ImmediateAssignments
 	}
 
+	if leftNode.Type() == "instance_variable" {
+		// Ruby - eg. @client = Client.new within a method of class Api
+		// must be resolved to Api//self//@client (assigned)=> Client
+		if instanceNamespace, ok := rubyInstanceNamespace(currentNamespace, callGraph, metadata); ok {
+			assigneeNodes = append(assigneeNodes, callGraph.assignmentGraph.addNode(
+				instanceNamespace+namespaceSeparator+leftNode.Content(treeData),
+				leftNode,
+			))
+		}
+	}
+
+	if leftNode.Type() == "member_access_expression" {
+		// PHP and C# - eg. $this->client = new Client() within a method of class Api
+		// must be resolved to Api//$this//client (assigned)=> GuzzleHttp//Client
+		memberAccessResult := memberAccessExpressionProcessorWrapper(leftNode, treeData, currentNamespace, callGraph, metadata)
+		assigneeNodes = memberAccessResult.ImmediateAssignments
+	}
+
 	// Create new fallback assignment node for leftNode if not found
 	if len(assigneeNodes) == 0 {
 		assigneeNodes = []*assignmentNode{
@@ -526,11 +609,20 @@ func callProcessor(callNode *sitter.Node, treeData []byte, currentNamespace stri
 
 	functionNode := callNode.ChildByFieldName("function")
 	argumentsNode := callNode.ChildByFieldName("arguments")
-	if functionNode != nil {
-		return functionCallProcessor(functionNode, argumentsNode, treeData, currentNamespace, callGraph, metadata)
+	if functionNode == nil {
+		return newProcessorResult()
 	}
 
-	return newProcessorResult()
+	result := functionCallProcessor(functionNode, argumentsNode, treeData, currentNamespace, callGraph, metadata)
+
+	// Calls importing a module at runtime return the module
+	// eg. http = importlib.import_module("requests") assigns http => requests
+	if moduleNamespace, ok := callGraph.dynamicImportCalls[callNode.StartByte()]; ok {
+		result.ImmediateAssignments = append(result.ImmediateAssignments,
+			callGraph.assignmentGraph.addNode(moduleNamespace, callNode))
+	}
+
+	return result
 }
 
 // resolveCallArguments processes the arguments of a function call as usual
@@ -1111,8 +1203,14 @@ func callExpressionProcessorWrapper(node *sitter.Node, treeData []byte, currentN
 	switch treeLanguage.Meta().Code {
 	case core.LanguageCodeGo:
 		return goCallExpressionProcessor(node, treeData, currentNamespace, callGraph, metadata)
-	case core.LanguageCodeJavascript:
+	case core.LanguageCodeJavascript, core.LanguageCodeTypescript:
 		return jsCallExpressionProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	case core.LanguageCodeRust:
+		return rustCallExpressionProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	case core.LanguageCodeKotlin:
+		return kotlinCallExpressionProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	case core.LanguageCodeC, core.LanguageCodeCpp:
+		return cCallExpressionProcessor(node, treeData, currentNamespace, callGraph, metadata)
 	default:
 		return newProcessorResult()
 	}
@@ -1127,8 +1225,10 @@ func functionDeclarationProcessorWrapper(node *sitter.Node, treeData []byte, cur
 	switch treeLanguage.Meta().Code {
 	case core.LanguageCodeGo:
 		return goFunctionDeclarationProcessor(node, treeData, currentNamespace, callGraph, metadata)
-	case core.LanguageCodeJavascript:
+	case core.LanguageCodeJavascript, core.LanguageCodeTypescript:
 		return jsFunctionDeclarationProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	case core.LanguageCodeKotlin:
+		return kotlinFunctionDeclarationProcessor(node, treeData, currentNamespace, callGraph, metadata)
 	default:
 		// Fallback to default function definition processor for other languages
 		return functionDefinitionProcessor(node, treeData, currentNamespace, callGraph, metadata)
@@ -1219,6 +1319,12 @@ func resolveGoCallArguments(argumentsNode *sitter.Node, treeData []byte, current
 		return []CallArgument{}
 	}
 
+	return resolvePositionalCallArguments(argumentsNode, treeData, currentNamespace, callGraph, metadata)
+}
+
+// resolvePositionalCallArguments resolves each named child of an arguments node
+// as a positional argument, registering calls made within the arguments
+func resolvePositionalCallArguments(argumentsNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) []CallArgument {
 	result := make([]CallArgument, 0, argumentsNode.NamedChildCount())
 
 	for i := 0; uint32(i) < argumentsNode.NamedChildCount(); i++ {
@@ -1870,3 +1976,2592 @@ func jsNewExpressionProcessor(newNode *sitter.Node, treeData []byte, currentName
 
 	return result
 }
+
+// Rust-specific ------
+
+// rustFunctionItemProcessor handles Rust fn items, both free functions and
+// associated functions within impl and trait blocks
+func rustFunctionItemProcessor(funcDefNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if funcDefNode == nil {
+		return newProcessorResult()
+	}
+
+	functionNameNode := funcDefNode.ChildByFieldName("name")
+	if functionNameNode == nil {
+		log.Errorf("Rust function item without name - %s", funcDefNode.Content(treeData))
+		return newProcessorResult()
+	}
+
+	funcName := functionNameNode.Content(treeData)
+	functionNamespace := currentNamespace + namespaceSeparator + funcName
+
+	// Similar to Go, module level functions are registered as callable from
+	// the root namespace since library crates have no main function
+	if !metadata.insideClass && !metadata.insideFunction {
+		callGraph.addEdge(
+			currentNamespace, nil, nil,
+			functionNamespace, funcDefNode,
+			[]CallArgument{},
+		)
+	}
+
+	if _, exists := callGraph.Nodes[functionNamespace]; !exists {
+		callGraph.addNode(functionNamespace, funcDefNode)
+		log.Debugf("Register Rust function definition for %s - %s", funcName, functionNamespace)
+	}
+
+	results := newProcessorResult()
+
+	functionBody := funcDefNode.ChildByFieldName("body")
+	if functionBody != nil {
+		metadata.insideFunction = true
+		result := processChildren(functionBody, treeData, functionNamespace, callGraph, metadata)
+		metadata.insideFunction = false
+		results.addResults(result)
+	}
+
+	return results
+}
+
+// rustImplItemProcessor handles impl and trait blocks. Associated functions
+// are namespaced by the implementing type or the trait
+// eg. impl fmt::Display for Counter { fn fmt() } -> file//Counter//fmt
+func rustImplItemProcessor(implNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if implNode == nil {
+		return newProcessorResult()
+	}
+
+	typeNode := implNode.ChildByFieldName("type")
+	if implNode.Type() == "trait_item" {
+		typeNode = implNode.ChildByFieldName("name")
+	}
+
+	typeName := rustTypeName(typeNode, treeData)
+	if typeName == "" {
+		log.Errorf("Rust %s without type name - %s", implNode.Type(), implNode.Content(treeData))
+		return newProcessorResult()
+	}
+
+	typeNamespace := currentNamespace + namespaceSeparator + typeName
+	callGraph.assignmentGraph.addNode(typeNamespace, typeNode)
+
+	body := implNode.ChildByFieldName("body")
+	if body != nil {
+		metadata.insideClass = true
+		processChildren(body, treeData, typeNamespace, callGraph, metadata)
+		metadata.insideClass = false
+	}
+
+	return newProcessorResult()
+}
+
+// rustTypeName returns the name of a type without its path and generic arguments
+// eg. Wrapper<T> -> Wrapper, fmt::Formatter -> Formatter
+func rustTypeName(typeNode *sitter.Node, treeData []byte) string {
+	if typeNode == nil {
+		return ""
+	}
+
+	switch typeNode.Type() {
+	case "type_identifier", "identifier":
+		return typeNode.Content(treeData)
+	case "generic_type":
+		return rustTypeName(typeNode.ChildByFieldName("type"), treeData)
+	case "scoped_type_identifier":
+		return rustTypeName(typeNode.ChildByFieldName("name"), treeData)
+	}
+
+	return ""
+}
+
+// rustLetDeclarationProcessor handles let bindings of a single identifier
+// eg. let mut client = Client::new(); Patterns destructuring the value are
+// not tracked, calls within the value are still processed
+func rustLetDeclarationProcessor(declarationNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if declarationNode == nil {
+		return newProcessorResult()
+	}
+
+	valueResult := newProcessorResult()
+
+	valueNode := declarationNode.ChildByFieldName("value")
+	if valueNode != nil {
+		valueResult.addResults(processNode(valueNode, treeData, currentNamespace, callGraph, metadata))
+	}
+
+	patternNode := declarationNode.ChildByFieldName("pattern")
+	if patternNode == nil || patternNode.Type() != "identifier" {
+		return newProcessorResult()
+	}
+
+	variableNamespace := currentNamespace + namespaceSeparator + patternNode.Content(treeData)
+	callGraph.assignmentGraph.addNode(variableNamespace, patternNode)
+
+	for _, immediateAssignment := range valueResult.ImmediateAssignments {
+		callGraph.assignmentGraph.addAssignment(
+			variableNamespace, patternNode,
+			immediateAssignment.Namespace, immediateAssignment.TreeNode,
+		)
+	}
+
+	return newProcessorResult()
+}
+
+// rustCallExpressionProcessor handles Rust call_expression nodes
+// Examples:
+// - reqwest::get(url) -> reqwest//get
+// - json::to_string(&value) -> serde_json//to_string (use serde_json as json)
+// - Self::new() -> file//Counter//new
+// - client.send() -> resolved type of client//send
+// - helper(10) -> helper (unqualified function call)
+func rustCallExpressionProcessor(callNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if callNode == nil {
+		return newProcessorResult()
+	}
+
+	result := newProcessorResult()
+
+	functionNode := callNode.ChildByFieldName("function")
+	if functionNode == nil {
+		return result
+	}
+
+	callArguments := []CallArgument{}
+	if argumentsNode := callNode.ChildByFieldName("arguments"); argumentsNode != nil {
+		callArguments = resolveRustCallArguments(argumentsNode, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	qualifiedName, resolved := resolveRustCallee(functionNode, treeData, currentNamespace, callGraph, metadata)
+	if !resolved {
+		return result
+	}
+
+	callGraph.addEdge(
+		currentNamespace, nil, functionNode,
+		qualifiedName, nil,
+		callArguments,
+	)
+
+	log.Debugf("Rust call: %s -> %s", currentNamespace, qualifiedName)
+
+	// By convention Type::new() constructs the type, hence the value
+	// is tracked as an instance of the type eg. let c = Client::new()
+	if typeNamespace, isConstructor := strings.CutSuffix(qualifiedName, namespaceSeparator+"new"); isConstructor && functionNode.Type() == "scoped_identifier" {
+		result.ImmediateAssignments = append(result.ImmediateAssignments,
+			callGraph.assignmentGraph.addNode(typeNamespace, nil))
+	}
+
+	return result
+}
+
+// resolveRustCallee resolves the function node of a call expression
+func resolveRustCallee(functionNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) (string, bool) {
+	switch functionNode.Type() {
+	case "identifier":
+		return resolveGoIdentifier(functionNode.Content(treeData), currentNamespace, callGraph)
+	case "scoped_identifier":
+		return resolveRustScopedIdentifier(functionNode, treeData, currentNamespace, callGraph, metadata)
+	case "field_expression":
+		return resolveRustFieldExpression(functionNode, treeData, currentNamespace, callGraph, metadata)
+	case "generic_function":
+		// Turbofish calls eg. parse::<i32>()
+		if innerFunctionNode := functionNode.ChildByFieldName("function"); innerFunctionNode != nil {
+			return resolveRustCallee(innerFunctionNode, treeData, currentNamespace, callGraph, metadata)
+		}
+	}
+
+	return functionNode.Content(treeData), true
+}
+
+// resolveRustScopedIdentifier resolves path qualified names eg. reqwest::get
+// The first segment is resolved through imports and the scope chain, Self
+// refers to the type of the enclosing impl block
+func resolveRustScopedIdentifier(scopedNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) (string, bool) {
+	segments := rustPathSegments(scopedNode, treeData)
+	if len(segments) == 0 {
+		return "", false
+	}
+
+	qualifier := segments[0]
+	remaining := strings.Join(segments[1:], namespaceSeparator)
+
+	if qualifier == "Self" {
+		if selfNamespace, ok := rustSelfNamespace(currentNamespace, metadata); ok {
+			return selfNamespace + namespaceSeparator + remaining, true
+		}
+	}
+
+	if qualifierAssignment, found := searchSymbolInScopeChain(qualifier, currentNamespace, callGraph); found {
+		resolvedObjects := callGraph.assignmentGraph.resolve(qualifierAssignment.Namespace)
+		if len(resolvedObjects) > 0 {
+			return resolvedObjects[0].Namespace + namespaceSeparator + remaining, true
+		}
+	}
+
+	// Fully qualified path without an import eg. std::fs::read_to_string
+	return strings.Join(segments, namespaceSeparator), true
+}
+
+// rustPathSegments returns the segments of a scoped identifier
+// eg. ::std::fs::read -> [std, fs, read]
+func rustPathSegments(node *sitter.Node, treeData []byte) []string {
+	if node == nil {
+		return []string{}
+	}
+
+	switch node.Type() {
+	case "scoped_identifier":
+		segments := rustPathSegments(node.ChildByFieldName("path"), treeData)
+		if nameNode := node.ChildByFieldName("name"); nameNode != nil {
+			segments = append(segments, nameNode.Content(treeData))
+		}
+		return segments
+	case "generic_type":
+		return rustPathSegments(node.ChildByFieldName("type"), treeData)
+	}
+
+	return []string{node.Content(treeData)}
+}
+
+// resolveRustFieldExpression resolves method calls on values eg. client.get(url)
+func resolveRustFieldExpression(fieldExpressionNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) (string, bool) {
+	valueNode := fieldExpressionNode.ChildByFieldName("value")
+	fieldNode := fieldExpressionNode.ChildByFieldName("field")
+	if valueNode == nil || fieldNode == nil {
+		return "", false
+	}
+
+	fieldName := fieldNode.Content(treeData)
+
+	switch valueNode.Type() {
+	case "self":
+		if selfNamespace, ok := rustSelfNamespace(currentNamespace, metadata); ok {
+			return selfNamespace + namespaceSeparator + fieldName, true
+		}
+	case "identifier":
+		valueAssignment, found := searchSymbolInScopeChain(valueNode.Content(treeData), currentNamespace, callGraph)
+		if found {
+			resolvedObjects := callGraph.assignmentGraph.resolve(valueAssignment.Namespace)
+			if len(resolvedObjects) > 0 {
+				return resolvedObjects[0].Namespace + namespaceSeparator + fieldName, true
+			}
+		}
+	default:
+		// Calls chained on the value eg. Client::new().get(url)
+		processNode(valueNode, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	return valueNode.Content(treeData) + namespaceSeparator + fieldName, true
+}
+
+// rustSelfNamespace returns the namespace of the type of the enclosing impl
+// block, associated functions are namespaced as file//Type//function
+func rustSelfNamespace(currentNamespace string, metadata processorMetadata) (string, bool) {
+	if !metadata.insideClass {
+		return "", false
+	}
+
+	index := strings.LastIndex(currentNamespace, namespaceSeparator)
+	if index < 0 {
+		return "", false
+	}
+
+	return currentNamespace[:index], true
+}
+
+// resolveRustCallArguments resolves the arguments of a Rust call expression
+func resolveRustCallArguments(argumentsNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) []CallArgument {
+	if argumentsNode == nil {
+		return []CallArgument{}
+	}
+
+	if argumentsNode.Type() != "arguments" {
+		log.Errorf("Expected arguments node, got %s for %s", argumentsNode.Type(), argumentsNode.Content(treeData))
+		return []CallArgument{}
+	}
+
+	return resolvePositionalCallArguments(argumentsNode, treeData, currentNamespace, callGraph, metadata)
+}
+
+// Ruby-specific ------
+
+// Methods of Kernel loading other files, imports are resolved separately
+var rubyImportMethods = map[string]bool{
+	"require":          true,
+	"require_relative": true,
+	"load":             true,
+}
+
+func callProcessorWrapper(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	treeLanguage, err := callGraph.Tree.Language()
+	if err != nil {
+		return newProcessorResult()
+	}
+
+	if treeLanguage.Meta().Code == core.LanguageCodeRuby {
+		return rubyCallProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	return callProcessor(node, treeData, currentNamespace, callGraph, metadata)
+}
+
+// moduleProcessorWrapper handles module nodes, which are the root node
+// of Python files and module definitions in Ruby
+func moduleProcessorWrapper(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	treeLanguage, err := callGraph.Tree.Language()
+	if err != nil {
+		return newProcessorResult()
+	}
+
+	if treeLanguage.Meta().Code == core.LanguageCodeRuby {
+		return rubyClassProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	return emptyProcessor(node, treeData, currentNamespace, callGraph, metadata)
+}
+
+func rubyClassProcessorWrapper(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	treeLanguage, err := callGraph.Tree.Language()
+	if err != nil {
+		return newProcessorResult()
+	}
+
+	if treeLanguage.Meta().Code == core.LanguageCodeRuby {
+		return rubyClassProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	return emptyProcessor(node, treeData, currentNamespace, callGraph, metadata)
+}
+
+// rubyClassProcessor handles Ruby classes and modules. Nested definitions
+// are namespaced by their path eg. class Admin::User -> file//Admin//User
+func rubyClassProcessor(classNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	// Keywords share the type of the definition eg. class in class << self
+	if classNode == nil || !classNode.IsNamed() {
+		return newProcessorResult()
+	}
+
+	classNameNode := classNode.ChildByFieldName("name")
+	if classNameNode == nil {
+		log.Errorf("Ruby %s without name - %s", classNode.Type(), classNode.Content(treeData))
+		return newProcessorResult()
+	}
+
+	classNamespace := currentNamespace + namespaceSeparator + strings.Join(rubyConstantPath(classNameNode, treeData), namespaceSeparator)
+	callGraph.addNode(classNamespace, classNode)
+	callGraph.assignmentGraph.addNode(classNamespace, classNode)
+
+	// Modules can't be instantiated
+	if classNode.Type() == "class" {
+		callGraph.classConstructors[classNamespace] = true
+	}
+
+	instanceKeyword, exists := callGraph.getInstanceKeyword()
+	if exists {
+		callGraph.addNode(classNamespace+namespaceSeparator+instanceKeyword, nil)
+	}
+
+	// Superclasses may be created by calls eg. Struct.new(:x, :y)
+	if superclassNode := classNode.ChildByFieldName("superclass"); superclassNode != nil {
+		processNode(superclassNode, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	classBody := classNode.ChildByFieldName("body")
+	if classBody != nil {
+		metadata.insideClass = true
+		metadata.insideFunction = false
+		processChildren(classBody, treeData, classNamespace, callGraph, metadata)
+	}
+
+	log.Debugf("Register Ruby %s definition - %s", classNode.Type(), classNamespace)
+
+	return newProcessorResult()
+}
+
+// rubyCallProcessor handles Ruby method calls. Calls without a receiver
+// are sent to the implicit self receiver
+// Examples:
+// - JSON.parse(payload) -> JSON//parse
+// - Net::HTTP.get(uri) -> Net//HTTP//get
+// - helper(x) within class Api -> file//Api//self//helper
+// - @client.fetch(path) -> resolved class of @client//fetch
+// - Client.new(url) -> file//Client (constructor)
+func rubyCallProcessor(callNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if callNode == nil {
+		return newProcessorResult()
+	}
+
+	result := newProcessorResult()
+
+	methodNode := callNode.ChildByFieldName("method")
+	if methodNode == nil {
+		return result
+	}
+
+	methodName := methodNode.Content(treeData)
+	receiverNode := callNode.ChildByFieldName("receiver")
+	if receiverNode == nil && rubyImportMethods[methodName] {
+		return result
+	}
+
+	callArguments := []CallArgument{}
+	if argumentsNode := callNode.ChildByFieldName("arguments"); argumentsNode != nil {
+		callArguments = resolveCallArguments(argumentsNode, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	// Calls within blocks are made by the enclosing method
+	if blockNode := callNode.ChildByFieldName("block"); blockNode != nil {
+		processNode(blockNode, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	// new without a receiver within a class constructs the class itself
+	if receiverNode == nil && methodName == "new" {
+		if classNamespace, ok := rubyClassNamespace(currentNamespace, metadata); ok {
+			callGraph.addEdge(currentNamespace, nil, methodNode, classNamespace, nil, callArguments)
+			result.ImmediateAssignments = append(result.ImmediateAssignments,
+				callGraph.assignmentGraph.addNode(classNamespace, nil))
+			return result
+		}
+	}
+
+	if receiverNode == nil {
+		qualifiedName := resolveRubyImplicitReceiverCall(methodName, currentNamespace, callGraph, metadata)
+		callGraph.addEdge(currentNamespace, nil, methodNode, qualifiedName, nil, callArguments)
+		log.Debugf("Ruby call: %s -> %s", currentNamespace, qualifiedName)
+		return result
+	}
+
+	for _, receiverNamespace := range resolveRubyReceiver(receiverNode, treeData, currentNamespace, callGraph, metadata) {
+		qualifiedName := receiverNamespace + namespaceSeparator + methodName
+
+		// Class.new calls the initializer of the class and returns an instance
+		if methodName == "new" {
+			if callGraph.classConstructors[receiverNamespace] {
+				qualifiedName = receiverNamespace
+			}
+
+			result.ImmediateAssignments = append(result.ImmediateAssignments,
+				callGraph.assignmentGraph.addNode(receiverNamespace, nil))
+		}
+
+		callGraph.addEdge(currentNamespace, nil, methodNode, qualifiedName, nil, callArguments)
+		log.Debugf("Ruby call: %s -> %s", currentNamespace, qualifiedName)
+	}
+
+	return result
+}
+
+// resolveRubyImplicitReceiverCall resolves calls without a receiver. Methods
+// are searched in the scope chain, followed by the instance of the enclosing
+// class since methods may be defined later or inherited. Top level methods
+// are private methods of the main object, hence namespaced by the file
+func resolveRubyImplicitReceiverCall(methodName string, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) string {
+	if methodAssignment, found := searchSymbolInScopeChain(methodName, currentNamespace, callGraph); found {
+		return methodAssignment.Namespace
+	}
+
+	if instanceNamespace, ok := rubyInstanceNamespace(currentNamespace, callGraph, metadata); ok {
+		return instanceNamespace + namespaceSeparator + methodName
+	}
+
+	return resolveRootNamespaceQualifier(currentNamespace) + namespaceSeparator + methodName
+}
+
+// resolveRubyReceiver resolves the receiver of a call to the namespaces of the
+// objects it may refer to, falling back to the receiver as written
+func resolveRubyReceiver(receiverNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) []string {
+	resolveSymbol := func(symbol string) []string {
+		symbolAssignment, found := searchSymbolInScopeChain(symbol, currentNamespace, callGraph)
+		if !found {
+			return []string{}
+		}
+
+		namespaces := []string{}
+		for _, resolvedObject := range callGraph.assignmentGraph.resolve(symbolAssignment.Namespace) {
+			namespaces = append(namespaces, resolvedObject.Namespace)
+		}
+
+		return namespaces
+	}
+
+	switch receiverNode.Type() {
+	case "self":
+		if instanceNamespace, ok := rubyInstanceNamespace(currentNamespace, callGraph, metadata); ok {
+			return []string{instanceNamespace}
+		}
+
+		return []string{resolveRootNamespaceQualifier(currentNamespace)}
+	case "identifier", "constant":
+		if namespaces := resolveSymbol(receiverNode.Content(treeData)); len(namespaces) > 0 {
+			return namespaces
+		}
+	case "instance_variable":
+		instanceVariableResult := rubyInstanceVariableProcessor(receiverNode, treeData, currentNamespace, callGraph, metadata)
+		namespaces := []string{}
+		for _, instanceVariable := range instanceVariableResult.ImmediateAssignments {
+			for _, resolvedObject := range callGraph.assignmentGraph.resolve(instanceVariable.Namespace) {
+				namespaces = append(namespaces, resolvedObject.Namespace)
+			}
+		}
+
+		if len(namespaces) > 0 {
+			return namespaces
+		}
+	case "scope_resolution":
+		// The first constant of the path is resolved eg. Admin::User within module Admin
+		segments := rubyConstantPath(receiverNode, treeData)
+		remaining := strings.Join(segments[1:], namespaceSeparator)
+
+		namespaces := []string{}
+		for _, namespace := range resolveSymbol(segments[0]) {
+			namespaces = append(namespaces, namespace+namespaceSeparator+remaining)
+		}
+
+		if len(namespaces) > 0 {
+			return namespaces
+		}
+
+		return []string{strings.Join(segments, namespaceSeparator)}
+	default:
+		// Calls chained on the receiver eg. Client.new(url).fetch(path)
+		receiverResult := processNode(receiverNode, treeData, currentNamespace, callGraph, metadata)
+
+		namespaces := []string{}
+		for _, immediateAssignment := range receiverResult.ImmediateAssignments {
+			for _, resolvedObject := range callGraph.assignmentGraph.resolve(immediateAssignment.Namespace) {
+				namespaces = append(namespaces, resolvedObject.Namespace)
+			}
+		}
+
+		if len(namespaces) > 0 {
+			return namespaces
+		}
+	}
+
+	return []string{receiverNode.Content(treeData)}
+}
+
+// rubyInstanceVariableProcessor resolves instance variables eg. @client
+// to their namespace within the instance of the enclosing class
+func rubyInstanceVariableProcessor(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	result := newProcessorResult()
+	if node == nil {
+		return result
+	}
+
+	instanceNamespace, ok := rubyInstanceNamespace(currentNamespace, callGraph, metadata)
+	if !ok {
+		return result
+	}
+
+	result.ImmediateAssignments = append(result.ImmediateAssignments, callGraph.assignmentGraph.addNode(
+		instanceNamespace+namespaceSeparator+node.Content(treeData),
+		node,
+	))
+
+	return result
+}
+
+// rubyInstanceNamespace returns the namespace of self within a class or
+// module eg. file//Api//self for methods and the body of class Api
+func rubyInstanceNamespace(currentNamespace string, callGraph *CallGraph, metadata processorMetadata) (string, bool) {
+	classNamespace, ok := rubyClassNamespace(currentNamespace, metadata)
+	if !ok {
+		return "", false
+	}
+
+	instanceKeyword, exists := callGraph.getInstanceKeyword()
+	if !exists {
+		return "", false
+	}
+
+	return classNamespace + namespaceSeparator + instanceKeyword, true
+}
+
+// rubyClassNamespace returns the namespace of the enclosing class or module,
+// methods are namespaced as file//Class//method
+func rubyClassNamespace(currentNamespace string, metadata processorMetadata) (string, bool) {
+	if !metadata.insideClass {
+		return "", false
+	}
+
+	if !metadata.insideFunction {
+		return currentNamespace, true
+	}
+
+	index := strings.LastIndex(currentNamespace, namespaceSeparator)
+	if index < 0 {
+		return "", false
+	}
+
+	return currentNamespace[:index], true
+}
+
+// rubyConstantPath returns the segments of a constant path eg. Net::HTTP -> [Net, HTTP]
+func rubyConstantPath(node *sitter.Node, treeData []byte) []string {
+	if node == nil {
+		return []string{}
+	}
+
+	if node.Type() != "scope_resolution" {
+		return []string{node.Content(treeData)}
+	}
+
+	// Paths relative to the top level eg. ::Rails have no scope
+	segments := rubyConstantPath(node.ChildByFieldName("scope"), treeData)
+	if nameNode := node.ChildByFieldName("name"); nameNode != nil {
+		segments = append(segments, nameNode.Content(treeData))
+	}
+
+	return segments
+}
+
+// PHP-specific ------
+
+// Scopes referring to the enclosing class eg. self::create(), new static()
+var phpClassScopeKeywords = map[string]bool{
+	"self":   true,
+	"static": true,
+}
+
+// objectCreationExpressionProcessorWrapper handles object_creation_expression
+// nodes, which are shared by Java, PHP and C#
+func objectCreationExpressionProcessorWrapper(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	treeLanguage, err := callGraph.Tree.Language()
+	if err != nil {
+		return newProcessorResult()
+	}
+
+	switch treeLanguage.Meta().Code {
+	case core.LanguageCodePhp:
+		return phpObjectCreationProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	case core.LanguageCodeCsharp:
+		return csharpObjectCreationProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	return objectCreationExpressionProcessor(node, treeData, currentNamespace, callGraph, metadata)
+}
+
+// typeDeclarationProcessorWrapper handles interfaces, traits and enums of PHP and
+// interfaces, structs and records of C# as classes, declarations of other
+// languages sharing these node types are processed as usual
+func typeDeclarationProcessorWrapper(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	treeLanguage, err := callGraph.Tree.Language()
+	if err != nil {
+		return newProcessorResult()
+	}
+
+	switch treeLanguage.Meta().Code {
+	case core.LanguageCodePhp, core.LanguageCodeCsharp:
+		return classDefinitionProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	return emptyProcessor(node, treeData, currentNamespace, callGraph, metadata)
+}
+
+// memberAccessExpressionProcessorWrapper handles member_access_expression
+// nodes, which are shared by PHP and C#
+func memberAccessExpressionProcessorWrapper(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	treeLanguage, err := callGraph.Tree.Language()
+	if err != nil {
+		return newProcessorResult()
+	}
+
+	if treeLanguage.Meta().Code == core.LanguageCodeCsharp {
+		return csharpMemberAccessProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	return phpMemberAccessProcessor(node, treeData, currentNamespace, callGraph, metadata)
+}
+
+// phpFunctionCallProcessor handles PHP function calls. Unresolved functions
+// are global functions eg. functions provided by extensions
+// Examples:
+// - collect($items) imported with use function -> Illuminate//Support//collect
+// - openssl_encrypt($data, $cipher, $key) -> openssl_encrypt
+// - \Monolog\debug($message) -> Monolog//debug
+func phpFunctionCallProcessor(callNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if callNode == nil {
+		return newProcessorResult()
+	}
+
+	functionNode := callNode.ChildByFieldName("function")
+	if functionNode == nil {
+		return newProcessorResult()
+	}
+
+	callArguments := resolvePhpCallArguments(callNode.ChildByFieldName("arguments"), treeData, currentNamespace, callGraph, metadata)
+
+	var functionNamespaces []string
+	switch functionNode.Type() {
+	case "name", "qualified_name":
+		functionNamespaces = resolvePhpName(functionNode, treeData, currentNamespace, callGraph, metadata)
+	default:
+		// Callables held by variables eg. $handler($request)
+		functionNamespaces = resolvePhpReceiver(functionNode, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	for _, functionNamespace := range functionNamespaces {
+		callGraph.addEdge(currentNamespace, nil, functionNode, functionNamespace, nil, callArguments)
+		log.Debugf("PHP call: %s -> %s", currentNamespace, functionNamespace)
+	}
+
+	return newProcessorResult()
+}
+
+// phpMemberCallProcessor handles PHP method calls on objects
+// Examples:
+// - $this->urlFor($path) within class Api -> file//Api//$this//urlFor
+// - $this->client->get($url) -> resolved class of $this->client//get
+// - $api->fetch($path) -> resolved class of $api//fetch
+func phpMemberCallProcessor(callNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if callNode == nil {
+		return newProcessorResult()
+	}
+
+	objectNode := callNode.ChildByFieldName("object")
+	methodNode := callNode.ChildByFieldName("name")
+	if objectNode == nil || methodNode == nil {
+		return newProcessorResult()
+	}
+
+	callArguments := resolvePhpCallArguments(callNode.ChildByFieldName("arguments"), treeData, currentNamespace, callGraph, metadata)
+
+	for _, objectNamespace := range resolvePhpReceiver(objectNode, treeData, currentNamespace, callGraph, metadata) {
+		qualifiedName := objectNamespace + namespaceSeparator + methodNode.Content(treeData)
+		callGraph.addEdge(currentNamespace, nil, methodNode, qualifiedName, nil, callArguments)
+		log.Debugf("PHP call: %s -> %s", currentNamespace, qualifiedName)
+	}
+
+	return newProcessorResult()
+}
+
+// phpScopedCallProcessor handles PHP static method calls
+// Examples:
+// - self::log($message) within class Api -> file//Api//log
+// - Uuid::uuid4() imported with use -> Ramsey//Uuid//Uuid//uuid4
+// - \DateTime::createFromFormat($format, $value) -> DateTime//createFromFormat
+func phpScopedCallProcessor(callNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if callNode == nil {
+		return newProcessorResult()
+	}
+
+	scopeNode := callNode.ChildByFieldName("scope")
+	methodNode := callNode.ChildByFieldName("name")
+	if scopeNode == nil || methodNode == nil {
+		return newProcessorResult()
+	}
+
+	callArguments := resolvePhpCallArguments(callNode.ChildByFieldName("arguments"), treeData, currentNamespace, callGraph, metadata)
+
+	for _, scopeNamespace := range resolvePhpReceiver(scopeNode, treeData, currentNamespace, callGraph, metadata) {
+		qualifiedName := scopeNamespace + namespaceSeparator + methodNode.Content(treeData)
+		callGraph.addEdge(currentNamespace, nil, methodNode, qualifiedName, nil, callArguments)
+		log.Debugf("PHP call: %s -> %s", currentNamespace, qualifiedName)
+	}
+
+	return newProcessorResult()
+}
+
+// phpObjectCreationProcessor handles PHP object creation, which calls the
+// constructor of the class and returns an instance
+// Examples:
+// - new Client($config) imported with use -> GuzzleHttp//Client
+// - new static($attributes) within class Model -> file//Model
+func phpObjectCreationProcessor(creationNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	result := newProcessorResult()
+	if creationNode == nil {
+		return result
+	}
+
+	var classNode, argumentsNode *sitter.Node
+	for i := 0; i < int(creationNode.NamedChildCount()); i++ {
+		childNode := creationNode.NamedChild(i)
+		switch childNode.Type() {
+		case "arguments":
+			argumentsNode = childNode
+		default:
+			if classNode == nil {
+				classNode = childNode
+			}
+		}
+	}
+
+	// Anonymous classes eg. new class { ... } are not namespaced
+	if classNode == nil || classNode.Type() == "anonymous_class" {
+		return emptyProcessor(creationNode, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	callArguments := resolvePhpCallArguments(argumentsNode, treeData, currentNamespace, callGraph, metadata)
+
+	for _, classNamespace := range resolvePhpReceiver(classNode, treeData, currentNamespace, callGraph, metadata) {
+		callGraph.addEdge(currentNamespace, nil, classNode, classNamespace, nil, callArguments)
+		result.ImmediateAssignments = append(result.ImmediateAssignments,
+			callGraph.assignmentGraph.addNode(classNamespace, nil))
+		log.Debugf("PHP object created: %s -> %s", currentNamespace, classNamespace)
+	}
+
+	return result
+}
+
+// phpMemberAccessProcessor resolves properties of objects eg. $this->client
+// to their namespace within the resolved objects
+func phpMemberAccessProcessor(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	result := newProcessorResult()
+	if node == nil {
+		return result
+	}
+
+	objectNode := node.ChildByFieldName("object")
+	propertyNode := node.ChildByFieldName("name")
+	if objectNode == nil || propertyNode == nil {
+		return result
+	}
+
+	for _, objectNamespace := range resolvePhpReceiver(objectNode, treeData, currentNamespace, callGraph, metadata) {
+		result.ImmediateAssignments = append(result.ImmediateAssignments, callGraph.assignmentGraph.addNode(
+			objectNamespace+namespaceSeparator+propertyNode.Content(treeData),
+			node,
+		))
+	}
+
+	return result
+}
+
+// phpVariableNameProcessor resolves variables, $this refers to the
+// instance of the enclosing class
+func phpVariableNameProcessor(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	result := newProcessorResult()
+	if node == nil {
+		return result
+	}
+
+	instanceKeyword, exists := callGraph.getInstanceKeyword()
+	if !exists || node.Content(treeData) != instanceKeyword {
+		return identifierProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	if instanceNamespace, ok := phpInstanceNamespace(currentNamespace, callGraph, metadata); ok {
+		result.ImmediateAssignments = append(result.ImmediateAssignments,
+			callGraph.assignmentGraph.addNode(instanceNamespace, node))
+	}
+
+	return result
+}
+
+// resolvePhpReceiver resolves the object or class a method is called on
+// to the namespaces of the objects it may refer to
+func resolvePhpReceiver(receiverNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) []string {
+	switch receiverNode.Type() {
+	case "name", "qualified_name", "relative_scope":
+		return resolvePhpName(receiverNode, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	// Variables, properties and calls chained on the receiver eg. (new Client())->get($url)
+	receiverResult := processNode(receiverNode, treeData, currentNamespace, callGraph, metadata)
+
+	namespaces := []string{}
+	for _, immediateAssignment := range receiverResult.ImmediateAssignments {
+		for _, resolvedObject := range callGraph.assignmentGraph.resolve(immediateAssignment.Namespace) {
+			namespaces = append(namespaces, resolvedObject.Namespace)
+		}
+	}
+
+	return namespaces
+}
+
+// resolvePhpName resolves names of classes and functions. The first segment
+// of a name is resolved by the imports and definitions in scope, fully
+// qualified and unresolved names are used as written
+// eg. Console\Command with use Symfony\Component\Console -> Symfony//Component//Console//Command
+func resolvePhpName(nameNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) []string {
+	name := nameNode.Content(treeData)
+
+	if phpClassScopeKeywords[name] {
+		if classNamespace, ok := phpClassNamespace(currentNamespace, metadata); ok {
+			return []string{classNamespace}
+		}
+
+		return []string{}
+	}
+
+	// Parent classes are not resolved eg. parent::__construct()
+	if name == "parent" {
+		return []string{}
+	}
+
+	segments := strings.Split(name, "\\")
+	if strings.HasPrefix(name, "\\") {
+		return []string{strings.Join(segments[1:], namespaceSeparator)}
+	}
+
+	symbolAssignment, found := searchSymbolInScopeChain(segments[0], currentNamespace, callGraph)
+	if !found {
+		return []string{strings.Join(segments, namespaceSeparator)}
+	}
+
+	namespaces := []string{}
+	for _, resolvedObject := range callGraph.assignmentGraph.resolve(symbolAssignment.Namespace) {
+		namespaces = append(namespaces, strings.Join(append([]string{resolvedObject.Namespace}, segments[1:]...), namespaceSeparator))
+	}
+
+	return namespaces
+}
+
+// resolvePhpCallArguments resolves the arguments of a PHP call
+func resolvePhpCallArguments(argumentsNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) []CallArgument {
+	if argumentsNode == nil {
+		return []CallArgument{}
+	}
+
+	if argumentsNode.Type() != "arguments" {
+		log.Errorf("Expected arguments node, got %s for %s", argumentsNode.Type(), argumentsNode.Content(treeData))
+		return []CallArgument{}
+	}
+
+	return resolvePositionalCallArguments(argumentsNode, treeData, currentNamespace, callGraph, metadata)
+}
+
+// phpInstanceNamespace returns the namespace of $this within a class
+// eg. file//Api//$this for methods of class Api
+func phpInstanceNamespace(currentNamespace string, callGraph *CallGraph, metadata processorMetadata) (string, bool) {
+	classNamespace, ok := phpClassNamespace(currentNamespace, metadata)
+	if !ok {
+		return "", false
+	}
+
+	instanceKeyword, exists := callGraph.getInstanceKeyword()
+	if !exists {
+		return "", false
+	}
+
+	return classNamespace + namespaceSeparator + instanceKeyword, true
+}
+
+// phpClassNamespace returns the namespace of the enclosing class, interface,
+// trait or enum, methods are namespaced as file//Class//method
+func phpClassNamespace(currentNamespace string, metadata processorMetadata) (string, bool) {
+	if !metadata.insideClass {
+		return "", false
+	}
+
+	if !metadata.insideFunction {
+		return currentNamespace, true
+	}
+
+	index := strings.LastIndex(currentNamespace, namespaceSeparator)
+	if index < 0 {
+		return "", false
+	}
+
+	return currentNamespace[:index], true
+}
+
+// C#-specific ------
+
+// constructorDeclarationProcessorWrapper handles constructors of C# as functions
+// of the class, constructors of other languages are processed as usual
+func constructorDeclarationProcessorWrapper(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	treeLanguage, err := callGraph.Tree.Language()
+	if err != nil {
+		return newProcessorResult()
+	}
+
+	if treeLanguage.Meta().Code == core.LanguageCodeCsharp {
+		return functionDefinitionProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	return emptyProcessor(node, treeData, currentNamespace, callGraph, metadata)
+}
+
+// variableDeclarationProcessorWrapper handles variable_declaration nodes. Only
+// the declarators of C# declarations are processed, the declared type is not
+// an identifier in scope eg. private AmazonS3Client client;
+func variableDeclarationProcessorWrapper(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	treeLanguage, err := callGraph.Tree.Language()
+	if err != nil {
+		return newProcessorResult()
+	}
+
+	if treeLanguage.Meta().Code != core.LanguageCodeCsharp {
+		return emptyProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		childNode := node.NamedChild(i)
+		if childNode.Type() == "variable_declarator" {
+			processNode(childNode, treeData, currentNamespace, callGraph, metadata)
+		}
+	}
+
+	return newProcessorResult()
+}
+
+// variableDeclaratorProcessorWrapper handles variable_declarator nodes. The
+// initial value of a C# declarator is not a field of the node eg. var x = 1;
+func variableDeclaratorProcessorWrapper(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	treeLanguage, err := callGraph.Tree.Language()
+	if err != nil {
+		return newProcessorResult()
+	}
+
+	if treeLanguage.Meta().Code == core.LanguageCodeCsharp {
+		return csharpVariableDeclaratorProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	return variableDeclaratorProcessor(node, treeData, currentNamespace, callGraph, metadata)
+}
+
+// csharpNamespaceDeclarationProcessor processes the declarations of a C#
+// namespace within the file namespace, the namespace name is not a symbol
+func csharpNamespaceDeclarationProcessor(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	result := newProcessorResult()
+	if node == nil {
+		return result
+	}
+
+	nameNode := node.ChildByFieldName("name")
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		childNode := node.NamedChild(i)
+		if nameNode != nil && childNode.Equal(nameNode) {
+			continue
+		}
+
+		result.addResults(processNode(childNode, treeData, currentNamespace, callGraph, metadata))
+	}
+
+	return result
+}
+
+// csharpVariableDeclaratorProcessor assigns the initial value of a
+// declared variable eg. var client = new AmazonS3Client(region);
+func csharpVariableDeclaratorProcessor(declaratorNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if declaratorNode == nil {
+		return newProcessorResult()
+	}
+
+	nameNode := declaratorNode.ChildByFieldName("name")
+	if nameNode == nil {
+		return emptyProcessor(declaratorNode, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	valueNode := declaratorNode.NamedChild(int(declaratorNode.NamedChildCount()) - 1)
+	if valueNode == nil || valueNode.Equal(nameNode) {
+		return newProcessorResult()
+	}
+
+	valueResult := processNode(valueNode, treeData, currentNamespace, callGraph, metadata)
+
+	variableNamespace := currentNamespace + namespaceSeparator + nameNode.Content(treeData)
+	for _, immediateAssignment := range valueResult.ImmediateAssignments {
+		callGraph.assignmentGraph.addAssignment(
+			variableNamespace, nameNode,
+			immediateAssignment.Namespace, immediateAssignment.TreeNode,
+		)
+	}
+
+	return newProcessorResult()
+}
+
+// csharpInvocationProcessor handles C# method invocations. Unqualified methods
+// not defined in scope are members of the enclosing class
+// Examples:
+// - Log(message) within class Uploader -> file//Uploader//Log
+// - this.client.PutObject(bucket, body) -> resolved class of this.client//PutObject
+// - Json.SerializeObject(payload) with using Json = Newtonsoft.Json.JsonConvert -> Newtonsoft//Json//JsonConvert//SerializeObject
+// - Console.WriteLine(message) -> Console//WriteLine
+func csharpInvocationProcessor(invocationNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if invocationNode == nil {
+		return newProcessorResult()
+	}
+
+	functionNode := invocationNode.ChildByFieldName("function")
+	if functionNode == nil {
+		return newProcessorResult()
+	}
+
+	callArguments := resolveCallArguments(invocationNode.ChildByFieldName("arguments"), treeData, currentNamespace, callGraph, metadata)
+
+	calleeNode := functionNode
+	var functionNamespaces []string
+	switch functionNode.Type() {
+	case "identifier", "generic_name":
+		functionName := csharpNameSegments(functionNode, treeData)[0]
+		if _, found := searchSymbolInScopeChain(functionName, currentNamespace, callGraph); !found {
+			if classNamespace, ok := csharpClassNamespace(currentNamespace, metadata); ok {
+				functionNamespaces = []string{classNamespace + namespaceSeparator + functionName}
+				break
+			}
+		}
+
+		functionNamespaces = resolveCsharpName(functionNode, treeData, currentNamespace, callGraph)
+	case "member_access_expression":
+		objectNode := functionNode.ChildByFieldName("expression")
+		methodNode := functionNode.ChildByFieldName("name")
+		if objectNode == nil || methodNode == nil {
+			return newProcessorResult()
+		}
+
+		calleeNode = methodNode
+		methodName := csharpNameSegments(methodNode, treeData)[0]
+		for _, objectNamespace := range resolveCsharpReceiver(objectNode, treeData, currentNamespace, callGraph, metadata) {
+			functionNamespaces = append(functionNamespaces, objectNamespace+namespaceSeparator+methodName)
+		}
+	default:
+		// Delegates held by variables or returned by calls eg. handlers[0](request)
+		functionNamespaces = resolveCsharpReceiver(functionNode, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	for _, functionNamespace := range functionNamespaces {
+		callGraph.addEdge(currentNamespace, nil, calleeNode, functionNamespace, nil, callArguments)
+		log.Debugf("C# call: %s -> %s", currentNamespace, functionNamespace)
+	}
+
+	return newProcessorResult()
+}
+
+// csharpObjectCreationProcessor handles C# object creation, which calls the
+// constructor of the class and returns an instance
+// Examples:
+// - new AmazonS3Client(region) with using Amazon.S3 -> AmazonS3Client
+// - new Uploader(region) within the same file -> file//Uploader
+func csharpObjectCreationProcessor(creationNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	result := newProcessorResult()
+	if creationNode == nil {
+		return result
+	}
+
+	typeNode := creationNode.ChildByFieldName("type")
+	if typeNode == nil {
+		return emptyProcessor(creationNode, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	callArguments := resolveCallArguments(creationNode.ChildByFieldName("arguments"), treeData, currentNamespace, callGraph, metadata)
+
+	for _, classNamespace := range resolveCsharpName(typeNode, treeData, currentNamespace, callGraph) {
+		callGraph.addEdge(currentNamespace, nil, typeNode, classNamespace, nil, callArguments)
+		result.ImmediateAssignments = append(result.ImmediateAssignments,
+			callGraph.assignmentGraph.addNode(classNamespace, nil))
+		log.Debugf("C# object created: %s -> %s", currentNamespace, classNamespace)
+	}
+
+	return result
+}
+
+// csharpMemberAccessProcessor resolves members of objects and types
+// eg. this.client to their namespace within the resolved objects
+func csharpMemberAccessProcessor(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	result := newProcessorResult()
+	if node == nil {
+		return result
+	}
+
+	objectNode := node.ChildByFieldName("expression")
+	memberNode := node.ChildByFieldName("name")
+	if objectNode == nil || memberNode == nil {
+		return result
+	}
+
+	memberName := csharpNameSegments(memberNode, treeData)[0]
+	for _, objectNamespace := range resolveCsharpReceiver(objectNode, treeData, currentNamespace, callGraph, metadata) {
+		result.ImmediateAssignments = append(result.ImmediateAssignments, callGraph.assignmentGraph.addNode(
+			objectNamespace+namespaceSeparator+memberName,
+			node,
+		))
+	}
+
+	return result
+}
+
+// resolveCsharpReceiver resolves the object or type a member is accessed on
+// to the namespaces of the objects it may refer to
+func resolveCsharpReceiver(receiverNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) []string {
+	switch receiverNode.Type() {
+	case "this":
+		if instanceNamespace, ok := csharpInstanceNamespace(currentNamespace, callGraph, metadata); ok {
+			return []string{instanceNamespace}
+		}
+
+		return []string{}
+	case "base":
+		// Base classes are not resolved eg. base.Dispose()
+		return []string{}
+	case "identifier", "generic_name", "qualified_name", "alias_qualified_name", "predefined_type":
+		return resolveCsharpName(receiverNode, treeData, currentNamespace, callGraph)
+	}
+
+	// Members and calls chained on the receiver eg. this.client.PutObject(...)
+	receiverResult := processNode(receiverNode, treeData, currentNamespace, callGraph, metadata)
+
+	namespaces := []string{}
+	for _, immediateAssignment := range receiverResult.ImmediateAssignments {
+		for _, resolvedObject := range callGraph.assignmentGraph.resolve(immediateAssignment.Namespace) {
+			namespaces = append(namespaces, resolvedObject.Namespace)
+		}
+	}
+
+	return namespaces
+}
+
+// resolveCsharpName resolves names of types, variables and methods. The first
+// segment of a name is resolved by the usings and definitions in scope,
+// unresolved names are used as written
+// eg. S3.AmazonS3Client with using S3 = Amazon.S3 -> Amazon//S3//AmazonS3Client
+func resolveCsharpName(nameNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph) []string {
+	segments := csharpNameSegments(nameNode, treeData)
+
+	symbolAssignment, found := searchSymbolInScopeChain(segments[0], currentNamespace, callGraph)
+	if !found {
+		return []string{strings.Join(segments, namespaceSeparator)}
+	}
+
+	namespaces := []string{}
+	for _, resolvedObject := range callGraph.assignmentGraph.resolve(symbolAssignment.Namespace) {
+		objectNamespace := resolvedObject.Namespace
+
+		// Constructors share the name of their class eg. Uploader within class Uploader refers to the class
+		classNamespace := strings.TrimSuffix(objectNamespace, namespaceSeparator+segments[0])
+		if callGraph.classConstructors[classNamespace] && strings.HasSuffix(classNamespace, namespaceSeparator+segments[0]) {
+			objectNamespace = classNamespace
+		}
+
+		namespaces = append(namespaces, strings.Join(append([]string{objectNamespace}, segments[1:]...), namespaceSeparator))
+	}
+
+	return namespaces
+}
+
+// csharpInstanceNamespace returns the namespace of this within a class
+// eg. file//Uploader//this for methods of class Uploader
+func csharpInstanceNamespace(currentNamespace string, callGraph *CallGraph, metadata processorMetadata) (string, bool) {
+	classNamespace, ok := csharpClassNamespace(currentNamespace, metadata)
+	if !ok {
+		return "", false
+	}
+
+	instanceKeyword, exists := callGraph.getInstanceKeyword()
+	if !exists {
+		return "", false
+	}
+
+	return classNamespace + namespaceSeparator + instanceKeyword, true
+}
+
+// csharpClassNamespace returns the namespace of the enclosing class, struct,
+// record or interface, methods are namespaced as file//Class//Method
+func csharpClassNamespace(currentNamespace string, metadata processorMetadata) (string, bool) {
+	if !metadata.insideClass {
+		return "", false
+	}
+
+	if !metadata.insideFunction {
+		return currentNamespace, true
+	}
+
+	index := strings.LastIndex(currentNamespace, namespaceSeparator)
+	if index < 0 {
+		return "", false
+	}
+
+	return currentNamespace[:index], true
+}
+
+// csharpNameSegments splits a name into its segments without type arguments
+// eg. global::System.Collections.Generic.List<int> -> [System Collections Generic List]
+func csharpNameSegments(nameNode *sitter.Node, treeData []byte) []string {
+	switch nameNode.Type() {
+	case "qualified_name":
+		qualifierNode := nameNode.ChildByFieldName("qualifier")
+		nameChildNode := nameNode.ChildByFieldName("name")
+		if qualifierNode != nil && nameChildNode != nil {
+			return append(csharpNameSegments(qualifierNode, treeData), csharpNameSegments(nameChildNode, treeData)...)
+		}
+	case "generic_name":
+		if nameNode.NamedChildCount() > 0 {
+			return csharpNameSegments(nameNode.NamedChild(0), treeData)
+		}
+	case "alias_qualified_name":
+		if nameChildNode := nameNode.ChildByFieldName("name"); nameChildNode != nil {
+			return csharpNameSegments(nameChildNode, treeData)
+		}
+	}
+
+	return []string{nameNode.Content(treeData)}
+}
+
+// Kotlin-specific ------
+
+// classDeclarationProcessorWrapper handles class_declaration nodes, Kotlin
+// declarations have no named fields hence are processed separately
+func classDeclarationProcessorWrapper(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	treeLanguage, err := callGraph.Tree.Language()
+	if err != nil {
+		return newProcessorResult()
+	}
+
+	if treeLanguage.Meta().Code == core.LanguageCodeKotlin {
+		return kotlinClassProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	return classDefinitionProcessor(node, treeData, currentNamespace, callGraph, metadata)
+}
+
+// assignmentProcessorWrapper handles assignment nodes, Kotlin assignments
+// have no left and right fields hence are processed separately
+func assignmentProcessorWrapper(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	treeLanguage, err := callGraph.Tree.Language()
+	if err != nil {
+		return newProcessorResult()
+	}
+
+	if treeLanguage.Meta().Code == core.LanguageCodeKotlin {
+		return kotlinAssignmentProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	return assignmentProcessor(node, treeData, currentNamespace, callGraph, metadata)
+}
+
+// kotlinClassProcessor handles classes, interfaces and objects. Properties and
+// methods are members of the class namespace, hence this refers to the class.
+// Methods are registered before processing the body so that calls to methods
+// declared later in the class are resolved eg. log(message) -> file//Gateway//log
+func kotlinClassProcessor(classNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if classNode == nil {
+		return newProcessorResult()
+	}
+
+	classNameNode := kotlinChildOfType(classNode, "type_identifier")
+	if classNameNode == nil {
+		log.Errorf("Kotlin class declaration without name - %s", classNode.Content(treeData))
+		return newProcessorResult()
+	}
+
+	classNamespace := currentNamespace + namespaceSeparator + classNameNode.Content(treeData)
+	callGraph.addNode(classNamespace, classNode)
+
+	// Assignment is added so that calls to the class name resolve to its constructor
+	callGraph.assignmentGraph.addNode(classNamespace, classNode)
+	callGraph.classConstructors[classNamespace] = true
+
+	// Properties declared in the primary constructor eg. class Gateway(private val baseUrl: String)
+	if constructorNode := kotlinChildOfType(classNode, "primary_constructor"); constructorNode != nil {
+		for i := 0; i < int(constructorNode.NamedChildCount()); i++ {
+			parameterNode := constructorNode.NamedChild(i)
+			if parameterNode.Type() != "class_parameter" || kotlinChildOfType(parameterNode, "binding_pattern_kind") == nil {
+				continue
+			}
+
+			if parameterNameNode := kotlinChildOfType(parameterNode, "simple_identifier"); parameterNameNode != nil {
+				callGraph.assignmentGraph.addNode(classNamespace+namespaceSeparator+parameterNameNode.Content(treeData), parameterNameNode)
+			}
+		}
+	}
+
+	classBody := kotlinChildOfType(classNode, "class_body", "enum_class_body")
+	if classBody == nil {
+		return newProcessorResult()
+	}
+
+	kotlinRegisterMethods(classBody, treeData, classNamespace, callGraph)
+
+	metadata.insideClass = true
+	processChildren(classBody, treeData, classNamespace, callGraph, metadata)
+	metadata.insideClass = false
+
+	log.Debugf("Register Kotlin class declaration for %s - %s", classNameNode.Content(treeData), classNamespace)
+
+	return newProcessorResult()
+}
+
+// kotlinCompanionObjectProcessor processes members of a companion object as
+// members of the enclosing class eg. Gateway.create(url) -> file//Gateway//create
+func kotlinCompanionObjectProcessor(companionNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if companionNode == nil {
+		return newProcessorResult()
+	}
+
+	if classBody := kotlinChildOfType(companionNode, "class_body"); classBody != nil {
+		processChildren(classBody, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	return newProcessorResult()
+}
+
+// kotlinRegisterMethods registers the methods of a class body along with the
+// methods of its companion object within the class namespace
+func kotlinRegisterMethods(classBody *sitter.Node, treeData []byte, classNamespace string, callGraph *CallGraph) {
+	for i := 0; i < int(classBody.NamedChildCount()); i++ {
+		memberNode := classBody.NamedChild(i)
+		switch memberNode.Type() {
+		case "function_declaration":
+			if methodNameNode := kotlinChildOfType(memberNode, "simple_identifier"); methodNameNode != nil {
+				callGraph.addNode(classNamespace+namespaceSeparator+methodNameNode.Content(treeData), memberNode)
+			}
+		case "companion_object":
+			if companionBody := kotlinChildOfType(memberNode, "class_body"); companionBody != nil {
+				kotlinRegisterMethods(companionBody, treeData, classNamespace, callGraph)
+			}
+		}
+	}
+}
+
+// kotlinFunctionDeclarationProcessor handles functions, methods and secondary
+// constructors. Secondary constructors are called by the class constructor
+// and top level main functions are called from the file namespace
+func kotlinFunctionDeclarationProcessor(functionNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if functionNode == nil {
+		return newProcessorResult()
+	}
+
+	isConstructor := functionNode.Type() == "secondary_constructor"
+
+	functionName := "constructor"
+	if !isConstructor {
+		functionNameNode := kotlinChildOfType(functionNode, "simple_identifier")
+		if functionNameNode == nil {
+			log.Errorf("Kotlin function declaration without name - %s", functionNode.Content(treeData))
+			return newProcessorResult()
+		}
+
+		functionName = functionNameNode.Content(treeData)
+	}
+
+	functionNamespace := currentNamespace + namespaceSeparator + functionName
+
+	if _, exists := callGraph.Nodes[functionNamespace]; !exists || callGraph.Nodes[functionNamespace].TreeNode == nil {
+		callGraph.addNode(functionNamespace, functionNode)
+		log.Debugf("Register Kotlin function declaration for %s - %s", functionName, functionNamespace)
+	}
+
+	if isConstructor && metadata.insideClass || functionName == "main" && !metadata.insideClass && !metadata.insideFunction {
+		callGraph.addEdge(
+			currentNamespace, nil, nil,
+			functionNamespace, functionNode,
+			[]CallArgument{},
+		)
+	}
+
+	results := newProcessorResult()
+
+	functionBody := kotlinChildOfType(functionNode, "function_body", "statements")
+	if functionBody != nil {
+		metadata.insideFunction = true
+		results.addResults(processChildren(functionBody, treeData, functionNamespace, callGraph, metadata))
+		metadata.insideFunction = false
+	}
+
+	return results
+}
+
+// kotlinPropertyDeclarationProcessor assigns the initial value of a property
+// or local variable eg. val client = OkHttpClient() -> okhttp3//OkHttpClient
+func kotlinPropertyDeclarationProcessor(declarationNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if declarationNode == nil {
+		return newProcessorResult()
+	}
+
+	var nameNode *sitter.Node
+	valueResult := newProcessorResult()
+	for i := 0; i < int(declarationNode.NamedChildCount()); i++ {
+		childNode := declarationNode.NamedChild(i)
+		switch childNode.Type() {
+		case "modifiers", "binding_pattern_kind", "type_parameters", "type_constraints", "multi_variable_declaration":
+		case "variable_declaration":
+			nameNode = kotlinChildOfType(childNode, "simple_identifier")
+		default:
+			valueResult.addResults(processNode(childNode, treeData, currentNamespace, callGraph, metadata))
+		}
+	}
+
+	if nameNode == nil {
+		return newProcessorResult()
+	}
+
+	// Variables are declared even if their value is unresolved eg. val cipher = Cipher.getInstance("AES")
+	variableNamespace := currentNamespace + namespaceSeparator + nameNode.Content(treeData)
+	callGraph.assignmentGraph.addNode(variableNamespace, nameNode)
+	for _, immediateAssignment := range valueResult.ImmediateAssignments {
+		callGraph.assignmentGraph.addAssignment(
+			variableNamespace, nameNode,
+			immediateAssignment.Namespace, immediateAssignment.TreeNode,
+		)
+	}
+
+	return newProcessorResult()
+}
+
+// kotlinAssignmentProcessor assigns the value to a variable or a property
+// eg. this.client = OkHttpClient() within class Gateway -> file//Gateway//client
+func kotlinAssignmentProcessor(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if node == nil || node.NamedChildCount() < 2 {
+		return newProcessorResult()
+	}
+
+	assigneeNode := node.NamedChild(0)
+	valueNode := node.NamedChild(int(node.NamedChildCount()) - 1)
+
+	assigneeNamespaces := []string{}
+	if suffixNode := kotlinChildOfType(assigneeNode, "navigation_suffix"); suffixNode != nil && assigneeNode.NamedChildCount() > 1 {
+		memberName := kotlinNavigationSuffixName(suffixNode, treeData)
+		for _, receiverNamespace := range resolveKotlinReceiver(assigneeNode.NamedChild(0), treeData, currentNamespace, callGraph, metadata) {
+			assigneeNamespaces = append(assigneeNamespaces, receiverNamespace+namespaceSeparator+memberName)
+		}
+	} else if variableNode := kotlinChildOfType(assigneeNode, "simple_identifier"); variableNode != nil {
+		variableAssignment, found := searchSymbolInScopeChain(variableNode.Content(treeData), currentNamespace, callGraph)
+		if found {
+			assigneeNamespaces = append(assigneeNamespaces, variableAssignment.Namespace)
+		} else {
+			assigneeNamespaces = append(assigneeNamespaces, currentNamespace+namespaceSeparator+variableNode.Content(treeData))
+		}
+	}
+
+	valueResult := processNode(valueNode, treeData, currentNamespace, callGraph, metadata)
+
+	for _, assigneeNamespace := range assigneeNamespaces {
+		for _, immediateAssignment := range valueResult.ImmediateAssignments {
+			callGraph.assignmentGraph.addAssignment(
+				assigneeNamespace, assigneeNode,
+				immediateAssignment.Namespace, immediateAssignment.TreeNode,
+			)
+		}
+	}
+
+	return newProcessorResult()
+}
+
+// kotlinCallExpressionProcessor handles Kotlin calls. Constructors are called
+// like functions, hence calls to capitalised names return an instance
+// Examples:
+// - OkHttpClient() with import okhttp3.OkHttpClient -> okhttp3//OkHttpClient
+// - Cipher.getInstance("AES") with import javax.crypto.Cipher -> javax//crypto//Cipher//getInstance
+// - log(message) within class Gateway -> file//Gateway//log
+// - println(message) -> println
+func kotlinCallExpressionProcessor(callNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	result := newProcessorResult()
+	if callNode == nil || callNode.NamedChildCount() == 0 {
+		return result
+	}
+
+	calleeNode := callNode.NamedChild(0)
+
+	callArguments := []CallArgument{}
+	if suffixNode := kotlinChildOfType(callNode, "call_suffix"); suffixNode != nil {
+		callArguments = resolveKotlinCallArguments(kotlinChildOfType(suffixNode, "value_arguments"), treeData, currentNamespace, callGraph, metadata)
+
+		// Trailing lambdas are processed within the current scope eg. runBlocking { ... }
+		if lambdaNode := kotlinChildOfType(suffixNode, "annotated_lambda"); lambdaNode != nil {
+			processNode(lambdaNode, treeData, currentNamespace, callGraph, metadata)
+		}
+	}
+
+	functionName := ""
+	var functionNamespaces []string
+	switch calleeNode.Type() {
+	case "simple_identifier":
+		functionName = calleeNode.Content(treeData)
+		functionNamespaces = resolveKotlinName(functionName, currentNamespace, callGraph)
+	case "navigation_expression":
+		suffixNode := kotlinChildOfType(calleeNode, "navigation_suffix")
+		if suffixNode == nil || calleeNode.NamedChildCount() < 2 {
+			return result
+		}
+
+		receiverNode := calleeNode.NamedChild(0)
+		functionName = kotlinNavigationSuffixName(suffixNode, treeData)
+		if memberNode := kotlinChildOfType(suffixNode, "simple_identifier"); memberNode != nil {
+			calleeNode = memberNode
+		}
+
+		for _, receiverNamespace := range resolveKotlinReceiver(receiverNode, treeData, currentNamespace, callGraph, metadata) {
+			functionNamespaces = append(functionNamespaces, receiverNamespace+namespaceSeparator+functionName)
+		}
+	default:
+		// Functions held by variables or returned by calls eg. handlers[0](request)
+		functionNamespaces = resolveKotlinReceiver(calleeNode, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	for _, functionNamespace := range functionNamespaces {
+		callGraph.addEdge(currentNamespace, nil, calleeNode, functionNamespace, nil, callArguments)
+		log.Debugf("Kotlin call: %s -> %s", currentNamespace, functionNamespace)
+
+		if callGraph.classConstructors[functionNamespace] || kotlinIsTypeName(functionName) {
+			result.ImmediateAssignments = append(result.ImmediateAssignments,
+				callGraph.assignmentGraph.addNode(functionNamespace, nil))
+		}
+	}
+
+	return result
+}
+
+// kotlinNavigationExpressionProcessor resolves members of objects and types
+// eg. this.client within class Gateway -> file//Gateway//client
+func kotlinNavigationExpressionProcessor(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	result := newProcessorResult()
+	if node == nil {
+		return result
+	}
+
+	for _, memberNamespace := range resolveKotlinReceiver(node, treeData, currentNamespace, callGraph, metadata) {
+		result.ImmediateAssignments = append(result.ImmediateAssignments,
+			callGraph.assignmentGraph.addNode(memberNamespace, node))
+	}
+
+	return result
+}
+
+// resolveKotlinReceiver resolves the object or type a member is accessed on
+// to the namespaces of the objects it may refer to
+func resolveKotlinReceiver(receiverNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) []string {
+	switch receiverNode.Type() {
+	case "this_expression":
+		if classNamespace, ok := kotlinClassNamespace(currentNamespace, metadata); ok {
+			return []string{classNamespace}
+		}
+
+		return []string{}
+	case "super_expression":
+		// Base classes are not resolved eg. super.onCreate()
+		return []string{}
+	case "simple_identifier":
+		return resolveKotlinName(receiverNode.Content(treeData), currentNamespace, callGraph)
+	case "navigation_expression":
+		// Qualified names and members eg. java.util.UUID, this.client
+		suffixNode := kotlinChildOfType(receiverNode, "navigation_suffix")
+		if suffixNode == nil || receiverNode.NamedChildCount() < 2 {
+			return []string{}
+		}
+
+		memberName := kotlinNavigationSuffixName(suffixNode, treeData)
+
+		namespaces := []string{}
+		for _, objectNamespace := range resolveKotlinReceiver(receiverNode.NamedChild(0), treeData, currentNamespace, callGraph, metadata) {
+			namespaces = append(namespaces, kotlinResolveNamespace(objectNamespace+namespaceSeparator+memberName, callGraph)...)
+		}
+
+		return namespaces
+	}
+
+	// Calls chained on the receiver eg. Gateway.create(url).fetch(path)
+	receiverResult := processNode(receiverNode, treeData, currentNamespace, callGraph, metadata)
+
+	namespaces := []string{}
+	for _, immediateAssignment := range receiverResult.ImmediateAssignments {
+		for _, resolvedObject := range callGraph.assignmentGraph.resolve(immediateAssignment.Namespace) {
+			namespaces = append(namespaces, resolvedObject.Namespace)
+		}
+	}
+
+	return namespaces
+}
+
+// resolveKotlinName resolves a name by the imports and declarations in
+// scope, unresolved names are used as written
+// eg. HttpRequest with import okhttp3.Request as HttpRequest -> okhttp3//Request
+func resolveKotlinName(name string, currentNamespace string, callGraph *CallGraph) []string {
+	symbolAssignment, found := searchSymbolInScopeChain(name, currentNamespace, callGraph)
+	if !found {
+		return []string{name}
+	}
+
+	return kotlinResolveNamespace(symbolAssignment.Namespace, callGraph)
+}
+
+// kotlinResolveNamespace resolves a namespace to the objects assigned to it,
+// namespaces without assignments eg. members of imported types are kept as is
+func kotlinResolveNamespace(namespace string, callGraph *CallGraph) []string {
+	resolvedObjects := callGraph.assignmentGraph.resolve(namespace)
+	if len(resolvedObjects) == 0 {
+		return []string{namespace}
+	}
+
+	namespaces := []string{}
+	for _, resolvedObject := range resolvedObjects {
+		namespaces = append(namespaces, resolvedObject.Namespace)
+	}
+
+	return namespaces
+}
+
+// resolveKotlinCallArguments resolves the value arguments of a call, names
+// of named arguments are skipped eg. connect(timeout = 10) -> [10]
+func resolveKotlinCallArguments(argumentsNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) []CallArgument {
+	if argumentsNode == nil {
+		return []CallArgument{}
+	}
+
+	result := make([]CallArgument, 0, argumentsNode.NamedChildCount())
+
+	for i := 0; uint32(i) < argumentsNode.NamedChildCount(); i++ {
+		argumentNode := argumentsNode.NamedChild(i)
+		if argumentNode == nil || argumentNode.Type() != "value_argument" || argumentNode.NamedChildCount() == 0 {
+			continue
+		}
+
+		valueNode := argumentNode.NamedChild(int(argumentNode.NamedChildCount()) - 1)
+		valueResult := processNode(valueNode, treeData, currentNamespace, callGraph, metadata)
+
+		resolvedTerminalAssignmentNodes := []*assignmentNode{}
+		for _, assignmentNode := range valueResult.ImmediateAssignments {
+			resolvedTerminalAssignmentNodes = append(resolvedTerminalAssignmentNodes,
+				callGraph.assignmentGraph.resolve(assignmentNode.Namespace)...)
+		}
+
+		result = append(result, CallArgument{
+			Nodes: resolvedTerminalAssignmentNodes,
+		})
+	}
+
+	return result
+}
+
+// kotlinClassNamespace returns the namespace of the enclosing class, methods
+// are namespaced as file//Class//method
+func kotlinClassNamespace(currentNamespace string, metadata processorMetadata) (string, bool) {
+	if !metadata.insideClass {
+		return "", false
+	}
+
+	if !metadata.insideFunction {
+		return currentNamespace, true
+	}
+
+	index := strings.LastIndex(currentNamespace, namespaceSeparator)
+	if index < 0 {
+		return "", false
+	}
+
+	return currentNamespace[:index], true
+}
+
+// kotlinIsTypeName reports whether a name follows the naming convention of
+// classes and objects eg. OkHttpClient
+func kotlinIsTypeName(name string) bool {
+	return name != "" && unicode.IsUpper([]rune(name)[0])
+}
+
+// kotlinNavigationSuffixName returns the member name of a navigation suffix
+// eg. .getInstance -> getInstance
+func kotlinNavigationSuffixName(suffixNode *sitter.Node, treeData []byte) string {
+	if memberNode := kotlinChildOfType(suffixNode, "simple_identifier"); memberNode != nil {
+		return memberNode.Content(treeData)
+	}
+
+	return strings.TrimPrefix(suffixNode.Content(treeData), ".")
+}
+
+// kotlinChildOfType returns the first named child of one of the types, the
+// Kotlin grammar does not declare field names
+func kotlinChildOfType(node *sitter.Node, types ...string) *sitter.Node {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		childNode := node.NamedChild(i)
+		if slices.Contains(types, childNode.Type()) {
+			return childNode
+		}
+	}
+
+	return nil
+}
+
+// C and C++-specific ------
+
+// functionDefinitionProcessorWrapper handles function_definition nodes, C and
+// C++ functions are named by their declarator hence are processed separately
+func functionDefinitionProcessorWrapper(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	treeLanguage, err := callGraph.Tree.Language()
+	if err != nil {
+		return newProcessorResult()
+	}
+
+	switch treeLanguage.Meta().Code {
+	case core.LanguageCodeC, core.LanguageCodeCpp:
+		return cFunctionDefinitionProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	default:
+		return functionDefinitionProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	}
+}
+
+// newExpressionProcessorWrapper handles new_expression nodes of JavaScript,
+// TypeScript and C++
+func newExpressionProcessorWrapper(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	treeLanguage, err := callGraph.Tree.Language()
+	if err != nil {
+		return newProcessorResult()
+	}
+
+	if treeLanguage.Meta().Code == core.LanguageCodeCpp {
+		return cppNewExpressionProcessor(node, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	return jsNewExpressionProcessor(node, treeData, currentNamespace, callGraph, metadata)
+}
+
+// cFunctionDefinitionProcessor handles functions, methods defined within the
+// class body and methods defined out of class eg. Runner *Runner::create().
+// Similar to Rust, free functions are registered as callable from the file
+// namespace since native extensions have no main function
+func cFunctionDefinitionProcessor(functionNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if functionNode == nil {
+		return newProcessorResult()
+	}
+
+	functionDeclaratorNode := cFunctionDeclarator(functionNode.ChildByFieldName("declarator"))
+	if functionDeclaratorNode == nil {
+		log.Errorf("C function definition without declarator - %s", functionNode.Content(treeData))
+		return newProcessorResult()
+	}
+
+	segments, isConstructor := cppMethodSegments(functionDeclaratorNode, treeData, currentNamespace, metadata)
+	if len(segments) == 0 {
+		return newProcessorResult()
+	}
+
+	functionName := segments[len(segments)-1]
+	functionNamespace := currentNamespace + namespaceSeparator + strings.Join(segments, namespaceSeparator)
+
+	if _, exists := callGraph.Nodes[functionNamespace]; !exists || callGraph.Nodes[functionNamespace].TreeNode == nil {
+		callGraph.addNode(functionNamespace, functionNode)
+		log.Debugf("Register C function definition for %s - %s", functionName, functionNamespace)
+	}
+
+	isQualified := len(segments) > 1
+	if !metadata.insideClass && !metadata.insideFunction && !isQualified {
+		callGraph.addEdge(
+			callGraph.FileName, nil, nil,
+			functionNamespace, functionNode,
+			[]CallArgument{},
+		)
+	}
+
+	// Constructors are called when the class is constructed
+	if isConstructor {
+		callGraph.addEdge(
+			strings.TrimSuffix(functionNamespace, namespaceSeparator+functionName), nil, nil,
+			functionNamespace, functionNode,
+			[]CallArgument{},
+		)
+	}
+
+	// Parameters are instances of their type eg. const std::string &command
+	if parametersNode := functionDeclaratorNode.ChildByFieldName("parameters"); parametersNode != nil {
+		for i := 0; i < int(parametersNode.NamedChildCount()); i++ {
+			parameterNode := parametersNode.NamedChild(i)
+
+			nameNode := cDeclaredName(parameterNode.ChildByFieldName("declarator"))
+			if nameNode == nil {
+				continue
+			}
+
+			parameterNamespace := functionNamespace + namespaceSeparator + nameNode.Content(treeData)
+			callGraph.assignmentGraph.addNode(parameterNamespace, nameNode)
+
+			for _, typeNamespace := range cResolveTypeNamespaces(parameterNode.ChildByFieldName("type"), treeData, currentNamespace, callGraph) {
+				callGraph.assignmentGraph.addAssignment(parameterNamespace, nameNode, typeNamespace, nil)
+			}
+		}
+	}
+
+	results := newProcessorResult()
+
+	functionBody := functionNode.ChildByFieldName("body")
+	if functionBody != nil {
+		metadata.insideClass = metadata.insideClass || isQualified
+		metadata.insideFunction = true
+		results.addResults(processChildren(functionBody, treeData, functionNamespace, callGraph, metadata))
+	}
+
+	return results
+}
+
+// cppNamespaceDefinitionProcessor processes the declarations of a C++
+// namespace within the namespace eg. native::execute -> file//native//execute
+func cppNamespaceDefinitionProcessor(node *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if node == nil {
+		return newProcessorResult()
+	}
+
+	namespace := currentNamespace
+	if nameNode := node.ChildByFieldName("name"); nameNode != nil {
+		// Nested namespace definitions eg. namespace net::http
+		namespace += namespaceSeparator + strings.ReplaceAll(nameNode.Content(treeData), "::", namespaceSeparator)
+		callGraph.assignmentGraph.addNode(namespace, nameNode)
+	}
+
+	if bodyNode := node.ChildByFieldName("body"); bodyNode != nil {
+		processChildren(bodyNode, treeData, namespace, callGraph, metadata)
+	}
+
+	return newProcessorResult()
+}
+
+// cppClassSpecifierProcessor handles classes and structs having a body. Methods
+// are registered before processing the body so that calls to methods declared
+// later in the class are resolved eg. log(message) -> file//Runner//log
+func cppClassSpecifierProcessor(classNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if classNode == nil {
+		return newProcessorResult()
+	}
+
+	// References to types eg. struct stat st;
+	classBody := classNode.ChildByFieldName("body")
+	classNameNode := classNode.ChildByFieldName("name")
+	if classBody == nil || classNameNode == nil {
+		return newProcessorResult()
+	}
+
+	classNamespace := currentNamespace + namespaceSeparator + strings.Join(cppQualifiedSegments(classNameNode, treeData), namespaceSeparator)
+	callGraph.addNode(classNamespace, classNode)
+
+	// Assignment is added so that calls to the class name resolve to its constructor
+	callGraph.assignmentGraph.addNode(classNamespace, classNode)
+	callGraph.classConstructors[classNamespace] = true
+
+	metadata.insideClass = true
+	metadata.insideFunction = false
+
+	for i := 0; i < int(classBody.NamedChildCount()); i++ {
+		memberNode := classBody.NamedChild(i)
+		if memberNode.Type() == "template_declaration" && memberNode.NamedChildCount() > 0 {
+			memberNode = memberNode.NamedChild(int(memberNode.NamedChildCount()) - 1)
+		}
+
+		functionDeclaratorNode := cFunctionDeclarator(memberNode.ChildByFieldName("declarator"))
+		if functionDeclaratorNode == nil {
+			continue
+		}
+
+		segments, _ := cppMethodSegments(functionDeclaratorNode, treeData, classNamespace, metadata)
+		if len(segments) == 0 {
+			continue
+		}
+
+		// Declarations are defined out of class
+		var methodTreeNode *sitter.Node
+		if memberNode.Type() == "function_definition" {
+			methodTreeNode = memberNode
+		}
+
+		callGraph.addNode(classNamespace+namespaceSeparator+strings.Join(segments, namespaceSeparator), methodTreeNode)
+	}
+
+	processChildren(classBody, treeData, classNamespace, callGraph, metadata)
+
+	log.Debugf("Register C++ class declaration for %s - %s", classNameNode.Content(treeData), classNamespace)
+
+	return newProcessorResult()
+}
+
+// cDeclarationProcessor handles declarations of variables and functions.
+// Variables are assigned their initial value along with their type, calls to
+// methods of the variable are resolved within the type. Function prototypes
+// are registered so that calls to functions defined later are resolved
+// Examples:
+// - native::Runner runner("/bin/bash") -> file//native//Runner
+// - std::ofstream out(path) -> std//ofstream
+// - static void run(const char *cmd) -> file//run
+func cDeclarationProcessor(declarationNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if declarationNode == nil {
+		return newProcessorResult()
+	}
+
+	typeNode := declarationNode.ChildByFieldName("type")
+	typeNamespaces := cResolveTypeNamespaces(typeNode, treeData, currentNamespace, callGraph)
+
+	for i := 0; i < int(declarationNode.ChildCount()); i++ {
+		if declarationNode.FieldNameForChild(i) != "declarator" {
+			continue
+		}
+
+		declaratorNode := declarationNode.Child(i)
+
+		if functionDeclaratorNode := cFunctionDeclarator(declaratorNode); functionDeclaratorNode != nil {
+			segments := cppQualifiedSegments(functionDeclaratorNode.ChildByFieldName("declarator"), treeData)
+			if len(segments) > 0 {
+				callGraph.addNode(currentNamespace+namespaceSeparator+strings.Join(segments, namespaceSeparator), nil)
+			}
+
+			continue
+		}
+
+		var valueNode *sitter.Node
+		if declaratorNode.Type() == "init_declarator" {
+			valueNode = declaratorNode.ChildByFieldName("value")
+			declaratorNode = declaratorNode.ChildByFieldName("declarator")
+		}
+
+		nameNode := cDeclaredName(declaratorNode)
+		if nameNode == nil {
+			processNode(valueNode, treeData, currentNamespace, callGraph, metadata)
+			continue
+		}
+
+		variableNamespace := currentNamespace + namespaceSeparator + nameNode.Content(treeData)
+		callGraph.assignmentGraph.addNode(variableNamespace, nameNode)
+
+		if valueNode != nil {
+			switch valueNode.Type() {
+			case "argument_list", "initializer_list":
+				// Direct initialisation calls the constructor eg. Runner runner("/bin/bash")
+				callArguments := resolvePositionalCallArguments(valueNode, treeData, currentNamespace, callGraph, metadata)
+				for _, typeNamespace := range typeNamespaces {
+					callGraph.addEdge(currentNamespace, nil, typeNode, typeNamespace, nil, callArguments)
+				}
+			default:
+				valueResult := processNode(valueNode, treeData, currentNamespace, callGraph, metadata)
+				for _, immediateAssignment := range valueResult.ImmediateAssignments {
+					callGraph.assignmentGraph.addAssignment(
+						variableNamespace, nameNode,
+						immediateAssignment.Namespace, immediateAssignment.TreeNode,
+					)
+				}
+			}
+		}
+
+		for _, typeNamespace := range typeNamespaces {
+			callGraph.assignmentGraph.addAssignment(variableNamespace, nameNode, typeNamespace, nil)
+		}
+	}
+
+	return newProcessorResult()
+}
+
+// cCallExpressionProcessor handles C and C++ call_expression nodes. Functions
+// not declared in the file are used as written
+// Examples:
+// - system(cmd) -> system
+// - EVP_DigestInit_ex(ctx, EVP_sha256(), NULL) -> EVP_DigestInit_ex
+// - std::system(command) -> std//system
+// - ::puts(message) -> puts
+// - runner.run("id") with native::Runner runner -> file//native//Runner//run
+// - this->log(message) within class Runner -> file//Runner//log
+func cCallExpressionProcessor(callNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	result := newProcessorResult()
+	if callNode == nil {
+		return result
+	}
+
+	functionNode := callNode.ChildByFieldName("function")
+	if functionNode == nil {
+		return result
+	}
+
+	callArguments := resolveCCallArguments(callNode.ChildByFieldName("arguments"), treeData, currentNamespace, callGraph, metadata)
+
+	calleeNamespaces, calleeIdentifierNode := resolveCCallee(functionNode, treeData, currentNamespace, callGraph, metadata)
+	for _, calleeNamespace := range calleeNamespaces {
+		callGraph.addEdge(
+			currentNamespace, nil, calleeIdentifierNode,
+			calleeNamespace, nil,
+			callArguments,
+		)
+
+		if callGraph.classConstructors[calleeNamespace] {
+			result.ImmediateAssignments = append(result.ImmediateAssignments,
+				callGraph.assignmentGraph.addNode(calleeNamespace, nil))
+		}
+
+		log.Debugf("C call: %s -> %s", currentNamespace, calleeNamespace)
+	}
+
+	return result
+}
+
+// resolveCCallee resolves the function node of a call expression along with
+// the node identifying the callee
+func resolveCCallee(functionNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) ([]string, *sitter.Node) {
+	switch functionNode.Type() {
+	case "identifier":
+		return cResolveName(functionNode.Content(treeData), currentNamespace, callGraph), functionNode
+	case "qualified_identifier":
+		return cppResolveQualifiedName(functionNode, treeData, currentNamespace, callGraph), functionNode
+	case "template_function":
+		// Explicit template arguments eg. make_unique<Runner>(shell)
+		if nameNode := functionNode.ChildByFieldName("name"); nameNode != nil {
+			return resolveCCallee(nameNode, treeData, currentNamespace, callGraph, metadata)
+		}
+	case "field_expression":
+		argumentNode := functionNode.ChildByFieldName("argument")
+		fieldNode := functionNode.ChildByFieldName("field")
+		if argumentNode == nil || fieldNode == nil {
+			break
+		}
+
+		fieldName := fieldNode.Content(treeData)
+
+		var objectNamespaces []string
+		switch argumentNode.Type() {
+		case "this":
+			if classNamespace, ok := cppClassNamespace(currentNamespace, metadata.insideClass); ok {
+				objectNamespaces = []string{classNamespace}
+			}
+		case "identifier":
+			objectNamespaces = cResolveName(argumentNode.Content(treeData), currentNamespace, callGraph)
+		default:
+			// Calls chained on the object eg. Runner::create()->run(command)
+			argumentResult := processNode(argumentNode, treeData, currentNamespace, callGraph, metadata)
+			for _, immediateAssignment := range argumentResult.ImmediateAssignments {
+				for _, resolvedObject := range callGraph.assignmentGraph.resolve(immediateAssignment.Namespace) {
+					objectNamespaces = append(objectNamespaces, resolvedObject.Namespace)
+				}
+			}
+		}
+
+		calleeNamespaces := []string{}
+		for _, objectNamespace := range objectNamespaces {
+			calleeNamespaces = append(calleeNamespaces, objectNamespace+namespaceSeparator+fieldName)
+		}
+
+		return calleeNamespaces, fieldNode
+	}
+
+	// Calls through function pointers or expressions eg. (*handler)(request)
+	processNode(functionNode, treeData, currentNamespace, callGraph, metadata)
+
+	return []string{}, functionNode
+}
+
+// cppNewExpressionProcessor handles C++ new expressions, it calls the
+// constructor of the class and returns an instance
+// eg. new Runner("/bin/sh") within namespace native -> file//native//Runner
+func cppNewExpressionProcessor(newNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	result := newProcessorResult()
+	if newNode == nil {
+		return result
+	}
+
+	typeNode := newNode.ChildByFieldName("type")
+	if typeNode == nil {
+		return emptyProcessor(newNode, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	callArguments := []CallArgument{}
+	if argumentsNode := newNode.ChildByFieldName("arguments"); argumentsNode != nil {
+		callArguments = resolvePositionalCallArguments(argumentsNode, treeData, currentNamespace, callGraph, metadata)
+	}
+
+	for _, classNamespace := range cResolveTypeNamespaces(typeNode, treeData, currentNamespace, callGraph) {
+		callGraph.addEdge(currentNamespace, nil, typeNode, classNamespace, nil, callArguments)
+		result.ImmediateAssignments = append(result.ImmediateAssignments,
+			callGraph.assignmentGraph.addNode(classNamespace, nil))
+		log.Debugf("C++ object created: %s -> %s", currentNamespace, classNamespace)
+	}
+
+	return result
+}
+
+// resolveCCallArguments resolves the arguments of a C or C++ call expression
+func resolveCCallArguments(argumentsNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) []CallArgument {
+	if argumentsNode == nil {
+		return []CallArgument{}
+	}
+
+	if argumentsNode.Type() != "argument_list" {
+		log.Errorf("Expected argument_list node, got %s for %s", argumentsNode.Type(), argumentsNode.Content(treeData))
+		return []CallArgument{}
+	}
+
+	return resolvePositionalCallArguments(argumentsNode, treeData, currentNamespace, callGraph, metadata)
+}
+
+// cResolveName resolves a name by the declarations in scope, unresolved names
+// eg. functions declared in included headers are used as written
+func cResolveName(name string, currentNamespace string, callGraph *CallGraph) []string {
+	symbolAssignment, found := searchSymbolInScopeChain(name, currentNamespace, callGraph)
+	if !found {
+		return []string{name}
+	}
+
+	return kotlinResolveNamespace(symbolAssignment.Namespace, callGraph)
+}
+
+// cppResolveQualifiedName resolves a qualified name by resolving its first
+// segment in scope eg. native::Runner::create -> file//native//Runner//create.
+// Names qualified by the global namespace eg. ::puts are resolved within
+// the file namespace
+func cppResolveQualifiedName(qualifiedNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph) []string {
+	segments := cppQualifiedSegments(qualifiedNode, treeData)
+	if len(segments) == 0 {
+		return []string{}
+	}
+
+	if qualifiedNode.ChildByFieldName("scope") == nil {
+		globalNamespace := callGraph.FileName + namespaceSeparator + strings.Join(segments, namespaceSeparator)
+		if _, exists := callGraph.assignmentGraph.Assignments[globalNamespace]; exists {
+			return []string{globalNamespace}
+		}
+
+		return []string{strings.Join(segments, namespaceSeparator)}
+	}
+
+	remaining := strings.Join(segments[1:], namespaceSeparator)
+
+	namespaces := []string{}
+	for _, qualifierNamespace := range cResolveName(segments[0], currentNamespace, callGraph) {
+		namespaces = append(namespaces, qualifierNamespace+namespaceSeparator+remaining)
+	}
+
+	return namespaces
+}
+
+// cResolveTypeNamespaces resolves the namespaces of a named type, primitive
+// types and placeholders eg. auto are not resolved
+func cResolveTypeNamespaces(typeNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph) []string {
+	if typeNode == nil {
+		return []string{}
+	}
+
+	switch typeNode.Type() {
+	case "type_identifier":
+		return cResolveName(typeNode.Content(treeData), currentNamespace, callGraph)
+	case "qualified_identifier":
+		return cppResolveQualifiedName(typeNode, treeData, currentNamespace, callGraph)
+	case "template_type":
+		return cResolveTypeNamespaces(typeNode.ChildByFieldName("name"), treeData, currentNamespace, callGraph)
+	}
+
+	return []string{}
+}
+
+// cppQualifiedSegments returns the segments of a qualified name
+// eg. native::Runner::create -> [native Runner create], ::puts -> [puts]
+func cppQualifiedSegments(node *sitter.Node, treeData []byte) []string {
+	if node == nil {
+		return []string{}
+	}
+
+	switch node.Type() {
+	case "qualified_identifier":
+		segments := cppQualifiedSegments(node.ChildByFieldName("scope"), treeData)
+		return append(segments, cppQualifiedSegments(node.ChildByFieldName("name"), treeData)...)
+	case "template_type", "template_function":
+		return cppQualifiedSegments(node.ChildByFieldName("name"), treeData)
+	}
+
+	return []string{node.Content(treeData)}
+}
+
+// cppMethodSegments returns the segments of the name of a function declarator.
+// Similar to Kotlin, constructors are named constructor so that the class name
+// within the class refers to the class eg. Runner::Runner -> [Runner constructor]
+func cppMethodSegments(functionDeclaratorNode *sitter.Node, treeData []byte, currentNamespace string, metadata processorMetadata) ([]string, bool) {
+	segments := cppQualifiedSegments(functionDeclaratorNode.ChildByFieldName("declarator"), treeData)
+	if len(segments) == 0 {
+		return segments, false
+	}
+
+	className := ""
+	if len(segments) > 1 {
+		className = segments[len(segments)-2]
+	} else if metadata.insideClass && !metadata.insideFunction {
+		className = currentNamespace[strings.LastIndex(currentNamespace, namespaceSeparator)+len(namespaceSeparator):]
+	}
+
+	if className == "" || segments[len(segments)-1] != className {
+		return segments, false
+	}
+
+	segments[len(segments)-1] = "constructor"
+	return segments, true
+}
+
+// cppClassNamespace returns the namespace of the enclosing class, methods
+// are namespaced as file//Class//method
+func cppClassNamespace(currentNamespace string, insideClass bool) (string, bool) {
+	if !insideClass {
+		return "", false
+	}
+
+	index := strings.LastIndex(currentNamespace, namespaceSeparator)
+	if index < 0 {
+		return "", false
+	}
+
+	return currentNamespace[:index], true
+}
+
+// cFunctionDeclarator unwraps the function declarator of a declaration
+// eg. *create() is a pointer declarator wrapping the function declarator
+func cFunctionDeclarator(node *sitter.Node) *sitter.Node {
+	for node != nil {
+		switch node.Type() {
+		case "function_declarator":
+			return node
+		case "pointer_declarator", "reference_declarator", "parenthesized_declarator":
+			node = cInnerDeclarator(node)
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// cDeclaredName unwraps the declarator of a variable eg. handle in *handle
+func cDeclaredName(node *sitter.Node) *sitter.Node {
+	for node != nil {
+		switch node.Type() {
+		case "identifier", "field_identifier":
+			return node
+		case "pointer_declarator", "reference_declarator", "array_declarator", "parenthesized_declarator":
+			node = cInnerDeclarator(node)
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// cInnerDeclarator returns the declarator wrapped by a declarator, reference
+// declarators have no declarator field
+func cInnerDeclarator(node *sitter.Node) *sitter.Node {
+	if innerNode := node.ChildByFieldName("declarator"); innerNode != nil {
+		return innerNode
+	}
+
+	if node.NamedChildCount() == 0 {
+		return nil
+	}
+
+	return node.NamedChild(int(node.NamedChildCount()) - 1)
+}
+
+// Bash-specific ------
+
+// Builtins reading and executing a file, which are resolved as imports
+var bashSourceCommands = map[string]bool{
+	"source": true,
+	".":      true,
+}
+
+// Commands running the command passed as their argument eg. sudo chmod +x
+var bashWrapperCommands = map[string]bool{
+	"sudo":    true,
+	"env":     true,
+	"nohup":   true,
+	"exec":    true,
+	"command": true,
+	"xargs":   true,
+}
+
+// bashCommandProcessor handles Bash commands. Functions defined in the file
+// are resolved by their namespace while other commands are resolved by their
+// name eg. /usr/bin/curl -> curl, such that signatures can target commands
+// the same way as library calls. Arguments are resolved as written eg. -d
+// for `base64 -d`, along with the values of expanded variables
+func bashCommandProcessor(commandNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	result := newProcessorResult()
+	if commandNode == nil {
+		return result
+	}
+
+	nameNode := commandNode.ChildByFieldName("name")
+	if nameNode == nil {
+		return result
+	}
+
+	commandNames := bashCommandNames(nameNode, treeData, currentNamespace, callGraph, metadata)
+	if len(commandNames) == 1 && bashSourceCommands[commandNames[0]] {
+		return result
+	}
+
+	argumentNodes := []*sitter.Node{}
+	for i := 0; i < int(commandNode.ChildCount()); i++ {
+		if commandNode.FieldNameForChild(i) == "argument" {
+			argumentNodes = append(argumentNodes, commandNode.Child(i))
+		}
+	}
+
+	callArguments := make([]CallArgument, 0, len(argumentNodes))
+	for _, argumentNode := range argumentNodes {
+		argumentResult := bashValueProcessor(argumentNode, treeData, currentNamespace, callGraph, metadata)
+
+		resolvedTerminalAssignmentNodes := []*assignmentNode{}
+		for _, assignmentNode := range argumentResult.ImmediateAssignments {
+			resolvedTerminalAssignmentNodes = append(resolvedTerminalAssignmentNodes,
+				callGraph.assignmentGraph.resolve(assignmentNode.Namespace)...)
+		}
+
+		callArguments = append(callArguments, CallArgument{
+			Nodes: resolvedTerminalAssignmentNodes,
+		})
+	}
+
+	for _, commandName := range commandNames {
+		calleeNamespace := bashResolveCommand(commandName, currentNamespace, callGraph)
+		callGraph.addEdge(
+			currentNamespace, nil, nameNode,
+			calleeNamespace, nil,
+			callArguments,
+		)
+		log.Debugf("Bash command: %s -> %s", currentNamespace, calleeNamespace)
+
+		if !bashWrapperCommands[commandName] {
+			continue
+		}
+
+		// Wrapped command eg. curl for `sudo -E curl -fsSL ...`
+		for i, argumentNode := range argumentNodes {
+			if argumentNode.Type() != "word" {
+				break
+			}
+
+			argument := argumentNode.Content(treeData)
+			if strings.HasPrefix(argument, "-") || strings.Contains(argument, "=") {
+				continue
+			}
+
+			wrappedNamespace := bashResolveCommand(argument, currentNamespace, callGraph)
+			callGraph.addEdge(
+				currentNamespace, nil, argumentNode,
+				wrappedNamespace, nil,
+				callArguments[i+1:],
+			)
+			log.Debugf("Bash wrapped command: %s -> %s", currentNamespace, wrappedNamespace)
+			break
+		}
+	}
+
+	return result
+}
+
+// bashCommandNames resolves the name of a command, commands named by a
+// variable eg. "$DOWNLOADER" are resolved to the values of the variable
+func bashCommandNames(nameNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) []string {
+	if nameNode.NamedChildCount() == 0 {
+		return []string{}
+	}
+
+	valueNode := nameNode.NamedChild(0)
+	if valueNode.Type() == "word" {
+		return []string{valueNode.Content(treeData)}
+	}
+
+	commandNames := []string{}
+	valueResult := bashValueProcessor(valueNode, treeData, currentNamespace, callGraph, metadata)
+	for _, assignmentNode := range valueResult.ImmediateAssignments {
+		for _, resolvedNode := range callGraph.assignmentGraph.resolve(assignmentNode.Namespace) {
+			if resolvedNode.IsLiteralValue() {
+				commandNames = append(commandNames, strings.Trim(resolvedNode.Namespace, `"'`))
+			}
+		}
+	}
+
+	return commandNames
+}
+
+// bashResolveCommand resolves a command to the namespace of the function
+// defined in the file, if any, otherwise to the command as written
+func bashResolveCommand(commandName string, currentNamespace string, callGraph *CallGraph) string {
+	if symbolAssignment, found := searchSymbolInScopeChain(commandName, currentNamespace, callGraph); found {
+		if _, isFunction := callGraph.Nodes[symbolAssignment.Namespace]; isFunction {
+			return symbolAssignment.Namespace
+		}
+	}
+
+	// Commands run from a bin directory eg. /usr/bin/curl are resolved by name
+	if directory := path.Dir(commandName); path.Base(directory) == "bin" || path.Base(directory) == "sbin" {
+		return path.Base(commandName)
+	}
+
+	return commandName
+}
+
+// bashValueProcessor resolves a shell word used as a command argument or an
+// assigned value. Literal words are resolved as written while expansions are
+// resolved to the variable they expand eg. url for "$url". Command
+// substitutions within words are processed as calls from current namespace
+func bashValueProcessor(valueNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if valueNode == nil {
+		return newProcessorResult()
+	}
+
+	switch valueNode.Type() {
+	case "word", "raw_string", "ansi_c_string", "number":
+		return literalValueProcessor(valueNode, treeData, currentNamespace, callGraph, metadata)
+	case "simple_expansion", "expansion":
+		return bashExpansionProcessor(valueNode, treeData, currentNamespace, callGraph, metadata)
+	case "string":
+		expansionNodes := []*sitter.Node{}
+		for i := 0; i < int(valueNode.NamedChildCount()); i++ {
+			childNode := valueNode.NamedChild(i)
+			if childNode.Type() != "string_content" {
+				expansionNodes = append(expansionNodes, childNode)
+			}
+		}
+
+		if len(expansionNodes) == 0 {
+			return literalValueProcessor(valueNode, treeData, currentNamespace, callGraph, metadata)
+		}
+
+		// Quoted expansion of a variable eg. "$url"
+		if len(expansionNodes) == 1 && valueNode.NamedChildCount() == 1 {
+			return bashValueProcessor(expansionNodes[0], treeData, currentNamespace, callGraph, metadata)
+		}
+	}
+
+	return skipResultsProcessor(valueNode, treeData, currentNamespace, callGraph, metadata)
+}
+
+// bashExpansionProcessor resolves a parameter expansion eg. $url or ${url}
+// to the variable in scope, environment variables are not resolved
+func bashExpansionProcessor(expansionNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	result := newProcessorResult()
+	if expansionNode == nil {
+		return result
+	}
+
+	for i := 0; i < int(expansionNode.NamedChildCount()); i++ {
+		childNode := expansionNode.NamedChild(i)
+		if childNode.Type() != "variable_name" {
+			continue
+		}
+
+		if variableAssignment, found := searchSymbolInScopeChain(childNode.Content(treeData), currentNamespace, callGraph); found {
+			result.ImmediateAssignments = append(result.ImmediateAssignments, variableAssignment)
+		}
+
+		break
+	}
+
+	return result
+}
+
+// Declaration commands defining variables local to the function
+var bashLocalDeclarationCommands = map[string]bool{
+	"local":   true,
+	"declare": true,
+	"typeset": true,
+}
+
+// bashVariableAssignmentProcessor handles variable assignments eg. url="$1".
+// Variables are global unless declared local within a function
+func bashVariableAssignmentProcessor(assignmentNode *sitter.Node, treeData []byte, currentNamespace string, callGraph *CallGraph, metadata processorMetadata) processorResult {
+	if assignmentNode == nil {
+		return newProcessorResult()
+	}
+
+	nameNode := assignmentNode.ChildByFieldName("name")
+	if nameNode == nil {
+		return newProcessorResult()
+	}
+
+	variableScope := callGraph.FileName
+	if parentNode := assignmentNode.Parent(); metadata.insideFunction && parentNode != nil &&
+		parentNode.Type() == "declaration_command" && parentNode.ChildCount() > 0 &&
+		bashLocalDeclarationCommands[parentNode.Child(0).Content(treeData)] {
+		variableScope = currentNamespace
+	}
+
+	variableNamespace := variableScope + namespaceSeparator + nameNode.Content(treeData)
+	callGraph.assignmentGraph.addNode(variableNamespace, nameNode)
+
+	valueResult := bashValueProcessor(assignmentNode.ChildByFieldName("value"), treeData, currentNamespace, callGraph, metadata)
+	for _, immediateAssignment := range valueResult.ImmediateAssignments {
+		callGraph.assignmentGraph.addAssignment(
+			variableNamespace, nameNode,
+			immediateAssignment.Namespace, immediateAssignment.TreeNode,
+		)
+	}
+
+	return newProcessorResult()
+}
@@ -0,0 +1,965 @@
+package callgraph
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/core/ast"
+	"github.com/safedep/code/lang"
+	"github.com/safedep/dry/log"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// ProjectNodeRef refers to a call graph node in a specific file of the project
+type ProjectNodeRef struct {
+	// Name of the call graph of the node, which is the name of the file
+	// except for host documents eg. README.md#python, see ProjectGraphName
+	FileName  string
+	Namespace string
+}
+
+const projectGraphLanguageSeparator = "#"
+
+// ProjectGraphName returns the name of the call graph of a file in the project.
+// Host documents eg. README.md have a call graph per embedded language, hence
+// their name is suffixed by the language eg. README.md#python
+func ProjectGraphName(fileName string, languageCode core.LanguageCode) string {
+	if !lang.IsHostDocument(fileName) {
+		return fileName
+	}
+
+	return fileName + projectGraphLanguageSeparator + string(languageCode)
+}
+
+func projectGraphName(cg *CallGraph) string {
+	language, err := cg.Tree.Language()
+	if err != nil {
+		return cg.FileName
+	}
+
+	return ProjectGraphName(cg.FileName, language.Meta().Code)
+}
+
+// ProjectLink connects a namespace referring to an imported module in one file
+// to the namespace defined by that module in another file of the project
+// eg. app//helpers//normalize in main.py is linked to app/helpers.py//normalize
+type ProjectLink struct {
+	From ProjectNodeRef
+	To   ProjectNodeRef
+}
+
+type ProjectCallGraphConfig struct {
+	// GoModules maps Go module paths to the directory containing their go.mod
+	// eg. github.com/safedep/code => /src/code
+	// Directories must be in the same form as the file names of the call graphs.
+	// Imports of packages outside of these modules are linked only when vendored.
+	GoModules map[string]string
+}
+
+// ProjectCallGraphBuilder collects the call graphs of all the files in a project
+// and links them through imports. It is safe for concurrent use, hence it can
+// be used with the parallel plugin executor.
+type ProjectCallGraphBuilder struct {
+	config ProjectCallGraphConfig
+
+	m      sync.Mutex
+	graphs map[string]*CallGraph
+}
+
+func NewProjectCallGraphBuilder(config ProjectCallGraphConfig) *ProjectCallGraphBuilder {
+	return &ProjectCallGraphBuilder{
+		config: config,
+		graphs: make(map[string]*CallGraph),
+	}
+}
+
+// Add adds the call graph of a file to the project, host documents are added
+// a call graph per embedded language. Call graphs of the sources of a host
+// document sharing a language eg. the code fences of a Markdown document are
+// merged since they share the namespaces of the document
+func (b *ProjectCallGraphBuilder) Add(cg *CallGraph) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	graphName := projectGraphName(cg)
+	if existing, exists := b.graphs[graphName]; exists && existing != cg {
+		cg = mergeCallGraphs(existing, cg)
+	}
+
+	b.graphs[graphName] = cg
+}
+
+// mergeCallGraphs returns the union of the call graphs of sources of the same
+// document, the tree of the first call graph is kept. Graphs are not modified
+func mergeCallGraphs(first, second *CallGraph) *CallGraph {
+	merged := &CallGraph{
+		FileName:                first.FileName,
+		Nodes:                   make(map[string]*CallGraphNode),
+		Tree:                    first.Tree,
+		imports:                 slices.Concat(first.imports, second.imports),
+		assignmentGraph:         *newAssignmentGraph(),
+		classConstructors:       make(map[string]bool),
+		dynamicImportNamespaces: make(map[string]bool),
+		dynamicImportCalls:      make(map[uint32]string),
+		limitExceeded:           first.limitExceeded || second.limitExceeded,
+	}
+
+	for _, cg := range []*CallGraph{first, second} {
+		for namespace, node := range cg.Nodes {
+			mergedNode, exists := merged.Nodes[namespace]
+			if !exists {
+				mergedNode = newCallGraphNode(namespace, node.TreeNode)
+				merged.Nodes[namespace] = mergedNode
+			}
+
+			if mergedNode.TreeNode == nil {
+				mergedNode.TreeNode = node.TreeNode
+			}
+
+			mergedNode.CallsTo = append(mergedNode.CallsTo, node.CallsTo...)
+		}
+
+		for identifier, assignment := range cg.assignmentGraph.Assignments {
+			merged.assignmentGraph.addNode(identifier, assignment.TreeNode)
+			for _, target := range assignment.AssignedTo {
+				var targetTreeNode *sitter.Node
+				if targetAssignment, exists := cg.assignmentGraph.Assignments[target]; exists {
+					targetTreeNode = targetAssignment.TreeNode
+				}
+
+				merged.assignmentGraph.addAssignment(identifier, assignment.TreeNode, target, targetTreeNode)
+			}
+		}
+
+		maps.Copy(merged.classConstructors, cg.classConstructors)
+		maps.Copy(merged.dynamicImportNamespaces, cg.dynamicImportNamespaces)
+		maps.Copy(merged.dynamicImportCalls, cg.dynamicImportCalls)
+	}
+
+	merged.RootNode = merged.Nodes[merged.FileName]
+	merged.nodeCount = len(merged.Nodes)
+
+	return merged
+}
+
+// Callback returns a CallgraphCallback which adds every call graph
+// built by the callgraph plugin to the project
+func (b *ProjectCallGraphBuilder) Callback() CallgraphCallback {
+	return func(_ context.Context, cg *CallGraph) error {
+		b.Add(cg)
+		return nil
+	}
+}
+
+// Build links the collected call graphs by resolving intra-project imports
+// to the files defining the imported modules. Imports which can't be resolved
+// to a file of the project (eg. stdlib or third party packages) are left as is.
+func (b *ProjectCallGraphBuilder) Build() (*ProjectCallGraph, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	pcg := &ProjectCallGraph{
+		Graphs:     maps.Clone(b.graphs),
+		graphNames: slices.Sorted(maps.Keys(b.graphs)),
+		links:      make(map[ProjectNodeRef]ProjectNodeRef),
+	}
+
+	resolver := newProjectModuleResolver(pcg, b.config)
+
+	for _, fileName := range pcg.graphNames {
+		cg := pcg.Graphs[fileName]
+
+		language, err := cg.Tree.Language()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get language from parse tree: %w", err)
+		}
+
+		modules := resolver.resolveImportedModules(cg, language)
+		pcg.linkImportedModules(fileName, cg, modules)
+
+		if language.Meta().Code == core.LanguageCodeGo {
+			pcg.linkGoPackageSiblings(fileName, cg, resolver.goPackageFiles(cg.FileName))
+		}
+	}
+
+	return pcg, nil
+}
+
+// ProjectCallGraph is the union of per file call graphs of a project,
+// connected through links between imported and defining namespaces
+type ProjectCallGraph struct {
+	// Call graphs by name, see ProjectGraphName
+	Graphs map[string]*CallGraph
+
+	graphNames []string
+	links      map[ProjectNodeRef]ProjectNodeRef
+}
+
+// Links returns all cross file links sorted by the linked namespace
+func (p *ProjectCallGraph) Links() []ProjectLink {
+	links := make([]ProjectLink, 0, len(p.links))
+	for from, to := range p.links {
+		links = append(links, ProjectLink{From: from, To: to})
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].From.FileName != links[j].From.FileName {
+			return links[i].From.FileName < links[j].From.FileName
+		}
+		return links[i].From.Namespace < links[j].From.Namespace
+	})
+
+	return links
+}
+
+// ResolveLink returns the namespace defined in another file which is
+// referred by the given namespace through an import
+func (p *ProjectCallGraph) ResolveLink(ref ProjectNodeRef) (ProjectNodeRef, bool) {
+	target, exists := p.links[ref]
+	return target, exists
+}
+
+// Entrypoints returns the root nodes of files which are not imported
+// by any other file of the project
+func (p *ProjectCallGraph) Entrypoints() []ProjectNodeRef {
+	imported := make(map[string]bool)
+	for from, to := range p.links {
+		if from.FileName != to.FileName {
+			imported[to.FileName] = true
+		}
+	}
+
+	entrypoints := []ProjectNodeRef{}
+	for _, fileName := range p.graphNames {
+		if !imported[fileName] {
+			entrypoints = append(entrypoints, ProjectNodeRef{FileName: fileName, Namespace: p.Graphs[fileName].FileName})
+		}
+	}
+
+	return entrypoints
+}
+
+type ProjectDfsResultItem struct {
+	DfsResultItem
+
+	// File in which the node of this item is present
+	FileName string
+
+	// File in which the caller of this item is present
+	CallerFileName string
+}
+
+// DFS traverses the project call graph following calls across files. Entrypoints
+// are traversed first so that cross file calls are reported from the
+// perspective of the importing file. Similar to CallGraph.DFS, all functions
+// and classes are considered reachable, hence traversed afterwards.
+func (p *ProjectCallGraph) DFS() []ProjectDfsResultItem {
+	visited := make(map[ProjectNodeRef]bool)
+	var dfsResult []ProjectDfsResultItem
+
+	sources := p.Entrypoints()
+	for _, fileName := range p.graphNames {
+		sources = append(sources, ProjectNodeRef{FileName: fileName, Namespace: p.Graphs[fileName].FileName})
+	}
+
+	for _, fileName := range p.graphNames {
+		cg := p.Graphs[fileName]
+		for _, namespace := range slices.Sorted(maps.Keys(cg.Nodes)) {
+			node := cg.Nodes[namespace]
+			if node.TreeNode != nil && dfsSourceNodeTypes[node.TreeNode.Type()] {
+				sources = append(sources, ProjectNodeRef{FileName: fileName, Namespace: namespace})
+			}
+		}
+	}
+
+	for _, source := range sources {
+		if len(dfsResult) >= maxDFSResultItems {
+			log.Warnf("DFS result limit (%d) reached for project, stopping traversal", maxDFSResultItems)
+			break
+		}
+
+		if !visited[source] {
+			cg := p.Graphs[source.FileName]
+			p.dfsUtil(source, cg.RootNode, source.FileName, nil, []CallArgument{}, visited, &dfsResult, 0)
+		}
+	}
+
+	return dfsResult
+}
+
+func (p *ProjectCallGraph) dfsUtil(ref ProjectNodeRef, caller *CallGraphNode, callerFileName string, callerIdentifier *sitter.Node,
+	arguments []CallArgument, visited map[ProjectNodeRef]bool, result *[]ProjectDfsResultItem, depth int) {
+	if len(*result) >= maxDFSResultItems {
+		return
+	}
+
+	cg, exists := p.Graphs[ref.FileName]
+	if !exists {
+		return
+	}
+
+	if visited[ref] {
+		for _, terminal := range p.resolveTerminals(ref) {
+			*result = append(*result, ProjectDfsResultItem{
+				DfsResultItem: DfsResultItem{
+					Namespace:        terminal.Namespace,
+					Node:             p.Graphs[terminal.FileName].Nodes[terminal.Namespace],
+					Caller:           caller,
+					CallerIdentifier: callerIdentifier,
+					Arguments:        arguments,
+					Depth:            depth,
+					Terminal:         true,
+				},
+				FileName:       terminal.FileName,
+				CallerFileName: callerFileName,
+			})
+		}
+		return
+	}
+
+	callgraphNode, callgraphNodeExists := cg.Nodes[ref.Namespace]
+	linkedRef, linked := p.links[ref]
+
+	visited[ref] = true
+	*result = append(*result, ProjectDfsResultItem{
+		DfsResultItem: DfsResultItem{
+			Namespace:        ref.Namespace,
+			Node:             callgraphNode,
+			Caller:           caller,
+			CallerIdentifier: callerIdentifier,
+			Arguments:        arguments,
+			Depth:            depth,
+			Terminal:         !linked && (!callgraphNodeExists || len(callgraphNode.CallsTo) == 0),
+		},
+		FileName:       ref.FileName,
+		CallerFileName: callerFileName,
+	})
+
+	// Imported namespace is visited as if it was assigned the defining namespace
+	if linked {
+		p.dfsUtil(linkedRef, caller, callerFileName, callerIdentifier, arguments, visited, result, depth)
+	}
+
+	assignmentGraphNode, assignmentNodeExists := cg.assignmentGraph.Assignments[ref.Namespace]
+	if assignmentNodeExists {
+		for _, assigned := range assignmentGraphNode.AssignedTo {
+			p.dfsUtil(ProjectNodeRef{FileName: ref.FileName, Namespace: assigned}, caller, callerFileName,
+				callerIdentifier, arguments, visited, result, depth)
+		}
+	}
+
+	if callgraphNodeExists {
+		for _, callRef := range callgraphNode.CallsTo {
+			p.dfsUtil(ProjectNodeRef{FileName: ref.FileName, Namespace: callRef.CalleeNamespace}, callgraphNode, ref.FileName,
+				callRef.CallerIdentifier, callRef.Arguments, visited, result, depth+1)
+		}
+	}
+}
+
+// resolveTerminals resolves a namespace to the call graph nodes it finally refers to,
+// following assignments within a file and links across files
+func (p *ProjectCallGraph) resolveTerminals(ref ProjectNodeRef) []ProjectNodeRef {
+	terminals := []ProjectNodeRef{}
+	p.resolveTerminalsUtil(ref, make(map[ProjectNodeRef]bool), &terminals)
+	return terminals
+}
+
+func (p *ProjectCallGraph) resolveTerminalsUtil(ref ProjectNodeRef, seen map[ProjectNodeRef]bool, terminals *[]ProjectNodeRef) {
+	if seen[ref] {
+		return
+	}
+	seen[ref] = true
+
+	if linkedRef, linked := p.links[ref]; linked {
+		p.resolveTerminalsUtil(linkedRef, seen, terminals)
+		return
+	}
+
+	cg := p.Graphs[ref.FileName]
+	for _, assignmentNode := range cg.assignmentGraph.resolve(ref.Namespace) {
+		resolved := ProjectNodeRef{FileName: ref.FileName, Namespace: assignmentNode.Namespace}
+		if linkedRef, linked := p.links[resolved]; linked {
+			p.resolveTerminalsUtil(linkedRef, seen, terminals)
+			continue
+		}
+
+		if _, exists := cg.Nodes[resolved.Namespace]; exists {
+			*terminals = append(*terminals, resolved)
+		}
+	}
+}
+
+// Reachable returns all the nodes reachable from the given node through
+// calls, assignments and imports in breadth first order. The given node is
+// returned first since it is reachable through an empty path
+func (p *ProjectCallGraph) Reachable(from ProjectNodeRef) []ProjectNodeRef {
+	reachable := []ProjectNodeRef{}
+	p.bfs(from, func(ref ProjectNodeRef) bool {
+		reachable = append(reachable, ref)
+		return false
+	})
+
+	return reachable
+}
+
+// CallPath returns the shortest path of calls, assignments and imports
+// from one node to another, including both the nodes. The path from a node
+// to itself is the node alone, even if the node is part of a cycle
+func (p *ProjectCallGraph) CallPath(from, to ProjectNodeRef) ([]ProjectNodeRef, bool) {
+	return p.bfs(from, func(ref ProjectNodeRef) bool {
+		return ref == to
+	})
+}
+
+// CallPathToNamespace returns the shortest path from a node to any node with the
+// given namespace, eg. the path to a vendored library function like os//system
+func (p *ProjectCallGraph) CallPathToNamespace(from ProjectNodeRef, namespace string) ([]ProjectNodeRef, bool) {
+	return p.bfs(from, func(ref ProjectNodeRef) bool {
+		return ref.Namespace == namespace
+	})
+}
+
+// EvidenceCallPath returns the path from a node (typically an entrypoint) to the call
+// which caused a signature match. The path ends with the matched callee.
+func (p *ProjectCallGraph) EvidenceCallPath(from ProjectNodeRef, match SignatureMatchResult, evidence MatchedEvidence) ([]ProjectNodeRef, bool) {
+	if evidence.Caller == nil {
+		return nil, false
+	}
+
+	graphName := ProjectGraphName(match.FilePath, match.MatchedLanguageCode)
+	callPath, found := p.CallPath(from, ProjectNodeRef{FileName: graphName, Namespace: evidence.Caller.Namespace})
+	if !found {
+		return nil, false
+	}
+
+	if evidence.Callee != nil {
+		callPath = append(callPath, ProjectNodeRef{FileName: graphName, Namespace: evidence.Callee.Namespace})
+	}
+
+	return callPath, true
+}
+
+// bfs traverses the graph from the given node, starting with the node
+// itself, until found returns true and returns the path to the node for
+// which it returned true
+func (p *ProjectCallGraph) bfs(from ProjectNodeRef, found func(ProjectNodeRef) bool) ([]ProjectNodeRef, bool) {
+	if _, exists := p.Graphs[from.FileName]; !exists {
+		return nil, false
+	}
+
+	parents := map[ProjectNodeRef]ProjectNodeRef{from: from}
+	queue := []ProjectNodeRef{from}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if found(current) {
+			callPath := []ProjectNodeRef{current}
+			for current != from {
+				current = parents[current]
+				callPath = append(callPath, current)
+			}
+
+			slices.Reverse(callPath)
+			return callPath, true
+		}
+
+		for _, next := range p.successors(current) {
+			if _, seen := parents[next]; !seen {
+				parents[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (p *ProjectCallGraph) successors(ref ProjectNodeRef) []ProjectNodeRef {
+	successors := []ProjectNodeRef{}
+	if linkedRef, linked := p.links[ref]; linked {
+		successors = append(successors, linkedRef)
+	}
+
+	cg := p.Graphs[ref.FileName]
+	if assignmentNode, exists := cg.assignmentGraph.Assignments[ref.Namespace]; exists {
+		for _, assigned := range assignmentNode.AssignedTo {
+			successors = append(successors, ProjectNodeRef{FileName: ref.FileName, Namespace: assigned})
+		}
+	}
+
+	if node, exists := cg.Nodes[ref.Namespace]; exists {
+		for _, callRef := range node.CallsTo {
+			successors = append(successors, ProjectNodeRef{FileName: ref.FileName, Namespace: callRef.CalleeNamespace})
+		}
+	}
+
+	return successors
+}
+
+// importedModule maps the namespace of a module as seen by the importing file
+// to the file of the project which defines the module
+type importedModule struct {
+	namespace []string
+	fileName  string
+
+	// Segments of the namespace retained within the defining file
+	// eg. the class name for a Java import
+	scope []string
+}
+
+// linkImportedModules links every namespace of the named call graph that refers
+// to an imported module to the matching namespace in the defining file.
+// Longer (more specific) module namespaces take precedence.
+func (p *ProjectCallGraph) linkImportedModules(graphName string, cg *CallGraph, modules []importedModule) {
+	if len(modules) == 0 {
+		return
+	}
+
+	sort.SliceStable(modules, func(i, j int) bool {
+		return len(modules[i].namespace) > len(modules[j].namespace)
+	})
+
+	for _, namespace := range slices.Sorted(maps.Keys(cg.assignmentGraph.Assignments)) {
+		segments := strings.Split(namespace, namespaceSeparator)
+
+		for _, module := range modules {
+			if module.fileName == graphName || !hasSegmentsPrefix(segments, module.namespace) {
+				continue
+			}
+
+			remainder := append(slices.Clone(module.scope), segments[len(module.namespace):]...)
+			target, found := p.resolveInFile(module.fileName, remainder)
+			if found {
+				p.links[ProjectNodeRef{FileName: graphName, Namespace: namespace}] = target
+				break
+			}
+		}
+	}
+}
+
+// resolveInFile finds the namespace defined by a file for the given
+// namespace segments relative to the module
+func (p *ProjectCallGraph) resolveInFile(fileName string, segments []string) (ProjectNodeRef, bool) {
+	cg := p.Graphs[fileName]
+	if len(segments) == 0 {
+		return ProjectNodeRef{FileName: fileName, Namespace: cg.FileName}, true
+	}
+
+	namespace := cg.FileName + namespaceSeparator + strings.Join(segments, namespaceSeparator)
+	if _, exists := cg.Nodes[namespace]; exists {
+		return ProjectNodeRef{FileName: fileName, Namespace: namespace}, true
+	}
+
+	// Identifiers re-exported by the module, eg. `from .api import get` in __init__.py
+	identifier := strings.Join(segments, namespaceSeparator)
+	if assignmentNode, exists := cg.assignmentGraph.Assignments[identifier]; exists && len(assignmentNode.AssignedTo) > 0 {
+		return ProjectNodeRef{FileName: fileName, Namespace: identifier}, true
+	}
+
+	return ProjectNodeRef{}, false
+}
+
+// Go functions of a package may be declared in any file of the package directory
+// without an import. Such calls are namespaced under the calling scope
+// eg. main.go//main//helper, hence linked to helper declared in a sibling file.
+var goDeclarationNodeTypes = map[string]bool{
+	"function_declaration": true,
+	"method_declaration":   true,
+}
+
+func (p *ProjectCallGraph) linkGoPackageSiblings(graphName string, cg *CallGraph, siblings []string) {
+	prefix := cg.FileName + namespaceSeparator
+
+	for _, namespace := range slices.Sorted(maps.Keys(cg.Nodes)) {
+		ref := ProjectNodeRef{FileName: graphName, Namespace: namespace}
+		if !strings.HasPrefix(namespace, prefix) || isGoDeclaration(cg.Nodes[namespace]) {
+			continue
+		}
+
+		if _, linked := p.links[ref]; linked {
+			continue
+		}
+
+		segments := strings.Split(namespace, namespaceSeparator)
+		name := segments[len(segments)-1]
+		if isGoDeclaration(cg.Nodes[prefix+name]) {
+			continue
+		}
+
+		for _, sibling := range siblings {
+			if sibling == graphName {
+				continue
+			}
+
+			siblingGraph := p.Graphs[sibling]
+			target := siblingGraph.FileName + namespaceSeparator + name
+			if isGoDeclaration(siblingGraph.Nodes[target]) {
+				p.links[ref] = ProjectNodeRef{FileName: sibling, Namespace: target}
+				break
+			}
+		}
+	}
+}
+
+func isGoDeclaration(node *CallGraphNode) bool {
+	return node != nil && node.TreeNode != nil && goDeclarationNodeTypes[node.TreeNode.Type()]
+}
+
+func hasSegmentsPrefix(segments, prefix []string) bool {
+	if len(prefix) == 0 || len(prefix) > len(segments) {
+		return false
+	}
+
+	for i := range prefix {
+		if segments[i] != prefix[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// projectModuleResolver resolves imported module names
+// to the files of the project defining them
+type projectModuleResolver struct {
+	config ProjectCallGraphConfig
+
+	// Normalised slash separated paths of files mapped to the names of their
+	// call graphs, host documents are mapped to their first call graph
+	files map[string]string
+
+	// Normalised paths of files by their base name eg. helpers.py, in order,
+	// such that suffixes are matched only against files of the same name
+	basenames map[string][]string
+
+	// Go files grouped by their normalised directory, excluding host documents
+	goPackages map[string][]string
+}
+
+func newProjectModuleResolver(pcg *ProjectCallGraph, config ProjectCallGraphConfig) *projectModuleResolver {
+	r := &projectModuleResolver{
+		config:     config,
+		files:      make(map[string]string),
+		basenames:  make(map[string][]string),
+		goPackages: make(map[string][]string),
+	}
+
+	for _, graphName := range pcg.graphNames {
+		cg := pcg.Graphs[graphName]
+		filePath := normalizeProjectPath(cg.FileName)
+		if _, exists := r.files[filePath]; !exists {
+			r.files[filePath] = graphName
+		}
+
+		if graphName != cg.FileName {
+			continue
+		}
+
+		language, err := cg.Tree.Language()
+		if err == nil && language.Meta().Code == core.LanguageCodeGo {
+			dir := path.Dir(filePath)
+			r.goPackages[dir] = append(r.goPackages[dir], graphName)
+		}
+	}
+
+	for _, filePath := range slices.Sorted(maps.Keys(r.files)) {
+		basename := path.Base(filePath)
+		r.basenames[basename] = append(r.basenames[basename], filePath)
+	}
+
+	return r
+}
+
+func normalizeProjectPath(fileName string) string {
+	return path.Clean(filepath.ToSlash(fileName))
+}
+
+func (r *projectModuleResolver) resolveImportedModules(cg *CallGraph, language core.Language) []importedModule {
+	modules := []importedModule{}
+	for _, imp := range cg.imports {
+		switch language.Meta().Code {
+		case core.LanguageCodePython:
+			modules = append(modules, r.resolvePythonImport(cg.FileName, imp, language)...)
+		case core.LanguageCodeJavascript, core.LanguageCodeTypescript:
+			modules = append(modules, r.resolveJavascriptImport(cg.FileName, imp, language)...)
+		case core.LanguageCodeGo:
+			modules = append(modules, r.resolveGoImport(cg.FileName, imp, language)...)
+		case core.LanguageCodeJava:
+			modules = append(modules, r.resolveJavaImport(cg.FileName, imp, language)...)
+		}
+	}
+
+	return modules
+}
+
+// Python modules are resolved relative to the importing file for relative imports
+// eg. `from .helpers import x`, else relative to the roots of the importing file
+// eg. app.helpers => app/helpers.py or app/helpers/__init__.py
+// When an item is imported, it may be a submodule eg. `from app import service`
+func (r *projectModuleResolver) resolvePythonImport(importer string, imp *ast.ImportNode, language core.Language) []importedModule {
+	moduleName := imp.ModuleName()
+	moduleNamespace := resolveNamespaceWithSeparator(moduleName, language)
+
+	modules := []importedModule{}
+	if fileName, found := r.pythonModuleFile(importer, moduleName); found {
+		modules = append(modules, importedModule{
+			namespace: strings.Split(moduleNamespace, namespaceSeparator),
+			fileName:  fileName,
+		})
+	}
+
+	moduleItem := imp.ModuleItem()
+	if moduleItem == "" || imp.IsWildcardImport() {
+		return modules
+	}
+
+	submoduleName := moduleName + "." + moduleItem
+	if strings.HasSuffix(moduleName, ".") {
+		submoduleName = moduleName + moduleItem
+	}
+
+	if fileName, found := r.pythonModuleFile(importer, submoduleName); found {
+		modules = append(modules, importedModule{
+			namespace: strings.Split(moduleNamespace+namespaceSeparator+moduleItem, namespaceSeparator),
+			fileName:  fileName,
+		})
+	}
+
+	return modules
+}
+
+func (r *projectModuleResolver) pythonModuleFile(importer string, moduleName string) (string, bool) {
+	level := len(moduleName) - len(strings.TrimLeft(moduleName, "."))
+
+	parts := []string{}
+	for _, part := range strings.Split(moduleName[level:], ".") {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	if level > 0 {
+		base := path.Dir(normalizeProjectPath(importer))
+		for i := 1; i < level; i++ {
+			base = path.Dir(base)
+		}
+
+		modulePath := path.Join(append([]string{base}, parts...)...)
+		if len(parts) == 0 {
+			return r.firstExisting(path.Join(modulePath, "__init__.py"))
+		}
+
+		return r.firstExisting(modulePath+".py", path.Join(modulePath, "__init__.py"))
+	}
+
+	if len(parts) == 0 {
+		return "", false
+	}
+
+	// Absolute imports are resolved from the directory containing the package
+	// of the importing file and its parents eg. the root of the project, along
+	// with the modules vendored in them. Similarly named files elsewhere
+	// eg. tests/json.py do not shadow modules such as json
+	modulePath := path.Join(parts...)
+	dir := path.Dir(normalizeProjectPath(importer))
+	for r.isPythonPackage(dir) {
+		dir = path.Dir(dir)
+	}
+
+	candidates := []string{}
+	for ; ; dir = path.Dir(dir) {
+		for _, root := range []string{dir, path.Join(dir, "vendor")} {
+			candidates = append(candidates, path.Join(root, modulePath+".py"), path.Join(root, modulePath, "__init__.py"))
+		}
+
+		if dir == "." || dir == "/" {
+			break
+		}
+	}
+
+	return r.firstExisting(candidates...)
+}
+
+// isPythonPackage checks whether a directory is a regular Python package
+func (r *projectModuleResolver) isPythonPackage(dir string) bool {
+	if dir == "." || dir == "/" {
+		return false
+	}
+
+	_, exists := r.files[path.Join(dir, "__init__.py")]
+	return exists
+}
+
+var javascriptModuleExtensions = []string{".js", ".mjs", ".cjs", ".jsx", ".ts", ".tsx", ".mts", ".cts"}
+
+// Javascript and TypeScript modules are resolved relative to the importing file for paths
+// eg. ./lib/utils => lib/utils.js or lib/utils/index.js, else looked up in
+// node_modules directories of the importing file and its parents
+func (r *projectModuleResolver) resolveJavascriptImport(importer string, imp *ast.ImportNode, language core.Language) []importedModule {
+	moduleName := strings.Trim(imp.ModuleName(), "\"'`")
+	if moduleName == "" || strings.HasPrefix(moduleName, "/") {
+		return nil
+	}
+
+	candidates := []string{}
+	if moduleName == "." || moduleName == ".." || strings.HasPrefix(moduleName, "./") || strings.HasPrefix(moduleName, "../") {
+		candidates = javascriptModuleCandidates(path.Join(path.Dir(normalizeProjectPath(importer)), moduleName))
+	} else {
+		for dir := path.Dir(normalizeProjectPath(importer)); ; dir = path.Dir(dir) {
+			candidates = append(candidates, javascriptModuleCandidates(path.Join(dir, "node_modules", moduleName))...)
+			if dir == "." || dir == "/" {
+				break
+			}
+		}
+	}
+
+	fileName, found := r.firstExisting(candidates...)
+	if !found {
+		return nil
+	}
+
+	return []importedModule{{
+		namespace: strings.Split(resolveNamespaceWithSeparator(imp.ModuleName(), language), namespaceSeparator),
+		fileName:  fileName,
+	}}
+}
+
+func javascriptModuleCandidates(modulePath string) []string {
+	candidates := []string{modulePath}
+	for _, extension := range javascriptModuleExtensions {
+		candidates = append(candidates, modulePath+extension)
+	}
+
+	for _, extension := range javascriptModuleExtensions {
+		candidates = append(candidates, path.Join(modulePath, "index"+extension))
+	}
+
+	return candidates
+}
+
+// Go packages are resolved to directories using the configured module paths
+// or to vendor directories of the importing file and its parents. Every file
+// of the package directory is a candidate for the imported namespace.
+func (r *projectModuleResolver) resolveGoImport(importer string, imp *ast.ImportNode, language core.Language) []importedModule {
+	importPath := strings.Trim(imp.ModuleName(), "\"")
+	namespace := strings.Split(resolveNamespaceWithSeparator(imp.ModuleName(), language), namespaceSeparator)
+
+	// Nested modules take precedence over their parent module
+	modulePaths := slices.Sorted(maps.Keys(r.config.GoModules))
+	sort.SliceStable(modulePaths, func(i, j int) bool {
+		return len(modulePaths[i]) > len(modulePaths[j])
+	})
+
+	dirs := []string{}
+	for _, modulePath := range modulePaths {
+		if importPath == modulePath || strings.HasPrefix(importPath, modulePath+"/") {
+			moduleDir := normalizeProjectPath(r.config.GoModules[modulePath])
+			dirs = append(dirs, path.Join(moduleDir, strings.TrimPrefix(importPath, modulePath)))
+		}
+	}
+
+	for dir := path.Dir(normalizeProjectPath(importer)); ; dir = path.Dir(dir) {
+		dirs = append(dirs, path.Join(dir, "vendor", importPath))
+		if dir == "." || dir == "/" {
+			break
+		}
+	}
+
+	for _, dir := range dirs {
+		files := r.goPackages[dir]
+		if len(files) == 0 {
+			continue
+		}
+
+		modules := make([]importedModule, 0, len(files))
+		for _, fileName := range files {
+			modules = append(modules, importedModule{namespace: namespace, fileName: fileName})
+		}
+
+		return modules
+	}
+
+	return nil
+}
+
+func (r *projectModuleResolver) goPackageFiles(fileName string) []string {
+	return r.goPackages[path.Dir(normalizeProjectPath(fileName))]
+}
+
+// Java imports are resolved to the closest source file matching the
+// package path of the imported class eg. com.acme.Helper => com/acme/Helper.java
+// Nested classes are declared in the file of their outermost class, hence
+// shorter qualified names are tried until a source file is found.
+func (r *projectModuleResolver) resolveJavaImport(importer string, imp *ast.ImportNode, _ core.Language) []importedModule {
+	if imp.IsWildcardImport() {
+		return nil
+	}
+
+	parts := strings.Split(imp.ModuleName(), ".")
+	for i := len(parts); i > 0; i-- {
+		fileName, found := r.closest(importer, strings.Join(parts[:i], "/")+".java")
+		if found {
+			return []importedModule{{
+				namespace: slices.Clone(parts[:i]),
+				fileName:  fileName,
+				scope:     []string{parts[i-1]},
+			}}
+		}
+	}
+
+	return nil
+}
+
+func (r *projectModuleResolver) firstExisting(candidates ...string) (string, bool) {
+	for _, candidate := range candidates {
+		if fileName, exists := r.files[candidate]; exists {
+			return fileName, true
+		}
+	}
+
+	return "", false
+}
+
+// closest finds the file whose path ends with one of the suffixes, preferring
+// the file sharing the longest directory prefix with the importing file, so
+// that a module of the same source root wins over a similarly named module elsewhere
+func (r *projectModuleResolver) closest(importer string, suffixes ...string) (string, bool) {
+	importerDirs := strings.Split(path.Dir(normalizeProjectPath(importer)), "/")
+
+	bestFileName, bestScore := "", -1
+	for _, suffix := range suffixes {
+		for _, filePath := range r.basenames[path.Base(suffix)] {
+			if filePath != suffix && !strings.HasSuffix(filePath, "/"+suffix) {
+				continue
+			}
+
+			score := 0
+			for i, dir := range strings.Split(path.Dir(filePath), "/") {
+				if i >= len(importerDirs) || importerDirs[i] != dir {
+					break
+				}
+				score++
+			}
+
+			if score > bestScore {
+				bestFileName, bestScore = r.files[filePath], score
+			}
+		}
+
+		if bestScore >= 0 {
+			return bestFileName, true
+		}
+	}
+
+	return "", false
+}
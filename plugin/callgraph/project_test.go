@@ -0,0 +1,377 @@
+package callgraph
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/pkg/test"
+	"github.com/safedep/code/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+type projectCallgraphTestcase struct {
+	Name     string
+	Language core.LanguageCode
+	Dir      string
+	Config   ProjectCallGraphConfig
+
+	// Cross file links expected to be present (not exhaustive)
+	ExpectedLinks []ProjectLink
+
+	// Namespaces expected to be reachable from the entrypoint, with the
+	// expected call path to each of them
+	Entrypoint        ProjectNodeRef
+	ExpectedCallPaths map[string][]ProjectNodeRef
+}
+
+var projectTestcases = []projectCallgraphTestcase{
+	{
+		Name:     "python relative, package and vendored imports",
+		Language: core.LanguageCodePython,
+		Dir:      "fixtures/project/python",
+		ExpectedLinks: []ProjectLink{
+			{
+				From: ProjectNodeRef{"fixtures/project/python/main.py", "app//service//process"},
+				To:   ProjectNodeRef{"fixtures/project/python/app/service.py", "fixtures/project/python/app/service.py//process"},
+			},
+			{
+				From: ProjectNodeRef{"fixtures/project/python/main.py", "app//helpers//normalize"},
+				To:   ProjectNodeRef{"fixtures/project/python/app/helpers.py", "fixtures/project/python/app/helpers.py//normalize"},
+			},
+			{
+				From: ProjectNodeRef{"fixtures/project/python/app/service.py", "//helpers//normalize"},
+				To:   ProjectNodeRef{"fixtures/project/python/app/helpers.py", "fixtures/project/python/app/helpers.py//normalize"},
+			},
+			{
+				From: ProjectNodeRef{"fixtures/project/python/app/service.py", "////storage//save"},
+				To:   ProjectNodeRef{"fixtures/project/python/app/storage.py", "fixtures/project/python/app/storage.py//save"},
+			},
+			{
+				From: ProjectNodeRef{"fixtures/project/python/main.py", "requests//get"},
+				To:   ProjectNodeRef{"fixtures/project/python/vendor/requests/__init__.py", "get"},
+			},
+			{
+				From: ProjectNodeRef{"fixtures/project/python/vendor/requests/__init__.py", "//api//get"},
+				To:   ProjectNodeRef{"fixtures/project/python/vendor/requests/api.py", "fixtures/project/python/vendor/requests/api.py//get"},
+			},
+		},
+		Entrypoint: ProjectNodeRef{"fixtures/project/python/main.py", "fixtures/project/python/main.py"},
+		ExpectedCallPaths: map[string][]ProjectNodeRef{
+			"os//system": {
+				{"fixtures/project/python/main.py", "fixtures/project/python/main.py"},
+				{"fixtures/project/python/main.py", "fixtures/project/python/main.py//run"},
+				{"fixtures/project/python/main.py", "app//service//process"},
+				{"fixtures/project/python/app/service.py", "fixtures/project/python/app/service.py//process"},
+				{"fixtures/project/python/app/service.py", "////storage//save"},
+				{"fixtures/project/python/app/storage.py", "fixtures/project/python/app/storage.py//save"},
+				{"fixtures/project/python/app/storage.py", "os//system"},
+			},
+			"urllib3//request": {
+				{"fixtures/project/python/main.py", "fixtures/project/python/main.py"},
+				{"fixtures/project/python/main.py", "requests//get"},
+				{"fixtures/project/python/vendor/requests/__init__.py", "get"},
+				{"fixtures/project/python/vendor/requests/__init__.py", "//api//get"},
+				{"fixtures/project/python/vendor/requests/api.py", "fixtures/project/python/vendor/requests/api.py//get"},
+				{"fixtures/project/python/vendor/requests/api.py", "fixtures/project/python/vendor/requests/api.py//request"},
+				{"fixtures/project/python/vendor/requests/api.py", "urllib3//request"},
+			},
+		},
+	},
+	{
+		Name:     "javascript relative imports",
+		Language: core.LanguageCodeJavascript,
+		Dir:      "fixtures/project/javascript",
+		ExpectedLinks: []ProjectLink{
+			{
+				From: ProjectNodeRef{"fixtures/project/javascript/index.js", ".//lib//utils//format"},
+				To:   ProjectNodeRef{"fixtures/project/javascript/lib/utils.js", "fixtures/project/javascript/lib/utils.js//format"},
+			},
+			{
+				From: ProjectNodeRef{"fixtures/project/javascript/index.js", ".//lib//view.js//render"},
+				To:   ProjectNodeRef{"fixtures/project/javascript/lib/view.js", "fixtures/project/javascript/lib/view.js//render"},
+			},
+			{
+				From: ProjectNodeRef{"fixtures/project/javascript/lib/view.js", ".//utils//format"},
+				To:   ProjectNodeRef{"fixtures/project/javascript/lib/utils.js", "fixtures/project/javascript/lib/utils.js//format"},
+			},
+		},
+		Entrypoint: ProjectNodeRef{"fixtures/project/javascript/index.js", "fixtures/project/javascript/index.js"},
+		ExpectedCallPaths: map[string][]ProjectNodeRef{
+			"fs//writeFileSync": {
+				{"fixtures/project/javascript/index.js", "fixtures/project/javascript/index.js"},
+				{"fixtures/project/javascript/index.js", "fixtures/project/javascript/index.js//main"},
+				{"fixtures/project/javascript/index.js", ".//lib//utils//format"},
+				{"fixtures/project/javascript/lib/utils.js", "fixtures/project/javascript/lib/utils.js//format"},
+				{"fixtures/project/javascript/lib/utils.js", "fs//writeFileSync"},
+			},
+			"console//log": {
+				{"fixtures/project/javascript/index.js", "fixtures/project/javascript/index.js"},
+				{"fixtures/project/javascript/index.js", "fixtures/project/javascript/index.js//main"},
+				{"fixtures/project/javascript/index.js", "render"},
+				{"fixtures/project/javascript/index.js", ".//lib//view.js//render"},
+				{"fixtures/project/javascript/lib/view.js", "fixtures/project/javascript/lib/view.js//render"},
+				{"fixtures/project/javascript/lib/view.js", "console//log"},
+			},
+		},
+	},
+	{
+		Name:     "go module local, vendored and same package calls",
+		Language: core.LanguageCodeGo,
+		Dir:      "fixtures/project/golang",
+		Config: ProjectCallGraphConfig{
+			GoModules: map[string]string{
+				"github.com/acme/project": "fixtures/project/golang",
+			},
+		},
+		ExpectedLinks: []ProjectLink{
+			{
+				From: ProjectNodeRef{"fixtures/project/golang/main.go", "github.com//acme//project//internal//store//Save"},
+				To:   ProjectNodeRef{"fixtures/project/golang/internal/store/store.go", "fixtures/project/golang/internal/store/store.go//Save"},
+			},
+			{
+				From: ProjectNodeRef{"fixtures/project/golang/main.go", "github.com//acme//logger//Info"},
+				To:   ProjectNodeRef{"fixtures/project/golang/vendor/github.com/acme/logger/logger.go", "fixtures/project/golang/vendor/github.com/acme/logger/logger.go//Info"},
+			},
+			{
+				From: ProjectNodeRef{"fixtures/project/golang/main.go", "fixtures/project/golang/main.go//main//helper"},
+				To:   ProjectNodeRef{"fixtures/project/golang/helper.go", "fixtures/project/golang/helper.go//helper"},
+			},
+			{
+				From: ProjectNodeRef{"fixtures/project/golang/internal/store/store.go", "fixtures/project/golang/internal/store/store.go//Save//flush"},
+				To:   ProjectNodeRef{"fixtures/project/golang/internal/store/flush.go", "fixtures/project/golang/internal/store/flush.go//flush"},
+			},
+		},
+		Entrypoint: ProjectNodeRef{"fixtures/project/golang/main.go", "fixtures/project/golang/main.go"},
+		ExpectedCallPaths: map[string][]ProjectNodeRef{
+			"os//Remove": {
+				{"fixtures/project/golang/main.go", "fixtures/project/golang/main.go"},
+				{"fixtures/project/golang/main.go", "fixtures/project/golang/main.go//main"},
+				{"fixtures/project/golang/main.go", "github.com//acme//project//internal//store//Save"},
+				{"fixtures/project/golang/internal/store/store.go", "fixtures/project/golang/internal/store/store.go//Save"},
+				{"fixtures/project/golang/internal/store/store.go", "fixtures/project/golang/internal/store/store.go//Save//flush"},
+				{"fixtures/project/golang/internal/store/flush.go", "fixtures/project/golang/internal/store/flush.go//flush"},
+				{"fixtures/project/golang/internal/store/flush.go", "os//Remove"},
+			},
+			"log//Println": {
+				{"fixtures/project/golang/main.go", "fixtures/project/golang/main.go"},
+				{"fixtures/project/golang/main.go", "fixtures/project/golang/main.go//main"},
+				{"fixtures/project/golang/main.go", "github.com//acme//logger//Info"},
+				{"fixtures/project/golang/vendor/github.com/acme/logger/logger.go", "fixtures/project/golang/vendor/github.com/acme/logger/logger.go//Info"},
+				{"fixtures/project/golang/vendor/github.com/acme/logger/logger.go", "log//Println"},
+			},
+		},
+	},
+	{
+		Name:     "java package imports",
+		Language: core.LanguageCodeJava,
+		Dir:      "fixtures/project/java",
+		ExpectedLinks: []ProjectLink{
+			{
+				From: ProjectNodeRef{"fixtures/project/java/src/com/acme/app/Main.java", "com//acme//util//Helper//run"},
+				To:   ProjectNodeRef{"fixtures/project/java/src/com/acme/util/Helper.java", "fixtures/project/java/src/com/acme/util/Helper.java//Helper//run"},
+			},
+		},
+		Entrypoint: ProjectNodeRef{"fixtures/project/java/src/com/acme/app/Main.java", "fixtures/project/java/src/com/acme/app/Main.java"},
+		ExpectedCallPaths: map[string][]ProjectNodeRef{
+			"Runtime//getRuntime": {
+				{"fixtures/project/java/src/com/acme/app/Main.java", "fixtures/project/java/src/com/acme/app/Main.java"},
+				{"fixtures/project/java/src/com/acme/app/Main.java", "fixtures/project/java/src/com/acme/app/Main.java//Main//main"},
+				{"fixtures/project/java/src/com/acme/app/Main.java", "com//acme//util//Helper//run"},
+				{"fixtures/project/java/src/com/acme/util/Helper.java", "fixtures/project/java/src/com/acme/util/Helper.java//Helper//run"},
+				{"fixtures/project/java/src/com/acme/util/Helper.java", "Runtime//getRuntime"},
+			},
+		},
+	},
+}
+
+func buildProjectCallGraph(t *testing.T, testcase projectCallgraphTestcase) *ProjectCallGraph {
+	t.Helper()
+
+	treeWalker, fileSystem, err := test.SetupBasicPluginContext([]string{testcase.Dir}, []core.LanguageCode{testcase.Language})
+	assert.NoError(t, err)
+
+	builder := NewProjectCallGraphBuilder(testcase.Config)
+	pluginExecutor, err := plugin.NewTreeWalkPluginExecutor(treeWalker, []core.Plugin{
+		NewCallGraphPlugin(builder.Callback()),
+	})
+	assert.NoError(t, err)
+
+	err = pluginExecutor.Execute(context.Background(), fileSystem)
+	assert.NoError(t, err)
+
+	pcg, err := builder.Build()
+	assert.NoError(t, err)
+
+	return pcg
+}
+
+func TestProjectCallGraph(t *testing.T) {
+	for _, testcase := range projectTestcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			pcg := buildProjectCallGraph(t, testcase)
+
+			links := pcg.Links()
+			for _, expectedLink := range testcase.ExpectedLinks {
+				assert.Contains(t, links, expectedLink)
+			}
+
+			assert.Contains(t, pcg.Entrypoints(), testcase.Entrypoint)
+
+			for namespace, expectedCallPath := range testcase.ExpectedCallPaths {
+				callPath, found := pcg.CallPathToNamespace(testcase.Entrypoint, namespace)
+				assert.True(t, found, "Expected %s to be reachable from %s", namespace, testcase.Entrypoint.FileName)
+				assert.Equal(t, expectedCallPath, callPath)
+
+				assert.Contains(t, pcg.Reachable(testcase.Entrypoint), expectedCallPath[len(expectedCallPath)-1])
+			}
+		})
+	}
+}
+
+func TestProjectCallGraphHostDocuments(t *testing.T) {
+	treeWalker, fileSystem, err := test.SetupBasicPluginContext([]string{"fixtures/project/embedded"},
+		[]core.LanguageCode{core.LanguageCodeJavascript, core.LanguageCodeTypescript})
+	assert.NoError(t, err)
+
+	builder := NewProjectCallGraphBuilder(ProjectCallGraphConfig{})
+	pluginExecutor, err := plugin.NewTreeWalkPluginExecutor(treeWalker, []core.Plugin{
+		NewCallGraphPlugin(builder.Callback()),
+	})
+	assert.NoError(t, err)
+
+	err = pluginExecutor.Execute(context.Background(), fileSystem)
+	assert.NoError(t, err)
+
+	pcg, err := builder.Build()
+	assert.NoError(t, err)
+
+	// Every embedded language of the host document has its own call graph
+	javascriptGraph := ProjectGraphName("fixtures/project/embedded/index.html", core.LanguageCodeJavascript)
+	typescriptGraph := ProjectGraphName("fixtures/project/embedded/index.html", core.LanguageCodeTypescript)
+	assert.Equal(t, "fixtures/project/embedded/index.html#javascript", javascriptGraph)
+	assert.Contains(t, pcg.Graphs, javascriptGraph)
+	assert.Contains(t, pcg.Graphs, typescriptGraph)
+
+	links := pcg.Links()
+	assert.Contains(t, links, ProjectLink{
+		From: ProjectNodeRef{javascriptGraph, ".//lib//utils.js//format"},
+		To:   ProjectNodeRef{"fixtures/project/embedded/lib/utils.js", "fixtures/project/embedded/lib/utils.js//format"},
+	})
+	assert.Contains(t, links, ProjectLink{
+		From: ProjectNodeRef{typescriptGraph, ".//lib//view.js//render"},
+		To:   ProjectNodeRef{"fixtures/project/embedded/lib/view.js", "fixtures/project/embedded/lib/view.js//render"},
+	})
+
+	// Code fences of a language are merged into a call graph of the document
+	markdownGraph := ProjectGraphName("fixtures/project/embedded/README.md", core.LanguageCodeJavascript)
+	assert.Contains(t, links, ProjectLink{
+		From: ProjectNodeRef{markdownGraph, ".//lib//utils.js//format"},
+		To:   ProjectNodeRef{"fixtures/project/embedded/lib/utils.js", "fixtures/project/embedded/lib/utils.js//format"},
+	})
+	assert.Contains(t, links, ProjectLink{
+		From: ProjectNodeRef{markdownGraph, ".//lib//view.js//render"},
+		To:   ProjectNodeRef{"fixtures/project/embedded/lib/view.js", "fixtures/project/embedded/lib/view.js//render"},
+	})
+
+	reachable := pcg.Reachable(ProjectNodeRef{markdownGraph, "fixtures/project/embedded/README.md"})
+	assert.Contains(t, reachable, ProjectNodeRef{"fixtures/project/embedded/lib/utils.js", "fs//writeFileSync"})
+	assert.Contains(t, reachable, ProjectNodeRef{"fixtures/project/embedded/lib/view.js", "console//log"})
+
+	callPath, found := pcg.CallPathToNamespace(ProjectNodeRef{javascriptGraph, "fixtures/project/embedded/index.html"}, "fs//writeFileSync")
+	if assert.True(t, found) {
+		assert.Equal(t, ProjectNodeRef{"fixtures/project/embedded/lib/utils.js", "fs//writeFileSync"}, callPath[len(callPath)-1])
+	}
+}
+
+func TestProjectCallGraphPythonRoots(t *testing.T) {
+	pcg := buildProjectCallGraph(t, projectCallgraphTestcase{
+		Language: core.LanguageCodePython,
+		Dir:      "fixtures/project/pythonroots",
+	})
+
+	links := pcg.Links()
+	assert.Contains(t, links, ProjectLink{
+		From: ProjectNodeRef{"fixtures/project/pythonroots/main.py", "lib//util//log"},
+		To:   ProjectNodeRef{"fixtures/project/pythonroots/lib/util.py", "fixtures/project/pythonroots/lib/util.py//log"},
+	})
+
+	// Modules are not linked to similarly named files outside of the roots
+	// of the importing file eg. the standard library json and logging modules
+	for _, link := range links {
+		assert.NotEqual(t, "fixtures/project/pythonroots/tests/json.py", link.To.FileName)
+		assert.NotEqual(t, "fixtures/project/pythonroots/lib/logging.py", link.To.FileName)
+	}
+}
+
+func TestProjectCallGraphReachable(t *testing.T) {
+	testcase := projectTestcases[0]
+	pcg := buildProjectCallGraph(t, testcase)
+
+	reachable := pcg.Reachable(testcase.Entrypoint)
+	assert.NotEmpty(t, reachable)
+	assert.Equal(t, testcase.Entrypoint, reachable[0])
+
+	seen := map[ProjectNodeRef]bool{}
+	for _, ref := range reachable {
+		assert.False(t, seen[ref], "Expected %v to be reachable once", ref)
+		seen[ref] = true
+	}
+
+	// Nodes are ordered by their distance from the entrypoint
+	for _, expectedCallPath := range testcase.ExpectedCallPaths {
+		for i := 1; i < len(expectedCallPath); i++ {
+			assert.Less(t, slices.Index(reachable, expectedCallPath[i-1]), slices.Index(reachable, expectedCallPath[i]))
+		}
+	}
+
+	callPath, found := pcg.CallPath(testcase.Entrypoint, testcase.Entrypoint)
+	assert.True(t, found)
+	assert.Equal(t, []ProjectNodeRef{testcase.Entrypoint}, callPath)
+
+	_, found = pcg.CallPath(ProjectNodeRef{FileName: "unknown.py", Namespace: "unknown.py"}, testcase.Entrypoint)
+	assert.False(t, found)
+}
+
+func TestProjectCallGraphDFS(t *testing.T) {
+	pcg := buildProjectCallGraph(t, projectTestcases[0])
+
+	type crossFileCall struct {
+		Namespace      string
+		FileName       string
+		CallerFileName string
+	}
+
+	actualCalls := []crossFileCall{}
+	for _, item := range pcg.DFS() {
+		actualCalls = append(actualCalls, crossFileCall{item.Namespace, item.FileName, item.CallerFileName})
+	}
+
+	// Functions of imported files are reached from the caller in the importing file
+	assert.Contains(t, actualCalls, crossFileCall{
+		"fixtures/project/python/app/service.py//process",
+		"fixtures/project/python/app/service.py",
+		"fixtures/project/python/main.py",
+	})
+	assert.Contains(t, actualCalls, crossFileCall{
+		"fixtures/project/python/app/storage.py//save",
+		"fixtures/project/python/app/storage.py",
+		"fixtures/project/python/app/service.py",
+	})
+	assert.Contains(t, actualCalls, crossFileCall{
+		"fixtures/project/python/vendor/requests/api.py//get",
+		"fixtures/project/python/vendor/requests/api.py",
+		"fixtures/project/python/main.py",
+	})
+
+	// Namespaces already visited through another file are reported as terminals
+	// resolved across files
+	assert.Contains(t, actualCalls, crossFileCall{
+		"fixtures/project/python/app/helpers.py//normalize",
+		"fixtures/project/python/app/helpers.py",
+		"fixtures/project/python/app/service.py",
+	})
+}
@@ -0,0 +1,394 @@
+package callgraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	callgraphv1 "buf.build/gen/go/safedep/api/protocolbuffers/go/safedep/messages/code/callgraph/v1"
+	"github.com/safedep/code/core"
+)
+
+const (
+	sarifVersion   = "2.1.0"
+	sarifSchemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	sarifDefaultToolName       = "safedep-code"
+	sarifDefaultInformationURI = "https://github.com/safedep/code"
+	sarifDefaultLevel          = "note"
+
+	// Columns are counted in UTF-16 code units, the default of SARIF which
+	// is declared on the run for viewers not applying the default
+	sarifColumnKind = "utf16CodeUnits"
+)
+
+// SarifLog is the root object of a SARIF 2.1.0 document. Only the subset of
+// the specification required to report signature matches is modelled.
+type SarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool       SarifTool     `json:"tool"`
+	ColumnKind string        `json:"columnKind,omitempty"`
+	Results    []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifToolComponent `json:"driver"`
+}
+
+type SarifToolComponent struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	ID               string                 `json:"id"`
+	ShortDescription *SarifMessage          `json:"shortDescription,omitempty"`
+	Properties       map[string]interface{} `json:"properties,omitempty"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifResult struct {
+	RuleID     string                 `json:"ruleId"`
+	RuleIndex  int                    `json:"ruleIndex"`
+	Level      string                 `json:"level"`
+	Message    SarifMessage           `json:"message"`
+	Locations  []SarifLocation        `json:"locations"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []SarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           *SarifRegion          `json:"region,omitempty"`
+	ContextRegion    *SarifRegion          `json:"contextRegion,omitempty"`
+}
+
+type SarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SarifRegion uses 1-based lines and columns as required by SARIF
+type SarifRegion struct {
+	StartLine   int                   `json:"startLine"`
+	StartColumn int                   `json:"startColumn,omitempty"`
+	EndLine     int                   `json:"endLine,omitempty"`
+	EndColumn   int                   `json:"endColumn,omitempty"`
+	Snippet     *SarifArtifactContent `json:"snippet,omitempty"`
+}
+
+type SarifArtifactContent struct {
+	Text string `json:"text"`
+}
+
+type SarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+type SarifReporterConfig struct {
+	// Name of the tool reported in the SARIF driver, defaults to safedep-code
+	ToolName string
+
+	// Optional version of the tool reported in the SARIF driver
+	ToolVersion string
+
+	// Optional information URI of the tool reported in the SARIF driver
+	InformationURI string
+
+	// Optional root directory, artifact URIs are made relative to it
+	// so that viewers such as GitHub code scanning can resolve them
+	SourceRoot string
+}
+
+// SarifReporter converts signature match results into a SARIF 2.1.0 log.
+// Each matched signature becomes a rule and each matched evidence
+// becomes a result pointing at the caller identifier.
+type SarifReporter struct {
+	config    SarifReporterConfig
+	rules     []SarifRule
+	ruleIndex map[string]int
+	results   []SarifResult
+}
+
+func NewSarifReporter(config SarifReporterConfig) *SarifReporter {
+	if config.ToolName == "" {
+		config.ToolName = sarifDefaultToolName
+		if config.InformationURI == "" {
+			config.InformationURI = sarifDefaultInformationURI
+		}
+	}
+
+	return &SarifReporter{
+		config:    config,
+		rules:     []SarifRule{},
+		ruleIndex: map[string]int{},
+		results:   []SarifResult{},
+	}
+}
+
+// AddMatches records the signature match results obtained for a callgraph.
+// The callgraph is required to extract code snippets for the evidences.
+func (r *SarifReporter) AddMatches(cg *CallGraph, matches []SignatureMatchResult) error {
+	treeData, err := cg.Tree.Data()
+	if err != nil {
+		return fmt.Errorf("failed to get tree data: %w", err)
+	}
+
+	// Lines are split once per file, regions of every evidence are built from them
+	var lines [][]byte
+	if treeData != nil {
+		lines = bytes.Split(*treeData, []byte("\n"))
+	}
+
+	for _, match := range matches {
+		if match.MatchedSignature == nil {
+			continue
+		}
+
+		ruleIndex := r.addRule(match.MatchedSignature)
+		artifactURI := r.artifactURI(match.FilePath)
+
+		for _, condition := range match.MatchedConditions {
+			for _, evidence := range condition.Evidences {
+				metadata := evidence.Metadata(treeData)
+				properties := map[string]interface{}{
+					"language":        string(match.MatchedLanguageCode),
+					"conditionType":   condition.Condition.GetType(),
+					"conditionValue":  condition.Condition.GetValue(),
+					"calleeNamespace": metadata.CalleeNamespace,
+				}
+
+				if notebookPosition := sarifNotebookPosition(metadata); notebookPosition != nil {
+					properties["notebookCell"] = notebookPosition.Cell
+				}
+
+				r.results = append(r.results, SarifResult{
+					RuleID:    match.MatchedSignature.GetId(),
+					RuleIndex: ruleIndex,
+					Level:     sarifDefaultLevel,
+					Message:   SarifMessage{Text: sarifResultMessage(match.MatchedSignature, metadata)},
+					Locations: []SarifLocation{
+						sarifEvidenceLocation(artifactURI, metadata, lines),
+					},
+					Properties: properties,
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// Report returns the SARIF log with all the matches added so far
+func (r *SarifReporter) Report() *SarifLog {
+	return &SarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchemaURI,
+		Runs: []SarifRun{
+			{
+				Tool: SarifTool{
+					Driver: SarifToolComponent{
+						Name:           r.config.ToolName,
+						Version:        r.config.ToolVersion,
+						InformationURI: r.config.InformationURI,
+						Rules:          r.rules,
+					},
+				},
+				ColumnKind: sarifColumnKind,
+				Results:    r.results,
+			},
+		},
+	}
+}
+
+// Write serializes the SARIF log as indented JSON
+func (r *SarifReporter) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(r.Report())
+	if err != nil {
+		return fmt.Errorf("failed to encode sarif log: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SarifReporter) addRule(signature *callgraphv1.Signature) int {
+	if index, exists := r.ruleIndex[signature.GetId()]; exists {
+		return index
+	}
+
+	rule := SarifRule{ID: signature.GetId()}
+	if signature.GetDescription() != "" {
+		rule.ShortDescription = &SarifMessage{Text: signature.GetDescription()}
+	}
+
+	properties := map[string]interface{}{}
+	if len(signature.GetTags()) > 0 {
+		properties["tags"] = signature.GetTags()
+	}
+	if signature.GetVendor() != "" {
+		properties["vendor"] = signature.GetVendor()
+	}
+	if signature.GetProduct() != "" {
+		properties["product"] = signature.GetProduct()
+	}
+	if signature.GetService() != "" {
+		properties["service"] = signature.GetService()
+	}
+	if len(properties) > 0 {
+		rule.Properties = properties
+	}
+
+	index := len(r.rules)
+	r.rules = append(r.rules, rule)
+	r.ruleIndex[signature.GetId()] = index
+
+	return index
+}
+
+func (r *SarifReporter) artifactURI(filePath string) string {
+	if r.config.SourceRoot != "" {
+		relativePath, err := filepath.Rel(r.config.SourceRoot, filePath)
+		if err == nil && !strings.HasPrefix(relativePath, "..") {
+			filePath = relativePath
+		}
+	}
+
+	return filepath.ToSlash(filePath)
+}
+
+func sarifResultMessage(signature *callgraphv1.Signature, metadata EvidenceMetadata) string {
+	message := signature.GetId()
+	if signature.GetDescription() != "" {
+		message = signature.GetDescription()
+	}
+
+	if metadata.CalleeNamespace != "" {
+		message = fmt.Sprintf("%s: %s", message, metadata.CalleeNamespace)
+	}
+
+	return message
+}
+
+// Builds the result location from the caller identifier, falling back to the
+// caller scope when the evidence has no identifier eg. implicit calls
+func sarifEvidenceLocation(artifactURI string, metadata EvidenceMetadata, lines [][]byte) SarifLocation {
+	location := SarifLocation{
+		PhysicalLocation: SarifPhysicalLocation{
+			ArtifactLocation: SarifArtifactLocation{URI: artifactURI},
+		},
+	}
+
+	nodeMetadata := metadata.CallerIdentifierMetadata
+	if nodeMetadata == nil {
+		nodeMetadata = metadata.CallerMetadata
+	}
+
+	if nodeMetadata != nil {
+		region := sarifRegion(nodeMetadata, lines)
+		if metadata.CallerIdentifierContent != "" {
+			region.Snippet = &SarifArtifactContent{Text: metadata.CallerIdentifierContent}
+		}
+
+		location.PhysicalLocation.Region = region
+		location.PhysicalLocation.ContextRegion = sarifContextRegion(nodeMetadata, lines)
+	}
+
+	if metadata.CallerNamespace != "" {
+		location.LogicalLocations = []SarifLogicalLocation{
+			{FullyQualifiedName: metadata.CallerNamespace},
+		}
+	}
+
+	return location
+}
+
+// Location of the evidence in the cells of a notebook, it is nil for files
+// other than notebooks
+func sarifNotebookPosition(metadata EvidenceMetadata) *core.NotebookPosition {
+	if metadata.CallerIdentifierMetadata != nil {
+		return metadata.CallerIdentifierMetadata.NotebookPosition
+	}
+
+	if metadata.CallerMetadata != nil {
+		return metadata.CallerMetadata.NotebookPosition
+	}
+
+	return nil
+}
+
+// Lines of notebooks are relative to the cell of the evidence, reported
+// in the notebookCell property of the result
+func sarifRegionLines(metadata *TreeNodeMetadata) (int, int) {
+	startLine := int(metadata.StartLine) + 1
+	if metadata.NotebookPosition != nil {
+		startLine = int(metadata.NotebookPosition.Line)
+	}
+
+	return startLine, startLine + int(metadata.EndLine) - int(metadata.StartLine)
+}
+
+func sarifRegion(metadata *TreeNodeMetadata, lines [][]byte) *SarifRegion {
+	startLine, endLine := sarifRegionLines(metadata)
+	return &SarifRegion{
+		StartLine:   startLine,
+		StartColumn: sarifColumn(lines, metadata.StartLine, metadata.StartColumn),
+		EndLine:     endLine,
+		EndColumn:   sarifColumn(lines, metadata.EndLine, metadata.EndColumn),
+	}
+}
+
+// Converts the byte offset of tree sitter within a line to a 1-based column
+// in UTF-16 code units, the offset is used as is if the line is unknown
+func sarifColumn(lines [][]byte, line uint32, byteColumn uint32) int {
+	if int(line) >= len(lines) {
+		return int(byteColumn) + 1
+	}
+
+	prefix := lines[line][:min(int(byteColumn), len(lines[line]))]
+
+	column := 1
+	for len(prefix) > 0 {
+		r, size := utf8.DecodeRune(prefix)
+		column += utf16.RuneLen(r)
+		prefix = prefix[size:]
+	}
+
+	return column
+}
+
+// Context region spans the complete source lines of the evidence
+func sarifContextRegion(metadata *TreeNodeMetadata, lines [][]byte) *SarifRegion {
+	if int(metadata.EndLine) >= len(lines) || metadata.StartLine > metadata.EndLine {
+		return nil
+	}
+
+	snippet := bytes.Join(lines[metadata.StartLine:metadata.EndLine+1], []byte("\n"))
+	startLine, endLine := sarifRegionLines(metadata)
+	return &SarifRegion{
+		StartLine: startLine,
+		EndLine:   endLine,
+		Snippet:   &SarifArtifactContent{Text: string(bytes.TrimRight(snippet, "\r"))},
+	}
+}
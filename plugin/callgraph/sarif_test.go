@@ -0,0 +1,217 @@
+package callgraph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	callgraphv1 "buf.build/gen/go/safedep/api/protocolbuffers/go/safedep/messages/code/callgraph/v1"
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/pkg/test"
+	"github.com/safedep/code/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSarifReporter(t *testing.T) {
+	signatures := []*callgraphv1.Signature{
+		{
+			Id:          "js.filesystem.access",
+			Description: "Filesystem access",
+			Vendor:      "Node.js",
+			Product:     "fs",
+			Tags:        []string{"filesystem", "io"},
+			Languages: map[string]*callgraphv1.Signature_LanguageMatcher{
+				"javascript": {
+					Match: "any",
+					Conditions: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition{
+						{
+							Type:  "call",
+							Value: "fs/readFileSync",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	matcher, err := NewSignatureMatcher(signatures)
+	assert.NoError(t, err)
+
+	treeWalker, fileSystem, err := test.SetupBasicPluginContext([]string{"fixtures/testJavascript.js"},
+		[]core.LanguageCode{core.LanguageCodeJavascript})
+	assert.NoError(t, err)
+
+	reporter := NewSarifReporter(SarifReporterConfig{SourceRoot: "fixtures"})
+	callgraphCallback := func(ctx context.Context, cg *CallGraph) error {
+		matches, err := matcher.MatchSignatures(cg)
+		if err != nil {
+			return err
+		}
+
+		return reporter.AddMatches(cg, matches)
+	}
+
+	pluginExecutor, err := plugin.NewTreeWalkPluginExecutor(treeWalker, []core.Plugin{
+		NewCallGraphPlugin(callgraphCallback),
+	})
+	assert.NoError(t, err)
+
+	err = pluginExecutor.Execute(context.Background(), fileSystem)
+	assert.NoError(t, err)
+
+	t.Run("should convert signatures to rules", func(t *testing.T) {
+		report := reporter.Report()
+		assert.Equal(t, "2.1.0", report.Version)
+		assert.Len(t, report.Runs, 1)
+
+		assert.Equal(t, "utf16CodeUnits", report.Runs[0].ColumnKind)
+
+		driver := report.Runs[0].Tool.Driver
+		assert.Equal(t, "safedep-code", driver.Name)
+		assert.Len(t, driver.Rules, 1)
+		assert.Equal(t, "js.filesystem.access", driver.Rules[0].ID)
+		assert.Equal(t, "Filesystem access", driver.Rules[0].ShortDescription.Text)
+		assert.Equal(t, map[string]interface{}{
+			"tags":    []string{"filesystem", "io"},
+			"vendor":  "Node.js",
+			"product": "fs",
+		}, driver.Rules[0].Properties)
+	})
+
+	t.Run("should convert evidences to result locations", func(t *testing.T) {
+		results := reporter.Report().Runs[0].Results
+		assert.Len(t, results, 1)
+
+		result := results[0]
+		assert.Equal(t, "js.filesystem.access", result.RuleID)
+		assert.Equal(t, 0, result.RuleIndex)
+		assert.Equal(t, "note", result.Level)
+		assert.Len(t, result.Locations, 1)
+
+		physicalLocation := result.Locations[0].PhysicalLocation
+		assert.Equal(t, "testJavascript.js", physicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, 59, physicalLocation.Region.StartLine)
+		assert.Equal(t, 1, physicalLocation.Region.StartColumn)
+		assert.Equal(t, "fs.readFileSync", physicalLocation.Region.Snippet.Text)
+		assert.Equal(t, `fs.readFileSync("file.txt");`, physicalLocation.ContextRegion.Snippet.Text)
+	})
+
+	t.Run("should write valid json", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, reporter.Write(&buf))
+
+		var decoded map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, "2.1.0", decoded["version"])
+		assert.Contains(t, decoded, "$schema")
+	})
+}
+
+func TestSarifRegionColumns(t *testing.T) {
+	lines := [][]byte{
+		[]byte(`name = "héllo"; fs.readFileSync()`),
+		[]byte(`emoji = "😀"; os.system()`),
+	}
+
+	testcases := []struct {
+		name          string
+		metadata      TreeNodeMetadata
+		expectedStart int
+		expectedEnd   int
+	}{
+		{
+			name:          "ascii prefix",
+			metadata:      TreeNodeMetadata{StartLine: 0, EndLine: 0, StartColumn: 0, EndColumn: 4},
+			expectedStart: 1,
+			expectedEnd:   5,
+		},
+		{
+			name:          "multi byte character prefix",
+			metadata:      TreeNodeMetadata{StartLine: 0, EndLine: 0, StartColumn: 17, EndColumn: 32},
+			expectedStart: 17,
+			expectedEnd:   32,
+		},
+		{
+			name:          "surrogate pair prefix",
+			metadata:      TreeNodeMetadata{StartLine: 1, EndLine: 1, StartColumn: 16, EndColumn: 25},
+			expectedStart: 15,
+			expectedEnd:   24,
+		},
+		{
+			name:          "unknown line",
+			metadata:      TreeNodeMetadata{StartLine: 5, EndLine: 5, StartColumn: 3, EndColumn: 7},
+			expectedStart: 4,
+			expectedEnd:   8,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			region := sarifRegion(&testcase.metadata, lines)
+			assert.Equal(t, testcase.expectedStart, region.StartColumn)
+			assert.Equal(t, testcase.expectedEnd, region.EndColumn)
+		})
+	}
+}
+
+func TestSarifReporterNotebook(t *testing.T) {
+	signatures := []*callgraphv1.Signature{
+		{
+			Id:          "py.process.exec",
+			Description: "Process execution",
+			Languages: map[string]*callgraphv1.Signature_LanguageMatcher{
+				"python": {
+					Match: "any",
+					Conditions: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition{
+						{
+							Type:  "call",
+							Value: "subprocess.run",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	matcher, err := NewSignatureMatcher(signatures)
+	assert.NoError(t, err)
+
+	treeWalker, fileSystem, err := test.SetupBasicPluginContext([]string{"fixtures/testNotebook.ipynb"},
+		[]core.LanguageCode{core.LanguageCodePython})
+	assert.NoError(t, err)
+
+	reporter := NewSarifReporter(SarifReporterConfig{SourceRoot: "fixtures"})
+	callgraphCallback := func(ctx context.Context, cg *CallGraph) error {
+		matches, err := matcher.MatchSignatures(cg)
+		if err != nil {
+			return err
+		}
+
+		return reporter.AddMatches(cg, matches)
+	}
+
+	pluginExecutor, err := plugin.NewTreeWalkPluginExecutor(treeWalker, []core.Plugin{
+		NewCallGraphPlugin(callgraphCallback),
+	})
+	assert.NoError(t, err)
+
+	err = pluginExecutor.Execute(context.Background(), fileSystem)
+	assert.NoError(t, err)
+
+	t.Run("should report locations relative to the notebook cells", func(t *testing.T) {
+		results := reporter.Report().Runs[0].Results
+		assert.Len(t, results, 1)
+
+		result := results[0]
+		assert.Equal(t, 2, result.Properties["notebookCell"])
+
+		physicalLocation := result.Locations[0].PhysicalLocation
+		assert.Equal(t, "testNotebook.ipynb", physicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, 2, physicalLocation.Region.StartLine)
+		assert.Equal(t, 2, physicalLocation.Region.EndLine)
+		assert.Equal(t, "subprocess.run", physicalLocation.Region.Snippet.Text)
+		assert.Equal(t, 2, physicalLocation.ContextRegion.StartLine)
+		assert.Equal(t, `subprocess.run(["ls", "-l"])`, physicalLocation.ContextRegion.Snippet.Text)
+	})
+}
@@ -25,6 +25,13 @@ type MatchedEvidence struct {
 	Callee           *CallGraphNode
 	CallerIdentifier *sitter.Node
 	Arguments        []CallArgument
+
+	// Whether the callee belongs to a module imported at runtime
+	// eg. importlib.import_module("requests") in Python
+	IsDynamicImport bool
+
+	// File of the callgraph, used to map positions in notebooks
+	file core.File
 }
 
 // Note - We're only providing content details for the caller identifier since its
@@ -40,15 +47,20 @@ type EvidenceMetadata struct {
 	// Keyword / statement that caused the match
 	CallerIdentifierContent  string
 	CallerIdentifierMetadata *TreeNodeMetadata
+
+	IsDynamicImport bool
 }
 
 func (evidence *MatchedEvidence) Metadata(treeData *[]byte) EvidenceMetadata {
-	result := EvidenceMetadata{}
+	result := EvidenceMetadata{
+		IsDynamicImport: evidence.IsDynamicImport,
+	}
 
 	if evidence.Caller != nil {
 		result.CallerNamespace = evidence.Caller.Namespace
 		callerMetadata, exists := evidence.Caller.Metadata()
 		if exists {
+			callerMetadata.NotebookPosition = core.GetNotebookPosition(evidence.file, callerMetadata.StartLine)
 			result.CallerMetadata = &callerMetadata
 		}
 	}
@@ -57,6 +69,7 @@ func (evidence *MatchedEvidence) Metadata(treeData *[]byte) EvidenceMetadata {
 		result.CalleeNamespace = evidence.Callee.Namespace
 		calleeMetadata, exists := evidence.Callee.Metadata()
 		if exists {
+			calleeMetadata.NotebookPosition = core.GetNotebookPosition(evidence.file, calleeMetadata.StartLine)
 			result.CalleeMetadata = &calleeMetadata
 		}
 	}
@@ -68,6 +81,8 @@ func (evidence *MatchedEvidence) Metadata(treeData *[]byte) EvidenceMetadata {
 			EndLine:     evidence.CallerIdentifier.EndPoint().Row,
 			StartColumn: evidence.CallerIdentifier.StartPoint().Column,
 			EndColumn:   evidence.CallerIdentifier.EndPoint().Column,
+
+			NotebookPosition: core.GetNotebookPosition(evidence.file, evidence.CallerIdentifier.StartPoint().Row),
 		}
 	}
 
@@ -113,6 +128,12 @@ func (sm *SignatureMatcher) MatchSignatures(cg *CallGraph) ([]SignatureMatchResu
 
 	languageCode := language.Meta().Code
 
+	file, err := cg.Tree.File()
+	if err != nil {
+		log.Errorf("failed to get file from parse tree: %v", err)
+		return nil, err
+	}
+
 	matcherResults := []SignatureMatchResult{}
 
 	// Early termination if callgraph is too large or was truncated
@@ -181,6 +202,8 @@ func (sm *SignatureMatcher) MatchSignatures(cg *CallGraph) ([]SignatureMatchResu
 							Callee:           evidenceResultItem.Node,
 							CallerIdentifier: evidenceResultItem.CallerIdentifier,
 							Arguments:        evidenceResultItem.Arguments,
+							IsDynamicImport:  cg.IsDynamicImport(evidenceResultItem.Namespace),
+							file:             file,
 						})
 					} else {
 						// Skip this evidence if it doesn't match the argument constraints
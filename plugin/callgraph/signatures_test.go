@@ -71,6 +71,7 @@ type signatureMatchExpectation struct {
 	ExpectedLanguage core.LanguageCode
 	MinEvidenceCount int
 	CalleeContains   string // Optional: substring to verify in callee namespace
+	DynamicImport    bool   // Whether the callee is imported at runtime
 }
 
 // signatureMatcherTestCase defines a test case for signature matching
@@ -177,6 +178,304 @@ func TestSignatureMatcher(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:      "Python dynamic import signatures",
+			Language:  core.LanguageCodePython,
+			FilePaths: []string{"fixtures/testDynamicImports.py"},
+			Signatures: []*callgraphv1.Signature{
+				{
+					Id: "py.http.request",
+					Languages: map[string]*callgraphv1.Signature_LanguageMatcher{
+						"python": {
+							Match: "any",
+							Conditions: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition{
+								{
+									Type:  "call",
+									Value: "requests.get",
+								},
+							},
+						},
+					},
+				},
+				{
+					Id: "py.native.library",
+					Languages: map[string]*callgraphv1.Signature_LanguageMatcher{
+						"python": {
+							Match: "any",
+							Conditions: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition{
+								{
+									Type:  "call",
+									Value: "ctypes.CDLL",
+								},
+							},
+						},
+					},
+				},
+				{
+					Id: "py.process.spawn",
+					Languages: map[string]*callgraphv1.Signature_LanguageMatcher{
+						"python": {
+							Match: "any",
+							Conditions: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition{
+								{
+									Type:  "call",
+									Value: "subprocess.run",
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectedMatches: []signatureMatchExpectation{
+				{
+					SignatureID:      "py.http.request",
+					ShouldMatch:      true,
+					ExpectedLanguage: core.LanguageCodePython,
+					MinEvidenceCount: 1,
+					CalleeContains:   "requests//get",
+					DynamicImport:    true,
+				},
+				{
+					SignatureID:      "py.native.library",
+					ShouldMatch:      true,
+					ExpectedLanguage: core.LanguageCodePython,
+					MinEvidenceCount: 1,
+					CalleeContains:   "ctypes//CDLL",
+					DynamicImport:    true,
+				},
+				{
+					SignatureID:      "py.process.spawn",
+					ShouldMatch:      true,
+					ExpectedLanguage: core.LanguageCodePython,
+					MinEvidenceCount: 1,
+					CalleeContains:   "subprocess//run",
+				},
+			},
+		},
+		{
+			Name:      "Rust signatures",
+			Language:  core.LanguageCodeRust,
+			FilePaths: []string{"fixtures/testRust.rs"},
+			Signatures: []*callgraphv1.Signature{
+				{
+					Id: "rust.http.request",
+					Languages: map[string]*callgraphv1.Signature_LanguageMatcher{
+						"rust": {
+							Match: "any",
+							Conditions: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition{
+								{
+									Type:  "call",
+									Value: "reqwest::get",
+								},
+							},
+						},
+					},
+				},
+				{
+					Id: "rust.json.serialize",
+					Languages: map[string]*callgraphv1.Signature_LanguageMatcher{
+						"rust": {
+							Match: "any",
+							Conditions: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition{
+								{
+									Type:  "call",
+									Value: "serde_json::to_string",
+								},
+							},
+						},
+					},
+				},
+				{
+					Id: "rust.process.spawn",
+					Languages: map[string]*callgraphv1.Signature_LanguageMatcher{
+						"rust": {
+							Match: "any",
+							Conditions: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition{
+								{
+									Type:  "call",
+									Value: "std::process::Command::new",
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectedMatches: []signatureMatchExpectation{
+				{
+					SignatureID:      "rust.http.request",
+					ShouldMatch:      true,
+					ExpectedLanguage: core.LanguageCodeRust,
+					MinEvidenceCount: 1,
+					CalleeContains:   "reqwest//get",
+				},
+				{
+					SignatureID:      "rust.json.serialize",
+					ShouldMatch:      true,
+					ExpectedLanguage: core.LanguageCodeRust,
+					MinEvidenceCount: 1,
+					CalleeContains:   "serde_json//to_string",
+				},
+				{
+					SignatureID: "rust.process.spawn",
+					ShouldMatch: false,
+				},
+			},
+		},
+		{
+			Name:      "PHP signatures",
+			Language:  core.LanguageCodePhp,
+			FilePaths: []string{"fixtures/testPhp.php"},
+			Signatures: []*callgraphv1.Signature{
+				{
+					Id: "php.crypto.encrypt",
+					Languages: map[string]*callgraphv1.Signature_LanguageMatcher{
+						"php": {
+							Match: "any",
+							Conditions: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition{
+								{
+									Type:  "call",
+									Value: "openssl_encrypt",
+								},
+							},
+						},
+					},
+				},
+				{
+					Id: "php.http.client",
+					Languages: map[string]*callgraphv1.Signature_LanguageMatcher{
+						"php": {
+							Match: "any",
+							Conditions: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition{
+								{
+									Type:  "call",
+									Value: "\\GuzzleHttp\\Client",
+								},
+							},
+						},
+					},
+				},
+				{
+					Id: "php.process.exec",
+					Languages: map[string]*callgraphv1.Signature_LanguageMatcher{
+						"php": {
+							Match: "any",
+							Conditions: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition{
+								{
+									Type:  "call",
+									Value: "shell_exec",
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectedMatches: []signatureMatchExpectation{
+				{
+					SignatureID:      "php.crypto.encrypt",
+					ShouldMatch:      true,
+					ExpectedLanguage: core.LanguageCodePhp,
+					MinEvidenceCount: 1,
+					CalleeContains:   "openssl_encrypt",
+				},
+				{
+					SignatureID:      "php.http.client",
+					ShouldMatch:      true,
+					ExpectedLanguage: core.LanguageCodePhp,
+					MinEvidenceCount: 1,
+					CalleeContains:   "GuzzleHttp//Client",
+				},
+				{
+					SignatureID: "php.process.exec",
+					ShouldMatch: false,
+				},
+			},
+		},
+		{
+			Name:      "Bash signatures",
+			Language:  core.LanguageCodeBash,
+			FilePaths: []string{"fixtures/testBash.sh"},
+			Signatures: []*callgraphv1.Signature{
+				{
+					Id: "bash.network.download",
+					Languages: map[string]*callgraphv1.Signature_LanguageMatcher{
+						"bash": {
+							Match: "any",
+							Conditions: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition{
+								{
+									Type:  "call",
+									Value: "curl",
+								},
+								{
+									Type:  "call",
+									Value: "wget",
+								},
+							},
+						},
+					},
+				},
+				{
+					Id: "bash.base64.decode",
+					Languages: map[string]*callgraphv1.Signature_LanguageMatcher{
+						"bash": {
+							Match: "all",
+							Conditions: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition{
+								{
+									Type:  "call",
+									Value: "base64",
+									Args: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition_Argument{
+										{Index: 0, Values: []string{"-d", "--decode"}},
+									},
+								},
+								{
+									Type:  "call",
+									Value: "chmod",
+									Args: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition_Argument{
+										{Index: 0, Values: []string{"+x"}},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Id: "bash.base64.encode",
+					Languages: map[string]*callgraphv1.Signature_LanguageMatcher{
+						"bash": {
+							Match: "any",
+							Conditions: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition{
+								{
+									Type:  "call",
+									Value: "base64",
+									Args: []*callgraphv1.Signature_LanguageMatcher_SignatureCondition_Argument{
+										{Index: 0, Values: []string{"-w0"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectedMatches: []signatureMatchExpectation{
+				{
+					SignatureID:      "bash.network.download",
+					ShouldMatch:      true,
+					ExpectedLanguage: core.LanguageCodeBash,
+					MinEvidenceCount: 2,
+					CalleeContains:   "curl",
+				},
+				{
+					SignatureID:      "bash.base64.decode",
+					ShouldMatch:      true,
+					ExpectedLanguage: core.LanguageCodeBash,
+					MinEvidenceCount: 2,
+					CalleeContains:   "base64",
+				},
+				{
+					SignatureID: "bash.base64.encode",
+					ShouldMatch: false,
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -245,6 +544,13 @@ func TestSignatureMatcher(t *testing.T) {
 						assert.GreaterOrEqual(t, totalEvidences, expectation.MinEvidenceCount,
 							"Expected at least %d evidences", expectation.MinEvidenceCount)
 
+						for _, condition := range matchResult.MatchedConditions {
+							for _, evidence := range condition.Evidences {
+								assert.Equal(t, expectation.DynamicImport, evidence.IsDynamicImport,
+									"Expected evidence to be labelled as dynamic import: %t", expectation.DynamicImport)
+							}
+						}
+
 						// Verify callee namespace if specified
 						if expectation.CalleeContains != "" && totalEvidences > 0 {
 							evidence := matchResult.MatchedConditions[0].Evidences[0]
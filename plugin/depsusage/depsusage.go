@@ -12,20 +12,45 @@ import (
 
 type DependencyUsageCallback core.PluginCallback[*UsageEvidence]
 
+type DependencyUsagePluginConfig struct {
+	// Resolver of the package hint of imported modules. Defaults to
+	// guessing the package from the module name when not set.
+	PackageHintResolver PackageHintResolver
+}
+
 type dependencyUsagePlugin struct {
 	// Callback function which is called with the usage evidence
 	usageCallback DependencyUsageCallback
+
+	packageHintResolver PackageHintResolver
 }
 
 // Verify contract
 var _ core.TreePlugin = (*dependencyUsagePlugin)(nil)
+var _ core.CascadingPlugin = (*dependencyUsagePlugin)(nil)
+
+// UsageEvidencesResult is the result key of the usage evidences
+// collected for a file, published for cascading plugins
+var UsageEvidencesResult = core.NewResultKey[[]*UsageEvidence]("depsusage.usage-evidences")
 
 // depsusage plugin collects the usage evidence for the imported dependencies.
 // It uses tree-sitter to parse the imported dependency-identifier relations in the
 // source code and verify the usage of dependencies based on identifier usage.
 func NewDependencyUsagePlugin(usageCallback DependencyUsageCallback) *dependencyUsagePlugin {
+	return NewDependencyUsagePluginWithConfig(DependencyUsagePluginConfig{}, usageCallback)
+}
+
+// NewDependencyUsagePluginWithConfig creates the depsusage plugin with a custom
+// package hint resolver eg. one backed by the manifests of the project
+func NewDependencyUsagePluginWithConfig(config DependencyUsagePluginConfig,
+	usageCallback DependencyUsageCallback) *dependencyUsagePlugin {
+	if config.PackageHintResolver == nil {
+		config.PackageHintResolver = NewModuleNamePackageHintResolver()
+	}
+
 	return &dependencyUsagePlugin{
-		usageCallback: usageCallback,
+		usageCallback:       usageCallback,
+		packageHintResolver: config.PackageHintResolver,
 	}
 }
 
@@ -38,6 +63,14 @@ var supportedLanguages = []core.LanguageCode{
 	core.LanguageCodeGo,
 	core.LanguageCodeJavascript,
 	core.LanguageCodeJava,
+	core.LanguageCodeTypescript,
+	core.LanguageCodeRust,
+	core.LanguageCodeRuby,
+	core.LanguageCodePhp,
+	core.LanguageCodeCsharp,
+	core.LanguageCodeKotlin,
+	core.LanguageCodeC,
+	core.LanguageCodeCpp,
 }
 
 func (p *dependencyUsagePlugin) SupportedLanguages() []core.LanguageCode {
@@ -47,9 +80,51 @@ func (p *dependencyUsagePlugin) SupportedLanguages() []core.LanguageCode {
 var usageEvidentNodeTypes = map[string]bool{
 	"identifier":      true,
 	"type_identifier": true,
+
+	// Identifiers of PHP eg. names of classes, functions and constants
+	"name": true,
+
+	// Identifiers of Kotlin eg. names of functions, properties and objects
+	"simple_identifier": true,
+}
+
+func (p *dependencyUsagePlugin) Produces() []core.ResultName {
+	return []core.ResultName{UsageEvidencesResult.Name()}
+}
+
+func (p *dependencyUsagePlugin) Consumes() []core.ResultName {
+	return nil
 }
 
 func (p *dependencyUsagePlugin) AnalyzeTree(ctx context.Context, tree core.ParseTree) error {
+	return p.analyzeTree(ctx, tree, p.usageCallback)
+}
+
+// AnalyzeResults publishes the usage evidences of the file
+// in addition to calling the usage callback, if any
+func (p *dependencyUsagePlugin) AnalyzeResults(ctx context.Context, tree core.ParseTree, results core.PluginResults) error {
+	evidences := []*UsageEvidence{}
+	err := p.analyzeTree(ctx, tree, func(ctx context.Context, evidence *UsageEvidence) error {
+		evidences = append(evidences, evidence)
+		if p.usageCallback == nil {
+			return nil
+		}
+
+		return p.usageCallback(ctx, evidence)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = core.PutResult(results.File(), UsageEvidencesResult, evidences)
+	if err != nil {
+		return fmt.Errorf("failed to publish usage evidences: %w", err)
+	}
+
+	return nil
+}
+
+func (p *dependencyUsagePlugin) analyzeTree(ctx context.Context, tree core.ParseTree, usageCallback DependencyUsageCallback) error {
 	lang, err := tree.Language()
 	if err != nil {
 		return fmt.Errorf("failed to get language: %w", err)
@@ -75,7 +150,7 @@ func (p *dependencyUsagePlugin) AnalyzeTree(ctx context.Context, tree core.Parse
 			return fmt.Errorf("failed to resolve import contents: %w", err)
 		}
 
-		packageHint, err := resolvePackageHint(importContents.ModuleName, lang)
+		packageHint, err := p.packageHintResolver.ResolvePackageHint(importContents.ModuleName, lang)
 		if err != nil {
 			log.Debugf("failed to resolve package hint: %s", err)
 		}
@@ -84,15 +159,23 @@ func (p *dependencyUsagePlugin) AnalyzeTree(ctx context.Context, tree core.Parse
 			// @TODO - This is false positive case for wildcard imports
 			// If it is a wildcard import, mark the module as used by default
 			evidence := newUsageEvidence(packageHint, importContents.ModuleName, importContents.ModuleItem, importContents.ModuleAlias, true, "", file.Name(), uint(imp.GetModuleNameNode().StartPoint().Row)+1)
-			if err := p.usageCallback(ctx, evidence); err != nil {
+			evidence.IsDynamicImport = imp.IsDynamicImport()
+			evidence.NotebookPosition = core.GetNotebookPosition(file, imp.GetModuleNameNode().StartPoint().Row)
+			if err := usageCallback(ctx, evidence); err != nil {
 				return fmt.Errorf("failed to call usage callback for wildcard import: %w", err)
 			}
 		} else {
 			identifierKey := helpers.GetFirstNonEmptyString(importContents.ModuleAlias, importContents.ModuleItem, importContents.ModuleName)
-			moduleIdentifiers[identifierKey] = newIdentifierItem(importContents.ModuleName, importContents.ModuleItem, importContents.ModuleAlias, identifierKey, packageHint)
+			identifiedItem := newIdentifierItem(importContents.ModuleName, importContents.ModuleItem, importContents.ModuleAlias, identifierKey, packageHint)
+			identifiedItem.IsDynamicImport = imp.IsDynamicImport()
+			moduleIdentifiers[identifierKey] = identifiedItem
 		}
 	}
 
+	if err := p.reportUnresolvableImports(ctx, tree, lang, file, usageCallback); err != nil {
+		return err
+	}
+
 	treeData, err := tree.Data()
 	if err != nil {
 		return fmt.Errorf("failed to get tree data: %w", err)
@@ -114,7 +197,9 @@ func (p *dependencyUsagePlugin) AnalyzeTree(ctx context.Context, tree core.Parse
 			identifiedItem, identifierKeyExists := moduleIdentifiers[identifierKey]
 			if identifierKeyExists {
 				evidence := newUsageEvidence(identifiedItem.PackageHint, identifiedItem.Module, identifiedItem.Item, identifiedItem.Alias, false, identifierKey, file.Name(), uint(n.StartPoint().Row)+1)
-				if err := p.usageCallback(ctx, evidence); err != nil {
+				evidence.IsDynamicImport = identifiedItem.IsDynamicImport
+				evidence.NotebookPosition = core.GetNotebookPosition(file, n.StartPoint().Row)
+				if err := usageCallback(ctx, evidence); err != nil {
 					return fmt.Errorf("failed to call usage callback: %w", err)
 				}
 			}
@@ -127,6 +212,35 @@ func (p *dependencyUsagePlugin) AnalyzeTree(ctx context.Context, tree core.Parse
 	return nil
 }
 
+// reportUnresolvableImports reports the modules imported at runtime by a name
+// computed at runtime eg. `require(process.env.PLUGIN)`, such that code hiding
+// the modules it loads can be flagged. The package of such modules is unknown.
+func (p *dependencyUsagePlugin) reportUnresolvableImports(ctx context.Context, tree core.ParseTree,
+	lang core.Language, file core.File, usageCallback DependencyUsageCallback) error {
+	resolvers, ok := lang.Resolvers().(core.DynamicImportLanguageResolvers)
+	if !ok {
+		return nil
+	}
+
+	unresolvableImports, err := resolvers.ResolveUnresolvableImports(tree)
+	if err != nil {
+		return fmt.Errorf("failed to resolve unresolvable imports: %w", err)
+	}
+
+	for _, imp := range unresolvableImports {
+		position := imp.Position()
+		evidence := newUsageEvidence(PackageHint{}, imp.SpecifierPattern(), "", "", false, imp.Specifier(), file.Name(), uint(position.StartLine))
+		evidence.IsDynamicImport = true
+		evidence.IsUnresolvableImport = true
+		evidence.NotebookPosition = core.GetNotebookPosition(file, position.StartLine-1)
+		if err := usageCallback(ctx, evidence); err != nil {
+			return fmt.Errorf("failed to call usage callback for unresolvable import: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func traverse(cursor *sitter.TreeCursor, treeLanguage *core.Language, treeData *[]byte, visit func(node *sitter.Node) error) error {
 	for {
 		// Call the visit function for the current node
@@ -17,91 +17,241 @@ type DepsTestcase struct {
 	ExpectedEvicences []*UsageEvidence
 }
 
+// moduleNameHint is the package hint guessed from the module name
+func moduleNameHint(name string) PackageHint {
+	if name == "" {
+		return PackageHint{}
+	}
+
+	return PackageHint{Name: name, Confidence: PackageHintConfidenceLow}
+}
+
+// dynamicImport marks the evidence as usage of a module imported at runtime
+func dynamicImport(evidence *UsageEvidence) *UsageEvidence {
+	evidence.IsDynamicImport = true
+	return evidence
+}
+
+// unresolvableImport is the evidence of a module imported by a name computed at runtime
+func unresolvableImport(pattern string, specifier string, filePath string, line uint) *UsageEvidence {
+	evidence := newUsageEvidence(PackageHint{}, pattern, "", "", false, specifier, filePath, line)
+	evidence.IsDynamicImport = true
+	evidence.IsUnresolvableImport = true
+	return evidence
+}
+
+// inNotebook sets the position of the evidence in the cells of a notebook
+func inNotebook(evidence *UsageEvidence, cell int, line uint32) *UsageEvidence {
+	evidence.NotebookPosition = &core.NotebookPosition{Cell: cell, Line: line}
+	return evidence
+}
+
 var testcases = []DepsTestcase{
 	{
 		Language: core.LanguageCodePython,
 		FilePath: "fixtures/testcases.py",
 		ExpectedEvicences: []*UsageEvidence{
-			newUsageEvidence("seaborn", "seaborn", "", "", true, "", "fixtures/testcases.py", 60),
-			newUsageEvidence("flask", "flask.helpers", "", "", true, "", "fixtures/testcases.py", 61),
-			newUsageEvidence("xyz", "xyz.pqr.mno", "", "", true, "", "fixtures/testcases.py", 62),
-			newUsageEvidence("sys", "sys", "", "sys", false, "sys", "fixtures/testcases.py", 6),
-			newUsageEvidence("math", "math", "sqrt", "sqrt", false, "sqrt", "fixtures/testcases.py", 13),
-			newUsageEvidence("pandas", "pandas", "", "pd", false, "pd", "fixtures/testcases.py", 18),
-			newUsageEvidence("matplotlib", "matplotlib.pyplot", "", "plt", false, "plt", "fixtures/testcases.py", 22),
-			newUsageEvidence("slumber", "slumber", "API", "sl", false, "sl", "fixtures/testcases.py", 27),
-			newUsageEvidence("sklearn", "sklearn", "datasets", "ds", false, "ds", "fixtures/testcases.py", 29),
-			newUsageEvidence("sklearn", "sklearn", "metrics", "met", false, "met", "fixtures/testcases.py", 30),
-			newUsageEvidence("random", "random", "randint", "randint", false, "randint", "fixtures/testcases.py", 35),
-			newUsageEvidence("collections", "collections", "deque", "deque", false, "deque", "fixtures/testcases.py", 37),
-			newUsageEvidence("collections", "collections", "defaultdict", "defaultdict", false, "defaultdict", "fixtures/testcases.py", 39),
-			newUsageEvidence("collections", "collections", "namedtuple", "namedtuple", false, "namedtuple", "fixtures/testcases.py", 40),
-			newUsageEvidence("json", "json.encoder.implementation", "JSONEncoder", "JSONEncoder", false, "JSONEncoder", "fixtures/testcases.py", 46),
-			newUsageEvidence("urllib", "urllib.parse", "urlsplit", "urlsplit", false, "urlsplit", "fixtures/testcases.py", 47),
-			newUsageEvidence("ujson", "ujson", "", "ujson", false, "ujson", "fixtures/testcases.py", 52),
-			newUsageEvidence("simplejson", "simplejson", "", "smpjson", false, "smpjson", "fixtures/testcases.py", 56),
+			newUsageEvidence(moduleNameHint("seaborn"), "seaborn", "", "", true, "", "fixtures/testcases.py", 60),
+			newUsageEvidence(moduleNameHint("flask"), "flask.helpers", "", "", true, "", "fixtures/testcases.py", 61),
+			newUsageEvidence(moduleNameHint("xyz"), "xyz.pqr.mno", "", "", true, "", "fixtures/testcases.py", 62),
+			newUsageEvidence(moduleNameHint("sys"), "sys", "", "sys", false, "sys", "fixtures/testcases.py", 6),
+			newUsageEvidence(moduleNameHint("math"), "math", "sqrt", "sqrt", false, "sqrt", "fixtures/testcases.py", 13),
+			newUsageEvidence(moduleNameHint("pandas"), "pandas", "", "pd", false, "pd", "fixtures/testcases.py", 18),
+			newUsageEvidence(moduleNameHint("matplotlib"), "matplotlib.pyplot", "", "plt", false, "plt", "fixtures/testcases.py", 22),
+			newUsageEvidence(moduleNameHint("slumber"), "slumber", "API", "sl", false, "sl", "fixtures/testcases.py", 27),
+			newUsageEvidence(moduleNameHint("sklearn"), "sklearn", "datasets", "ds", false, "ds", "fixtures/testcases.py", 29),
+			newUsageEvidence(moduleNameHint("sklearn"), "sklearn", "metrics", "met", false, "met", "fixtures/testcases.py", 30),
+			newUsageEvidence(moduleNameHint("random"), "random", "randint", "randint", false, "randint", "fixtures/testcases.py", 35),
+			newUsageEvidence(moduleNameHint("collections"), "collections", "deque", "deque", false, "deque", "fixtures/testcases.py", 37),
+			newUsageEvidence(moduleNameHint("collections"), "collections", "defaultdict", "defaultdict", false, "defaultdict", "fixtures/testcases.py", 39),
+			newUsageEvidence(moduleNameHint("collections"), "collections", "namedtuple", "namedtuple", false, "namedtuple", "fixtures/testcases.py", 40),
+			newUsageEvidence(moduleNameHint("json"), "json.encoder.implementation", "JSONEncoder", "JSONEncoder", false, "JSONEncoder", "fixtures/testcases.py", 46),
+			newUsageEvidence(moduleNameHint("urllib"), "urllib.parse", "urlsplit", "urlsplit", false, "urlsplit", "fixtures/testcases.py", 47),
+			newUsageEvidence(moduleNameHint("ujson"), "ujson", "", "ujson", false, "ujson", "fixtures/testcases.py", 52),
+			newUsageEvidence(moduleNameHint("simplejson"), "simplejson", "", "smpjson", false, "smpjson", "fixtures/testcases.py", 56),
+		},
+	},
+	{
+		Language: core.LanguageCodePython,
+		FilePath: "fixtures/testcases_dynamic.py",
+		ExpectedEvicences: []*UsageEvidence{
+			dynamicImport(newUsageEvidence(moduleNameHint("colorama"), "colorama", "", "", true, "", "fixtures/testcases_dynamic.py", 12)),
+			unresolvableImport("*", "plugin_name", "fixtures/testcases_dynamic.py", 15),
+			dynamicImport(newUsageEvidence(moduleNameHint("yaml"), "yaml", "", "yml", false, "yml", "fixtures/testcases_dynamic.py", 4)),
+			newUsageEvidence(moduleNameHint("importlib"), "importlib", "", "importlib", false, "importlib", "fixtures/testcases_dynamic.py", 4),
+			dynamicImport(newUsageEvidence(moduleNameHint("yaml"), "yaml", "", "yml", false, "yml", "fixtures/testcases_dynamic.py", 5)),
+			dynamicImport(newUsageEvidence(moduleNameHint("sqlalchemy"), "sqlalchemy.dialects.*", "", "driver", false, "driver", "fixtures/testcases_dynamic.py", 8)),
+			newUsageEvidence(moduleNameHint("importlib"), "importlib", "", "importlib", false, "importlib", "fixtures/testcases_dynamic.py", 8),
+			dynamicImport(newUsageEvidence(moduleNameHint("sqlalchemy"), "sqlalchemy.dialects.*", "", "driver", false, "driver", "fixtures/testcases_dynamic.py", 9)),
+			newUsageEvidence(moduleNameHint("importlib"), "importlib", "", "importlib", false, "importlib", "fixtures/testcases_dynamic.py", 15),
 		},
 	},
 	{
 		Language: core.LanguageCodeGo,
 		FilePath: "fixtures/testcases.go",
 		ExpectedEvicences: []*UsageEvidence{
-			newUsageEvidence("embed", "embed", "", "", true, "", "fixtures/testcases.go", 12),
-			newUsageEvidence("math", "math", "", "", true, "", "fixtures/testcases.go", 13),
-			newUsageEvidence("github.com/labstack/echo-contrib", "github.com/labstack/echo-contrib/pprof", "", "", true, "", "fixtures/testcases.go", 18),
-			newUsageEvidence("net", "net/http", "", "", true, "", "fixtures/testcases.go", 20),
-			newUsageEvidence("fmt", "fmt", "", "fmt", false, "fmt", "fixtures/testcases.go", 25),
-			newUsageEvidence("github.com/safedep/code", "github.com/safedep/code/lang", "", "lang", false, "lang", "fixtures/testcases.go", 25),
-			newUsageEvidence("os", "os", "", "osalias", false, "osalias", "fixtures/testcases.go", 27),
-			newUsageEvidence("crypto", "crypto", "", "cryptoalias", false, "cryptoalias", "fixtures/testcases.go", 33),
-			newUsageEvidence("strings", "strings", "", "strings", false, "strings", "fixtures/testcases.go", 39),
+			newUsageEvidence(moduleNameHint("embed"), "embed", "", "", true, "", "fixtures/testcases.go", 12),
+			newUsageEvidence(moduleNameHint("math"), "math", "", "", true, "", "fixtures/testcases.go", 13),
+			newUsageEvidence(moduleNameHint("github.com/labstack/echo-contrib"), "github.com/labstack/echo-contrib/pprof", "", "", true, "", "fixtures/testcases.go", 18),
+			newUsageEvidence(moduleNameHint("net"), "net/http", "", "", true, "", "fixtures/testcases.go", 20),
+			newUsageEvidence(moduleNameHint("fmt"), "fmt", "", "fmt", false, "fmt", "fixtures/testcases.go", 25),
+			newUsageEvidence(moduleNameHint("github.com/safedep/code"), "github.com/safedep/code/lang", "", "lang", false, "lang", "fixtures/testcases.go", 25),
+			newUsageEvidence(moduleNameHint("os"), "os", "", "osalias", false, "osalias", "fixtures/testcases.go", 27),
+			newUsageEvidence(moduleNameHint("crypto"), "crypto", "", "cryptoalias", false, "cryptoalias", "fixtures/testcases.go", 33),
+			newUsageEvidence(moduleNameHint("strings"), "strings", "", "strings", false, "strings", "fixtures/testcases.go", 39),
 		},
 	},
 	{
 		Language: core.LanguageCodeJavascript,
 		FilePath: "fixtures/testcases.js",
 		ExpectedEvicences: []*UsageEvidence{
-			newUsageEvidence("express", "express", "", "express", false, "express", "fixtures/testcases.js", 10),
-			newUsageEvidence("cluster", "cluster", "", "Cluster", false, "Cluster", "fixtures/testcases.js", 11),
-			newUsageEvidence("@gilbarbara/eslint-config", "@gilbarbara/eslint-config", "", "EslintConfig", false, "EslintConfig", "fixtures/testcases.js", 14),
-			newUsageEvidence("./config.js", "./config.js", "", "config", false, "config", "fixtures/testcases.js", 20),
-			newUsageEvidence("./utils.js", "./utils.js", "", "utils", false, "utils", "fixtures/testcases.js", 21),
-			newUsageEvidence("../utils/helper.js", "../utils/helper.js", "", "helper", false, "helper", "fixtures/testcases.js", 27),
-			newUsageEvidence("../utils/sideeffects.js", "../utils/sideeffects.js", "", "sideeffects", false, "sideeffects", "fixtures/testcases.js", 28),
-			newUsageEvidence("./data1.json", "./data1.json", "", "jsonData", false, "jsonData", "fixtures/testcases.js", 34),
-			newUsageEvidence("./data2.json", "./data2.json", "", "jsonData2", false, "jsonData2", "fixtures/testcases.js", 35),
-			newUsageEvidence("lodash", "lodash", "", "lodash", false, "lodash", "fixtures/testcases.js", 42),
-			newUsageEvidence("./math-utils", "./math-utils", "", "mathUtils", false, "mathUtils", "fixtures/testcases.js", 43),
-			newUsageEvidence("./dynamic-module.js", "./dynamic-module.js", "", "dynamicModule", false, "dynamicModule", "fixtures/testcases.js", 46),
-			newUsageEvidence("./dynamic-module.js", "./dynamic-module.js", "", "dynamicModule", false, "dynamicModule", "fixtures/testcases.js", 48),
-			newUsageEvidence("react-dom", "react-dom", "flushSync", "flushIt", false, "flushIt", "fixtures/testcases.js", 53),
-			newUsageEvidence("react-dom", "react-dom", "render", "render", false, "render", "fixtures/testcases.js", 54),
-			newUsageEvidence("react-dom", "react-dom", "", "ReactDOM", false, "ReactDOM", "fixtures/testcases.js", 56),
-			newUsageEvidence("constants", "constants", "EADDRINUSE", "EADDRINUSE", false, "EADDRINUSE", "fixtures/testcases.js", 66),
-			newUsageEvidence("chalk", "chalk/ansi-styles", "hex", "hex", false, "hex", "fixtures/testcases.js", 67),
-			newUsageEvidence("@xyz/xyz", "@xyz/xyz", "", "b", false, "b", "fixtures/testcases.js", 67),
-			newUsageEvidence("virtual-dom", "virtual-dom", "patch", "patch", false, "patch", "fixtures/testcases.js", 68),
-			newUsageEvidence("react", "react", "useState", "useMyState", false, "useMyState", "fixtures/testcases.js", 75),
-			newUsageEvidence("react", "react", "useEffect", "useEffect", false, "useEffect", "fixtures/testcases.js", 76),
-			newUsageEvidence("@xyz/pqr", "@xyz/pqr", "foo", "fooAlias", false, "fooAlias", "fixtures/testcases.js", 78),
-			newUsageEvidence("@xyz/pqr", "@xyz/pqr", "bar", "bar", false, "bar", "fixtures/testcases.js", 78),
-			newUsageEvidence("dotenv", "dotenv", "", "DotEnv", false, "DotEnv", "fixtures/testcases.js", 86),
+			newUsageEvidence(moduleNameHint("express"), "express", "", "express", false, "express", "fixtures/testcases.js", 10),
+			newUsageEvidence(moduleNameHint("cluster"), "cluster", "", "Cluster", false, "Cluster", "fixtures/testcases.js", 11),
+			newUsageEvidence(moduleNameHint("@gilbarbara/eslint-config"), "@gilbarbara/eslint-config", "", "EslintConfig", false, "EslintConfig", "fixtures/testcases.js", 14),
+			newUsageEvidence(moduleNameHint("./config.js"), "./config.js", "", "config", false, "config", "fixtures/testcases.js", 20),
+			newUsageEvidence(moduleNameHint("./utils.js"), "./utils.js", "", "utils", false, "utils", "fixtures/testcases.js", 21),
+			newUsageEvidence(moduleNameHint("../utils/helper.js"), "../utils/helper.js", "", "helper", false, "helper", "fixtures/testcases.js", 27),
+			newUsageEvidence(moduleNameHint("../utils/sideeffects.js"), "../utils/sideeffects.js", "", "sideeffects", false, "sideeffects", "fixtures/testcases.js", 28),
+			newUsageEvidence(moduleNameHint("./data1.json"), "./data1.json", "", "jsonData", false, "jsonData", "fixtures/testcases.js", 34),
+			newUsageEvidence(moduleNameHint("./data2.json"), "./data2.json", "", "jsonData2", false, "jsonData2", "fixtures/testcases.js", 35),
+			newUsageEvidence(moduleNameHint("lodash"), "lodash", "", "lodash", false, "lodash", "fixtures/testcases.js", 42),
+			newUsageEvidence(moduleNameHint("./math-utils"), "./math-utils", "", "mathUtils", false, "mathUtils", "fixtures/testcases.js", 43),
+			newUsageEvidence(moduleNameHint("./dynamic-module.js"), "./dynamic-module.js", "", "dynamicModule", false, "dynamicModule", "fixtures/testcases.js", 46),
+			newUsageEvidence(moduleNameHint("./dynamic-module.js"), "./dynamic-module.js", "", "dynamicModule", false, "dynamicModule", "fixtures/testcases.js", 48),
+			newUsageEvidence(moduleNameHint("react-dom"), "react-dom", "flushSync", "flushIt", false, "flushIt", "fixtures/testcases.js", 53),
+			newUsageEvidence(moduleNameHint("react-dom"), "react-dom", "render", "render", false, "render", "fixtures/testcases.js", 54),
+			newUsageEvidence(moduleNameHint("react-dom"), "react-dom", "", "ReactDOM", false, "ReactDOM", "fixtures/testcases.js", 56),
+			newUsageEvidence(moduleNameHint("constants"), "constants", "EADDRINUSE", "EADDRINUSE", false, "EADDRINUSE", "fixtures/testcases.js", 66),
+			newUsageEvidence(moduleNameHint("chalk"), "chalk/ansi-styles", "hex", "hex", false, "hex", "fixtures/testcases.js", 67),
+			newUsageEvidence(moduleNameHint("@xyz/xyz"), "@xyz/xyz", "", "b", false, "b", "fixtures/testcases.js", 67),
+			newUsageEvidence(moduleNameHint("virtual-dom"), "virtual-dom", "patch", "patch", false, "patch", "fixtures/testcases.js", 68),
+			newUsageEvidence(moduleNameHint("react"), "react", "useState", "useMyState", false, "useMyState", "fixtures/testcases.js", 75),
+			newUsageEvidence(moduleNameHint("react"), "react", "useEffect", "useEffect", false, "useEffect", "fixtures/testcases.js", 76),
+			newUsageEvidence(moduleNameHint("@xyz/pqr"), "@xyz/pqr", "foo", "fooAlias", false, "fooAlias", "fixtures/testcases.js", 78),
+			newUsageEvidence(moduleNameHint("@xyz/pqr"), "@xyz/pqr", "bar", "bar", false, "bar", "fixtures/testcases.js", 78),
+			newUsageEvidence(moduleNameHint("dotenv"), "dotenv", "", "DotEnv", false, "DotEnv", "fixtures/testcases.js", 86),
+		},
+	},
+	{
+		Language: core.LanguageCodeTypescript,
+		FilePath: "fixtures/testcases.ts",
+		ExpectedEvicences: []*UsageEvidence{
+			newUsageEvidence(moduleNameHint("express"), "express", "", "express", false, "express", "fixtures/testcases.ts", 11),
+			newUsageEvidence(moduleNameHint("express"), "express", "Request", "Request", false, "Request", "fixtures/testcases.ts", 13),
+			newUsageEvidence(moduleNameHint("express"), "express", "Response", "Response", false, "Response", "fixtures/testcases.ts", 13),
+			newUsageEvidence(moduleNameHint("path"), "path", "", "path", false, "path", "fixtures/testcases.ts", 14),
+			newUsageEvidence(moduleNameHint("./config"), "./config", "Config", "Config", false, "Config", "fixtures/testcases.ts", 17),
+			newUsageEvidence(moduleNameHint("uuid"), "uuid", "v4", "uuid", false, "uuid", "fixtures/testcases.ts", 18),
+			newUsageEvidence(moduleNameHint("lodash"), "lodash", "", "lodash", false, "lodash", "fixtures/testcases.ts", 18),
+			newUsageEvidence(moduleNameHint("chalk"), "chalk/ansi-styles", "hex", "hex", false, "hex", "fixtures/testcases.ts", 21),
 		},
 	},
 	{
 		Language: core.LanguageCodeJava,
 		FilePath: "fixtures/testcases.java",
 		ExpectedEvicences: []*UsageEvidence{
-			newUsageEvidence("java.util", "java.util", "", "", true, "", "fixtures/testcases.java", 12),
-			newUsageEvidence("java.awt", "java.awt.print", "", "", true, "", "fixtures/testcases.java", 13),
-			newUsageEvidence("", "org.springframework.beans.factory.annotation", "", "", true, "", "fixtures/testcases.java", 14),
-			newUsageEvidence("java.lang", "java.lang.Math", "", "", true, "", "fixtures/testcases.java", 15),
-			newUsageEvidence("java.util", "java.util.List", "", "List", false, "List", "fixtures/testcases.java", 19),
-			newUsageEvidence("java.util", "java.util.stream.Collectors", "", "Collectors", false, "Collectors", "fixtures/testcases.java", 20),
-			newUsageEvidence("", "com.sun.activation.registries.MailcapFile", "", "MailcapFile", false, "MailcapFile", "fixtures/testcases.java", 21),
-			newUsageEvidence("", "com.sun.activation.registries.MailcapFile", "", "MailcapFile", false, "MailcapFile", "fixtures/testcases.java", 21),
-			newUsageEvidence("java.lang", "java.lang.Math.PI", "", "PI", false, "PI", "fixtures/testcases.java", 23),
-			newUsageEvidence("", "org.junit.jupiter.api.Assertions.assertEquals", "", "assertEquals", false, "assertEquals", "fixtures/testcases.java", 24),
+			newUsageEvidence(moduleNameHint("java.util"), "java.util", "", "", true, "", "fixtures/testcases.java", 12),
+			newUsageEvidence(moduleNameHint("java.awt"), "java.awt.print", "", "", true, "", "fixtures/testcases.java", 13),
+			newUsageEvidence(moduleNameHint(""), "org.springframework.beans.factory.annotation", "", "", true, "", "fixtures/testcases.java", 14),
+			newUsageEvidence(moduleNameHint("java.lang"), "java.lang.Math", "", "", true, "", "fixtures/testcases.java", 15),
+			newUsageEvidence(moduleNameHint("java.util"), "java.util.List", "", "List", false, "List", "fixtures/testcases.java", 19),
+			newUsageEvidence(moduleNameHint("java.util"), "java.util.stream.Collectors", "", "Collectors", false, "Collectors", "fixtures/testcases.java", 20),
+			newUsageEvidence(moduleNameHint(""), "com.sun.activation.registries.MailcapFile", "", "MailcapFile", false, "MailcapFile", "fixtures/testcases.java", 21),
+			newUsageEvidence(moduleNameHint(""), "com.sun.activation.registries.MailcapFile", "", "MailcapFile", false, "MailcapFile", "fixtures/testcases.java", 21),
+			newUsageEvidence(moduleNameHint("java.lang"), "java.lang.Math.PI", "", "PI", false, "PI", "fixtures/testcases.java", 23),
+			newUsageEvidence(moduleNameHint(""), "org.junit.jupiter.api.Assertions.assertEquals", "", "assertEquals", false, "assertEquals", "fixtures/testcases.java", 24),
+		},
+	},
+	{
+		Language: core.LanguageCodeRust,
+		FilePath: "fixtures/testcases.rs",
+		ExpectedEvicences: []*UsageEvidence{
+			newUsageEvidence(moduleNameHint("std"), "std::fmt", "", "", true, "", "fixtures/testcases.rs", 3),
+			newUsageEvidence(moduleNameHint("std"), "std::collections", "HashMap", "", false, "HashMap", "fixtures/testcases.rs", 13),
+			newUsageEvidence(moduleNameHint("std"), "std::collections", "HashMap", "", false, "HashMap", "fixtures/testcases.rs", 13),
+			newUsageEvidence(moduleNameHint("crate"), "crate::config", "Settings", "", false, "Settings", "fixtures/testcases.rs", 14),
+			newUsageEvidence(moduleNameHint("serde_json"), "serde_json", "", "json", false, "json", "fixtures/testcases.rs", 16),
+			newUsageEvidence(moduleNameHint("reqwest"), "reqwest", "", "", false, "reqwest", "fixtures/testcases.rs", 19),
+			newUsageEvidence(moduleNameHint("log"), "log", "info", "", false, "info", "fixtures/testcases.rs", 20),
+			newUsageEvidence(moduleNameHint("tokio"), "tokio::sync", "Mutex", "AsyncMutex", false, "AsyncMutex", "fixtures/testcases.rs", 22),
+			newUsageEvidence(moduleNameHint("rand"), "rand", "", "", false, "rand", "fixtures/testcases.rs", 23),
+		},
+	},
+	{
+		Language: core.LanguageCodeRuby,
+		FilePath: "fixtures/testcases.rb",
+		ExpectedEvicences: []*UsageEvidence{
+			newUsageEvidence(moduleNameHint("json"), "json", "", "", true, "", "fixtures/testcases.rb", 1),
+			newUsageEvidence(moduleNameHint("sidekiq"), "sidekiq/web", "", "", true, "", "fixtures/testcases.rb", 2),
+			newUsageEvidence(moduleNameHint("activesupport"), "active_support/core_ext/string", "", "", true, "", "fixtures/testcases.rb", 3),
+			newUsageEvidence(moduleNameHint("./lib/formatter"), "./lib/formatter", "", "", true, "", "fixtures/testcases.rb", 4),
+			newUsageEvidence(moduleNameHint("nokogiri"), "nokogiri", "", "", true, "", "fixtures/testcases.rb", 14),
+		},
+	},
+	{
+		Language: core.LanguageCodePhp,
+		FilePath: "fixtures/testcases.php",
+		ExpectedEvicences: []*UsageEvidence{
+			newUsageEvidence(moduleNameHint("vendor/autoload.php"), "vendor/autoload.php", "", "", true, "", "fixtures/testcases.php", 10),
+			newUsageEvidence(moduleNameHint("symfony/console"), "Symfony\\Component\\Console\\Command", "Command", "", false, "Command", "fixtures/testcases.php", 13),
+			newUsageEvidence(moduleNameHint("psr/log"), "Psr\\Log", "LoggerInterface", "Logger", false, "Logger", "fixtures/testcases.php", 15),
+			newUsageEvidence(moduleNameHint("guzzlehttp/guzzle"), "GuzzleHttp", "Client", "", false, "Client", "fixtures/testcases.php", 19),
+			newUsageEvidence(moduleNameHint("laravel/framework"), "Illuminate\\Support", "collect", "", false, "collect", "fixtures/testcases.php", 20),
+		},
+	},
+	{
+		Language: core.LanguageCodeCsharp,
+		FilePath: "fixtures/testcases.cs",
+		ExpectedEvicences: []*UsageEvidence{
+			newUsageEvidence(moduleNameHint("System"), "System", "", "", true, "", "fixtures/testcases.cs", 1),
+			newUsageEvidence(moduleNameHint("Newtonsoft.Json"), "Newtonsoft.Json", "", "", true, "", "fixtures/testcases.cs", 2),
+			newUsageEvidence(moduleNameHint("Serilog"), "Serilog.Log", "", "", true, "", "fixtures/testcases.cs", 3),
+			newUsageEvidence(moduleNameHint("AWSSDK.S3"), "Amazon.S3", "AmazonS3Client", "S3", false, "S3", "fixtures/testcases.cs", 14),
+			newUsageEvidence(moduleNameHint("System.Text"), "System.Text.Json", "JsonSerializer", "Json", false, "Json", "fixtures/testcases.cs", 16),
+		},
+	},
+	{
+		Language: core.LanguageCodeKotlin,
+		FilePath: "fixtures/testcases.kt",
+		ExpectedEvicences: []*UsageEvidence{
+			newUsageEvidence(moduleNameHint(""), "kotlinx.coroutines", "", "", true, "", "fixtures/testcases.kt", 7),
+			newUsageEvidence(moduleNameHint(""), "okhttp3.OkHttpClient", "", "OkHttpClient", false, "OkHttpClient", "fixtures/testcases.kt", 11),
+			newUsageEvidence(moduleNameHint(""), "org.slf4j.LoggerFactory.getLogger", "", "getLogger", false, "getLogger", "fixtures/testcases.kt", 12),
+			newUsageEvidence(moduleNameHint(""), "okhttp3.Request", "", "HttpRequest", false, "HttpRequest", "fixtures/testcases.kt", 15),
+			newUsageEvidence(moduleNameHint("java.util"), "java.util.concurrent.TimeUnit", "", "TimeUnit", false, "TimeUnit", "fixtures/testcases.kt", 16),
+		},
+	},
+	{
+		Language: core.LanguageCodePython,
+		FilePath: "fixtures/testcases.ipynb",
+		ExpectedEvicences: []*UsageEvidence{
+			inNotebook(newUsageEvidence(moduleNameHint("helpers"), "helpers", "", "", true, "", "fixtures/testcases.ipynb", 2), 0, 2),
+			inNotebook(newUsageEvidence(moduleNameHint("requests"), "requests", "", "requests", false, "requests", "fixtures/testcases.ipynb", 6), 2, 2),
+		},
+	},
+	{
+		Language: core.LanguageCodeJavascript,
+		FilePath: "fixtures/testcases.html",
+		ExpectedEvicences: []*UsageEvidence{
+			newUsageEvidence(moduleNameHint("dayjs"), "dayjs", "", "dayjs", false, "dayjs", "fixtures/testcases.html", 12),
+			newUsageEvidence(moduleNameHint("canvas-confetti"), "canvas-confetti", "", "confetti", false, "confetti", "fixtures/testcases.html", 13),
+		},
+	},
+	{
+		Language: core.LanguageCodePython,
+		FilePath: "fixtures/testcases.md",
+		ExpectedEvicences: []*UsageEvidence{
+			newUsageEvidence(moduleNameHint("requests"), "requests", "", "requests", false, "requests", "fixtures/testcases.md", 6),
+		},
+	},
+	{
+		Language: core.LanguageCodeC,
+		FilePath: "fixtures/testcases.c",
+		ExpectedEvicences: []*UsageEvidence{
+			newUsageEvidence(moduleNameHint("stdio"), "stdio.h", "", "", true, "", "fixtures/testcases.c", 1),
+			newUsageEvidence(moduleNameHint("openssl"), "openssl/evp.h", "", "", true, "", "fixtures/testcases.c", 2),
+			newUsageEvidence(moduleNameHint("curl"), "curl/curl.h", "", "", true, "", "fixtures/testcases.c", 3),
+			newUsageEvidence(moduleNameHint("./util.h"), "./util.h", "", "", true, "", "fixtures/testcases.c", 4),
 		},
 	},
 }
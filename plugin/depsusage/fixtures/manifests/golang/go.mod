@@ -0,0 +1,10 @@
+module github.com/safedep/app
+
+go 1.22
+
+require github.com/labstack/echo-contrib v0.17.1
+
+require (
+	github.com/robfig/cron/v3 v3.0.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1
+)
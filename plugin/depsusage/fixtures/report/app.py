@@ -0,0 +1,10 @@
+import os
+import requests
+import numpy as np
+from flask import *
+from . import utils
+
+response = requests.get("https://example.com")
+print(os.getcwd(), response.json())
+np.array([1, 2, 3])
+np.zeros(3)
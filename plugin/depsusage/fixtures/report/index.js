@@ -0,0 +1,12 @@
+const express = require('express');
+const fs = require('node:fs');
+const path = require('path');
+const lodash = require('lodash');
+const utils = require('./utils');
+
+const app = express();
+lodash.map([1, 2], (x) => x * 2);
+fs.readFileSync(path.join(__dirname, utils.name));
+
+const plugin = require(`./plugins/${process.env.PLUGIN}`);
+plugin.start(app);
@@ -0,0 +1,20 @@
+#include <stdio.h>
+#include <openssl/evp.h>
+#include <curl/curl.h>
+#include "util.h"
+
+#include PLATFORM_HEADER
+
+int main(void)
+{
+    CURL *curl = curl_easy_init();
+    const EVP_MD *md = EVP_sha256();
+
+    if (curl == NULL || md == NULL) {
+        return 1;
+    }
+
+    printf("%s\n", util_version());
+    curl_easy_cleanup(curl);
+    return 0;
+}
@@ -0,0 +1,19 @@
+using System;
+using Newtonsoft.Json;
+using static Serilog.Log;
+using Json = System.Text.Json.JsonSerializer;
+using S3 = Amazon.S3.AmazonS3Client;
+
+namespace Acme.Orders.Services
+{
+    // S3 in a comment
+    public class Exporter
+    {
+        public string Export(object order)
+        {
+            var client = new S3();
+            Information("exporting");
+            return JsonConvert.SerializeObject(order) + Json.Serialize(order);
+        }
+    }
+}
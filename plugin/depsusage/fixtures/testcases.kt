@@ -0,0 +1,19 @@
+package plugin.depsusage.fixtures
+
+import okhttp3.OkHttpClient
+import okhttp3.Request as HttpRequest
+import java.util.concurrent.TimeUnit
+import com.google.gson.Gson // unused
+import kotlinx.coroutines.*
+import org.slf4j.LoggerFactory.getLogger
+
+class Fetcher {
+    private val client = OkHttpClient()
+    private val logger = getLogger("fetcher")
+
+    fun fetch(url: String) = runBlocking {
+        val request = HttpRequest.Builder().url(url).build()
+        logger.info("timeout {}", TimeUnit.SECONDS)
+        client.newCall(request).execute()
+    }
+}
@@ -0,0 +1,22 @@
+<?php
+
+namespace App\Service;
+
+use GuzzleHttp\Client;
+use Psr\Log\LoggerInterface as Logger;
+use Symfony\Component\Console\{Application, Command\Command};
+use function Illuminate\Support\collect;
+
+require_once 'vendor/autoload.php';
+
+// new Client() in a comment
+class Fetcher extends Command
+{
+    public function __construct(private Logger $logger) {}
+
+    public function fetch(string $url): array
+    {
+        $client = new Client(['timeout' => 2]);
+        return collect($client->get($url))->all();
+    }
+}
@@ -0,0 +1,14 @@
+require 'json'
+require "sidekiq/web"
+require 'active_support/core_ext/string'
+require_relative 'lib/formatter'
+
+# require 'commented'
+class Worker
+  def perform(payload)
+    data = JSON.parse(payload)
+    Formatter.format(data)
+  end
+end
+
+require 'nokogiri' if defined?(Rails)
@@ -0,0 +1,24 @@
+use serde_json as json;
+use std::collections::HashMap;
+use std::fmt::*;
+use tokio::sync::{Mutex as AsyncMutex, RwLock};
+use crate::config::Settings;
+use reqwest;
+use log::{info, warn};
+
+extern crate rand;
+
+// HashMap and reqwest are not used in comments
+fn main() {
+    let mut map: HashMap<String, String> = HashMap::new();
+    let settings = Settings::load();
+
+    let value = json::to_string(&settings).unwrap();
+    map.insert("settings".to_string(), value);
+
+    let body = reqwest::blocking::get("https://example.com");
+    info!("fetched {:?}", body);
+
+    let lock = AsyncMutex::new(map);
+    let number: u32 = rand::random();
+}
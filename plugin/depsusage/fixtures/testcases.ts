@@ -0,0 +1,21 @@
+import express, { Request, Response } from 'express';
+import * as path from 'path';
+import type { Config } from './config';
+import { v4 as uuid } from 'uuid';
+import lodash = require('lodash');
+import '@acme/polyfills';
+
+const chalk = require('chalk');
+const { hex } = require('chalk/ansi-styles');
+
+const app = express();
+
+app.get('/', (req: Request, res: Response) => {
+  res.send(path.join('a', 'b'));
+});
+
+function load(config: Config): string {
+  return uuid() + lodash.trim(config.name);
+}
+
+console.log(hex('#fff'));
@@ -0,0 +1,15 @@
+import importlib
+
+# 1. Importing a module at runtime
+yml = importlib.import_module("yaml")
+print(yml.safe_load("a: 1"))
+
+# 2. Importing a submodule with a name computed at runtime
+driver = importlib.import_module("sqlalchemy.dialects." + dialect)
+engine = driver.dialect()
+
+# 3. Importing a module for its side effects
+__import__("colorama")
+
+# 4. Module names computed entirely at runtime can not be resolved
+plugin = importlib.import_module(plugin_name)
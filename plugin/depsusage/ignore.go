@@ -23,32 +23,135 @@ var ignoreRules = map[core.LanguageCode]languageIgnoreRules{
 	},
 	core.LanguageCodeJavascript: {
 		rule: []func(node *sitter.Node, data *[]byte) bool{
-			func(node *sitter.Node, data *[]byte) bool {
-				// requires aren't identified as import by tree sitter, instead they follow
-				// the pattern - variable_declarator -> call_expression -> (identifier = "require")
-				if node.Type() != "variable_declarator" {
-					return false
-				}
-
-				for i := range int(node.ChildCount()) {
-					if node.Child(i).Type() != "call_expression" {
-						continue
-					}
-
-					callExpression := node.Child(i)
-					for j := range int(callExpression.ChildCount()) {
-						identifier := callExpression.Child(j)
-						if identifier.Type() == "identifier" && identifier.Content(*data) == "require" {
-							return true
-						}
-					}
-					break
-				}
-
-				return false
-			},
+			isRequireDeclarator,
 		},
 	},
+	core.LanguageCodeTypescript: {
+		rule: []func(node *sitter.Node, data *[]byte) bool{
+			isRequireDeclarator,
+		},
+	},
+	core.LanguageCodeRust: {
+		rule: []func(node *sitter.Node, data *[]byte) bool{
+			isRustImportOrComment,
+		},
+	},
+	core.LanguageCodeRuby: {
+		rule: []func(node *sitter.Node, data *[]byte) bool{},
+	},
+	core.LanguageCodePhp: {
+		rule: []func(node *sitter.Node, data *[]byte) bool{
+			isPhpImportOrNamespace,
+		},
+	},
+	core.LanguageCodeCsharp: {
+		rule: []func(node *sitter.Node, data *[]byte) bool{
+			isCsharpImportOrNamespace,
+		},
+	},
+	core.LanguageCodeKotlin: {
+		rule: []func(node *sitter.Node, data *[]byte) bool{
+			isKotlinImportOrPackage,
+		},
+	},
+	core.LanguageCodeC: {
+		rule: []func(node *sitter.Node, data *[]byte) bool{
+			isCInclude,
+		},
+	},
+	core.LanguageCodeCpp: {
+		rule: []func(node *sitter.Node, data *[]byte) bool{
+			isCInclude,
+		},
+	},
+}
+
+// Rust imports are use declarations and extern crates, comments
+// are split in line and block comments
+var rustIgnoredTypes = map[string]bool{
+	"use_declaration":          true,
+	"extern_crate_declaration": true,
+	"line_comment":             true,
+	"block_comment":            true,
+}
+
+func isRustImportOrComment(node *sitter.Node, _ *[]byte) bool {
+	return rustIgnoredTypes[node.Type()]
+}
+
+// PHP imports are use declarations and file inclusions
+var phpIgnoredTypes = map[string]bool{
+	"namespace_use_declaration": true,
+	"require_expression":        true,
+	"require_once_expression":   true,
+	"include_expression":        true,
+	"include_once_expression":   true,
+}
+
+// Names of the namespace declared by a file are not usages of an import,
+// the body of a namespace declared with braces is not ignored
+func isPhpImportOrNamespace(node *sitter.Node, _ *[]byte) bool {
+	if phpIgnoredTypes[node.Type()] {
+		return true
+	}
+
+	parent := node.Parent()
+	return node.Type() == "namespace_name" && parent != nil && parent.Type() == "namespace_definition"
+}
+
+// Qualified names of the namespace declared by a file are not usages of a
+// using directive, the body of a namespace declaration is not ignored
+func isCsharpImportOrNamespace(node *sitter.Node, _ *[]byte) bool {
+	if node.Type() == "using_directive" {
+		return true
+	}
+
+	parent := node.Parent()
+	return parent != nil && (parent.Type() == "namespace_declaration" || parent.Type() == "file_scoped_namespace_declaration") &&
+		node.Equal(parent.ChildByFieldName("name"))
+}
+
+// Kotlin imports are import headers, the package header names the
+// package declared by the file
+var kotlinIgnoredTypes = map[string]bool{
+	"import_list":    true,
+	"import_header":  true,
+	"package_header": true,
+}
+
+func isKotlinImportOrPackage(node *sitter.Node, _ *[]byte) bool {
+	return kotlinIgnoredTypes[node.Type()]
+}
+
+// Includes are resolved as wildcard imports, the macro of a computed
+// include eg. `#include PLATFORM_HEADER` is not a usage
+func isCInclude(node *sitter.Node, _ *[]byte) bool {
+	return node.Type() == "preproc_include"
+}
+
+// requires aren't identified as import by tree sitter, instead they follow
+// the pattern - variable_declarator -> call_expression -> (identifier = "require")
+func isRequireDeclarator(node *sitter.Node, data *[]byte) bool {
+	if node.Type() != "variable_declarator" {
+		return false
+	}
+
+	for i := range int(node.ChildCount()) {
+		if node.Child(i).Type() != "call_expression" {
+			continue
+		}
+
+		callExpression := node.Child(i)
+		for j := range int(callExpression.ChildCount()) {
+			identifier := callExpression.Child(j)
+			if identifier.Type() == "identifier" && identifier.Content(*data) == "require" {
+				return true
+			}
+		}
+		break
+	}
+
+	return false
 }
 
 func init() {
@@ -0,0 +1,677 @@
+package depsusage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/dry/log"
+)
+
+// Ecosystems of packages declared in manifests
+const (
+	EcosystemPyPI      = "pypi"
+	EcosystemNpm       = "npm"
+	EcosystemGo        = "go"
+	EcosystemMaven     = "maven"
+	EcosystemCargo     = "cargo"
+	EcosystemGem       = "rubygems"
+	EcosystemPackagist = "packagist"
+	EcosystemNuGet     = "nuget"
+)
+
+// DeclaredPackage is a package declared in a manifest of the project
+type DeclaredPackage struct {
+	Name      string
+	Version   string
+	Ecosystem string
+
+	// Manifest declaring the package
+	Source string
+}
+
+// manifestPackage is a package declared in a manifest, locked in a
+// lockfile or installed in the file system
+type manifestPackage struct {
+	name    string
+	version string
+	source  string
+
+	// Version is the exact version from a lockfile or installed
+	// metadata instead of a constraint declared in a manifest
+	exactVersion bool
+
+	// Package is installed in the file system
+	installed bool
+}
+
+// javaArtifact is a maven artifact along with the java packages it provides.
+// Packages are only known for artifacts installed in the file system.
+type javaArtifact struct {
+	manifestPackage
+
+	groupID    string
+	artifactID string
+	packages   []string
+}
+
+// Well known python modules which are provided by a distribution of
+// a different name. Used only to match dependencies declared in manifests.
+var pythonModuleDistributions = map[string]string{
+	"yaml":      "pyyaml",
+	"cv2":       "opencv-python",
+	"pil":       "pillow",
+	"sklearn":   "scikit-learn",
+	"skimage":   "scikit-image",
+	"bs4":       "beautifulsoup4",
+	"dateutil":  "python-dateutil",
+	"dotenv":    "python-dotenv",
+	"jwt":       "pyjwt",
+	"usb":       "pyusb",
+	"serial":    "pyserial",
+	"crypto":    "pycryptodome",
+	"attr":      "attrs",
+	"magic":     "python-magic",
+	"docx":      "python-docx",
+	"gi":        "pygobject",
+	"openssl":   "pyopenssl",
+	"git":       "gitpython",
+	"jose":      "python-jose",
+	"multipart": "python-multipart",
+}
+
+var pythonPackageNameSeparatorRegexp = regexp.MustCompile(`[-_.]+`)
+
+// normalizePythonPackageName normalizes distribution names as per PEP 503
+func normalizePythonPackageName(name string) string {
+	return pythonPackageNameSeparatorRegexp.ReplaceAllString(strings.ToLower(name), "-")
+}
+
+type manifestPackageHintResolver struct {
+	// Top level python modules to installed distributions (top_level.txt)
+	pythonModules map[string]manifestPackage
+
+	// Normalized python distribution names to declared packages
+	pythonPackages map[string]manifestPackage
+
+	javascriptPackages map[string]manifestPackage
+
+	// Go modules required by go.mod files, including the modules themselves
+	goModules []manifestPackage
+
+	javaArtifacts []javaArtifact
+
+	// Normalized crate names to cargo packages
+	rustCrates map[string]manifestPackage
+
+	// Normalized crate names of renamed dependencies to
+	// the normalized names of their packages
+	rustCrateRenames map[string]string
+
+	rubyGems map[string]manifestPackage
+
+	// Paths required in place of the gem name eg. gem 'rspec-rails', require: 'rspec/rails'
+	rubyRequirePaths map[string]string
+
+	// Lower case composer package names to composer packages
+	phpPackages map[string]manifestPackage
+
+	// Autoloaded namespaces (PSR-4 and PSR-0) to the packages providing them,
+	// including the namespaces of the project itself
+	phpNamespaces map[string]manifestPackage
+
+	// Lower case NuGet package ids to NuGet packages
+	nugetPackages map[string]manifestPackage
+
+	// Root namespaces of the .NET projects to the projects
+	csharpProjectNamespaces map[string]manifestPackage
+
+	// Jar metadata keyed by the jar root, merged into java artifacts
+	// once all the files of a jar are indexed
+	jars map[string]*jarMetadata
+
+	// Direct dependencies declared in the manifests. Lockfiles and
+	// installed metadata are not included as they list transitive ones
+	declaredPackages []DeclaredPackage
+
+	// Packages of the project itself eg. module of go.mod
+	projectPackages []DeclaredPackage
+
+	fallback PackageHintResolver
+}
+
+var _ PackageHintResolver = (*manifestPackageHintResolver)(nil)
+
+// manifestParser indexes a manifest file. Parsers are selected by file name.
+type manifestParser func(r *manifestPackageHintResolver, filePath string, content []byte) error
+
+// NewManifestPackageHintResolver creates a package hint resolver backed by the
+// manifests, lockfiles and installed package metadata found in the file system.
+// Modules which can not be resolved using them fall back to the module name.
+//
+// Supported sources are requirements.txt, pyproject.toml, Pipfile, Pipfile.lock,
+// top_level.txt, package.json, package-lock.json, go.mod, pom.xml,
+// build.gradle, jar manifests (META-INF/MANIFEST.MF, pom.properties), Cargo.toml,
+// Cargo.lock, Gemfile, Gemfile.lock, gemspecs, composer.json, composer.lock,
+// installed composer packages (vendor/composer/installed.json), .csproj files,
+// packages.config, Directory.Packages.props and packages.lock.json
+func NewManifestPackageHintResolver(ctx context.Context, fs core.FileSystem) (*manifestPackageHintResolver, error) {
+	resolver := &manifestPackageHintResolver{
+		pythonModules:      make(map[string]manifestPackage),
+		pythonPackages:     make(map[string]manifestPackage),
+		javascriptPackages: make(map[string]manifestPackage),
+		jars:               make(map[string]*jarMetadata),
+		rustCrates:         make(map[string]manifestPackage),
+		rustCrateRenames:   make(map[string]string),
+		rubyGems:           make(map[string]manifestPackage),
+		rubyRequirePaths:   make(map[string]string),
+		phpPackages:        make(map[string]manifestPackage),
+		phpNamespaces:      make(map[string]manifestPackage),
+		nugetPackages:      make(map[string]manifestPackage),
+
+		csharpProjectNamespaces: make(map[string]manifestPackage),
+		fallback:                NewModuleNamePackageHintResolver(),
+	}
+
+	err := fs.Enumerate(ctx, func(file core.File) error {
+		parser, exists := manifestParserForPath(file.Name())
+		if !exists {
+			return nil
+		}
+
+		content, err := readManifest(file)
+		if err != nil {
+			return err
+		}
+
+		// A malformed manifest must not fail the resolution
+		// of packages declared in other manifests
+		if err := parser(resolver, file.Name(), content); err != nil {
+			log.Debugf("failed to parse manifest %s: %v", file.Name(), err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enumerate manifests: %w", err)
+	}
+
+	resolver.indexJars()
+
+	// Longest module path first so that nested modules take precedence
+	sort.SliceStable(resolver.goModules, func(i, j int) bool {
+		return len(resolver.goModules[i].name) > len(resolver.goModules[j].name)
+	})
+
+	return resolver, nil
+}
+
+func readManifest(file core.File) ([]byte, error) {
+	reader, err := file.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open manifest %s: %w", file.Name(), err)
+	}
+
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", file.Name(), err)
+	}
+
+	return content, nil
+}
+
+var requirementsFileRegexp = regexp.MustCompile(`^requirements[\w.-]*\.txt$`)
+
+func manifestParserForPath(filePath string) (manifestParser, bool) {
+	fileName := path.Base(filePath)
+	switch {
+	case requirementsFileRegexp.MatchString(fileName):
+		return parseRequirementsTxt, true
+	case fileName == "pyproject.toml":
+		return parsePyprojectToml, true
+	case fileName == "Pipfile":
+		return parsePipfile, true
+	case fileName == "Pipfile.lock":
+		return parsePipfileLock, true
+	case fileName == "top_level.txt":
+		return parsePythonTopLevel, true
+	case fileName == "package.json":
+		return parsePackageJson, true
+	case fileName == "package-lock.json":
+		return parsePackageLockJson, true
+	case fileName == "go.mod":
+		return parseGoMod, true
+	case fileName == "pom.xml":
+		return parsePomXml, true
+	case fileName == "build.gradle" || fileName == "build.gradle.kts":
+		return parseBuildGradle, true
+	case fileName == "Cargo.toml":
+		return parseCargoToml, true
+	case fileName == "Cargo.lock":
+		return parseCargoLock, true
+	case fileName == "Gemfile" || fileName == "gems.rb":
+		return parseGemfile, true
+	case fileName == "Gemfile.lock" || fileName == "gems.locked":
+		return parseGemfileLock, true
+	case strings.HasSuffix(fileName, ".gemspec"):
+		return parseGemspec, true
+	case fileName == "composer.json":
+		return parseComposerJson, true
+	case fileName == "composer.lock":
+		return parseComposerLock, true
+	case fileName == "installed.json" && strings.HasSuffix(filePath, "vendor/composer/installed.json"):
+		return parseComposerInstalledJson, true
+	case strings.HasSuffix(fileName, ".csproj"):
+		return parseCsproj, true
+	case fileName == "packages.config":
+		return parsePackagesConfig, true
+	case fileName == "Directory.Packages.props":
+		return parseDirectoryPackagesProps, true
+	case fileName == "packages.lock.json":
+		return parseNuGetPackagesLockJson, true
+	case strings.HasSuffix(filePath, "META-INF/MANIFEST.MF"):
+		return parseJarManifest, true
+	case fileName == "pom.properties" && strings.Contains(filePath, "META-INF/maven/"):
+		return parsePomProperties, true
+	}
+
+	return nil, false
+}
+
+// addPackage adds a package to the index. Installed packages and exact
+// versions take precedence over packages declared with a constraint.
+func addPackage(packages map[string]manifestPackage, key string, pkg manifestPackage) {
+	existing, exists := packages[key]
+	if !exists || pkg.installed && !existing.installed ||
+		pkg.exactVersion && !existing.exactVersion && pkg.installed == existing.installed {
+		packages[key] = pkg
+	}
+}
+
+// DeclaredPackages returns the direct dependencies declared in the manifests
+func (r *manifestPackageHintResolver) DeclaredPackages() []DeclaredPackage {
+	return r.declaredPackages
+}
+
+// ProjectPackages returns the packages defined by the manifests themselves
+// eg. the module of go.mod or the name of package.json
+func (r *manifestPackageHintResolver) ProjectPackages() []DeclaredPackage {
+	return r.projectPackages
+}
+
+func (r *manifestPackageHintResolver) declare(ecosystem string, pkg manifestPackage) {
+	r.declaredPackages = append(r.declaredPackages, newDeclaredPackage(ecosystem, pkg))
+}
+
+func (r *manifestPackageHintResolver) declareProject(ecosystem string, pkg manifestPackage) {
+	if pkg.name != "" {
+		r.projectPackages = append(r.projectPackages, newDeclaredPackage(ecosystem, pkg))
+	}
+}
+
+func newDeclaredPackage(ecosystem string, pkg manifestPackage) DeclaredPackage {
+	return DeclaredPackage{
+		Name:      pkg.name,
+		Version:   pkg.version,
+		Ecosystem: ecosystem,
+		Source:    pkg.source,
+	}
+}
+
+func (r *manifestPackageHintResolver) ResolvePackageHint(moduleName string, lang core.Language) (PackageHint, error) {
+	if moduleName == "" {
+		return PackageHint{}, fmt.Errorf("invalid module name: %s", moduleName)
+	}
+
+	resolvers := map[core.LanguageCode]func(string) (PackageHint, bool){
+		core.LanguageCodePython:     r.resolvePythonPackage,
+		core.LanguageCodeGo:         r.resolveGoPackage,
+		core.LanguageCodeJavascript: r.resolveJavascriptPackage,
+		core.LanguageCodeTypescript: r.resolveJavascriptPackage,
+		core.LanguageCodeJava:       r.resolveJavaPackage,
+		core.LanguageCodeRust:       r.resolveRustPackage,
+		core.LanguageCodeRuby:       r.resolveRubyPackage,
+		core.LanguageCodePhp:        r.resolvePhpPackage,
+		core.LanguageCodeCsharp:     r.resolveCsharpPackage,
+		core.LanguageCodeKotlin:     r.resolveJavaPackage,
+	}
+
+	if resolver, ok := resolvers[lang.Meta().Code]; ok {
+		if hint, resolved := resolver(moduleName); resolved {
+			return hint, nil
+		}
+	}
+
+	return r.fallback.ResolvePackageHint(moduleName, lang)
+}
+
+func newManifestPackageHint(pkg manifestPackage, confidence PackageHintConfidence) PackageHint {
+	return PackageHint{
+		Name:       pkg.name,
+		Version:    pkg.version,
+		Confidence: confidence,
+		Source:     pkg.source,
+	}
+}
+
+func (r *manifestPackageHintResolver) resolvePythonPackage(moduleName string) (PackageHint, bool) {
+	// Relative imports are part of the application
+	if strings.HasPrefix(moduleName, ".") {
+		return PackageHint{}, false
+	}
+
+	topLevelModule, _, _ := strings.Cut(moduleName, ".")
+	if pkg, exists := r.pythonModules[topLevelModule]; exists {
+		return newManifestPackageHint(pkg, PackageHintConfidenceHigh), true
+	}
+
+	candidates := []string{normalizePythonPackageName(topLevelModule)}
+	if distribution, exists := pythonModuleDistributions[strings.ToLower(topLevelModule)]; exists {
+		candidates = append(candidates, distribution)
+	}
+
+	for _, candidate := range candidates {
+		if pkg, exists := r.pythonPackages[candidate]; exists {
+			return newManifestPackageHint(pkg, PackageHintConfidenceMedium), true
+		}
+	}
+
+	return PackageHint{}, false
+}
+
+func (r *manifestPackageHintResolver) resolveJavascriptPackage(moduleName string) (PackageHint, bool) {
+	packageName, err := resolveJavascriptPackageHint(moduleName)
+	if err != nil || strings.HasPrefix(packageName, ".") {
+		return PackageHint{}, false
+	}
+
+	// Node.js builtins may be imported with the node: scheme
+	packageName = strings.TrimPrefix(packageName, "node:")
+
+	pkg, exists := r.javascriptPackages[packageName]
+	if !exists {
+		return PackageHint{}, false
+	}
+
+	return newManifestPackageHint(pkg, PackageHintConfidenceHigh), true
+}
+
+func (r *manifestPackageHintResolver) resolveGoPackage(moduleName string) (PackageHint, bool) {
+	moduleName = strings.Trim(moduleName, "/")
+	for _, pkg := range r.goModules {
+		if moduleName == pkg.name || strings.HasPrefix(moduleName, pkg.name+"/") {
+			return newManifestPackageHint(pkg, PackageHintConfidenceHigh), true
+		}
+	}
+
+	return PackageHint{}, false
+}
+
+func (r *manifestPackageHintResolver) resolveJavaPackage(moduleName string) (PackageHint, bool) {
+	// Installed artifacts declare the packages they provide
+	var installed *javaArtifact
+	installedPackageLength := 0
+
+	for i, artifact := range r.javaArtifacts {
+		for _, pkg := range artifact.packages {
+			if hasJavaPackagePrefix(moduleName, pkg) && len(pkg) > installedPackageLength {
+				installed = &r.javaArtifacts[i]
+				installedPackageLength = len(pkg)
+			}
+		}
+	}
+
+	if installed != nil {
+		return newManifestPackageHint(installed.manifestPackage, PackageHintConfidenceHigh), true
+	}
+
+	// Declared artifacts are matched by the packages they likely provide. The
+	// group id shares a prefix with the module eg. com.fasterxml.jackson.core
+	// provides com.fasterxml.jackson.databind, and parts of the artifact id
+	// present in the module are used to pick among artifacts of the group.
+	var declared *javaArtifact
+	declaredScore := 0
+
+	moduleParts := strings.Split(moduleName, ".")
+	for i, artifact := range r.javaArtifacts {
+		groupParts := strings.Split(artifact.groupID, ".")
+
+		commonParts := 0
+		for commonParts < min(len(groupParts), len(moduleParts)) && groupParts[commonParts] == moduleParts[commonParts] {
+			commonParts++
+		}
+
+		matchedArtifactParts := 0
+		for _, part := range strings.FieldsFunc(artifact.artifactID, isArtifactIDSeparator) {
+			if slices.ContainsFunc(moduleParts[commonParts:], func(modulePart string) bool {
+				return strings.EqualFold(part, modulePart)
+			}) {
+				matchedArtifactParts++
+			}
+		}
+
+		// Unless the module is within the group, a common domain
+		// eg. com.google is not enough to match an artifact
+		withinGroup := commonParts == len(groupParts)
+		if !withinGroup && (commonParts < 2 || commonParts < 3 && matchedArtifactParts == 0) {
+			continue
+		}
+
+		score := 2*commonParts + matchedArtifactParts
+		if score > declaredScore {
+			declared = &r.javaArtifacts[i]
+			declaredScore = score
+		}
+	}
+
+	if declared != nil {
+		return newManifestPackageHint(declared.manifestPackage, PackageHintConfidenceMedium), true
+	}
+
+	return PackageHint{}, false
+}
+
+// normalizeRustCrateName normalizes a cargo package name to the crate name
+// used in paths eg. serde-json is imported as serde_json
+func normalizeRustCrateName(name string) string {
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+func (r *manifestPackageHintResolver) resolveRustPackage(moduleName string) (PackageHint, bool) {
+	crate, err := resolveRustPackageHint(moduleName)
+	if err != nil {
+		return PackageHint{}, false
+	}
+
+	crate = normalizeRustCrateName(crate)
+	if renamed, exists := r.rustCrateRenames[crate]; exists {
+		crate = renamed
+	}
+
+	pkg, exists := r.rustCrates[crate]
+	if !exists {
+		return PackageHint{}, false
+	}
+
+	return newManifestPackageHint(pkg, PackageHintConfidenceHigh), true
+}
+
+// resolveRubyPackage matches a required path with the declared gems. Gems
+// are required by their name with dashes as path separators by convention
+// eg. net/http/persistent is provided by net-http-persistent, in which case
+// the longest matching prefix of the path is used
+func (r *manifestPackageHintResolver) resolveRubyPackage(moduleName string) (PackageHint, bool) {
+	requirePath := strings.TrimSuffix(moduleName, ".rb")
+	if strings.HasPrefix(requirePath, ".") || strings.HasPrefix(requirePath, "/") {
+		return PackageHint{}, false
+	}
+
+	if gem, exists := r.rubyRequirePaths[requirePath]; exists {
+		if pkg, exists := r.rubyGems[gem]; exists {
+			return newManifestPackageHint(pkg, PackageHintConfidenceHigh), true
+		}
+	}
+
+	segments := strings.Split(requirePath, "/")
+	candidates := []string{}
+	for i := len(segments); i > 0; i-- {
+		candidates = append(candidates, strings.Join(segments[:i], "-"))
+	}
+
+	if gem, exists := rubyRequireGems[segments[0]]; exists {
+		candidates = append(candidates, gem)
+	}
+
+	for _, candidate := range candidates {
+		if pkg, exists := r.rubyGems[candidate]; exists {
+			return newManifestPackageHint(pkg, PackageHintConfidenceMedium), true
+		}
+	}
+
+	return PackageHint{}, false
+}
+
+// normalizeComposerPackageName normalizes a composer package name,
+// which are case insensitive eg. Monolog/Monolog is monolog/monolog
+func normalizeComposerPackageName(name string) string {
+	return strings.ToLower(name)
+}
+
+// resolvePhpPackage matches a namespace with the namespaces autoloaded by the
+// composer packages. The longest autoloaded namespace containing the imported
+// namespace wins eg. Symfony\Component\Console\Command is provided by the
+// package autoloading Symfony\Component\Console. Otherwise the package guessed
+// from the namespace is matched with the declared packages
+func (r *manifestPackageHintResolver) resolvePhpPackage(moduleName string) (PackageHint, bool) {
+	namespace := strings.Trim(moduleName, "\\")
+	if namespace == "" || strings.ContainsAny(namespace, "/.") {
+		return PackageHint{}, false
+	}
+
+	providerNamespace := ""
+	for prefix := range r.phpNamespaces {
+		if (namespace == prefix || strings.HasPrefix(namespace, prefix+"\\")) && len(prefix) > len(providerNamespace) {
+			providerNamespace = prefix
+		}
+	}
+
+	if provider, exists := r.phpNamespaces[providerNamespace]; exists {
+		if pkg, exists := r.phpPackages[normalizeComposerPackageName(provider.name)]; exists {
+			return newManifestPackageHint(pkg, PackageHintConfidenceHigh), true
+		}
+
+		return newManifestPackageHint(provider, PackageHintConfidenceHigh), true
+	}
+
+	packageName, err := resolvePhpPackageHint(namespace)
+	if err != nil {
+		return PackageHint{}, false
+	}
+
+	pkg, exists := r.phpPackages[normalizeComposerPackageName(packageName)]
+	if !exists {
+		return PackageHint{}, false
+	}
+
+	return newManifestPackageHint(pkg, PackageHintConfidenceMedium), true
+}
+
+// normalizeNuGetPackageName normalizes a NuGet package id,
+// which are case insensitive eg. Newtonsoft.Json is newtonsoft.json
+func normalizeNuGetPackageName(name string) string {
+	return strings.ToLower(name)
+}
+
+// resolveCsharpPackage matches a namespace with the root namespaces of the
+// projects and the ids of the NuGet packages. Packages are named after the
+// namespace they provide by convention, hence the longest package id which is
+// a prefix of the namespace wins eg. Newtonsoft.Json.Linq is provided by
+// Newtonsoft.Json. Otherwise the package guessed from the namespace is matched
+// with the declared packages eg. Amazon.S3 is provided by AWSSDK.S3
+func (r *manifestPackageHintResolver) resolveCsharpPackage(moduleName string) (PackageHint, bool) {
+	namespace := strings.TrimPrefix(strings.TrimSpace(moduleName), "global::")
+	if namespace == "" {
+		return PackageHint{}, false
+	}
+
+	if project, exists := longestNamespacePrefixMatch(r.csharpProjectNamespaces, namespace, false); exists {
+		return newManifestPackageHint(project, PackageHintConfidenceHigh), true
+	}
+
+	if pkg, exists := longestNamespacePrefixMatch(r.nugetPackages, namespace, true); exists {
+		return newManifestPackageHint(pkg, PackageHintConfidenceHigh), true
+	}
+
+	packageName, err := resolveCsharpPackageHint(namespace)
+	if err != nil {
+		return PackageHint{}, false
+	}
+
+	pkg, exists := r.nugetPackages[normalizeNuGetPackageName(packageName)]
+	if !exists {
+		return PackageHint{}, false
+	}
+
+	return newManifestPackageHint(pkg, PackageHintConfidenceMedium), true
+}
+
+// longestNamespacePrefixMatch returns the package keyed by the longest
+// dot separated prefix of the namespace
+func longestNamespacePrefixMatch(packages map[string]manifestPackage, namespace string, lowerCaseKeys bool) (manifestPackage, bool) {
+	if lowerCaseKeys {
+		namespace = strings.ToLower(namespace)
+	}
+
+	for prefix := namespace; prefix != ""; {
+		if pkg, exists := packages[prefix]; exists {
+			return pkg, true
+		}
+
+		index := strings.LastIndex(prefix, ".")
+		if index < 0 {
+			break
+		}
+
+		prefix = prefix[:index]
+	}
+
+	return manifestPackage{}, false
+}
+
+func hasJavaPackagePrefix(moduleName string, pkg string) bool {
+	return moduleName == pkg || strings.HasPrefix(moduleName, pkg+".")
+}
+
+func isArtifactIDSeparator(r rune) bool {
+	return r == '-' || r == '_' || r == '.'
+}
+
+// addJavaArtifact adds an artifact to the index, merging it with an
+// artifact of the same coordinates if already present
+func (r *manifestPackageHintResolver) addJavaArtifact(artifact javaArtifact) {
+	for i, existing := range r.javaArtifacts {
+		if existing.name != artifact.name {
+			continue
+		}
+
+		if artifact.installed && !existing.installed ||
+			artifact.exactVersion && !existing.exactVersion && artifact.installed == existing.installed {
+			artifact.packages = append(artifact.packages, existing.packages...)
+			r.javaArtifacts[i] = artifact
+		} else {
+			r.javaArtifacts[i].packages = append(r.javaArtifacts[i].packages, artifact.packages...)
+		}
+
+		return
+	}
+
+	r.javaArtifacts = append(r.javaArtifacts, artifact)
+}
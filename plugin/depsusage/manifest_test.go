@@ -0,0 +1,221 @@
+package depsusage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/fs"
+	"github.com/safedep/code/lang"
+	"github.com/safedep/code/pkg/test"
+	"github.com/safedep/code/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newManifestFixtureResolver(t *testing.T) *manifestPackageHintResolver {
+	t.Helper()
+
+	fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+		AppDirectories: []string{"fixtures/manifests"},
+	})
+	assert.NoError(t, err)
+
+	resolver, err := NewManifestPackageHintResolver(context.Background(), fileSystem)
+	assert.NoError(t, err)
+
+	return resolver
+}
+
+func TestManifestPackageHintResolver(t *testing.T) {
+	resolver := newManifestFixtureResolver(t)
+
+	languageWiseTests := map[core.LanguageCode][]struct {
+		moduleName string
+		expected   PackageHint
+	}{
+		core.LanguageCodePython: {
+			{"yaml", PackageHint{"PyYAML", "6.0.1", PackageHintConfidenceHigh, "fixtures/manifests/python/site-packages/PyYAML-6.0.1.dist-info/top_level.txt"}},
+			{"requests.adapters", PackageHint{"requests", "2.31.0", PackageHintConfidenceMedium, "fixtures/manifests/python/requirements.txt"}},
+			{"flask", PackageHint{"flask", ">=2.0,<3.0", PackageHintConfidenceMedium, "fixtures/manifests/python/requirements.txt"}},
+			{"sklearn.metrics", PackageHint{"scikit-learn", "1.3.0", PackageHintConfidenceMedium, "fixtures/manifests/python/requirements.txt"}},
+			{"simplejson", PackageHint{"simplejson", "", PackageHintConfidenceMedium, "fixtures/manifests/python/requirements.txt"}},
+			{"pytest", PackageHint{"pytest", "7.4.0", PackageHintConfidenceMedium, "fixtures/manifests/python/Pipfile.lock"}},
+			{"pandas", PackageHint{"pandas", "2.1.0", PackageHintConfidenceMedium, "fixtures/manifests/python/Pipfile.lock"}},
+			{"bs4", PackageHint{"beautifulsoup4", "4.12.2", PackageHintConfidenceMedium, "fixtures/manifests/python/pyproject.toml"}},
+			{"matplotlib.pyplot", PackageHint{"matplotlib", ">=3.7", PackageHintConfidenceMedium, "fixtures/manifests/python/pyproject.toml"}},
+			{"slumber", PackageHint{"slumber", "^0.7.1", PackageHintConfidenceMedium, "fixtures/manifests/python/pyproject.toml"}},
+			{"seaborn", PackageHint{"seaborn", "0.12.2", PackageHintConfidenceMedium, "fixtures/manifests/python/pyproject.toml"}},
+			{"os.path", PackageHint{"os", "", PackageHintConfidenceLow, ""}},
+		},
+		core.LanguageCodeJavascript: {
+			{"express", PackageHint{"express", "4.18.2", PackageHintConfidenceHigh, "fixtures/manifests/javascript/package-lock.json"}},
+			{"react-dom/server", PackageHint{"react-dom", "^18.2.0", PackageHintConfidenceHigh, "fixtures/manifests/javascript/package.json"}},
+			{"@gilbarbara/eslint-config", PackageHint{"@gilbarbara/eslint-config", "0.7.1", PackageHintConfidenceHigh, "fixtures/manifests/javascript/package-lock.json"}},
+			{"lodash/fp", PackageHint{"lodash", "4.17.21", PackageHintConfidenceHigh, "fixtures/manifests/javascript/node_modules/lodash/package.json"}},
+			{"debug", PackageHint{"debug", "", PackageHintConfidenceLow, ""}},
+			{"./utils", PackageHint{"./utils", "", PackageHintConfidenceLow, ""}},
+		},
+		core.LanguageCodeTypescript: {
+			{"express", PackageHint{"express", "4.18.2", PackageHintConfidenceHigh, "fixtures/manifests/javascript/package-lock.json"}},
+		},
+		core.LanguageCodeGo: {
+			{"github.com/labstack/echo-contrib/pprof", PackageHint{"github.com/labstack/echo-contrib", "v0.17.1", PackageHintConfidenceHigh, "fixtures/manifests/golang/go.mod"}},
+			{"github.com/robfig/cron/v3", PackageHint{"github.com/robfig/cron/v3", "v3.0.1", PackageHintConfidenceHigh, "fixtures/manifests/golang/go.mod"}},
+			{"gopkg.in/yaml.v3", PackageHint{"gopkg.in/yaml.v3", "v3.0.1", PackageHintConfidenceHigh, "fixtures/manifests/golang/go.mod"}},
+			{"github.com/safedep/app/internal/server", PackageHint{"github.com/safedep/app", "", PackageHintConfidenceHigh, "fixtures/manifests/golang/go.mod"}},
+			{"net/http", PackageHint{"net", "", PackageHintConfidenceLow, ""}},
+		},
+		core.LanguageCodeJava: {
+			{"com.fasterxml.jackson.databind.ObjectMapper", PackageHint{"com.fasterxml.jackson.core:jackson-databind", "2.15.2", PackageHintConfidenceMedium, "fixtures/manifests/java/pom.xml"}},
+			{"com.fasterxml.jackson.core.JsonParser", PackageHint{"com.fasterxml.jackson.core:jackson-core", "2.15.2", PackageHintConfidenceMedium, "fixtures/manifests/java/pom.xml"}},
+			{"org.springframework.ai.chat.client.ChatClient", PackageHint{"org.springframework.ai:spring-ai-core", "1.0.0-M1", PackageHintConfidenceMedium, "fixtures/manifests/java/build.gradle"}},
+			{"junit.framework.TestCase", PackageHint{"junit:junit", "4.13.2", PackageHintConfidenceMedium, "fixtures/manifests/java/build.gradle"}},
+			{"org.apache.commons.lang3.builder.ToStringBuilder", PackageHint{"org.apache.commons:commons-lang3", "3.13.0", PackageHintConfidenceHigh, "fixtures/manifests/java/lib/commons-lang3/META-INF/maven/org.apache.commons/commons-lang3/pom.properties"}},
+			{"java.util.concurrent", PackageHint{"java.util", "", PackageHintConfidenceLow, ""}},
+		},
+		core.LanguageCodeRust: {
+			{"serde_json", PackageHint{"serde-json", "1.0.108", PackageHintConfidenceHigh, "fixtures/manifests/rust/Cargo.toml"}},
+			{"serde::de", PackageHint{"serde", "1.0.193", PackageHintConfidenceHigh, "fixtures/manifests/rust/Cargo.lock"}},
+			{"::reqwest", PackageHint{"reqwest", "0.11.23", PackageHintConfidenceHigh, "fixtures/manifests/rust/Cargo.lock"}},
+			{"tokio::sync", PackageHint{"tokio", "1.35", PackageHintConfidenceHigh, "fixtures/manifests/rust/Cargo.toml"}},
+			{"json5", PackageHint{"json-five", "0.3", PackageHintConfidenceHigh, "fixtures/manifests/rust/Cargo.toml"}},
+			{"nix::unistd", PackageHint{"nix", "0.27", PackageHintConfidenceHigh, "fixtures/manifests/rust/Cargo.toml"}},
+			{"std::collections", PackageHint{"std", "", PackageHintConfidenceLow, ""}},
+		},
+		core.LanguageCodeRuby: {
+			{"sidekiq/web", PackageHint{"sidekiq", "7.2.0", PackageHintConfidenceMedium, "fixtures/manifests/ruby/Gemfile.lock"}},
+			{"rspec/rails", PackageHint{"rspec-rails", "", PackageHintConfidenceHigh, "fixtures/manifests/ruby/Gemfile"}},
+			{"net/http/persistent", PackageHint{"net-http-persistent", "", PackageHintConfidenceMedium, "fixtures/manifests/ruby/Gemfile"}},
+			{"rack/test", PackageHint{"rack-test", "2.1.0", PackageHintConfidenceMedium, "fixtures/manifests/ruby/Gemfile.lock"}},
+			{"active_support/core_ext", PackageHint{"activesupport", "7.1.2", PackageHintConfidenceMedium, "fixtures/manifests/ruby/Gemfile.lock"}},
+			{"pg", PackageHint{"pg", "1.5.4", PackageHintConfidenceMedium, "fixtures/manifests/ruby/Gemfile"}},
+			{"rubocop", PackageHint{"rubocop", "~> 1.57", PackageHintConfidenceMedium, "fixtures/manifests/ruby/admin.gemspec"}},
+			{"json", PackageHint{"json", "", PackageHintConfidenceLow, ""}},
+			{"./helper", PackageHint{"./helper", "", PackageHintConfidenceLow, ""}},
+		},
+		core.LanguageCodePhp: {
+			{"GuzzleHttp", PackageHint{"guzzlehttp/guzzle", "7.8.1", PackageHintConfidenceHigh, "fixtures/manifests/php/composer.lock"}},
+			{"GuzzleHttp\\Psr7", PackageHint{"guzzlehttp/psr7", "2.6.2", PackageHintConfidenceHigh, "fixtures/manifests/php/composer.lock"}},
+			{"Symfony\\Component\\Console\\Command", PackageHint{"symfony/console", "v6.4.3", PackageHintConfidenceHigh, "fixtures/manifests/php/composer.lock"}},
+			{"Twig", PackageHint{"twig/twig", "v3.8.0", PackageHintConfidenceHigh, "fixtures/manifests/php/composer.lock"}},
+			{"\\Ramsey\\Uuid", PackageHint{"ramsey/uuid", "4.7.5", PackageHintConfidenceHigh, "fixtures/manifests/php/vendor/composer/installed.json"}},
+			{"Monolog\\Handler", PackageHint{"Monolog/Monolog", "^3.5", PackageHintConfidenceMedium, "fixtures/manifests/php/composer.json"}},
+			{"PHPUnit\\Framework", PackageHint{"phpunit/phpunit", "10.5.9", PackageHintConfidenceMedium, "fixtures/manifests/php/composer.lock"}},
+			{"Acme\\Shop\\Tests", PackageHint{"acme/shop", "", PackageHintConfidenceHigh, "fixtures/manifests/php/composer.json"}},
+			{"Doctrine\\ORM", PackageHint{"doctrine/orm", "", PackageHintConfidenceLow, ""}},
+			{"vendor/autoload.php", PackageHint{"vendor/autoload.php", "", PackageHintConfidenceLow, ""}},
+		},
+		core.LanguageCodeCsharp: {
+			{"Newtonsoft.Json.Linq", PackageHint{"Newtonsoft.Json", "13.0.3", PackageHintConfidenceHigh, "fixtures/manifests/csharp/src/Acme.Orders/packages.lock.json"}},
+			{"Serilog", PackageHint{"Serilog", "3.1.1", PackageHintConfidenceHigh, "fixtures/manifests/csharp/src/Acme.Orders/packages.lock.json"}},
+			{"NUnit.Framework", PackageHint{"NUnit", "3.14.0", PackageHintConfidenceHigh, "fixtures/manifests/csharp/legacy/packages.config"}},
+			{"Amazon.S3.Model", PackageHint{"AWSSDK.S3", "3.7.305.22", PackageHintConfidenceMedium, "fixtures/manifests/csharp/Directory.Packages.props"}},
+			{"Acme.Orders.Models", PackageHint{"Acme.Orders", "1.2.0", PackageHintConfidenceHigh, "fixtures/manifests/csharp/src/Acme.Orders/Acme.Orders.csproj"}},
+			{"Microsoft.Extensions.Http", PackageHint{"Microsoft.Extensions.Http", "", PackageHintConfidenceLow, ""}},
+			{"System.Linq", PackageHint{"System.Linq", "", PackageHintConfidenceLow, ""}},
+		},
+	}
+
+	for languageCode, tests := range languageWiseTests {
+		t.Run(string(languageCode), func(t *testing.T) {
+			language, err := lang.GetLanguage(string(languageCode))
+			assert.NoError(t, err)
+
+			for _, test := range tests {
+				hint, err := resolver.ResolvePackageHint(test.moduleName, language)
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, hint, "module: %s", test.moduleName)
+			}
+		})
+	}
+
+	t.Run("should fail for unresolvable java modules", func(t *testing.T) {
+		language, err := lang.GetLanguage(string(core.LanguageCodeJava))
+		assert.NoError(t, err)
+
+		for _, moduleName := range []string{"lombok.extern.slf4j.Slf4j", "com.google.common.collect"} {
+			_, err = resolver.ResolvePackageHint(moduleName, language)
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestParseOsgiExportPackage(t *testing.T) {
+	assert.Equal(t, []string{"com.foo", "com.foo.impl", "com.bar"},
+		parseOsgiExportPackage(`com.foo;version="1.0";uses:="com.bar,com.baz",com.foo.impl, com.bar`))
+	assert.Empty(t, parseOsgiExportPackage(""))
+}
+
+func TestDepsusageWithManifestPackageHints(t *testing.T) {
+	treeWalker, fileSystem, err := test.SetupBasicPluginContext([]string{"fixtures/testcases.py"},
+		[]core.LanguageCode{core.LanguageCodePython})
+	assert.NoError(t, err)
+
+	evidences := map[string]*UsageEvidence{}
+	pluginExecutor, err := plugin.NewTreeWalkPluginExecutor(treeWalker, []core.Plugin{
+		NewDependencyUsagePluginWithConfig(DependencyUsagePluginConfig{
+			PackageHintResolver: newManifestFixtureResolver(t),
+		}, func(ctx context.Context, evidence *UsageEvidence) error {
+			evidences[evidence.ModuleName] = evidence
+			return nil
+		}),
+	})
+	assert.NoError(t, err)
+
+	err = pluginExecutor.Execute(context.Background(), fileSystem)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "scikit-learn", evidences["sklearn"].PackageHint)
+	assert.Equal(t, "1.3.0", evidences["sklearn"].PackageVersion)
+	assert.Equal(t, PackageHintConfidenceMedium, evidences["sklearn"].PackageHintConfidence)
+	assert.Equal(t, "fixtures/manifests/python/requirements.txt", evidences["sklearn"].PackageHintSource)
+
+	assert.Equal(t, "sys", evidences["sys"].PackageHint)
+	assert.Equal(t, PackageHintConfidenceLow, evidences["sys"].PackageHintConfidence)
+}
+
+func TestManifestDeclaredPackages(t *testing.T) {
+	resolver := newManifestFixtureResolver(t)
+
+	declared := map[string]DeclaredPackage{}
+	for _, pkg := range resolver.DeclaredPackages() {
+		declared[pkg.Name] = pkg
+	}
+
+	assert.Equal(t, DeclaredPackage{"httpx", "", EcosystemPyPI, "fixtures/manifests/python/Pipfile"}, declared["httpx"])
+	assert.Equal(t, DeclaredPackage{"black", "==23.1.0", EcosystemPyPI, "fixtures/manifests/python/Pipfile"}, declared["black"])
+	assert.Equal(t, DeclaredPackage{"slumber", "^0.7.1", EcosystemPyPI, "fixtures/manifests/python/pyproject.toml"}, declared["slumber"])
+	assert.Equal(t, DeclaredPackage{"react-dom", "^18.2.0", EcosystemNpm, "fixtures/manifests/javascript/package.json"}, declared["react-dom"])
+	assert.Equal(t, DeclaredPackage{"gopkg.in/yaml.v3", "v3.0.1", EcosystemGo, "fixtures/manifests/golang/go.mod"}, declared["gopkg.in/yaml.v3"])
+	assert.Equal(t, EcosystemMaven, declared["junit:junit"].Ecosystem)
+	assert.Equal(t, DeclaredPackage{"tokio", "1.35", EcosystemCargo, "fixtures/manifests/rust/Cargo.toml"}, declared["tokio"])
+	assert.Equal(t, DeclaredPackage{"mockito", "1.2", EcosystemCargo, "fixtures/manifests/rust/Cargo.toml"}, declared["mockito"])
+	assert.Equal(t, DeclaredPackage{"json-five", "0.3", EcosystemCargo, "fixtures/manifests/rust/Cargo.toml"}, declared["json-five"])
+	assert.Equal(t, DeclaredPackage{"pg", "1.5.4", EcosystemGem, "fixtures/manifests/ruby/Gemfile"}, declared["pg"])
+	assert.Equal(t, DeclaredPackage{"concurrent-ruby", "1.2.2", EcosystemGem, "fixtures/manifests/ruby/Gemfile"}, declared["concurrent-ruby"])
+	assert.Equal(t, DeclaredPackage{"rack-test", "", EcosystemGem, "fixtures/manifests/ruby/Gemfile"}, declared["rack-test"])
+	assert.Equal(t, DeclaredPackage{"rubocop", "~> 1.57", EcosystemGem, "fixtures/manifests/ruby/admin.gemspec"}, declared["rubocop"])
+	assert.Equal(t, DeclaredPackage{"guzzlehttp/guzzle", "^7.8", EcosystemPackagist, "fixtures/manifests/php/composer.json"}, declared["guzzlehttp/guzzle"])
+	assert.Equal(t, DeclaredPackage{"phpunit/phpunit", "^10.5", EcosystemPackagist, "fixtures/manifests/php/composer.json"}, declared["phpunit/phpunit"])
+	assert.Equal(t, DeclaredPackage{"Serilog.AspNetCore", "8.0.1", EcosystemNuGet, "fixtures/manifests/csharp/src/Acme.Orders/Acme.Orders.csproj"}, declared["Serilog.AspNetCore"])
+	assert.Equal(t, DeclaredPackage{"AWSSDK.S3", "", EcosystemNuGet, "fixtures/manifests/csharp/src/Acme.Orders/Acme.Orders.csproj"}, declared["AWSSDK.S3"])
+	assert.Equal(t, DeclaredPackage{"Dapper", "2.1.28", EcosystemNuGet, "fixtures/manifests/csharp/legacy/packages.config"}, declared["Dapper"])
+
+	// Lockfiles, indirect requirements and installed packages are not declarations
+	for _, name := range []string{"PyYAML", "debug", "github.com/robfig/cron/v3", "lodash", "org.apache.commons:commons-lang3", "bytes", "nokogiri", "pry", "guzzlehttp/psr7", "php", "ext-json", "Serilog", "acme.shared"} {
+		assert.NotContains(t, declared, name)
+	}
+
+	project := map[string]DeclaredPackage{}
+	for _, pkg := range resolver.ProjectPackages() {
+		project[pkg.Ecosystem] = pkg
+	}
+
+	assert.Equal(t, "app", project[EcosystemPyPI].Name)
+	assert.Equal(t, "app", project[EcosystemNpm].Name)
+	assert.Equal(t, "github.com/safedep/app", project[EcosystemGo].Name)
+	assert.Equal(t, "app", project[EcosystemCargo].Name)
+	assert.Equal(t, "admin", project[EcosystemGem].Name)
+	assert.Equal(t, "acme/shop", project[EcosystemPackagist].Name)
+	assert.Equal(t, "Acme.Orders", project[EcosystemNuGet].Name)
+}
@@ -0,0 +1,1307 @@
+package depsusage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/safedep/code/pkg/helpers"
+)
+
+// pythonRequirementRegexp matches a PEP 508 requirement eg. requests[security]>=2.0; python_version > "3.8"
+var pythonRequirementRegexp = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(\[[^\]]*\])?\s*(.*)$`)
+
+// parsePythonRequirement returns the package declared by a PEP 508 requirement
+func parsePythonRequirement(requirement string, source string) (manifestPackage, bool) {
+	requirement, _, _ = strings.Cut(requirement, ";")
+	requirement = strings.TrimSpace(requirement)
+
+	matches := pythonRequirementRegexp.FindStringSubmatch(requirement)
+	if matches == nil {
+		return manifestPackage{}, false
+	}
+
+	pkg := manifestPackage{name: matches[1], source: source}
+
+	// Direct references eg. name @ https://... have no version
+	constraint := strings.TrimSpace(matches[3])
+	if strings.HasPrefix(constraint, "@") {
+		return pkg, true
+	}
+
+	constraint = strings.Trim(constraint, "()")
+	if version, pinned := strings.CutPrefix(constraint, "=="); pinned && !strings.Contains(version, ",") {
+		pkg.version = strings.TrimSpace(version)
+		pkg.exactVersion = !strings.Contains(pkg.version, "*")
+	} else {
+		pkg.version = constraint
+	}
+
+	return pkg, true
+}
+
+func (r *manifestPackageHintResolver) addPythonPackage(pkg manifestPackage) {
+	addPackage(r.pythonPackages, normalizePythonPackageName(pkg.name), pkg)
+}
+
+func (r *manifestPackageHintResolver) declarePythonPackage(pkg manifestPackage) {
+	r.addPythonPackage(pkg)
+	r.declare(EcosystemPyPI, pkg)
+}
+
+func parseRequirementsTxt(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if index := strings.Index(line, " #"); index >= 0 {
+			line = strings.TrimSpace(line[:index])
+		}
+
+		// Skip comments, options eg. -r, -e, --hash and references to archives
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") ||
+			strings.Contains(line, "://") && !strings.Contains(line, "@") {
+			continue
+		}
+
+		if pkg, ok := parsePythonRequirement(line, filePath); ok {
+			r.declarePythonPackage(pkg)
+		}
+	}
+
+	return scanner.Err()
+}
+
+var (
+	tomlTableRegexp        = regexp.MustCompile(`^\[\[?\s*([^\]]+?)\s*\]\]?`)
+	tomlKeyValueRegexp     = regexp.MustCompile(`^["']?([A-Za-z0-9._-]+)["']?\s*=\s*(.*)$`)
+	tomlStringRegexp       = regexp.MustCompile(`"([^"]*)"|'([^']*)'`)
+	tomlInlineVersionRegex = regexp.MustCompile(`version\s*=\s*["']([^"']*)["']`)
+	poetryDependencyTables = regexp.MustCompile(`^tool\.poetry\.(dependencies|dev-dependencies|group\.[^.]+\.dependencies)$`)
+)
+
+// parsePyprojectToml indexes PEP 621 dependencies and poetry dependencies.
+// Only the subset of TOML used for declaring dependencies is supported.
+func parsePyprojectToml(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	table := ""
+	inArray := false
+
+	addRequirements := func(value string) {
+		for _, match := range tomlStringRegexp.FindAllStringSubmatch(value, -1) {
+			if pkg, ok := parsePythonRequirement(match[1]+match[2], filePath); ok {
+				r.declarePythonPackage(pkg)
+			}
+		}
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if inArray {
+			addRequirements(line)
+			inArray = !strings.Contains(line, "]")
+			continue
+		}
+
+		if matches := tomlTableRegexp.FindStringSubmatch(line); matches != nil && !strings.Contains(line, "=") {
+			table = matches[1]
+			continue
+		}
+
+		matches := tomlKeyValueRegexp.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		key, value := matches[1], matches[2]
+		switch {
+		case (table == "project" || table == "tool.poetry") && key == "name":
+			if name := tomlStringRegexp.FindStringSubmatch(value); name != nil {
+				r.declareProject(EcosystemPyPI, manifestPackage{name: name[1] + name[2], source: filePath})
+			}
+		case table == "project" && key == "dependencies",
+			table == "project.optional-dependencies",
+			table == "dependency-groups":
+			if !strings.HasPrefix(value, "[") {
+				continue
+			}
+
+			addRequirements(value)
+			inArray = !strings.Contains(value, "]")
+		case poetryDependencyTables.MatchString(table) && key != "python":
+			r.declarePythonPackage(parseTomlDependency(key, value, filePath))
+		}
+	}
+
+	return scanner.Err()
+}
+
+// parseTomlDependency parses a dependency declared as a key value pair
+// eg. requests = "^2.0" or requests = { version = "^2.0", extras = ["socks"] }
+func parseTomlDependency(name string, value string, source string) manifestPackage {
+	pkg := manifestPackage{name: name, source: source}
+	if version := tomlInlineVersionRegex.FindStringSubmatch(value); version != nil {
+		pkg.version = version[1]
+	} else if strings.HasPrefix(value, "{") {
+		return pkg
+	} else if version := tomlStringRegexp.FindStringSubmatch(value); version != nil {
+		pkg.version = version[1] + version[2]
+	}
+
+	if pkg.version == "*" {
+		pkg.version = ""
+	}
+
+	return pkg
+}
+
+func parsePipfile(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	table := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if matches := tomlTableRegexp.FindStringSubmatch(line); matches != nil && !strings.Contains(line, "=") {
+			table = matches[1]
+			continue
+		}
+
+		if table != "packages" && table != "dev-packages" {
+			continue
+		}
+
+		if matches := tomlKeyValueRegexp.FindStringSubmatch(line); matches != nil {
+			r.declarePythonPackage(parseTomlDependency(matches[1], matches[2], filePath))
+		}
+	}
+
+	return scanner.Err()
+}
+
+type pipfileLock struct {
+	Default map[string]pipfileLockPackage `json:"default"`
+	Develop map[string]pipfileLockPackage `json:"develop"`
+}
+
+type pipfileLockPackage struct {
+	Version string `json:"version"`
+}
+
+func parsePipfileLock(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	var lock pipfileLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return fmt.Errorf("failed to parse Pipfile.lock: %w", err)
+	}
+
+	for _, packages := range []map[string]pipfileLockPackage{lock.Default, lock.Develop} {
+		for name, pkg := range packages {
+			r.addPythonPackage(manifestPackage{
+				name:         name,
+				version:      strings.TrimPrefix(pkg.Version, "=="),
+				source:       filePath,
+				exactVersion: strings.HasPrefix(pkg.Version, "=="),
+			})
+		}
+	}
+
+	return nil
+}
+
+// parsePythonTopLevel indexes the top level modules of a distribution
+// installed in site-packages eg. PyYAML-6.0.1.dist-info/top_level.txt
+func parsePythonTopLevel(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	metadataDirectory := path.Base(path.Dir(filePath))
+
+	distribution, found := strings.CutSuffix(metadataDirectory, ".dist-info")
+	if !found {
+		distribution, found = strings.CutSuffix(metadataDirectory, ".egg-info")
+	}
+
+	if !found {
+		return fmt.Errorf("top_level.txt is not within package metadata: %s", filePath)
+	}
+
+	// Versions of egg-info directories may be followed by a python version
+	// eg. foo-1.0-py3.8.egg-info, while directories in source trees have none
+	parts := strings.Split(distribution, "-")
+	pkg := manifestPackage{
+		name:      parts[0],
+		source:    filePath,
+		installed: true,
+	}
+
+	if len(parts) > 1 {
+		pkg.version = parts[1]
+		pkg.exactVersion = true
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		module := strings.TrimSpace(scanner.Text())
+		if module == "" {
+			continue
+		}
+
+		addPackage(r.pythonModules, strings.ReplaceAll(module, "/", "."), pkg)
+	}
+
+	return scanner.Err()
+}
+
+type packageJson struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+// parsePackageJson indexes the declared dependencies of a project or
+// the package itself when it is installed within node_modules
+func parsePackageJson(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	var manifest packageJson
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	if strings.Contains(filePath, "node_modules/") {
+		if manifest.Name != "" {
+			addPackage(r.javascriptPackages, manifest.Name, manifestPackage{
+				name:         manifest.Name,
+				version:      manifest.Version,
+				source:       filePath,
+				exactVersion: true,
+				installed:    true,
+			})
+		}
+
+		return nil
+	}
+
+	r.declareProject(EcosystemNpm, manifestPackage{name: manifest.Name, version: manifest.Version, source: filePath})
+
+	for _, dependencies := range []map[string]string{manifest.Dependencies, manifest.DevDependencies,
+		manifest.PeerDependencies, manifest.OptionalDependencies} {
+		for name, version := range dependencies {
+			pkg := manifestPackage{
+				name:    name,
+				version: version,
+				source:  filePath,
+			}
+
+			addPackage(r.javascriptPackages, name, pkg)
+			r.declare(EcosystemNpm, pkg)
+		}
+	}
+
+	return nil
+}
+
+type packageLockJson struct {
+	// Lockfile v2 and v3 eg. "node_modules/@scope/name": { "version": "1.0.0" }
+	Packages map[string]packageLockPackage `json:"packages"`
+
+	// Lockfile v1
+	Dependencies map[string]packageLockPackage `json:"dependencies"`
+}
+
+type packageLockPackage struct {
+	Version string `json:"version"`
+}
+
+func parsePackageLockJson(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	var lock packageLockJson
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return fmt.Errorf("failed to parse package-lock.json: %w", err)
+	}
+
+	addLocked := func(name string, pkg packageLockPackage) {
+		addPackage(r.javascriptPackages, name, manifestPackage{
+			name:         name,
+			version:      pkg.Version,
+			source:       filePath,
+			exactVersion: true,
+		})
+	}
+
+	for key, pkg := range lock.Packages {
+		index := strings.LastIndex(key, "node_modules/")
+		if index < 0 {
+			continue
+		}
+
+		// Nested dependencies are not importable by the application
+		if strings.Contains(key[:index], "node_modules/") {
+			continue
+		}
+
+		addLocked(key[index+len("node_modules/"):], pkg)
+	}
+
+	for name, pkg := range lock.Dependencies {
+		addLocked(name, pkg)
+	}
+
+	return nil
+}
+
+func parseGoMod(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	inRequireBlock := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line, comment, _ := strings.Cut(scanner.Text(), "//")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case inRequireBlock && fields[0] == ")":
+			inRequireBlock = false
+			continue
+		case inRequireBlock:
+		case fields[0] == "module" && len(fields) == 2:
+			pkg := manifestPackage{
+				name:   strings.Trim(fields[1], `"`),
+				source: filePath,
+			}
+
+			r.goModules = append(r.goModules, pkg)
+			r.declareProject(EcosystemGo, pkg)
+			continue
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			inRequireBlock = true
+			continue
+		case fields[0] == "require":
+			fields = fields[1:]
+		default:
+			continue
+		}
+
+		if len(fields) < 2 {
+			continue
+		}
+
+		pkg := manifestPackage{
+			name:         strings.Trim(fields[0], `"`),
+			version:      fields[1],
+			source:       filePath,
+			exactVersion: true,
+		}
+
+		r.goModules = append(r.goModules, pkg)
+
+		// Indirect requirements are dependencies of dependencies
+		if strings.TrimSpace(comment) != "indirect" {
+			r.declare(EcosystemGo, pkg)
+		}
+	}
+
+	return scanner.Err()
+}
+
+type pomXml struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Parent     struct {
+		GroupID string `xml:"groupId"`
+		Version string `xml:"version"`
+	} `xml:"parent"`
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+	Dependencies         []pomDependency `xml:"dependencies>dependency"`
+	DependencyManagement []pomDependency `xml:"dependencyManagement>dependencies>dependency"`
+}
+
+type pomDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+}
+
+var pomPropertyRegexp = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+func parsePomXml(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	var pom pomXml
+	if err := xml.Unmarshal(content, &pom); err != nil {
+		return fmt.Errorf("failed to parse pom.xml: %w", err)
+	}
+
+	properties := map[string]string{
+		"project.groupId": pom.GroupID,
+		"project.version": pom.Version,
+	}
+
+	if pom.GroupID == "" {
+		properties["project.groupId"] = pom.Parent.GroupID
+	}
+
+	if pom.Version == "" {
+		properties["project.version"] = pom.Parent.Version
+	}
+
+	for _, entry := range pom.Properties.Entries {
+		properties[entry.XMLName.Local] = strings.TrimSpace(entry.Value)
+	}
+
+	interpolate := func(value string) string {
+		return pomPropertyRegexp.ReplaceAllStringFunc(strings.TrimSpace(value), func(property string) string {
+			if resolved, exists := properties[property[2:len(property)-1]]; exists {
+				return resolved
+			}
+
+			return property
+		})
+	}
+
+	r.declareProject(EcosystemMaven, manifestPackage{
+		name:    properties["project.groupId"] + ":" + pom.ArtifactID,
+		version: properties["project.version"],
+		source:  filePath,
+	})
+
+	for _, dependency := range pom.Dependencies {
+		r.addDeclaredJavaArtifact(interpolate(dependency.GroupID), interpolate(dependency.ArtifactID),
+			interpolate(dependency.Version), filePath)
+	}
+
+	// Managed dependencies only pin versions of dependencies, they are
+	// used for resolving the package hint but are not declarations
+	for _, dependency := range pom.DependencyManagement {
+		r.addJavaArtifact(newJavaArtifact(interpolate(dependency.GroupID), interpolate(dependency.ArtifactID),
+			interpolate(dependency.Version), filePath))
+	}
+
+	return nil
+}
+
+var (
+	// eg. implementation 'com.google.guava:guava:32.1.2-jre' or implementation("g:a:v")
+	gradleStringDependencyRegexp = regexp.MustCompile(`^\s*\w+\s*\(?\s*["']([^:"'\s]+):([^:"'\s]+)(?::([^:"'@\s]+))?[^"']*["']`)
+
+	// eg. implementation group: 'com.google.guava', name: 'guava', version: '32.1.2-jre'
+	gradleMapDependencyRegexp = regexp.MustCompile(`^\s*\w+\s*\(?\s*group\s*[:=]\s*["']([^"']+)["']\s*,\s*name\s*[:=]\s*["']([^"']+)["'](?:\s*,\s*version\s*[:=]\s*["']([^"']+)["'])?`)
+)
+
+func parseBuildGradle(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		matches := gradleMapDependencyRegexp.FindStringSubmatch(line)
+		if matches == nil {
+			matches = gradleStringDependencyRegexp.FindStringSubmatch(line)
+		}
+
+		if matches == nil {
+			continue
+		}
+
+		r.addDeclaredJavaArtifact(matches[1], matches[2], matches[3], filePath)
+	}
+
+	return scanner.Err()
+}
+
+func newJavaArtifact(groupID, artifactID, version, source string) javaArtifact {
+	return javaArtifact{
+		manifestPackage: manifestPackage{
+			name:    groupID + ":" + artifactID,
+			version: version,
+			source:  source,
+		},
+		groupID:    groupID,
+		artifactID: artifactID,
+	}
+}
+
+func (r *manifestPackageHintResolver) addDeclaredJavaArtifact(groupID, artifactID, version, source string) {
+	if groupID == "" || artifactID == "" {
+		return
+	}
+
+	artifact := newJavaArtifact(groupID, artifactID, version, source)
+	r.addJavaArtifact(artifact)
+	r.declare(EcosystemMaven, artifact.manifestPackage)
+}
+
+// jarMetadata is the metadata of an installed jar
+// collected from its manifest and pom.properties
+type jarMetadata struct {
+	manifest      map[string]string
+	manifestPath  string
+	pomProperties map[string]string
+	pomPath       string
+}
+
+// jarRoot returns the path of the jar containing a META-INF entry
+func jarRoot(filePath string) string {
+	return filePath[:strings.Index(filePath, "META-INF/")]
+}
+
+func (r *manifestPackageHintResolver) jar(filePath string) *jarMetadata {
+	root := jarRoot(filePath)
+	if _, exists := r.jars[root]; !exists {
+		r.jars[root] = &jarMetadata{}
+	}
+
+	return r.jars[root]
+}
+
+// parseJarManifest reads the main section of META-INF/MANIFEST.MF
+// where long values are continued on lines starting with a space
+func parseJarManifest(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	headers := map[string]string{}
+	lastHeader := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		// Main section ends at the first empty line
+		if line == "" {
+			break
+		}
+
+		if strings.HasPrefix(line, " ") && lastHeader != "" {
+			headers[lastHeader] += line[1:]
+			continue
+		}
+
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+
+		lastHeader = strings.TrimSpace(name)
+		headers[lastHeader] = strings.TrimSpace(value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	jar := r.jar(filePath)
+	jar.manifest = headers
+	jar.manifestPath = filePath
+
+	return nil
+}
+
+func parsePomProperties(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	properties := map[string]string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if found {
+			properties[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	jar := r.jar(filePath)
+	jar.pomProperties = properties
+	jar.pomPath = filePath
+
+	return nil
+}
+
+// indexJars adds installed jars as java artifacts. Maven coordinates are taken
+// from pom.properties when available, else from the OSGi or JAR manifest headers.
+func (r *manifestPackageHintResolver) indexJars() {
+	for _, jar := range r.jars {
+		artifact := javaArtifact{
+			manifestPackage: manifestPackage{
+				installed:    true,
+				exactVersion: true,
+			},
+		}
+
+		if jar.pomProperties != nil {
+			artifact.groupID = jar.pomProperties["groupId"]
+			artifact.artifactID = jar.pomProperties["artifactId"]
+			artifact.version = jar.pomProperties["version"]
+			artifact.source = jar.pomPath
+		}
+
+		if jar.manifest != nil {
+			// Bundle-SymbolicName may have directives eg. foo.bar;singleton:=true
+			symbolicName, _, _ := strings.Cut(jar.manifest["Bundle-SymbolicName"], ";")
+
+			for _, pkg := range []string{jar.manifest["Automatic-Module-Name"], strings.TrimSpace(symbolicName)} {
+				if pkg != "" {
+					artifact.packages = append(artifact.packages, pkg)
+				}
+			}
+
+			artifact.packages = append(artifact.packages, parseOsgiExportPackage(jar.manifest["Export-Package"])...)
+
+			if artifact.artifactID == "" {
+				artifact.groupID = jar.manifest["Implementation-Vendor-Id"]
+				artifact.artifactID = helpers.GetFirstNonEmptyString(jar.manifest["Implementation-Title"], strings.TrimSpace(symbolicName),
+					jar.manifest["Automatic-Module-Name"])
+				artifact.version = helpers.GetFirstNonEmptyString(jar.manifest["Implementation-Version"], jar.manifest["Bundle-Version"])
+				artifact.source = jar.manifestPath
+			}
+		}
+
+		if artifact.artifactID == "" {
+			continue
+		}
+
+		artifact.name = artifact.artifactID
+		if artifact.groupID != "" {
+			artifact.name = artifact.groupID + ":" + artifact.artifactID
+		}
+
+		r.addJavaArtifact(artifact)
+	}
+
+	r.jars = nil
+}
+
+// parseOsgiExportPackage returns the packages of an Export-Package header
+// eg. com.foo;version="1.0";uses:="com.bar,com.baz",com.foo.impl
+func parseOsgiExportPackage(header string) []string {
+	packages := []string{}
+	inQuotes := false
+	start := 0
+
+	for i := 0; i <= len(header); i++ {
+		if i < len(header) && header[i] == '"' {
+			inQuotes = !inQuotes
+		}
+
+		if i < len(header) && (header[i] != ',' || inQuotes) {
+			continue
+		}
+
+		clause, _, _ := strings.Cut(header[start:i], ";")
+		if clause = strings.TrimSpace(clause); clause != "" {
+			packages = append(packages, clause)
+		}
+
+		start = i + 1
+	}
+
+	return packages
+}
+
+var (
+	// eg. dependencies, dev-dependencies, target.'cfg(unix)'.build-dependencies
+	cargoDependencyTableRegexp = regexp.MustCompile(`^(?:workspace\.|target\..+\.)?(?:dev-|build-)?dependencies$`)
+
+	// Dependency declared as a table eg. [dependencies.serde]
+	cargoDependencySubTableRegexp = regexp.MustCompile(`^(?:target\..+\.)?(?:dev-|build-)?dependencies\.["']?([A-Za-z0-9_-]+)["']?$`)
+
+	tomlInlinePackageRegexp = regexp.MustCompile(`package\s*=\s*["']([^"']*)["']`)
+)
+
+func (r *manifestPackageHintResolver) addRustCrate(pkg manifestPackage) {
+	addPackage(r.rustCrates, normalizeRustCrateName(pkg.name), pkg)
+}
+
+// declareRustCrate declares a dependency of a crate. Dependencies may be
+// renamed eg. json = { package = "serde_json", version = "1" } in which
+// case the crate is imported by the name of the dependency
+func (r *manifestPackageHintResolver) declareRustCrate(crate string, pkg manifestPackage) {
+	if crate != pkg.name {
+		r.rustCrateRenames[normalizeRustCrateName(crate)] = normalizeRustCrateName(pkg.name)
+	}
+
+	r.addRustCrate(pkg)
+	r.declare(EcosystemCargo, pkg)
+}
+
+// parseCargoToml indexes the dependencies of a crate or a workspace.
+// Only the subset of TOML used for declaring dependencies is supported.
+func parseCargoToml(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	table := ""
+
+	// Dependency declared as a table, declared once the table ends
+	var tableCrate string
+	var tablePackage *manifestPackage
+
+	declareTablePackage := func() {
+		if tablePackage != nil {
+			r.declareRustCrate(tableCrate, *tablePackage)
+			tablePackage = nil
+		}
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Table names of target specific dependencies may contain an
+		// equal sign eg. [target.'cfg(target_os = "linux")'.dependencies]
+		if matches := tomlTableRegexp.FindStringSubmatch(line); matches != nil && strings.HasPrefix(line, "[") {
+			declareTablePackage()
+			table = matches[1]
+
+			if crate := cargoDependencySubTableRegexp.FindStringSubmatch(table); crate != nil {
+				tableCrate = crate[1]
+				tablePackage = &manifestPackage{name: crate[1], source: filePath}
+			}
+
+			continue
+		}
+
+		matches := tomlKeyValueRegexp.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		key, value := matches[1], matches[2]
+		switch {
+		case table == "package" && key == "name":
+			if name := tomlStringRegexp.FindStringSubmatch(value); name != nil {
+				r.declareProject(EcosystemCargo, manifestPackage{name: name[1] + name[2], source: filePath})
+			}
+		case tablePackage != nil && key == "version":
+			if version := tomlStringRegexp.FindStringSubmatch(value); version != nil {
+				tablePackage.version = version[1] + version[2]
+			}
+		case tablePackage != nil && key == "package":
+			if name := tomlStringRegexp.FindStringSubmatch(value); name != nil {
+				tablePackage.name = name[1] + name[2]
+			}
+		case cargoDependencyTableRegexp.MatchString(table):
+			pkg := parseTomlDependency(key, value, filePath)
+			if name := tomlInlinePackageRegexp.FindStringSubmatch(value); name != nil {
+				pkg.name = name[1]
+			}
+
+			r.declareRustCrate(key, pkg)
+		}
+	}
+
+	declareTablePackage()
+	return scanner.Err()
+}
+
+// parseCargoLock indexes the exact versions of the packages locked in
+// Cargo.lock, including the transitive dependencies
+func parseCargoLock(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	var pkg *manifestPackage
+
+	addLocked := func() {
+		if pkg != nil && pkg.name != "" {
+			r.addRustCrate(*pkg)
+		}
+
+		pkg = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			addLocked()
+			if line == "[[package]]" {
+				pkg = &manifestPackage{source: filePath, exactVersion: true}
+			}
+
+			continue
+		}
+
+		matches := tomlKeyValueRegexp.FindStringSubmatch(line)
+		if pkg == nil || matches == nil {
+			continue
+		}
+
+		value := tomlStringRegexp.FindStringSubmatch(matches[2])
+		if value == nil {
+			continue
+		}
+
+		switch matches[1] {
+		case "name":
+			pkg.name = value[1] + value[2]
+		case "version":
+			pkg.version = value[1] + value[2]
+		}
+	}
+
+	addLocked()
+	return scanner.Err()
+}
+
+var (
+	// eg. gem 'rails', '~> 7.1', require: false
+	gemfileGemRegexp = regexp.MustCompile(`^gem\s*\(?\s*["']([^"']+)["']\s*(.*)$`)
+
+	// Leading string arguments of a gem declaration are version constraints
+	gemVersionConstraintRegexp = regexp.MustCompile(`^,\s*["']([^"']*)["']`)
+
+	gemRequireOptionRegexp = regexp.MustCompile(`(?:require:|:require\s*=>)\s*["']([^"']+)["']`)
+
+	// eg. spec.add_dependency "rack", ">= 2.0" or s.add_development_dependency 'rspec'
+	gemspecDependencyRegexp = regexp.MustCompile(`^\w+\.add_(?:runtime_|development_)?dependency\s*\(?\s*["']([^"']+)["']\s*(.*)$`)
+	gemspecNameRegexp       = regexp.MustCompile(`^\w+\.name\s*=\s*["']([^"']+)["']`)
+
+	// Locked gem eg. nokogiri (1.15.4-x86_64-linux)
+	gemfileLockSpecRegexp = regexp.MustCompile(`^    ([^\s(]+) \(([^)]+)\)$`)
+)
+
+func (r *manifestPackageHintResolver) addRubyGem(pkg manifestPackage) {
+	addPackage(r.rubyGems, pkg.name, pkg)
+}
+
+// parseGemDeclaration returns the gem declared with its version constraints
+// eg. "rails", and the arguments following the name eg. `, '~> 7.1', '>= 7.1.2'`
+func parseGemDeclaration(name string, arguments string, source string) manifestPackage {
+	constraints := []string{}
+	for {
+		matches := gemVersionConstraintRegexp.FindStringSubmatch(arguments)
+		if matches == nil {
+			break
+		}
+
+		constraints = append(constraints, strings.TrimSpace(matches[1]))
+		arguments = strings.TrimSpace(arguments[len(matches[0]):])
+	}
+
+	pkg := manifestPackage{name: name, source: source, version: strings.Join(constraints, ", ")}
+	if len(constraints) == 1 {
+		version := strings.TrimSpace(strings.TrimPrefix(constraints[0], "= "))
+		if version != "" && strings.IndexAny(version, "<>=~! ") < 0 {
+			pkg.version = version
+			pkg.exactVersion = true
+		}
+	}
+
+	return pkg
+}
+
+// parseGemfile indexes the gems declared in a Gemfile along with the
+// paths required in place of the gem name using the require option
+func parseGemfile(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		matches := gemfileGemRegexp.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		pkg := parseGemDeclaration(matches[1], strings.TrimSpace(matches[2]), filePath)
+		if requirePath := gemRequireOptionRegexp.FindStringSubmatch(matches[2]); requirePath != nil {
+			r.rubyRequirePaths[requirePath[1]] = pkg.name
+		}
+
+		r.addRubyGem(pkg)
+		r.declare(EcosystemGem, pkg)
+	}
+
+	return scanner.Err()
+}
+
+// parseGemspec indexes the dependencies of a gem. The gem itself is
+// a package of the project
+func parseGemspec(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if name := gemspecNameRegexp.FindStringSubmatch(line); name != nil {
+			r.declareProject(EcosystemGem, manifestPackage{name: name[1], source: filePath})
+			continue
+		}
+
+		if matches := gemspecDependencyRegexp.FindStringSubmatch(line); matches != nil {
+			pkg := parseGemDeclaration(matches[1], strings.TrimSpace(strings.TrimSuffix(matches[2], ")")), filePath)
+			r.addRubyGem(pkg)
+			r.declare(EcosystemGem, pkg)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// parseGemfileLock indexes the exact versions of the gems locked in
+// Gemfile.lock, including the transitive dependencies. Specs are indented
+// by four spaces, their dependencies by six
+func parseGemfileLock(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	section := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \r")
+		if line != "" && !strings.HasPrefix(line, " ") {
+			section = line
+			continue
+		}
+
+		// Gems of PATH sections are sources of the project eg. the gemspec
+		if section != "GEM" && section != "GIT" && section != "PATH" {
+			continue
+		}
+
+		matches := gemfileLockSpecRegexp.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		pkg := manifestPackage{name: matches[1], version: matches[2], source: filePath, exactVersion: true}
+		if section == "PATH" {
+			r.declareProject(EcosystemGem, pkg)
+			continue
+		}
+
+		r.addRubyGem(pkg)
+	}
+
+	return scanner.Err()
+}
+
+type composerJson struct {
+	Name       string            `json:"name"`
+	Version    string            `json:"version"`
+	Require    map[string]string `json:"require"`
+	RequireDev map[string]string `json:"require-dev"`
+	Autoload   composerAutoload  `json:"autoload"`
+
+	// Autoload rules of development, used only by the project itself
+	AutoloadDev composerAutoload `json:"autoload-dev"`
+}
+
+// composerAutoload maps namespaces to their source directories. Directories
+// may be a path or a list of paths, only the namespaces are used
+type composerAutoload struct {
+	Psr4 map[string]json.RawMessage `json:"psr-4"`
+	Psr0 map[string]json.RawMessage `json:"psr-0"`
+}
+
+func (a composerAutoload) namespaces() []string {
+	var namespaces []string
+	for _, rules := range []map[string]json.RawMessage{a.Psr4, a.Psr0} {
+		for namespace := range rules {
+			// PSR-0 prefixes may end with an underscore eg. Twig_ and the
+			// empty namespace is a fallback directory for any namespace
+			if namespace = strings.TrimSuffix(strings.Trim(namespace, "\\"), "_"); namespace != "" {
+				namespaces = append(namespaces, namespace)
+			}
+		}
+	}
+
+	return namespaces
+}
+
+// Platform requirements of composer which are not packages eg. php, ext-json
+func isComposerPlatformPackage(name string) bool {
+	name = normalizeComposerPackageName(name)
+	return name == "php" || name == "php-64bit" || name == "hhvm" || name == "composer" ||
+		name == "composer-plugin-api" || name == "composer-runtime-api" ||
+		strings.HasPrefix(name, "ext-") || strings.HasPrefix(name, "lib-")
+}
+
+// addComposerPackage adds a composer package along with the namespaces it autoloads
+func (r *manifestPackageHintResolver) addComposerPackage(pkg manifestPackage, autoload composerAutoload) {
+	if pkg.name == "" {
+		return
+	}
+
+	addPackage(r.phpPackages, normalizeComposerPackageName(pkg.name), pkg)
+	for _, namespace := range autoload.namespaces() {
+		addPackage(r.phpNamespaces, namespace, pkg)
+	}
+}
+
+// parseComposerJson indexes the declared dependencies and the autoloaded
+// namespaces of a project, or the package itself when it is installed within
+// the vendor directory
+func parseComposerJson(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	var manifest composerJson
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return fmt.Errorf("failed to parse composer.json: %w", err)
+	}
+
+	if strings.Contains(filePath, "vendor/") {
+		r.addComposerPackage(manifestPackage{
+			name:         manifest.Name,
+			version:      manifest.Version,
+			source:       filePath,
+			exactVersion: manifest.Version != "",
+			installed:    true,
+		}, manifest.Autoload)
+
+		return nil
+	}
+
+	project := manifestPackage{name: manifest.Name, version: manifest.Version, source: filePath}
+	r.declareProject(EcosystemPackagist, project)
+
+	// Namespaces of the project are resolved to the project itself so that
+	// their usage is not reported as usage of a dependency
+	for _, autoload := range []composerAutoload{manifest.Autoload, manifest.AutoloadDev} {
+		for _, namespace := range autoload.namespaces() {
+			pkg := project
+			if pkg.name == "" {
+				// Projects without a name are identified by their namespaces
+				pkg = manifestPackage{name: namespace, source: filePath}
+				r.declareProject(EcosystemPackagist, pkg)
+			}
+
+			r.phpNamespaces[namespace] = pkg
+		}
+	}
+
+	for _, requirements := range []map[string]string{manifest.Require, manifest.RequireDev} {
+		for name, version := range requirements {
+			if isComposerPlatformPackage(name) {
+				continue
+			}
+
+			pkg := manifestPackage{name: name, version: version, source: filePath}
+			addPackage(r.phpPackages, normalizeComposerPackageName(name), pkg)
+			r.declare(EcosystemPackagist, pkg)
+		}
+	}
+
+	return nil
+}
+
+type composerLockPackage struct {
+	Name     string           `json:"name"`
+	Version  string           `json:"version"`
+	Autoload composerAutoload `json:"autoload"`
+}
+
+type composerLock struct {
+	Packages    []composerLockPackage `json:"packages"`
+	PackagesDev []composerLockPackage `json:"packages-dev"`
+}
+
+// parseComposerLock indexes the exact versions and the autoloaded namespaces
+// of the packages locked in composer.lock, including the transitive dependencies
+func parseComposerLock(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	var lock composerLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return fmt.Errorf("failed to parse composer.lock: %w", err)
+	}
+
+	for _, packages := range [][]composerLockPackage{lock.Packages, lock.PackagesDev} {
+		for _, locked := range packages {
+			r.addComposerPackage(manifestPackage{
+				name:         locked.Name,
+				version:      locked.Version,
+				source:       filePath,
+				exactVersion: true,
+			}, locked.Autoload)
+		}
+	}
+
+	return nil
+}
+
+// parseComposerInstalledJson indexes the packages installed in the vendor
+// directory. Composer 2 lists them under packages, composer 1 as an array
+func parseComposerInstalledJson(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	var installed struct {
+		Packages []composerLockPackage `json:"packages"`
+	}
+
+	if err := json.Unmarshal(content, &installed); err != nil {
+		if err := json.Unmarshal(content, &installed.Packages); err != nil {
+			return fmt.Errorf("failed to parse installed.json: %w", err)
+		}
+	}
+
+	for _, pkg := range installed.Packages {
+		r.addComposerPackage(manifestPackage{
+			name:         pkg.Name,
+			version:      pkg.Version,
+			source:       filePath,
+			exactVersion: true,
+			installed:    true,
+		}, pkg.Autoload)
+	}
+
+	return nil
+}
+
+type csprojXml struct {
+	PropertyGroups []struct {
+		PackageID     string `xml:"PackageId"`
+		AssemblyName  string `xml:"AssemblyName"`
+		RootNamespace string `xml:"RootNamespace"`
+		Version       string `xml:"Version"`
+	} `xml:"PropertyGroup"`
+	PackageReferences []nugetPackageReference `xml:"ItemGroup>PackageReference"`
+}
+
+// nugetPackageReference is a PackageReference of a project or a PackageVersion
+// of central package management. The version is either an attribute or a child
+type nugetPackageReference struct {
+	Include        string `xml:"Include,attr"`
+	Version        string `xml:"Version,attr"`
+	VersionElement string `xml:"Version"`
+}
+
+func (p nugetPackageReference) version() string {
+	if p.Version != "" {
+		return p.Version
+	}
+
+	return strings.TrimSpace(p.VersionElement)
+}
+
+// parseCsproj indexes the package references of a SDK style project. The
+// project is named after its package id or assembly name, or after the project
+// file otherwise. Its root namespace is resolved to the project itself
+func parseCsproj(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	var project csprojXml
+	if err := xml.Unmarshal(content, &project); err != nil {
+		return fmt.Errorf("failed to parse csproj: %w", err)
+	}
+
+	projectName := strings.TrimSuffix(path.Base(filePath), ".csproj")
+	packageID, assemblyName, rootNamespace, version := "", "", "", ""
+	for _, group := range project.PropertyGroups {
+		packageID = helpers.GetFirstNonEmptyString(packageID, strings.TrimSpace(group.PackageID))
+		assemblyName = helpers.GetFirstNonEmptyString(assemblyName, strings.TrimSpace(group.AssemblyName))
+		rootNamespace = helpers.GetFirstNonEmptyString(rootNamespace, strings.TrimSpace(group.RootNamespace))
+		version = helpers.GetFirstNonEmptyString(version, strings.TrimSpace(group.Version))
+	}
+
+	pkg := manifestPackage{
+		name:    helpers.GetFirstNonEmptyString(packageID, assemblyName, projectName),
+		version: version,
+		source:  filePath,
+	}
+
+	r.declareProject(EcosystemNuGet, pkg)
+	r.csharpProjectNamespaces[helpers.GetFirstNonEmptyString(rootNamespace, assemblyName, projectName)] = pkg
+
+	for _, reference := range project.PackageReferences {
+		if reference.Include == "" {
+			continue
+		}
+
+		dependency := manifestPackage{name: reference.Include, version: reference.version(), source: filePath}
+		addPackage(r.nugetPackages, normalizeNuGetPackageName(dependency.name), dependency)
+		r.declare(EcosystemNuGet, dependency)
+	}
+
+	return nil
+}
+
+// parsePackagesConfig indexes the packages of a project using packages.config
+func parsePackagesConfig(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	var config struct {
+		Packages []struct {
+			ID      string `xml:"id,attr"`
+			Version string `xml:"version,attr"`
+		} `xml:"package"`
+	}
+
+	if err := xml.Unmarshal(content, &config); err != nil {
+		return fmt.Errorf("failed to parse packages.config: %w", err)
+	}
+
+	for _, reference := range config.Packages {
+		if reference.ID == "" {
+			continue
+		}
+
+		pkg := manifestPackage{name: reference.ID, version: reference.Version, source: filePath, exactVersion: true}
+		addPackage(r.nugetPackages, normalizeNuGetPackageName(pkg.name), pkg)
+		r.declare(EcosystemNuGet, pkg)
+	}
+
+	return nil
+}
+
+// parseDirectoryPackagesProps indexes the versions of central package
+// management. They only pin versions of packages referenced by the projects,
+// hence they are used for resolving the package hint but are not declarations
+func parseDirectoryPackagesProps(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	var props struct {
+		PackageVersions []nugetPackageReference `xml:"ItemGroup>PackageVersion"`
+	}
+
+	if err := xml.Unmarshal(content, &props); err != nil {
+		return fmt.Errorf("failed to parse Directory.Packages.props: %w", err)
+	}
+
+	for _, reference := range props.PackageVersions {
+		if reference.Include == "" {
+			continue
+		}
+
+		addPackage(r.nugetPackages, normalizeNuGetPackageName(reference.Include), manifestPackage{
+			name:         reference.Include,
+			version:      reference.version(),
+			source:       filePath,
+			exactVersion: true,
+		})
+	}
+
+	return nil
+}
+
+// parseNuGetPackagesLockJson indexes the resolved versions of the packages
+// locked for each target framework, including the transitive dependencies
+func parseNuGetPackagesLockJson(r *manifestPackageHintResolver, filePath string, content []byte) error {
+	var lock struct {
+		Dependencies map[string]map[string]struct {
+			Type     string `json:"type"`
+			Resolved string `json:"resolved"`
+		} `json:"dependencies"`
+	}
+
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return fmt.Errorf("failed to parse packages.lock.json: %w", err)
+	}
+
+	for _, packages := range lock.Dependencies {
+		for name, locked := range packages {
+			// Project references are not packages
+			if locked.Type == "Project" {
+				continue
+			}
+
+			addPackage(r.nugetPackages, normalizeNuGetPackageName(name), manifestPackage{
+				name:         name,
+				version:      locked.Resolved,
+				source:       filePath,
+				exactVersion: true,
+			})
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package depsusage
 
 import (
 	"fmt"
+	"path"
 	"regexp"
 	"strings"
 
@@ -15,6 +16,65 @@ func init() {
 	goModuleQualifierVersionSuffixRegexp = regexp.MustCompile(`v\d+$`)
 }
 
+// PackageHintConfidence is the confidence of a package hint being
+// the actual package which provides an imported module
+type PackageHintConfidence string
+
+const (
+	// Resolved from installed package metadata or from a manifest
+	// of an ecosystem where the module name identifies the package
+	PackageHintConfidenceHigh PackageHintConfidence = "high"
+
+	// Matched with a dependency declared in a manifest by name or namespace
+	PackageHintConfidenceMedium PackageHintConfidence = "medium"
+
+	// Guessed from the module name
+	PackageHintConfidenceLow PackageHintConfidence = "low"
+)
+
+// PackageHint is a hint of the package providing an imported module
+type PackageHint struct {
+	// Name of the package eg. PyYAML, lodash, com.google.guava:guava
+	Name string
+
+	// Version of the package, when known. This is either the exact version
+	// from a lockfile or installed metadata, or the declared constraint
+	Version string
+
+	Confidence PackageHintConfidence
+
+	// Manifest or metadata file from which the hint was resolved.
+	// Empty when the hint is guessed from the module name
+	Source string
+}
+
+// PackageHintResolver resolves the package providing an imported module
+type PackageHintResolver interface {
+	ResolvePackageHint(moduleName string, lang core.Language) (PackageHint, error)
+}
+
+type moduleNamePackageHintResolver struct{}
+
+var _ PackageHintResolver = (*moduleNamePackageHintResolver)(nil)
+
+// NewModuleNamePackageHintResolver creates a resolver which guesses
+// the package from the module name without any external source
+func NewModuleNamePackageHintResolver() *moduleNamePackageHintResolver {
+	return &moduleNamePackageHintResolver{}
+}
+
+func (r *moduleNamePackageHintResolver) ResolvePackageHint(moduleName string, lang core.Language) (PackageHint, error) {
+	packageHint, err := resolvePackageHint(moduleName, lang)
+	if err != nil {
+		return PackageHint{}, err
+	}
+
+	return PackageHint{
+		Name:       packageHint,
+		Confidence: PackageHintConfidenceLow,
+	}, nil
+}
+
 // resolvePackageHint returns the package name hint for an imported module
 //
 // eg. for a python module "foo.bar" it should return "foo"
@@ -24,6 +84,14 @@ func resolvePackageHint(moduleName string, lang core.Language) (string, error) {
 		core.LanguageCodeGo:         resolveGoPackageHint,
 		core.LanguageCodeJavascript: resolveJavascriptPackageHint,
 		core.LanguageCodeJava:       resolveJavaPackageHint,
+		core.LanguageCodeTypescript: resolveJavascriptPackageHint,
+		core.LanguageCodeRust:       resolveRustPackageHint,
+		core.LanguageCodeRuby:       resolveRubyPackageHint,
+		core.LanguageCodePhp:        resolvePhpPackageHint,
+		core.LanguageCodeCsharp:     resolveCsharpPackageHint,
+		core.LanguageCodeKotlin:     resolveJavaPackageHint,
+		core.LanguageCodeC:          resolveCPackageHint,
+		core.LanguageCodeCpp:        resolveCPackageHint,
 	}
 	if resolver, ok := resolvers[lang.Meta().Code]; ok {
 		return resolver(moduleName)
@@ -129,3 +197,191 @@ func resolveJavaPackageHint(moduleName string) (string, error) {
 	// For other packages, we can't determine a hint deterministically without known external sources
 	return "", fmt.Errorf("unable to resolve package hint for module: %s", moduleName)
 }
+
+// resolveRustPackageHint returns the crate of a path
+// eg. serde_json::value -> serde_json, ::tokio::sync -> tokio
+func resolveRustPackageHint(moduleName string) (string, error) {
+	crate, _, _ := strings.Cut(strings.TrimPrefix(moduleName, "::"), "::")
+	crate = strings.TrimSpace(crate)
+	if crate == "" {
+		return "", fmt.Errorf("invalid module name: %s", moduleName)
+	}
+
+	return crate, nil
+}
+
+// Well known paths required from gems of a different name
+// eg. require 'active_support/all' loads the activesupport gem
+var rubyRequireGems = map[string]string{
+	"active_support":      "activesupport",
+	"active_record":       "activerecord",
+	"active_model":        "activemodel",
+	"active_job":          "activejob",
+	"active_storage":      "activestorage",
+	"action_controller":   "actionpack",
+	"action_dispatch":     "actionpack",
+	"abstract_controller": "actionpack",
+	"action_view":         "actionview",
+	"action_mailer":       "actionmailer",
+	"action_cable":        "actioncable",
+	"action_text":         "actiontext",
+	"action_mailbox":      "actionmailbox",
+	"concurrent":          "concurrent-ruby",
+}
+
+// resolveRubyPackageHint returns the gem likely providing a required path.
+// Gems are required by their name eg. sidekiq/web -> sidekiq, relative
+// paths are returned as is
+func resolveRubyPackageHint(moduleName string) (string, error) {
+	requirePath := strings.TrimSuffix(strings.TrimSpace(moduleName), ".rb")
+	if requirePath == "" {
+		return "", fmt.Errorf("invalid module name: %s", moduleName)
+	}
+
+	if strings.HasPrefix(requirePath, ".") || strings.HasPrefix(requirePath, "/") {
+		return requirePath, nil
+	}
+
+	topLevel, _, _ := strings.Cut(requirePath, "/")
+	if gem, exists := rubyRequireGems[topLevel]; exists {
+		return gem, nil
+	}
+
+	return topLevel, nil
+}
+
+// Well known namespaces provided by composer packages which do not
+// follow the Vendor\Package convention eg. GuzzleHttp -> guzzlehttp/guzzle
+var phpNamespacePackages = map[string]string{
+	"GuzzleHttp":                "guzzlehttp/guzzle",
+	"GuzzleHttp\\Psr7":          "guzzlehttp/psr7",
+	"Illuminate":                "laravel/framework",
+	"Carbon":                    "nesbot/carbon",
+	"Monolog":                   "monolog/monolog",
+	"PHPUnit":                   "phpunit/phpunit",
+	"Twig":                      "twig/twig",
+	"Dotenv":                    "vlucas/phpdotenv",
+	"Firebase\\JWT":             "firebase/php-jwt",
+	"PhpParser":                 "nikic/php-parser",
+	"Aws":                       "aws/aws-sdk-php",
+	"Predis":                    "predis/predis",
+	"Faker":                     "fakerphp/faker",
+	"Mockery":                   "mockery/mockery",
+	"Doctrine\\ORM":             "doctrine/orm",
+	"Doctrine\\DBAL":            "doctrine/dbal",
+	"PhpOffice\\PhpSpreadsheet": "phpoffice/phpspreadsheet",
+}
+
+var phpCamelCaseBoundaryRegexp = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// resolvePhpPackageHint returns the composer package likely providing a
+// namespace. Packages are named after the first two segments of their
+// namespace by convention eg. Acme\HttpUtils -> acme/http-utils, and components of
+// Symfony are named after the component eg. Symfony\Component\HttpClient ->
+// symfony/http-client. Paths of included files are returned as is
+func resolvePhpPackageHint(moduleName string) (string, error) {
+	namespace := strings.Trim(strings.TrimSpace(moduleName), "\\")
+	if namespace == "" {
+		return "", fmt.Errorf("invalid module name: %s", moduleName)
+	}
+
+	if strings.ContainsAny(namespace, "/.") {
+		return namespace, nil
+	}
+
+	segments := strings.Split(namespace, "\\")
+	for i := len(segments); i > 0; i-- {
+		if pkg, exists := phpNamespacePackages[strings.Join(segments[:i], "\\")]; exists {
+			return pkg, nil
+		}
+	}
+
+	if len(segments) > 2 && segments[0] == "Symfony" && segments[1] == "Component" {
+		return "symfony/" + phpKebabCase(segments[2]), nil
+	}
+
+	if len(segments) == 1 {
+		return strings.ToLower(namespace), nil
+	}
+
+	return strings.ToLower(segments[0]) + "/" + phpKebabCase(segments[1]), nil
+}
+
+func phpKebabCase(name string) string {
+	return strings.ToLower(phpCamelCaseBoundaryRegexp.ReplaceAllString(name, "${1}-${2}"))
+}
+
+// Well known namespaces provided by NuGet packages which are
+// not named after the namespace eg. Amazon.S3 -> AWSSDK.S3
+var csharpNamespacePackages = map[string]string{
+	"Amazon":                 "AWSSDK.Core",
+	"Amazon.S3":              "AWSSDK.S3",
+	"Amazon.DynamoDBv2":      "AWSSDK.DynamoDBv2",
+	"Amazon.SQS":             "AWSSDK.SQS",
+	"Amazon.Lambda.Core":     "Amazon.Lambda.Core",
+	"Xunit":                  "xunit",
+	"NUnit.Framework":        "NUnit",
+	"Moq":                    "Moq",
+	"Serilog":                "Serilog",
+	"Dapper":                 "Dapper",
+	"AutoMapper":             "AutoMapper",
+	"MediatR":                "MediatR",
+	"Polly":                  "Polly",
+	"FluentValidation":       "FluentValidation",
+	"FluentAssertions":       "FluentAssertions",
+	"StackExchange.Redis":    "StackExchange.Redis",
+	"Npgsql":                 "Npgsql",
+	"MongoDB.Driver":         "MongoDB.Driver",
+	"MongoDB.Bson":           "MongoDB.Bson",
+	"RestSharp":              "RestSharp",
+	"Swashbuckle.AspNetCore": "Swashbuckle.AspNetCore",
+	"YamlDotNet":             "YamlDotNet",
+}
+
+// resolveCsharpPackageHint returns the NuGet package likely providing a
+// namespace. Packages are named after the first two segments of their namespace
+// by convention eg. Newtonsoft.Json.Linq -> Newtonsoft.Json, and extensions of
+// Microsoft are named after the extension eg. Microsoft.Extensions.Logging.Abstractions
+// -> Microsoft.Extensions.Logging
+func resolveCsharpPackageHint(moduleName string) (string, error) {
+	namespace := strings.TrimPrefix(strings.TrimSpace(moduleName), "global::")
+	if namespace == "" {
+		return "", fmt.Errorf("invalid module name: %s", moduleName)
+	}
+
+	segments := strings.Split(namespace, ".")
+	for i := len(segments); i > 0; i-- {
+		if pkg, exists := csharpNamespacePackages[strings.Join(segments[:i], ".")]; exists {
+			return pkg, nil
+		}
+	}
+
+	if len(segments) > 2 && segments[0] == "Microsoft" && segments[1] == "Extensions" {
+		return strings.Join(segments[:3], "."), nil
+	}
+
+	return strings.Join(segments[:min(len(segments), 2)], "."), nil
+}
+
+// resolveCPackageHint returns the library of an included header which is
+// the top level directory or the header name without extension
+// eg. openssl/evp.h -> openssl, zlib.h -> zlib
+func resolveCPackageHint(moduleName string) (string, error) {
+	headerPath := strings.TrimSpace(moduleName)
+	if headerPath == "" {
+		return "", fmt.Errorf("invalid module name: %s", moduleName)
+	}
+
+	// Local headers are part of the project
+	if strings.HasPrefix(headerPath, ".") || strings.HasPrefix(headerPath, "/") {
+		return headerPath, nil
+	}
+
+	topLevel, _, _ := strings.Cut(headerPath, "/")
+	topLevel = strings.TrimSuffix(topLevel, path.Ext(topLevel))
+	if topLevel == "" {
+		return "", fmt.Errorf("unable to resolve package hint for module: %s", moduleName)
+	}
+
+	return topLevel, nil
+}
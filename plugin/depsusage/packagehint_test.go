@@ -73,6 +73,53 @@ func TestResolvePackageHint(t *testing.T) {
 				{"org.springframework.ai.chat.client.ChatClient", "", true},
 				{"lombok.extern.slf4j.Slf4j", "", true},
 			},
+			core.LanguageCodeRust: {
+				{"serde", "serde", false},
+				{"serde_json::value", "serde_json", false},
+				{"tokio::sync::mpsc", "tokio", false},
+				{"::reqwest", "reqwest", false},
+				{"crate::config", "crate", false},
+				{"::", "", true},
+			},
+			core.LanguageCodeRuby: {
+				{"sidekiq", "sidekiq", false},
+				{"sidekiq/web", "sidekiq", false},
+				{"active_support/core_ext/string", "activesupport", false},
+				{"tasks/setup.rb", "tasks", false},
+				{"./helpers/formatter", "./helpers/formatter", false},
+				{"../lib/version", "../lib/version", false},
+			},
+			core.LanguageCodePhp: {
+				{"GuzzleHttp", "guzzlehttp/guzzle", false},
+				{"Illuminate\\Support\\Facades", "laravel/framework", false},
+				{"Symfony\\Component\\HttpClient", "symfony/http-client", false},
+				{"Ramsey\\Uuid", "ramsey/uuid", false},
+				{"\\League\\Flysystem\\Local", "league/flysystem", false},
+				{"Acme\\HttpUtils", "acme/http-utils", false},
+				{"Exception", "exception", false},
+				{"vendor/autoload.php", "vendor/autoload.php", false},
+			},
+			core.LanguageCodeCsharp: {
+				{"Newtonsoft.Json", "Newtonsoft.Json", false},
+				{"Newtonsoft.Json.Linq", "Newtonsoft.Json", false},
+				{"Microsoft.Extensions.Logging.Abstractions", "Microsoft.Extensions.Logging", false},
+				{"Amazon.S3.Model", "AWSSDK.S3", false},
+				{"Xunit", "xunit", false},
+				{"global::Serilog.Events", "Serilog", false},
+				{"Acme", "Acme", false},
+			},
+			core.LanguageCodeC: {
+				{"stdio.h", "stdio", false},
+				{"openssl/evp.h", "openssl", false},
+				{"curl/curl.h", "curl", false},
+				{"./util.h", "./util.h", false},
+				{"../include/config.h", "../include/config.h", false},
+			},
+			core.LanguageCodeCpp: {
+				{"vector", "vector", false},
+				{"boost/asio.hpp", "boost", false},
+				{"./crypto.hpp", "./crypto.hpp", false},
+			},
 		}
 
 		for langCode, tests := range languageWiseTests {
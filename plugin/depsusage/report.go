@@ -0,0 +1,425 @@
+package depsusage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/lang"
+	"github.com/safedep/code/pkg/helpers"
+)
+
+// DependencyReportEvidence summarizes the usage of a package in a file
+type DependencyReportEvidence struct {
+	FilePath string `json:"file_path"`
+	Count    int    `json:"count"`
+	Lines    []uint `json:"lines"`
+}
+
+// DependencyReportEntry is a finding about a package of the project
+type DependencyReportEntry struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+	Version   string `json:"version,omitempty"`
+
+	// Whether the package is declared in a manifest of the project
+	Declared bool `json:"declared"`
+
+	// Manifests declaring the package
+	DeclaredIn []string `json:"declared_in,omitempty"`
+
+	// Confidence of the finding
+	Confidence PackageHintConfidence `json:"confidence"`
+
+	// Number of usage evidences of the package and of files containing them
+	EvidenceCount int `json:"evidence_count"`
+	FileCount     int `json:"file_count"`
+
+	Evidences []DependencyReportEvidence `json:"evidences"`
+}
+
+// DependencyReport joins the usage evidences of a project with the packages
+// declared in its manifests
+type DependencyReport struct {
+	// Packages declared in a manifest but never used
+	Unused []DependencyReportEntry `json:"unused"`
+
+	// Packages used but not declared in any manifest (phantom dependencies)
+	Undeclared []DependencyReportEntry `json:"undeclared"`
+
+	// Packages used only through wildcard imports. Wildcard usage evidences
+	// are reported for every wildcard import, hence these are low confidence.
+	// Packages not declared are reported as undeclared as well.
+	WildcardOnly []DependencyReportEntry `json:"wildcard_only"`
+
+	// Files importing modules by a name computed at runtime eg.
+	// `require(process.env.PLUGIN)`, the packages of which are unknown.
+	// These are commonly used by plugin loaders, but also by obfuscated code.
+	UnresolvableImports []DependencyReportEvidence `json:"unresolvable_imports"`
+}
+
+// Write writes the report as JSON
+func (r *DependencyReport) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode dependency report: %w", err)
+	}
+
+	return nil
+}
+
+type DependencyReportConfig struct {
+	// Packages declared in the manifests of the project
+	DeclaredPackages []DeclaredPackage
+
+	// Packages defined by the project itself, their usage is not reported
+	ProjectPackages []DeclaredPackage
+}
+
+// DependencyReportBuilder collects the usage evidences of a project and builds
+// a DependencyReport. It is safe for concurrent use, hence it can be used with
+// the parallel plugin executor.
+type DependencyReportBuilder struct {
+	config DependencyReportConfig
+
+	m         sync.Mutex
+	evidences []*UsageEvidence
+}
+
+// NewDependencyReportBuilder creates a builder for the packages declared
+// in the manifests found by the resolver. Use NewDependencyReportBuilderWithConfig
+// when the declared packages are known from elsewhere.
+func NewDependencyReportBuilder(resolver *manifestPackageHintResolver) *DependencyReportBuilder {
+	return NewDependencyReportBuilderWithConfig(DependencyReportConfig{
+		DeclaredPackages: resolver.DeclaredPackages(),
+		ProjectPackages:  resolver.ProjectPackages(),
+	})
+}
+
+func NewDependencyReportBuilderWithConfig(config DependencyReportConfig) *DependencyReportBuilder {
+	return &DependencyReportBuilder{
+		config: config,
+	}
+}
+
+// Add adds a usage evidence to the report
+func (b *DependencyReportBuilder) Add(evidence *UsageEvidence) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.evidences = append(b.evidences, evidence)
+}
+
+// Callback returns a DependencyUsageCallback which adds every usage
+// evidence found by the depsusage plugin to the report
+func (b *DependencyReportBuilder) Callback() DependencyUsageCallback {
+	return func(_ context.Context, evidence *UsageEvidence) error {
+		b.Add(evidence)
+		return nil
+	}
+}
+
+// dependencyReportKey identifies a package within its ecosystem
+type dependencyReportKey struct {
+	ecosystem string
+	name      string
+}
+
+func newDependencyReportKey(ecosystem string, name string) dependencyReportKey {
+	switch ecosystem {
+	case EcosystemPyPI:
+		name = normalizePythonPackageName(name)
+	case EcosystemCargo:
+		name = normalizeRustCrateName(name)
+	case EcosystemPackagist:
+		name = normalizeComposerPackageName(name)
+	case EcosystemNuGet:
+		name = normalizeNuGetPackageName(name)
+	}
+
+	return dependencyReportKey{ecosystem: ecosystem, name: name}
+}
+
+// dependencyReportPackage accumulates the declarations and usages of a package
+type dependencyReportPackage struct {
+	entry     DependencyReportEntry
+	files     map[string]*DependencyReportEvidence
+	wildcards int
+}
+
+// Build joins the collected usage evidences with the declared packages.
+// Usage of standard libraries, builtin modules, relative imports and
+// packages of the project itself is not reported.
+func (b *DependencyReportBuilder) Build() (*DependencyReport, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	projectPackages := make(map[dependencyReportKey]bool)
+	for _, pkg := range b.config.ProjectPackages {
+		projectPackages[newDependencyReportKey(pkg.Ecosystem, pkg.Name)] = true
+	}
+
+	packages := make(map[dependencyReportKey]*dependencyReportPackage)
+	packageFor := func(key dependencyReportKey, name string) *dependencyReportPackage {
+		pkg, exists := packages[key]
+		if !exists {
+			pkg = &dependencyReportPackage{
+				entry: DependencyReportEntry{Name: name, Ecosystem: key.ecosystem},
+				files: make(map[string]*DependencyReportEvidence),
+			}
+
+			packages[key] = pkg
+		}
+
+		return pkg
+	}
+
+	for _, declared := range b.config.DeclaredPackages {
+		key := newDependencyReportKey(declared.Ecosystem, declared.Name)
+		if projectPackages[key] {
+			continue
+		}
+
+		pkg := packageFor(key, declared.Name)
+		pkg.entry.Declared = true
+		pkg.entry.Version = helpers.GetFirstNonEmptyString(pkg.entry.Version, declared.Version)
+		pkg.entry.DeclaredIn = appendUnique(pkg.entry.DeclaredIn, declared.Source)
+	}
+
+	unresolvableImports := make(map[string]*DependencyReportEvidence)
+	for _, evidence := range b.evidences {
+		if evidence.IsUnresolvableImport {
+			file, exists := unresolvableImports[evidence.FilePath]
+			if !exists {
+				file = &DependencyReportEvidence{FilePath: evidence.FilePath}
+				unresolvableImports[evidence.FilePath] = file
+			}
+
+			file.Count++
+			file.Lines = append(file.Lines, evidence.Line)
+			continue
+		}
+
+		ecosystem, ok := evidenceEcosystem(evidence)
+		if !ok || isBuiltinModule(ecosystem, evidence.ModuleName) {
+			continue
+		}
+
+		name := helpers.GetFirstNonEmptyString(evidence.PackageHint, evidence.ModuleName)
+		key := newDependencyReportKey(ecosystem, name)
+		if projectPackages[key] {
+			continue
+		}
+
+		pkg := packageFor(key, name)
+		pkg.entry.EvidenceCount++
+		if evidence.IsWildCardUsage {
+			pkg.wildcards++
+		}
+
+		if pkg.entry.Confidence == "" || confidenceRank(evidence.PackageHintConfidence) > confidenceRank(pkg.entry.Confidence) {
+			pkg.entry.Confidence = evidence.PackageHintConfidence
+		}
+
+		if pkg.entry.Version == "" {
+			pkg.entry.Version = evidence.PackageVersion
+		}
+
+		file, exists := pkg.files[evidence.FilePath]
+		if !exists {
+			file = &DependencyReportEvidence{FilePath: evidence.FilePath}
+			pkg.files[evidence.FilePath] = file
+		}
+
+		file.Count++
+		file.Lines = append(file.Lines, evidence.Line)
+	}
+
+	report := &DependencyReport{
+		Unused:              []DependencyReportEntry{},
+		Undeclared:          []DependencyReportEntry{},
+		WildcardOnly:        []DependencyReportEntry{},
+		UnresolvableImports: []DependencyReportEvidence{},
+	}
+
+	for _, file := range unresolvableImports {
+		sort.Slice(file.Lines, func(i, j int) bool { return file.Lines[i] < file.Lines[j] })
+		report.UnresolvableImports = append(report.UnresolvableImports, *file)
+	}
+
+	sort.Slice(report.UnresolvableImports, func(i, j int) bool {
+		return report.UnresolvableImports[i].FilePath < report.UnresolvableImports[j].FilePath
+	})
+
+	for _, pkg := range packages {
+		entry := pkg.entry
+		entry.FileCount = len(pkg.files)
+		entry.Evidences = []DependencyReportEvidence{}
+
+		for _, file := range pkg.files {
+			sort.Slice(file.Lines, func(i, j int) bool { return file.Lines[i] < file.Lines[j] })
+			entry.Evidences = append(entry.Evidences, *file)
+		}
+
+		sort.Slice(entry.Evidences, func(i, j int) bool {
+			return entry.Evidences[i].FilePath < entry.Evidences[j].FilePath
+		})
+
+		if entry.EvidenceCount == 0 {
+			entry.Confidence = PackageHintConfidenceHigh
+			report.Unused = append(report.Unused, entry)
+			continue
+		}
+
+		// Importing a package not declared is a phantom dependency even
+		// when it is used only through wildcard imports
+		if !entry.Declared {
+			report.Undeclared = append(report.Undeclared, entry)
+		}
+
+		if pkg.wildcards == entry.EvidenceCount {
+			entry.Confidence = PackageHintConfidenceLow
+			report.WildcardOnly = append(report.WildcardOnly, entry)
+		}
+	}
+
+	for _, entries := range [][]DependencyReportEntry{report.Unused, report.Undeclared, report.WildcardOnly} {
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i].Ecosystem != entries[j].Ecosystem {
+				return entries[i].Ecosystem < entries[j].Ecosystem
+			}
+
+			return entries[i].Name < entries[j].Name
+		})
+	}
+
+	return report, nil
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
+
+func confidenceRank(confidence PackageHintConfidence) int {
+	switch confidence {
+	case PackageHintConfidenceHigh:
+		return 3
+	case PackageHintConfidenceMedium:
+		return 2
+	case PackageHintConfidenceLow:
+		return 1
+	default:
+		return 0
+	}
+}
+
+var languageEcosystems = map[core.LanguageCode]string{
+	core.LanguageCodePython:     EcosystemPyPI,
+	core.LanguageCodeJavascript: EcosystemNpm,
+	core.LanguageCodeTypescript: EcosystemNpm,
+	core.LanguageCodeGo:         EcosystemGo,
+	core.LanguageCodeJava:       EcosystemMaven,
+	core.LanguageCodeRust:       EcosystemCargo,
+	core.LanguageCodeRuby:       EcosystemGem,
+	core.LanguageCodePhp:        EcosystemPackagist,
+	core.LanguageCodeCsharp:     EcosystemNuGet,
+	core.LanguageCodeKotlin:     EcosystemMaven,
+}
+
+// evidenceEcosystem returns the package ecosystem of the file containing the evidence
+func evidenceEcosystem(evidence *UsageEvidence) (string, bool) {
+	language, ok := lang.ResolveLanguageFromPath(evidence.FilePath)
+	if !ok {
+		return "", false
+	}
+
+	ecosystem, ok := languageEcosystems[language.Meta().Code]
+	return ecosystem, ok
+}
+
+// Java packages provided by the JDK along with the Kotlin standard
+// library, which the Kotlin compiler adds to every module
+var javaBuiltinPackagePrefixes = []string{"java.", "javax.", "jdk.", "sun.", "com.sun.", "kotlin."}
+
+// .NET namespaces provided by the base class library and the runtime
+var csharpBuiltinNamespaces = []string{"System", "Microsoft.CSharp", "Microsoft.VisualBasic", "Microsoft.Win32"}
+
+// Rust crates provided by the toolchain along with the
+// path keywords referring to the modules of the crate itself
+var rustBuiltinCrates = map[string]bool{
+	"std":        true,
+	"core":       true,
+	"alloc":      true,
+	"proc_macro": true,
+	"test":       true,
+	"crate":      true,
+	"self":       true,
+	"super":      true,
+	"Self":       true,
+}
+
+// isBuiltinModule checks if a module is provided by the standard library
+// or the runtime, or is a relative import of a module of the project
+func isBuiltinModule(ecosystem string, moduleName string) bool {
+	switch ecosystem {
+	case EcosystemPyPI:
+		topLevel, _, _ := strings.Cut(moduleName, ".")
+		return topLevel == "" || helpers.PythonStdLibs[topLevel]
+	case EcosystemNpm:
+		if strings.HasPrefix(moduleName, ".") || strings.HasPrefix(moduleName, "/") ||
+			strings.HasPrefix(moduleName, "node:") {
+			return true
+		}
+
+		topLevel, _, _ := strings.Cut(moduleName, "/")
+		return helpers.NodeBuiltinModules[topLevel]
+	case EcosystemGo:
+		return helpers.GoStdLibs[moduleName]
+	case EcosystemMaven:
+		for _, prefix := range javaBuiltinPackagePrefixes {
+			if strings.HasPrefix(moduleName, prefix) {
+				return true
+			}
+		}
+	case EcosystemCargo:
+		crate, _, _ := strings.Cut(strings.TrimPrefix(moduleName, "::"), "::")
+		return rustBuiltinCrates[crate]
+	case EcosystemGem:
+		if strings.HasPrefix(moduleName, ".") || strings.HasPrefix(moduleName, "/") {
+			return true
+		}
+
+		topLevel, _, _ := strings.Cut(strings.TrimSuffix(moduleName, ".rb"), "/")
+		return helpers.RubyStdLibs[topLevel]
+	case EcosystemPackagist:
+		// Included files are part of the project
+		if strings.ContainsAny(moduleName, "/.") {
+			return true
+		}
+
+		topLevel, _, _ := strings.Cut(strings.TrimPrefix(moduleName, "\\"), "\\")
+		return helpers.PhpBuiltinNamespaces[topLevel]
+	case EcosystemNuGet:
+		namespace := strings.TrimPrefix(moduleName, "global::")
+		for _, builtin := range csharpBuiltinNamespaces {
+			if namespace == builtin || strings.HasPrefix(namespace, builtin+".") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
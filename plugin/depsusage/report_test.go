@@ -0,0 +1,165 @@
+package depsusage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/fs"
+	"github.com/safedep/code/pkg/test"
+	"github.com/safedep/code/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func findReportEntry(entries []DependencyReportEntry, name string) (DependencyReportEntry, bool) {
+	for _, entry := range entries {
+		if entry.Name == name {
+			return entry, true
+		}
+	}
+
+	return DependencyReportEntry{}, false
+}
+
+func reportEntryNames(entries []DependencyReportEntry) []string {
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+
+	return names
+}
+
+func TestDependencyReport(t *testing.T) {
+	fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+		AppDirectories: []string{"fixtures/report"},
+	})
+	assert.NoError(t, err)
+
+	resolver, err := NewManifestPackageHintResolver(context.Background(), fileSystem)
+	assert.NoError(t, err)
+
+	treeWalker, sourceFileSystem, err := test.SetupBasicPluginContext([]string{
+		"fixtures/report/app.py",
+		"fixtures/report/index.js",
+	}, []core.LanguageCode{core.LanguageCodePython, core.LanguageCodeJavascript})
+	assert.NoError(t, err)
+
+	builder := NewDependencyReportBuilder(resolver)
+	pluginExecutor, err := plugin.NewTreeWalkPluginExecutor(treeWalker, []core.Plugin{
+		NewDependencyUsagePluginWithConfig(DependencyUsagePluginConfig{
+			PackageHintResolver: resolver,
+		}, builder.Callback()),
+	})
+	assert.NoError(t, err)
+
+	err = pluginExecutor.Execute(context.Background(), sourceFileSystem)
+	assert.NoError(t, err)
+
+	report, err := builder.Build()
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"left-pad", "PyYAML"}, reportEntryNames(report.Unused))
+	assert.Equal(t, []string{"lodash", "numpy"}, reportEntryNames(report.Undeclared))
+	assert.Equal(t, []string{"Flask"}, reportEntryNames(report.WildcardOnly))
+
+	unused, _ := findReportEntry(report.Unused, "PyYAML")
+	assert.Equal(t, EcosystemPyPI, unused.Ecosystem)
+	assert.Equal(t, "6.0.1", unused.Version)
+	assert.Equal(t, []string{"fixtures/report/requirements.txt"}, unused.DeclaredIn)
+	assert.Equal(t, 0, unused.EvidenceCount)
+
+	undeclared, _ := findReportEntry(report.Undeclared, "numpy")
+	assert.False(t, undeclared.Declared)
+	assert.Equal(t, PackageHintConfidenceLow, undeclared.Confidence)
+	assert.Equal(t, 2, undeclared.EvidenceCount)
+	assert.Equal(t, 1, undeclared.FileCount)
+	assert.Equal(t, []DependencyReportEvidence{
+		{FilePath: "fixtures/report/app.py", Count: 2, Lines: []uint{9, 10}},
+	}, undeclared.Evidences)
+
+	wildcard, _ := findReportEntry(report.WildcardOnly, "Flask")
+	assert.True(t, wildcard.Declared)
+	assert.Equal(t, PackageHintConfidenceLow, wildcard.Confidence)
+
+	// Modules imported by a name computed at runtime are not packages
+	assert.Equal(t, []DependencyReportEvidence{
+		{FilePath: "fixtures/report/index.js", Count: 1, Lines: []uint{11}},
+	}, report.UnresolvableImports)
+
+	var buf bytes.Buffer
+	assert.NoError(t, report.Write(&buf))
+
+	var decoded map[string][]map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Len(t, decoded["undeclared"], 2)
+	assert.Equal(t, "numpy", decoded["undeclared"][1]["name"])
+	assert.Equal(t, float64(2), decoded["undeclared"][1]["evidence_count"])
+	assert.Len(t, decoded["unresolvable_imports"], 1)
+}
+
+func TestDependencyReportBuilder(t *testing.T) {
+	builder := NewDependencyReportBuilderWithConfig(DependencyReportConfig{
+		DeclaredPackages: []DeclaredPackage{
+			{Name: "github.com/labstack/echo/v4", Version: "v4.11.1", Ecosystem: EcosystemGo, Source: "go.mod"},
+			{Name: "scikit_learn", Ecosystem: EcosystemPyPI, Source: "requirements.txt"},
+			{Name: "Monolog/Monolog", Ecosystem: EcosystemPackagist, Source: "composer.json"},
+			{Name: "Newtonsoft.Json", Ecosystem: EcosystemNuGet, Source: "App.csproj"},
+		},
+		ProjectPackages: []DeclaredPackage{
+			{Name: "github.com/safedep/app", Ecosystem: EcosystemGo, Source: "go.mod"},
+		},
+	})
+
+	for _, evidence := range []*UsageEvidence{
+		{PackageHint: "github.com/labstack/echo/v4", ModuleName: "github.com/labstack/echo/v4", FilePath: "main.go", Line: 10},
+		{PackageHint: "github.com/safedep/app", ModuleName: "github.com/safedep/app/internal", FilePath: "main.go", Line: 11},
+		{PackageHint: "net", ModuleName: "net/http", FilePath: "main.go", Line: 12},
+		{PackageHint: "scikit-learn", ModuleName: "sklearn", FilePath: "app.py", Line: 3},
+		{PackageHint: "utils", ModuleName: "utils", FilePath: "README.md", Line: 1},
+		{PackageHint: "net", ModuleName: "net/http", FilePath: "worker.rb", Line: 1, IsWildCardUsage: true},
+		{PackageHint: "./lib/formatter", ModuleName: "./lib/formatter", FilePath: "worker.rb", Line: 2, IsWildCardUsage: true},
+		{PackageHint: "exception", ModuleName: "Exception", FilePath: "index.php", Line: 3},
+		{PackageHint: "vendor/autoload.php", ModuleName: "vendor/autoload.php", FilePath: "index.php", Line: 4, IsWildCardUsage: true},
+		{PackageHint: "monolog/monolog", ModuleName: "Monolog", FilePath: "index.php", Line: 5},
+		{PackageHint: "System.Linq", ModuleName: "System.Linq", FilePath: "Program.cs", Line: 1, IsWildCardUsage: true},
+		{PackageHint: "newtonsoft.json", ModuleName: "Newtonsoft.Json", FilePath: "Program.cs", Line: 2},
+	} {
+		builder.Add(evidence)
+	}
+
+	report, err := builder.Build()
+	assert.NoError(t, err)
+
+	assert.Empty(t, report.Unused)
+	assert.Empty(t, report.Undeclared)
+	assert.Empty(t, report.WildcardOnly)
+}
+
+func TestDependencyReportUndeclaredWildcardOnly(t *testing.T) {
+	builder := NewDependencyReportBuilderWithConfig(DependencyReportConfig{
+		DeclaredPackages: []DeclaredPackage{
+			{Name: "Flask", Ecosystem: EcosystemPyPI, Source: "requirements.txt"},
+		},
+	})
+
+	for _, evidence := range []*UsageEvidence{
+		{PackageHint: "flask", ModuleName: "flask", FilePath: "app.py", Line: 1, IsWildCardUsage: true},
+		{PackageHint: "bottle", ModuleName: "bottle", FilePath: "app.py", Line: 2, IsWildCardUsage: true},
+	} {
+		builder.Add(evidence)
+	}
+
+	report, err := builder.Build()
+	assert.NoError(t, err)
+
+	assert.Empty(t, report.Unused)
+	assert.Equal(t, []string{"bottle"}, reportEntryNames(report.Undeclared))
+	assert.Equal(t, []string{"Flask", "bottle"}, reportEntryNames(report.WildcardOnly))
+
+	undeclared, _ := findReportEntry(report.Undeclared, "bottle")
+	assert.False(t, undeclared.Declared)
+	assert.Equal(t, 1, undeclared.EvidenceCount)
+}
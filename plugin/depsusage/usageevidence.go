@@ -2,6 +2,8 @@ package depsusage
 
 import (
 	"fmt"
+
+	"github.com/safedep/code/core"
 )
 
 // identifierItem represents an item from module for usage evidence
@@ -10,10 +12,13 @@ type identifierItem struct {
 	Item        string
 	Alias       string
 	Identifier  string
-	PackageHint string
+	PackageHint PackageHint
+
+	// Whether the module is imported at runtime eg. importlib.import_module
+	IsDynamicImport bool
 }
 
-func newIdentifierItem(module string, item string, alias string, identifier string, packageHint string) *identifierItem {
+func newIdentifierItem(module string, item string, alias string, identifier string, packageHint PackageHint) *identifierItem {
 	return &identifierItem{
 		Module:      module,
 		Item:        item,
@@ -31,6 +36,15 @@ func (i *identifierItem) String() string {
 type UsageEvidence struct {
 	PackageHint string // PackageHint: A hint of what could be the package containing this module
 
+	// Version of the hinted package when resolved from a manifest or installed metadata
+	PackageVersion string
+
+	// Confidence of the hinted package being the one containing this module
+	PackageHintConfidence PackageHintConfidence
+
+	// Manifest or metadata file from which the package hint was resolved
+	PackageHintSource string
+
 	// ModuleName: The module name taken directly from the ImportNode
 	ModuleName string
 
@@ -43,6 +57,17 @@ type UsageEvidence struct {
 	// Whether the usage is a wildcard usage
 	IsWildCardUsage bool
 
+	// Whether the module is imported at runtime by a function call eg.
+	// importlib.import_module in Python, the module name may be partially
+	// resolved eg. plugins.* when computed at runtime
+	IsDynamicImport bool
+
+	// Whether the module is imported at runtime by a name which can not be
+	// resolved statically eg. `require(process.env.PLUGIN)`. No package is
+	// hinted, the ModuleName is the pattern of the name if any eg. ./locale/*.js
+	// and the Identifier is the expression computing the name
+	IsUnresolvableImport bool
+
 	// The identifier which led to this usage evidence
 	Identifier string
 
@@ -51,24 +76,42 @@ type UsageEvidence struct {
 
 	// Line number where the usage was found
 	Line uint
+
+	// Position of the usage in the cells of a notebook eg. a Jupyter
+	// notebook, where Line is the line in the source of its code cells.
+	// It is nil for files other than notebooks
+	NotebookPosition *core.NotebookPosition
 }
 
-func newUsageEvidence(packageHint string, module string, itemName string, alias string, isWildCardUsage bool, identifier string, filePath string, line uint) *UsageEvidence {
+func newUsageEvidence(packageHint PackageHint, module string, itemName string, alias string, isWildCardUsage bool, identifier string, filePath string, line uint) *UsageEvidence {
 	return &UsageEvidence{
-		PackageHint:     packageHint,
-		ModuleName:      module,
-		ModuleItem:      itemName,
-		ModuleAlias:     alias,
-		IsWildCardUsage: isWildCardUsage,
-		Identifier:      identifier,
-		FilePath:        filePath,
-		Line:            line,
+		PackageHint:           packageHint.Name,
+		PackageVersion:        packageHint.Version,
+		PackageHintConfidence: packageHint.Confidence,
+		PackageHintSource:     packageHint.Source,
+		ModuleName:            module,
+		ModuleItem:            itemName,
+		ModuleAlias:           alias,
+		IsWildCardUsage:       isWildCardUsage,
+		Identifier:            identifier,
+		FilePath:              filePath,
+		Line:                  line,
 	}
 }
 
 func (e *UsageEvidence) String() string {
+	labels := ""
 	if e.IsWildCardUsage {
-		return fmt.Sprintf("UsageEvidence (WildCardUsage) - PackageHint: %s, Module: %s, ModuleItem: %s, Alias: %s, Identifier: %s, FilePath: %s, Line: %d", e.PackageHint, e.ModuleName, e.ModuleItem, e.ModuleAlias, e.Identifier, e.FilePath, e.Line)
+		labels += " (WildCardUsage)"
+	}
+
+	if e.IsDynamicImport {
+		labels += " (DynamicImport)"
 	}
-	return fmt.Sprintf("UsageEvidence - PackageHint: %s, Module: %s, ModuleItem: %s, Alias: %s, Identifier: %s, FilePath: %s, Line: %d", e.PackageHint, e.ModuleName, e.ModuleItem, e.ModuleAlias, e.Identifier, e.FilePath, e.Line)
+
+	if e.IsUnresolvableImport {
+		labels += " (UnresolvableImport)"
+	}
+
+	return fmt.Sprintf("UsageEvidence%s - PackageHint: %s, Module: %s, ModuleItem: %s, Alias: %s, Identifier: %s, FilePath: %s, Line: %d", labels, e.PackageHint, e.ModuleName, e.ModuleItem, e.ModuleAlias, e.Identifier, e.FilePath, e.Line)
 }
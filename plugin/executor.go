@@ -6,7 +6,6 @@ import (
 	"slices"
 
 	"github.com/safedep/code/core"
-	"github.com/safedep/code/lang"
 )
 
 type PluginExecutor interface {
@@ -21,30 +20,54 @@ type treeWalkPluginExecutor struct {
 var _ PluginExecutor = &treeWalkPluginExecutor{}
 
 type treeVisitor struct {
-	plugins []core.Plugin
-	ctx     context.Context
+	plugins        []core.Plugin
+	projectResults *resultStore
+	ctx            context.Context
 }
 
 func (v *treeVisitor) VisitTree(tree core.ParseTree) error {
-	for _, plugin := range v.plugins {
-		file, err := tree.File()
-		if err != nil {
-			return fmt.Errorf("failed to get file from tree: %w", err)
+	return analyzeTree(v.ctx, v.plugins, tree, v.projectResults)
+}
+
+// analyzeTree runs every plugin supporting the language of the tree
+// against it. It is shared by all executors so that they have the same
+// plugin selection and error semantics. Plugins are expected to be
+// ordered such that producers of results run before their consumers.
+func analyzeTree(ctx context.Context, plugins []core.Plugin, tree core.ParseTree, projectResults *resultStore) error {
+	file, err := tree.File()
+	if err != nil {
+		return fmt.Errorf("failed to get file from tree: %w", err)
+	}
+
+	// The language is resolved once by the parser
+	language, err := tree.Language()
+	if err != nil {
+		return fmt.Errorf("failed to get language from tree: %w", err)
+	}
+
+	fileResults := newResultStore()
+	for _, plugin := range plugins {
+		if !slices.Contains(plugin.SupportedLanguages(), language.Meta().Code) {
+			continue
 		}
 
-		language, exists := lang.ResolveLanguageFromPath(file.Name())
-		if !exists || !slices.Contains(plugin.SupportedLanguages(), language.Meta().Code) {
+		if cascadingPlugin, ok := plugin.(core.CascadingPlugin); ok {
+			results := newPluginResults(cascadingPlugin, fileResults, projectResults)
+			if err := cascadingPlugin.AnalyzeResults(ctx, tree, results); err != nil {
+				return fmt.Errorf("failed to analyze results: %w", err)
+			}
+
 			continue
 		}
 
 		if filePlugin, ok := plugin.(core.FilePlugin); ok {
-			if err := filePlugin.AnalyzeSource(v.ctx, file); err != nil {
+			if err := filePlugin.AnalyzeSource(ctx, file); err != nil {
 				return fmt.Errorf("failed to analyze source: %w", err)
 			}
 		}
 
 		if treePlugin, ok := plugin.(core.TreePlugin); ok {
-			if err := treePlugin.AnalyzeTree(v.ctx, tree); err != nil {
+			if err := treePlugin.AnalyzeTree(ctx, tree); err != nil {
 				return fmt.Errorf("failed to analyze tree: %w", err)
 			}
 		}
@@ -55,14 +78,24 @@ func (v *treeVisitor) VisitTree(tree core.ParseTree) error {
 }
 
 // NewTreeWalkPluginExecutor creates a simple plugin executor using a tree walker.
-// It just makes it easy to execute plugins suitable for ParseTree and File
+// It just makes it easy to execute plugins suitable for ParseTree and File.
+// Cascading plugins are ordered such that producers run before consumers.
 func NewTreeWalkPluginExecutor(walker core.TreeWalker, plugins []core.Plugin) (*treeWalkPluginExecutor, error) {
+	orderedPlugins, err := orderPlugins(plugins)
+	if err != nil {
+		return nil, fmt.Errorf("failed to order plugins: %w", err)
+	}
+
 	return &treeWalkPluginExecutor{
 		walker:  walker,
-		plugins: plugins,
+		plugins: orderedPlugins,
 	}, nil
 }
 
 func (e *treeWalkPluginExecutor) Execute(ctx context.Context, fs core.ImportAwareFileSystem) error {
-	return e.walker.Walk(ctx, fs, &treeVisitor{plugins: e.plugins, ctx: ctx})
+	return e.walker.Walk(ctx, fs, &treeVisitor{
+		plugins:        e.plugins,
+		projectResults: newResultStore(),
+		ctx:            ctx,
+	})
 }
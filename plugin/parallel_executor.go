@@ -0,0 +1,312 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/parser"
+)
+
+// errExecutionStopped is used to stop the source walker from
+// dispatching more files once a file has failed
+var errExecutionStopped = errors.New("plugin execution stopped")
+
+// ParallelPluginExecutorConfig configures the parallel plugin executor
+type ParallelPluginExecutorConfig struct {
+	// Number of workers parsing and analyzing files concurrently.
+	// Defaults to the number of CPUs when not set.
+	Workers int
+
+	// Ordered enables delivery of plugin results in the same order in which
+	// files are enumerated by the source walker. Files are still read and parsed
+	// concurrently, but plugins are executed one file at a time in enumeration order.
+	//
+	// When disabled, plugins are executed on the worker goroutines and plugin
+	// callbacks may be invoked concurrently. Callbacks must be safe for
+	// concurrent use in that case.
+	Ordered bool
+}
+
+type parallelPluginExecutor struct {
+	walker    core.SourceWalker
+	languages []core.Language
+	plugins   []core.Plugin
+	config    ParallelPluginExecutorConfig
+}
+
+var _ PluginExecutor = (*parallelPluginExecutor)(nil)
+
+// NewParallelPluginExecutor creates a plugin executor which parses and analyzes
+// files using a bounded pool of workers. Tree-Sitter parsers are not safe for
+// concurrent use, hence every worker owns a parser for the given languages.
+//
+// Error semantics are the same as the tree walk executor. The first file that
+// fails (in enumeration order) stops the execution and its error is returned.
+func NewParallelPluginExecutor(walker core.SourceWalker, languages []core.Language,
+	plugins []core.Plugin, config ParallelPluginExecutorConfig) (*parallelPluginExecutor, error) {
+	if config.Workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", config.Workers)
+	}
+
+	if config.Workers == 0 {
+		config.Workers = runtime.NumCPU()
+	}
+
+	orderedPlugins, err := orderPlugins(plugins)
+	if err != nil {
+		return nil, fmt.Errorf("failed to order plugins: %w", err)
+	}
+
+	return &parallelPluginExecutor{
+		walker:    walker,
+		languages: languages,
+		plugins:   orderedPlugins,
+		config:    config,
+	}, nil
+}
+
+type parallelJob struct {
+	index int
+	file  core.File
+}
+
+type parallelJobResult struct {
+	index int
+	trees []core.ParseTree
+	err   error
+}
+
+func (e *parallelPluginExecutor) Execute(ctx context.Context, fs core.ImportAwareFileSystem) error {
+	parsers := make([]core.Parser, e.config.Workers)
+	for i := range parsers {
+		p, err := parser.NewParser(e.languages)
+		if err != nil {
+			return fmt.Errorf("failed to create parser: %w", err)
+		}
+
+		parsers[i] = p
+	}
+
+	failures := &firstFailure{}
+	projectResults := newResultStore()
+	jobs := make(chan parallelJob, e.config.Workers)
+
+	// In ordered mode, slots bound the number of files which are parsed
+	// but not yet delivered so that a slow file does not cause all
+	// remaining parse trees to be held in memory
+	var slots chan struct{}
+	var results chan parallelJobResult
+	deliveryDone := make(chan struct{})
+
+	if e.config.Ordered {
+		slots = make(chan struct{}, 2*e.config.Workers)
+		results = make(chan parallelJobResult, e.config.Workers)
+
+		go func() {
+			defer close(deliveryDone)
+			e.deliverInOrder(ctx, results, slots, failures, projectResults)
+		}()
+	} else {
+		close(deliveryDone)
+	}
+
+	var wg sync.WaitGroup
+	for _, p := range parsers {
+		wg.Add(1)
+		go func(p core.Parser) {
+			defer wg.Done()
+
+			for job := range jobs {
+				// Files enumerated before a failed file are still analyzed
+				// since they may fail as well and take precedence
+				if failures.skips(job.index) {
+					if results != nil {
+						// Delivery must still advance to release the slot
+						results <- parallelJobResult{index: job.index}
+					}
+
+					continue
+				}
+
+				trees, err := parser.ParseAll(ctx, p, job.file)
+				if err != nil {
+					err = fmt.Errorf("failed to parse file: %w", err)
+				}
+
+				if results != nil {
+					results <- parallelJobResult{index: job.index, trees: trees, err: err}
+					continue
+				}
+
+				if err == nil {
+					err = analyzeTrees(ctx, e.plugins, trees, projectResults)
+				}
+
+				if err != nil {
+					failures.record(job.index, err)
+				}
+			}
+		}(p)
+	}
+
+	walkErr := e.walker.Walk(ctx, fs, &dispatchingVisitor{
+		ctx:      ctx,
+		jobs:     jobs,
+		slots:    slots,
+		failures: failures,
+	})
+
+	close(jobs)
+	wg.Wait()
+
+	if results != nil {
+		close(results)
+	}
+
+	<-deliveryDone
+
+	// A failed file is always enumerated before the point where the walker
+	// stopped, hence it takes precedence over the error returned by the walker
+	if err := failures.error(); err != nil {
+		return err
+	}
+
+	if walkErr != nil && !errors.Is(walkErr, errExecutionStopped) {
+		return walkErr
+	}
+
+	return nil
+}
+
+// deliverInOrder executes plugins on parsed files in the order
+// in which they were dispatched by the source walker
+func (e *parallelPluginExecutor) deliverInOrder(ctx context.Context, results <-chan parallelJobResult,
+	slots <-chan struct{}, failures *firstFailure, projectResults *resultStore) {
+	pending := make(map[int]parallelJobResult)
+	next := 0
+
+	for result := range results {
+		pending[result.index] = result
+
+		for {
+			current, exists := pending[next]
+			if !exists {
+				break
+			}
+
+			delete(pending, next)
+			next++
+
+			// Continue draining after a failure so that the
+			// walker and workers are never blocked on us
+			if !failures.failed() {
+				err := current.err
+				if err == nil {
+					err = analyzeTrees(ctx, e.plugins, current.trees, projectResults)
+				}
+
+				if err != nil {
+					failures.record(current.index, err)
+				}
+			}
+
+			<-slots
+		}
+	}
+}
+
+// analyzeTrees analyzes the trees parsed from a file eg. the scripts
+// embedded in an HTML file, stopping at the first failed tree
+func analyzeTrees(ctx context.Context, plugins []core.Plugin, trees []core.ParseTree, projectResults *resultStore) error {
+	for _, tree := range trees {
+		if err := analyzeTree(ctx, plugins, tree, projectResults); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dispatchingVisitor is a core.SourceVisitor which hands over
+// the files enumerated by the source walker to the workers
+type dispatchingVisitor struct {
+	ctx      context.Context
+	jobs     chan<- parallelJob
+	slots    chan struct{}
+	failures *firstFailure
+	next     int
+}
+
+var _ core.SourceVisitor = (*dispatchingVisitor)(nil)
+
+func (v *dispatchingVisitor) VisitFile(f core.File) error {
+	if v.failures.failed() {
+		return errExecutionStopped
+	}
+
+	if v.slots != nil {
+		select {
+		case v.slots <- struct{}{}:
+		case <-v.ctx.Done():
+			return fmt.Errorf("dispatch cancelled by context: %w", v.ctx.Err())
+		}
+	}
+
+	select {
+	case v.jobs <- parallelJob{index: v.next, file: f}:
+	case <-v.ctx.Done():
+		if v.slots != nil {
+			<-v.slots
+		}
+
+		return fmt.Errorf("dispatch cancelled by context: %w", v.ctx.Err())
+	}
+
+	v.next++
+	return nil
+}
+
+// firstFailure keeps the error of the earliest failed file
+// in enumeration order, which is the error a serial executor
+// would have returned
+type firstFailure struct {
+	m     sync.Mutex
+	index int
+	err   error
+}
+
+func (f *firstFailure) record(index int, err error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	if f.err == nil || index < f.index {
+		f.index = index
+		f.err = err
+	}
+}
+
+// skips checks whether a file is enumerated after the failed file
+func (f *firstFailure) skips(index int) bool {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	return f.err != nil && index > f.index
+}
+
+func (f *firstFailure) failed() bool {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	return f.err != nil
+}
+
+func (f *firstFailure) error() error {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	return f.err
+}
@@ -0,0 +1,245 @@
+package plugin_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/fs"
+	"github.com/safedep/code/lang"
+	"github.com/safedep/code/parser"
+	"github.com/safedep/code/plugin"
+	"github.com/safedep/code/plugin/depsusage"
+	"github.com/stretchr/testify/assert"
+)
+
+var parallelExecutorFixtureDirs = []string{
+	"depsusage/fixtures",
+	"stripcomments/fixtures",
+}
+
+type failingPlugin struct {
+	failOn []string
+}
+
+var _ core.TreePlugin = (*failingPlugin)(nil)
+
+func (p *failingPlugin) Name() string {
+	return "FailingPlugin"
+}
+
+func (p *failingPlugin) SupportedLanguages() []core.LanguageCode {
+	return []core.LanguageCode{core.LanguageCodePython, core.LanguageCodeJavascript,
+		core.LanguageCodeGo, core.LanguageCodeJava}
+}
+
+var errFailingPlugin = errors.New("failing plugin")
+
+func (p *failingPlugin) AnalyzeTree(_ context.Context, tree core.ParseTree) error {
+	file, err := tree.File()
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(p.failOn, file.Name()) {
+		return fmt.Errorf("%w: %s", errFailingPlugin, file.Name())
+	}
+
+	return nil
+}
+
+func setupExecutorContext(t *testing.T) (core.SourceWalker, []core.Language, core.ImportAwareFileSystem) {
+	t.Helper()
+
+	languages, err := lang.AllLanguages()
+	assert.NoError(t, err)
+
+	walker, err := fs.NewSourceWalker(fs.SourceWalkerConfig{}, languages)
+	assert.NoError(t, err)
+
+	fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+		AppDirectories: parallelExecutorFixtureDirs,
+	})
+	assert.NoError(t, err)
+
+	return walker, languages, fileSystem
+}
+
+// setupFailureContext creates the executor context for a dedicated set of
+// files such that failures do not depend on the fixtures of the plugins.
+// Files are returned in the order in which they are walked
+func setupFailureContext(t *testing.T) (core.SourceWalker, []core.Language, core.ImportAwareFileSystem, []string) {
+	t.Helper()
+
+	languages, err := lang.AllLanguages()
+	assert.NoError(t, err)
+
+	walker, err := fs.NewSourceWalker(fs.SourceWalkerConfig{}, languages)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+
+	var fileNames []string
+	for i := range 16 {
+		fileName := filepath.Join(dir, fmt.Sprintf("app_%02d.py", i))
+		assert.NoError(t, os.WriteFile(fileName, []byte("import requests\n\nrequests.get(\"/\")\n"), 0o644))
+
+		fileNames = append(fileNames, fileName)
+	}
+
+	fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+		AppDirectories: []string{dir},
+	})
+	assert.NoError(t, err)
+
+	return walker, languages, fileSystem, fileNames
+}
+
+// executeSerial collects the usage evidences produced by the tree walk executor
+func executeSerial(t *testing.T) []string {
+	t.Helper()
+
+	walker, languages, fileSystem := setupExecutorContext(t)
+
+	treeWalker, err := parser.NewWalkingParser(walker, languages)
+	assert.NoError(t, err)
+
+	var evidences []string
+	executor, err := plugin.NewTreeWalkPluginExecutor(treeWalker, []core.Plugin{
+		depsusage.NewDependencyUsagePlugin(func(_ context.Context, evidence *depsusage.UsageEvidence) error {
+			evidences = append(evidences, evidence.String())
+			return nil
+		}),
+	})
+	assert.NoError(t, err)
+
+	err = executor.Execute(context.Background(), fileSystem)
+	assert.NoError(t, err)
+
+	return evidences
+}
+
+func TestParallelPluginExecutor(t *testing.T) {
+	serialEvidences := executeSerial(t)
+	assert.NotEmpty(t, serialEvidences)
+
+	// Sources embedded in host documents are analyzed as well
+	assert.Condition(t, func() bool {
+		for _, evidence := range serialEvidences {
+			if strings.Contains(evidence, "FilePath: depsusage/fixtures/testcases.html") {
+				return true
+			}
+		}
+
+		return false
+	})
+
+	for _, ordered := range []bool{true, false} {
+		for _, workers := range []int{1, 2, 8} {
+			t.Run(fmt.Sprintf("ordered=%t/workers=%d", ordered, workers), func(t *testing.T) {
+				walker, languages, fileSystem := setupExecutorContext(t)
+
+				var m sync.Mutex
+				var evidences []string
+				executor, err := plugin.NewParallelPluginExecutor(walker, languages, []core.Plugin{
+					depsusage.NewDependencyUsagePlugin(func(_ context.Context, evidence *depsusage.UsageEvidence) error {
+						m.Lock()
+						defer m.Unlock()
+
+						evidences = append(evidences, evidence.String())
+						return nil
+					}),
+				}, plugin.ParallelPluginExecutorConfig{
+					Workers: workers,
+					Ordered: ordered,
+				})
+				assert.NoError(t, err)
+
+				err = executor.Execute(context.Background(), fileSystem)
+				assert.NoError(t, err)
+
+				if ordered {
+					assert.Equal(t, serialEvidences, evidences)
+				} else {
+					assert.ElementsMatch(t, serialEvidences, evidences)
+				}
+			})
+		}
+	}
+}
+
+func TestParallelPluginExecutorErrors(t *testing.T) {
+	t.Run("should reject negative number of workers", func(t *testing.T) {
+		walker, languages, _ := setupExecutorContext(t)
+
+		_, err := plugin.NewParallelPluginExecutor(walker, languages, nil,
+			plugin.ParallelPluginExecutorConfig{Workers: -1})
+		assert.Error(t, err)
+	})
+
+	for _, ordered := range []bool{true, false} {
+		t.Run(fmt.Sprintf("should return the error of the first failed file when ordered=%t", ordered), func(t *testing.T) {
+			walker, languages, fileSystem, fileNames := setupFailureContext(t)
+
+			executor, err := plugin.NewParallelPluginExecutor(walker, languages, []core.Plugin{
+				&failingPlugin{failOn: []string{fileNames[3], fileNames[12]}},
+			}, plugin.ParallelPluginExecutorConfig{
+				Workers: 4,
+				Ordered: ordered,
+			})
+			assert.NoError(t, err)
+
+			err = executor.Execute(context.Background(), fileSystem)
+			assert.ErrorIs(t, err, errFailingPlugin)
+			assert.ErrorContains(t, err, fileNames[3])
+		})
+	}
+
+	t.Run("should stop delivery after the first failure when ordered", func(t *testing.T) {
+		walker, languages, fileSystem, fileNames := setupFailureContext(t)
+
+		failing := len(fileNames) / 2
+
+		var delivered []string
+		executor, err := plugin.NewParallelPluginExecutor(walker, languages, []core.Plugin{
+			depsusage.NewDependencyUsagePlugin(func(_ context.Context, evidence *depsusage.UsageEvidence) error {
+				delivered = append(delivered, evidence.FilePath)
+				return nil
+			}),
+			&failingPlugin{failOn: []string{fileNames[failing]}},
+		}, plugin.ParallelPluginExecutorConfig{
+			Workers: 4,
+			Ordered: true,
+		})
+		assert.NoError(t, err)
+
+		err = executor.Execute(context.Background(), fileSystem)
+		assert.ErrorIs(t, err, errFailingPlugin)
+
+		assert.NotEmpty(t, delivered)
+		for _, filePath := range delivered {
+			assert.Contains(t, fileNames[:failing+1], filePath)
+		}
+	})
+
+	t.Run("should stop when context is cancelled", func(t *testing.T) {
+		walker, languages, fileSystem := setupExecutorContext(t)
+
+		executor, err := plugin.NewParallelPluginExecutor(walker, languages, nil,
+			plugin.ParallelPluginExecutorConfig{Workers: 2, Ordered: true})
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err = executor.Execute(ctx, fileSystem)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
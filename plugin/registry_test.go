@@ -0,0 +1,110 @@
+package plugin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/fs"
+	"github.com/safedep/code/lang"
+	"github.com/safedep/code/parser"
+	"github.com/safedep/code/plugin"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/toml"
+	"github.com/stretchr/testify/assert"
+)
+
+const languageCodeToml core.LanguageCode = "toml"
+
+// tomlLanguage is a toy language registered at runtime
+type tomlLanguage struct{}
+
+var _ core.Language = (*tomlLanguage)(nil)
+
+func (l *tomlLanguage) Name() string {
+	return "toml"
+}
+
+func (l *tomlLanguage) Meta() core.LanguageMeta {
+	return core.LanguageMeta{
+		Name:                 "toml",
+		Code:                 languageCodeToml,
+		SourceFileExtensions: []string{".toml"},
+	}
+}
+
+func (l *tomlLanguage) Language() *sitter.Language {
+	return toml.GetLanguage()
+}
+
+func (l *tomlLanguage) Resolvers() core.LanguageResolvers {
+	return nil
+}
+
+// tableCollectingPlugin collects the names of tables in TOML files
+type tableCollectingPlugin struct {
+	tables []string
+}
+
+var _ core.TreePlugin = (*tableCollectingPlugin)(nil)
+
+func (p *tableCollectingPlugin) Name() string {
+	return "TableCollectingPlugin"
+}
+
+func (p *tableCollectingPlugin) SupportedLanguages() []core.LanguageCode {
+	return []core.LanguageCode{languageCodeToml}
+}
+
+func (p *tableCollectingPlugin) AnalyzeTree(_ context.Context, tree core.ParseTree) error {
+	data, err := tree.Data()
+	if err != nil {
+		return err
+	}
+
+	root := tree.Tree().RootNode()
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		table := root.NamedChild(i)
+		if table.Type() != "table" {
+			continue
+		}
+
+		p.tables = append(p.tables, table.NamedChild(0).Content(*data))
+	}
+
+	return nil
+}
+
+func TestTreeWalkPluginExecutorWithRegisteredLanguage(t *testing.T) {
+	err := lang.RegisterLanguage(func() (core.Language, error) {
+		return &tomlLanguage{}, nil
+	})
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, lang.UnregisterLanguage(languageCodeToml))
+	})
+
+	languages, err := lang.AllLanguages()
+	assert.NoError(t, err)
+
+	walker, err := fs.NewSourceWalker(fs.SourceWalkerConfig{}, languages)
+	assert.NoError(t, err)
+
+	treeWalker, err := parser.NewWalkingParser(walker, languages)
+	assert.NoError(t, err)
+
+	fileSystem, err := fs.NewLocalFileSystem(fs.LocalFileSystemConfig{
+		AppDirectories: []string{"fixtures/toml"},
+	})
+	assert.NoError(t, err)
+
+	tablePlugin := &tableCollectingPlugin{}
+	executor, err := plugin.NewTreeWalkPluginExecutor(treeWalker, []core.Plugin{tablePlugin})
+	assert.NoError(t, err)
+
+	err = executor.Execute(context.Background(), fileSystem)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"package", "dependencies"}, tablePlugin.tables)
+}
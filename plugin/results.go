@@ -0,0 +1,166 @@
+package plugin
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/safedep/code/core"
+)
+
+// resultStore is a concurrency safe store of results. A new store is
+// created for every analyzed file and one is shared across the project
+type resultStore struct {
+	m       sync.RWMutex
+	results map[core.ResultName]any
+}
+
+func newResultStore() *resultStore {
+	return &resultStore{
+		results: make(map[core.ResultName]any),
+	}
+}
+
+func (s *resultStore) get(name core.ResultName) (any, bool) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	value, exists := s.results[name]
+	return value, exists
+}
+
+func (s *resultStore) put(name core.ResultName, value any) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.results[name] = value
+}
+
+// pluginResultSet is the view of a result store for a plugin. It restricts
+// the plugin to the results it declared to produce and consume
+type pluginResultSet struct {
+	plugin core.CascadingPlugin
+	store  *resultStore
+}
+
+var _ core.ResultSet = (*pluginResultSet)(nil)
+
+func (s *pluginResultSet) Get(name core.ResultName) (any, bool) {
+	if !slices.Contains(s.plugin.Consumes(), name) && !slices.Contains(s.plugin.Produces(), name) {
+		return nil, false
+	}
+
+	return s.store.get(name)
+}
+
+func (s *pluginResultSet) Put(name core.ResultName, value any) error {
+	if !slices.Contains(s.plugin.Produces(), name) {
+		return fmt.Errorf("plugin %s does not produce result %s", s.plugin.Name(), name)
+	}
+
+	s.store.put(name, value)
+	return nil
+}
+
+type pluginResults struct {
+	file    core.ResultSet
+	project core.ResultSet
+}
+
+var _ core.PluginResults = (*pluginResults)(nil)
+
+func newPluginResults(plugin core.CascadingPlugin, file, project *resultStore) *pluginResults {
+	return &pluginResults{
+		file:    &pluginResultSet{plugin: plugin, store: file},
+		project: &pluginResultSet{plugin: plugin, store: project},
+	}
+}
+
+func (r *pluginResults) File() core.ResultSet {
+	return r.file
+}
+
+func (r *pluginResults) Project() core.ResultSet {
+	return r.project
+}
+
+// orderPlugins topologically sorts the plugins such that the producers of a
+// result are executed before its consumers. Plugins without dependencies
+// between them retain their relative order.
+func orderPlugins(plugins []core.Plugin) ([]core.Plugin, error) {
+	producers := make(map[core.ResultName]int)
+	for i, plugin := range plugins {
+		cascadingPlugin, ok := plugin.(core.CascadingPlugin)
+		if !ok {
+			continue
+		}
+
+		for _, name := range cascadingPlugin.Produces() {
+			if producer, exists := producers[name]; exists {
+				return nil, fmt.Errorf("result %s is produced by both %s and %s",
+					name, plugins[producer].Name(), plugin.Name())
+			}
+
+			producers[name] = i
+		}
+	}
+
+	dependencies := make([][]int, len(plugins))
+	for i, plugin := range plugins {
+		cascadingPlugin, ok := plugin.(core.CascadingPlugin)
+		if !ok {
+			continue
+		}
+
+		for _, name := range cascadingPlugin.Consumes() {
+			producer, exists := producers[name]
+			if !exists {
+				return nil, fmt.Errorf("plugin %s consumes result %s which is not produced by any plugin",
+					plugin.Name(), name)
+			}
+
+			if producer != i {
+				dependencies[i] = append(dependencies[i], producer)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make([]int, len(plugins))
+	ordered := make([]core.Plugin, 0, len(plugins))
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("plugin %s has a cyclic result dependency", plugins[i].Name())
+		}
+
+		state[i] = visiting
+		for _, dependency := range dependencies[i] {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+
+		state[i] = visited
+		ordered = append(ordered, plugins[i])
+
+		return nil
+	}
+
+	for i := range plugins {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
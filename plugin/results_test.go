@@ -0,0 +1,241 @@
+package plugin_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/safedep/code/core"
+	"github.com/safedep/code/lang"
+	"github.com/safedep/code/parser"
+	"github.com/safedep/code/pkg/test"
+	"github.com/safedep/code/plugin"
+	"github.com/safedep/code/plugin/callgraph"
+	"github.com/safedep/code/plugin/depsusage"
+	"github.com/stretchr/testify/assert"
+)
+
+type cascadingTestPlugin struct {
+	name     string
+	produces []core.ResultName
+	consumes []core.ResultName
+	analyze  func(context.Context, core.ParseTree, core.PluginResults) error
+}
+
+var _ core.CascadingPlugin = (*cascadingTestPlugin)(nil)
+
+func (p *cascadingTestPlugin) Name() string {
+	return p.name
+}
+
+// Languages are those of the registry such that every file walked by the
+// executors is analyzed, whatever the languages of the fixtures
+func (p *cascadingTestPlugin) SupportedLanguages() []core.LanguageCode {
+	return lang.RegisteredLanguages()
+}
+
+func (p *cascadingTestPlugin) Produces() []core.ResultName {
+	return p.produces
+}
+
+func (p *cascadingTestPlugin) Consumes() []core.ResultName {
+	return p.consumes
+}
+
+func (p *cascadingTestPlugin) AnalyzeResults(ctx context.Context, tree core.ParseTree, results core.PluginResults) error {
+	return p.analyze(ctx, tree, results)
+}
+
+var fileCountResult = core.NewResultKey[*atomic.Int64]("test.file-count")
+
+func newTreeWalkExecutor(t *testing.T, plugins []core.Plugin) (plugin.PluginExecutor, core.ImportAwareFileSystem, error) {
+	t.Helper()
+
+	walker, languages, fileSystem := setupExecutorContext(t)
+
+	treeWalker, err := parser.NewWalkingParser(walker, languages)
+	assert.NoError(t, err)
+
+	executor, err := plugin.NewTreeWalkPluginExecutor(treeWalker, plugins)
+	return executor, fileSystem, err
+}
+
+func TestCascadingPlugins(t *testing.T) {
+	t.Run("should share the callgraph with consumers", func(t *testing.T) {
+		var m sync.Mutex
+		built := map[string]*callgraph.CallGraph{}
+		consumed := map[string]*callgraph.CallGraph{}
+
+		consumer := &cascadingTestPlugin{
+			name:     "CallGraphConsumer",
+			consumes: []core.ResultName{callgraph.CallGraphResult.Name(), depsusage.UsageEvidencesResult.Name()},
+			analyze: func(_ context.Context, tree core.ParseTree, results core.PluginResults) error {
+				cg, exists := core.GetResult(results.File(), callgraph.CallGraphResult)
+				assert.True(t, exists)
+
+				_, exists = core.GetResult(results.File(), depsusage.UsageEvidencesResult)
+				assert.True(t, exists)
+
+				m.Lock()
+				defer m.Unlock()
+
+				consumed[cg.FileName] = cg
+				return nil
+			},
+		}
+
+		treeWalker, fileSystem, err := test.SetupBasicPluginContext([]string{
+			"callgraph/fixtures/testClass.py",
+			"callgraph/fixtures/testJavascript.js",
+		}, []core.LanguageCode{core.LanguageCodePython, core.LanguageCodeJavascript})
+		assert.NoError(t, err)
+
+		// Consumer is listed first to verify that producers are executed before it
+		executor, err := plugin.NewTreeWalkPluginExecutor(treeWalker, []core.Plugin{
+			consumer,
+			depsusage.NewDependencyUsagePlugin(nil),
+			callgraph.NewCallGraphPlugin(func(_ context.Context, cg *callgraph.CallGraph) error {
+				m.Lock()
+				defer m.Unlock()
+
+				assert.NotContains(t, built, cg.FileName, "callgraph must be built once per file")
+				built[cg.FileName] = cg
+				return nil
+			}),
+		})
+		assert.NoError(t, err)
+
+		err = executor.Execute(context.Background(), fileSystem)
+		assert.NoError(t, err)
+
+		assert.Len(t, consumed, 2)
+		assert.Equal(t, built, consumed)
+	})
+
+	t.Run("should share project results across files", func(t *testing.T) {
+		counter := &cascadingTestPlugin{
+			name:     "FileCounter",
+			produces: []core.ResultName{fileCountResult.Name()},
+			analyze: func(_ context.Context, _ core.ParseTree, results core.PluginResults) error {
+				count, exists := core.GetResult(results.Project(), fileCountResult)
+				if !exists {
+					count = &atomic.Int64{}
+					if err := core.PutResult(results.Project(), fileCountResult, count); err != nil {
+						return err
+					}
+				}
+
+				count.Add(1)
+				return nil
+			},
+		}
+
+		var files atomic.Int64
+		var count *atomic.Int64
+		reader := &cascadingTestPlugin{
+			name:     "FileCountReader",
+			consumes: []core.ResultName{fileCountResult.Name()},
+			analyze: func(_ context.Context, _ core.ParseTree, results core.PluginResults) error {
+				files.Add(1)
+				count, _ = core.GetResult(results.Project(), fileCountResult)
+				return nil
+			},
+		}
+
+		executor, fileSystem, err := newTreeWalkExecutor(t, []core.Plugin{reader, counter})
+		assert.NoError(t, err)
+
+		err = executor.Execute(context.Background(), fileSystem)
+		assert.NoError(t, err)
+
+		assert.NotNil(t, count)
+		assert.Equal(t, files.Load(), count.Load())
+	})
+
+	t.Run("should reject publishing undeclared results", func(t *testing.T) {
+		executor, fileSystem, err := newTreeWalkExecutor(t, []core.Plugin{
+			&cascadingTestPlugin{
+				name: "UndeclaredProducer",
+				analyze: func(_ context.Context, _ core.ParseTree, results core.PluginResults) error {
+					return core.PutResult(results.File(), fileCountResult, &atomic.Int64{})
+				},
+			},
+		})
+		assert.NoError(t, err)
+
+		err = executor.Execute(context.Background(), fileSystem)
+		assert.ErrorContains(t, err, "does not produce result")
+	})
+
+	t.Run("should work with the parallel executor", func(t *testing.T) {
+		var consumed atomic.Int64
+		walker, languages, fileSystem := setupExecutorContext(t)
+
+		executor, err := plugin.NewParallelPluginExecutor(walker, languages, []core.Plugin{
+			&cascadingTestPlugin{
+				name:     "UsageEvidenceConsumer",
+				consumes: []core.ResultName{depsusage.UsageEvidencesResult.Name()},
+				analyze: func(_ context.Context, _ core.ParseTree, results core.PluginResults) error {
+					evidences, _ := core.GetResult(results.File(), depsusage.UsageEvidencesResult)
+					consumed.Add(int64(len(evidences)))
+					return nil
+				},
+			},
+			depsusage.NewDependencyUsagePlugin(nil),
+		}, plugin.ParallelPluginExecutorConfig{Workers: 4})
+		assert.NoError(t, err)
+
+		err = executor.Execute(context.Background(), fileSystem)
+		assert.NoError(t, err)
+
+		assert.Equal(t, int64(len(executeSerial(t))), consumed.Load())
+	})
+}
+
+func TestCascadingPluginOrderErrors(t *testing.T) {
+	resultA := core.ResultName("test.a")
+	resultB := core.ResultName("test.b")
+
+	testCases := []struct {
+		name    string
+		plugins []core.Plugin
+		err     string
+	}{
+		{
+			name: "should reject missing producers",
+			plugins: []core.Plugin{
+				&cascadingTestPlugin{name: "Consumer", consumes: []core.ResultName{resultA}},
+			},
+			err: "not produced by any plugin",
+		},
+		{
+			name: "should reject duplicate producers",
+			plugins: []core.Plugin{
+				&cascadingTestPlugin{name: "ProducerA", produces: []core.ResultName{resultA}},
+				&cascadingTestPlugin{name: "ProducerB", produces: []core.ResultName{resultA}},
+			},
+			err: "is produced by both",
+		},
+		{
+			name: "should reject cyclic dependencies",
+			plugins: []core.Plugin{
+				&cascadingTestPlugin{name: "A", produces: []core.ResultName{resultA}, consumes: []core.ResultName{resultB}},
+				&cascadingTestPlugin{name: "B", produces: []core.ResultName{resultB}, consumes: []core.ResultName{resultA}},
+			},
+			err: "cyclic result dependency",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := newTreeWalkExecutor(t, tc.plugins)
+			assert.ErrorContains(t, err, tc.err)
+
+			walker, languages, _ := setupExecutorContext(t)
+			_, err = plugin.NewParallelPluginExecutor(walker, languages, tc.plugins,
+				plugin.ParallelPluginExecutorConfig{})
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
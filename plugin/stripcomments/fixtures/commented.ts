@@ -0,0 +1,25 @@
+import type { Config } from './config'; // Type only import
+import fs = require('fs'); /* require style import */
+
+/**
+ * Service interface docstring
+ */
+interface Service {
+  // Starts the service
+  start(config: Config): Promise<void>;
+}
+
+class FileService implements Service {
+  constructor(private readonly root: string /* root directory */) {}
+
+  /**
+   * config - service configuration
+   */
+  async start(config: Config): Promise<void> {
+    // const files = fs.readdirSync(this.root);
+    console.log(fs.existsSync(this.root)); // check root exists
+  }
+}
+
+const service: Service = new FileService('/tmp'); /// triple slash comment
+service.start({} as Config);
@@ -0,0 +1,21 @@
+import type { Config } from './config'; 
+import fs = require('fs'); 
+
+
+interface Service {
+  
+  start(config: Config): Promise<void>;
+}
+
+class FileService implements Service {
+  constructor(private readonly root: string ) {}
+
+  
+  async start(config: Config): Promise<void> {
+    
+    console.log(fs.existsSync(this.root)); 
+  }
+}
+
+const service: Service = new FileService('/tmp'); 
+service.start({} as Config);
\ No newline at end of file
@@ -31,6 +31,11 @@ var testcases = []StripCommentsTestcase{
 		CommentedFilePath: "fixtures/commented.js",
 		StrippedFilePath:  "fixtures/stripped.js",
 	},
+	{
+		Language:          core.LanguageCodeTypescript,
+		CommentedFilePath: "fixtures/commented.ts",
+		StrippedFilePath:  "fixtures/stripped.ts",
+	},
 }
 
 func TestStripComments(t *testing.T) {